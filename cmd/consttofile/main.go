@@ -4,15 +4,11 @@ import (
 	"flag"
 	"fmt"
 	"go/constant"
-	"go/token"
 	"go/types"
-	"io/ioutil"
 	"os"
-	"regexp"
-	"sort"
 	"strings"
 
-	"golang.org/x/tools/go/packages"
+	"myitcv.io/gogenerate"
 )
 
 func main() {
@@ -31,91 +27,47 @@ func main1() int {
 	}
 }
 
-// TODO take into account build tags more explicitly
-
-func mainerr() (retErr error) {
+func mainerr() error {
 	fs := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
 	fs.Usage = func() {
 		mainUsage(os.Stderr)
 	}
-	if err := fs.Parse(os.Args[1:]); err != nil {
-		return err
-	}
-
-	if len(fs.Args()) == 0 {
-		return fmt.Errorf("consttofile takes at least one argument")
-	}
 
-	envPkg := os.Getenv("GOPACKAGE")
-
-	config := &packages.Config{
-		Mode:  packages.LoadSyntax,
-		Fset:  token.NewFileSet(),
-		Tests: true,
+	g := &gogenerate.Generator{
+		ImportPath: "myitcv.io/cmd/consttofile",
+		Flags:      fs,
+		Perm:       0666,
+		Generate:   generate,
 	}
 
-	pkgs, err := packages.Load(config, ".")
+	wd, err := os.Getwd()
 	if err != nil {
-		return fmt.Errorf("could not load package from current dir: %v", err)
+		return fmt.Errorf("unable to get working directory: %v", err)
 	}
 
-	forTest := regexp.MustCompile(` \[[^\]]+\]$`)
-
-	testPkgs := make(map[string]*packages.Package)
-	var nonTestPkg *packages.Package
-
-	// Becase of https://github.com/golang/go/issues/27910 we have to
-	// apply some janky logic to find the "right" package
-	for _, p := range pkgs {
-		switch {
-		case strings.HasSuffix(p.PkgPath, ".test"):
-			// we don't ever want this package
-			continue
-		case forTest.MatchString(p.ID):
-			testPkgs[p.Name] = p
-		default:
-			nonTestPkg = p
-		}
-	}
-
-	ids := func() []string {
-		var ids []string
-		for _, p := range pkgs {
-			ids = append(ids, p.ID)
-		}
-		sort.Strings(ids)
-		return ids
-	}
-
-	if nonTestPkg == nil {
-		return fmt.Errorf("always expect to have the actual package. Got %v", ids())
-	}
-
-	var pkg *packages.Package
+	// consttofile does not require the environment set by go generate, hence
+	// we use Run rather than Main
+	return g.Run(wd, os.Getenv(gogenerate.GOFILE), os.Getenv(gogenerate.GOPACKAGE), os.Args[1:])
+}
 
-	if strings.HasSuffix(envPkg, "_test") {
-		if pkg = testPkgs[envPkg]; pkg == nil {
-			return fmt.Errorf("called with package name %v, but go/packages did not give us such a package. Got %v", envPkg, ids())
-		}
-	} else {
-		if pkg = testPkgs[envPkg]; pkg == nil {
-			pkg = nonTestPkg
-		}
+func generate(c *gogenerate.Context) error {
+	if len(c.Args) == 0 {
+		return fmt.Errorf("consttofile takes at least one argument")
 	}
 
-	for _, cn := range fs.Args() {
-		co := pkg.Types.Scope().Lookup(cn)
+	for _, cn := range c.Args {
+		co := c.Pkg.Types.Scope().Lookup(cn)
 		if co == nil {
 			return fmt.Errorf("failed to find const %v\n", cn)
 		}
 
-		c, ok := co.(*types.Const)
+		cst, ok := co.(*types.Const)
 		if !ok {
 			return fmt.Errorf("found %v, but it was not a const, instead it was a %T", cn, co)
 		}
 
-		if c.Val().Kind() != constant.String {
-			return fmt.Errorf("expected %v to be a string constant; got %v", cn, c.Val().Kind())
+		if cst.Val().Kind() != constant.String {
+			return fmt.Errorf("expected %v to be a string constant; got %v", cn, cst.Val().Kind())
 		}
 
 		i := strings.LastIndex(cn, "_")
@@ -125,9 +77,7 @@ func mainerr() (retErr error) {
 
 		fn := "gen_" + cn[:i] + "_consttofile" + "." + cn[i+1:]
 
-		if err := ioutil.WriteFile(fn, []byte(constant.StringVal(c.Val())), 0666); err != nil {
-			return fmt.Errorf("failed to write to %v: %v", fn, err)
-		}
+		c.RawOutput(fn).WriteString(constant.StringVal(cst.Val()))
 	}
 
 	return nil
@@ -23,7 +23,7 @@ type usage struct {
 }
 
 func (u usage) usage() {
-	fmt.Fprintf(os.Stderr, `
+	fmt.Fprint(os.Stderr, `
 Usage:
 
    egrunner [flags] DOCKERFILE SCRIPT
@@ -676,7 +676,7 @@ func handleRootRequest(res http.ResponseWriter, req *http.Request, s *server) {
 					res.WriteHeader(http.StatusInternalServerError)
 
 					msg := fmt.Sprintf("Did not understand Gitlab refresh request; unknown object_kind: %v", pHook.ObjectKind)
-					infof(msg)
+					infof("%v", msg)
 					fmt.Fprintln(res, msg)
 
 					return
@@ -692,7 +692,7 @@ func handleRootRequest(res http.ResponseWriter, req *http.Request, s *server) {
 				res.WriteHeader(http.StatusInternalServerError)
 
 				msg := fmt.Sprintf("Did not understand refresh request: %v", req.URL)
-				infof(msg)
+				infof("%v", msg)
 				fmt.Fprintln(res, msg)
 
 				return
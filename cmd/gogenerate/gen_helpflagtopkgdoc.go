@@ -3,7 +3,7 @@
 // gogenerate is a cache-based wrapper around go generate directives.
 //
 // Usage:
-//         gogenerate [-p n] [-r n] [-trace] [-skipCache] [-tags 'tag list'] [packages]
+//         gogenerate [-p n] [-r n] [-trace] [-json] [-skipCache] [-check] [-explain pkg] [-clean [-n]] [-watch] [-mod mode] [-tags 'tag list'] [packages]
 //
 // gogenerate runs go generate directives found in packages according to the
 // reverse dependency graph implied by those packages' imports, and the
@@ -26,30 +26,126 @@
 // packages resolves to packages outside of the main module.
 //
 // The -tags flag is similar to the build flag that can be passed to the go
-// command. It takes a space-separated list of build tags to consider satisfied as
-// gogenerate runs, and can appear multiple times.
+// command. It takes a space- or comma-separated list of build tags to consider
+// satisfied as gogenerate runs, and can appear multiple times.
+//
+// The -mod flag is similar to the build flag that can be passed to the go
+// command, and takes one of the values readonly, vendor or mod.
+//
+// gogenerate understands the -tags and -mod flags in the GOFLAGS environment
+// variable. Build tags in GOFLAGS (which must be comma-separated) are combined
+// with those given via -tags; a -mod flag takes precedence over a -mod value in
+// GOFLAGS. Each go generate directive is run with GOFLAGS set to the effective
+// flags: any other flags from GOFLAGS, followed by -mod=mode (if set) and
+// -tags=tag,list (if there are any build tags). Hence go commands run by a
+// generator see the same build configuration as gogenerate. The effective build
+// tags and GOFLAGS form part of each package's cache key.
 //
 // The -p flag controls the concurrency level of gogenerate. By default will
-// assume a -p value of GOMAXPROCS. go generate directives only ever run in serial
-// and never concurrently with other work (this may be relaxed in the future to
-// allow concurrent execution of go generate directives). A -p value of 1 implies
-// serial execution of work in a well defined order.
+// assume a -p value of GOMAXPROCS. The go generate directives of packages with no
+// dependency relation between them run concurrently, except where those packages
+// would generate to a common directory. Within a package, directives run in
+// serial in the order in which they appear. When -p is greater than 1, the trace
+// output for a package, along with the output of its generators, is written as a
+// single block. A -p value of 1 implies serial execution of work in a well
+// defined order.
 //
 // The -trace flag outputs a log of work being executed by gogenerate. It is most
 // useful when specified along with -p 1 (else the order of execution of work is
 // not well defined).
 //
+// The -json flag causes gogenerate to write a stream of JSON events to stdout,
+// one per line, in the spirit of go test -json. The output of generators, as
+// well as any -trace output, is written to stderr. Each event is a JSON object of
+// the form:
+//
+//   type Event struct {
+//           Time      time.Time
+//           Action    string
+//           Package   string   // optional
+//           Iteration int      // optional; the generation iteration for Package
+//           ActionID  string   // optional; the hex-encoded cache key
+//           File      string   // optional; the file containing the directive
+//           Line      int      // optional; the line of the directive
+//           Generator string   // optional; the command name of the directive
+//           Args      []string // optional; the expanded directive arguments
+//           Elapsed   float64  // optional; seconds taken to run the directive
+//           Output    string   // optional; generator output or error message
+//   }
+//
+// The Action field is one of:
+//
+//   queue       the package has been scheduled for generation
+//   cache-hit   the cache contains an entry for the current iteration
+//   cache-miss  the cache does not contain an entry for the current iteration
+//   start       the directive has started running
+//   finish      the directive has finished running
+//   fixedpoint  generation of the package has reached a fixed point
+//   error       gogenerate failed; File and Line identify the directive
+//               responsible, where known
+//
+// -json cannot be combined with -graph, -explain or -check, each of which writes
+// a plain text report to stdout.
+//
 // The -skipCache flag causes gogenerate to skip checking for cache hits.
 // Consequently, all generators are run, regardless of cache state, until a fixed
 // point is reached. The cache is updated after each iteration in a package. As
 // such, this flag can be used to heal a broken cache, i.e. correct the delta for
 // a given cache key.
 //
-// Note: at present, gogenerate does not understand the GOFLAGS environment
-// variable.  Neither does it pass the effective build tags via GOFLAGS to each go
-// generate directive. For more details see:
-//
-// https://github.com/golang/go/issues/26849#issuecomment-460301061
+// The -check flag causes gogenerate to verify that the generated files of each
+// package are up to date, without writing anything to the packages' directories.
+// When gogenerate reaches a fixed point for a package, it records the generated
+// files in the cache against the inputs to the package's directives, excluding
+// the generated files themselves. Where the cache has such a record, the expected
+// generated files are determined from it, without running any directives. The
+// cache key includes the absolute paths of the package's files, hence a record
+// is typically only available on the machine (and in the directory) in which
+// gogenerate was last run, or via a shared cache (see below). Where the cache
+// has no record, e.g. on a CI machine with a cold cache, gogenerate instead
+// copies the main module, without its generated files, to a temporary directory,
+// runs gogenerate on that copy with an empty cache, and takes the resulting
+// generated files as those expected. This fallback requires module mode, and
+// that the package generates files only within the main module; for other
+// packages, gogenerate reports that the cache has no record. For each package,
+// gogenerate reports generated files that are missing, stale (e.g. edited by
+// hand) or extra, along with the directive responsible, and generated files for
+// which there is no longer a corresponding directive. If any such problems are
+// found, gogenerate exits with a non-zero exit code.
+//
+// The -explain flag takes the import path of a package to be generated, and
+// causes gogenerate to write to stdout whether each generation iteration of that
+// package was a cache hit or miss. For each cache miss, gogenerate lists the
+// inputs to the cache key that have been added, removed or changed since the
+// package was previously generated (or found in the cache) at the same
+// iteration: files, directives, generators, dependencies and the like. Where a
+// dependency is a package, the inputs of that package that changed are listed
+// beneath it. Recording the inputs has a cost, hence they are only recorded by
+// runs with -explain: the comparison is with the previous such run. -explain can
+// be combined with -check.
+//
+// The -clean flag causes gogenerate to remove generated files for which there is
+// no longer a corresponding directive, for example because the directive was
+// deleted or its command renamed. A generated file is removed if it is in the
+// directory of one of the named packages, and the command name in its file name
+// (see above) is not that of any directive that generates to that directory. The
+// directives considered are those of all packages in the dependency graph and of
+// all packages in the main module, so that files generated via -outdir: by a
+// package that is not named are not removed. No directives are run. With -n,
+// gogenerate instead prints the files that would be removed. -clean cannot be
+// combined with -check, -explain or -json.
+//
+// The -watch flag causes gogenerate to run as normal, and then to watch for file
+// changes until interrupted. The directories watched are those of packages in the
+// dependency graph (other than those in the standard library or module cache),
+// and those of -infiles: patterns (see below). Changes are batched; for each
+// batch, gogenerate regenerates only those packages whose directory contains a
+// changed file or whose directives have a matching -infiles: pattern, along with
+// their reverse dependencies. The dependency graph is retained between cycles.
+// Changes to generated files and hidden files are ignored, as are new packages
+// (restart gogenerate to pick these up). After each cycle, gogenerate writes a
+// one-line summary to stderr. An error in a cycle is reported, and gogenerate
+// continues to watch for changes.
 //
 // go generate directives can take three forms:
 //
@@ -118,13 +214,32 @@
 // details on how to configure its location. Setting GOGENERATECACHE overrides the
 // default.
 //
+// Setting GOGENERATEREMOTECACHE configures a second level cache that can be
+// shared between machines. The local cache is consulted first; on a miss, the
+// remote cache is consulted, and a hit there is added to the local cache.
+// Artefacts are written to both caches. The value is interpreted as follows:
+//
+//   http://... or https://...  an HTTP cache. Artefacts are retrieved by GET and
+//                              stored by PUT requests to the URL with the
+//                              hex-encoded cache key appended. A 404 response to
+//                              a GET is a cache miss.
+//   /path/to/dir               a directory cache. Artefacts are written atomically
+//                              by rename, and so the directory can safely be
+//                              shared between machines, for example over NFS.
+//                              gogenerate never trims such a directory.
+//   file:///path/to/dir        the same as /path/to/dir.
+//
+// The remote cache is best-effort: a failure to retrieve an artefact from, or
+// store an artefact in, the remote cache is reported as a warning and otherwise
+// treated as a miss.
+//
+// Cache keys include the absolute paths of packages, hence artefacts are only
+// shared between machines where the main module is located at the same path.
+//
 // TODO
 //
 // The following is a rough list of TODOs for gogenerate:
 //
-// 	* add support for parsing of GOFLAGS
-// 	* add support for setting of GOFLAGS for go generate directives
-// 	* consider supporting concurrent execution of go generate directives
 // 	* define semantics for when generated files are removed by a generator
 // 	* add full tests for cgo
 package main
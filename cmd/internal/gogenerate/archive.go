@@ -31,7 +31,11 @@ func newArchiveReader(path string) (*archiveReader, error) {
 	}, nil
 }
 
-func (r *archiveReader) ExtractFile() (string, error) {
+// NextFile returns the name and size of the next file in the archive along
+// with a reader for its contents. The contents must be consumed before the
+// next call to NextFile or ExtractFile. io.EOF is returned when there are no
+// more files.
+func (r *archiveReader) NextFile() (string, int64, io.Reader, error) {
 	if !r.readVersion {
 		b, err := r.und.ReadByte()
 		if err == nil {
@@ -41,34 +45,41 @@ func (r *archiveReader) ExtractFile() (string, error) {
 			r.readVersion = true
 		}
 		if err != nil {
-			return "", err
+			return "", 0, nil, err
 		}
 	}
 	fn, err := r.und.ReadString(archiveDelim)
 	if err != nil {
 		if err == io.EOF {
-			return "", err
+			return "", 0, nil, err
 		}
-		return "", fmt.Errorf("could not read filename: %v", err)
+		return "", 0, nil, fmt.Errorf("could not read filename: %v", err)
 	}
 	fn = fn[:len(fn)-1]
 	if len(fn) == 0 {
-		return "", fmt.Errorf("invalid zero-length filename")
+		return "", 0, nil, fmt.Errorf("invalid zero-length filename")
 	}
 	ls, err := r.und.ReadString(archiveDelim)
 	if err != nil {
-		return "", fmt.Errorf("failed to read length string: %v", err)
+		return "", 0, nil, fmt.Errorf("failed to read length string: %v", err)
 	}
 	ls = ls[:len(ls)-1]
 	l, err := strconv.ParseInt(ls, 10, 64)
 	if err != nil {
-		return "", fmt.Errorf("failed to read length of file: %v", err)
+		return "", 0, nil, fmt.Errorf("failed to read length of file: %v", err)
+	}
+	return fn, l, io.LimitReader(r.und, l), nil
+}
+
+func (r *archiveReader) ExtractFile() (string, error) {
+	fn, l, lr, err := r.NextFile()
+	if err != nil {
+		return "", err
 	}
 	f, err := os.Create(fn)
 	if err != nil {
 		return "", fmt.Errorf("failed to create %v: %v", fn, err)
 	}
-	lr := io.LimitReader(r.und, l)
 	if n, err := io.Copy(f, lr); err != nil || n != l {
 		return "", fmt.Errorf("failed to extract %v bytes (read %v) to %v: %v", l, n, fn, err)
 	}
@@ -0,0 +1,112 @@
+package gogenerate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rogpeppe/go-internal/cache"
+)
+
+// artefactCache is a store of the archives of generated files that result from
+// running the directives of a package, keyed by the action ID of that
+// generation step.
+type artefactCache interface {
+	// GetFile returns the path of a local file that contains the archive for
+	// id. errCacheMiss is returned if there is no such archive.
+	GetFile(id cache.ActionID) (string, error)
+
+	// PutFile stores the archive contained in the local file path against id.
+	PutFile(id cache.ActionID, path string) error
+}
+
+var errCacheMiss = errors.New("cache miss")
+
+// openRemoteCache returns the artefactCache described by spec, the value of
+// GOGENERATEREMOTECACHE. An http:// or https:// URL results in an HTTP cache;
+// an absolute directory path results in a shared directory cache.
+func openRemoteCache(spec string, tempDir string) (artefactCache, error) {
+	switch {
+	case strings.HasPrefix(spec, "http://"), strings.HasPrefix(spec, "https://"):
+		return newHTTPCache(spec, tempDir), nil
+	case strings.HasPrefix(spec, "file://"):
+		return openDirCache(strings.TrimPrefix(spec, "file://"))
+	default:
+		return openDirCache(spec)
+	}
+}
+
+// localCache is an artefactCache backed by a go-internal/cache.Cache
+type localCache struct {
+	*cache.Cache
+}
+
+func (l localCache) GetFile(id cache.ActionID) (string, error) {
+	fp, _, err := l.Cache.GetFile(id)
+	if err != nil {
+		return "", errCacheMiss
+	}
+	return fp, nil
+}
+
+func (l localCache) PutFile(id cache.ActionID, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open archive %v for reading: %v", path, err)
+	}
+	defer f.Close()
+	if _, _, err := l.Cache.Put(id, f); err != nil {
+		return fmt.Errorf("failed to write archive to cache: %v", err)
+	}
+	return nil
+}
+
+// tieredCache is an artefactCache that consults a remote cache on a miss in
+// the local cache. Hits in the remote cache are added to the local cache.
+// Archives are put in both.
+//
+// The remote cache is best-effort: a failure to get from or put to the remote
+// cache is reported via warnf, and is otherwise treated as a miss, in order
+// that an unavailable remote cache does not prevent generation.
+type tieredCache struct {
+	local  artefactCache
+	remote artefactCache
+	warnf  func(format string, args ...interface{})
+}
+
+func (t *tieredCache) GetFile(id cache.ActionID) (string, error) {
+	fp, err := t.local.GetFile(id)
+	if err == nil {
+		return fp, nil
+	}
+	if err != errCacheMiss {
+		return "", err
+	}
+	fp, err = t.remote.GetFile(id)
+	if err != nil {
+		if err != errCacheMiss {
+			t.warnf("remote cache: %v", err)
+		}
+		return "", errCacheMiss
+	}
+	if err := t.local.PutFile(id, fp); err != nil {
+		return "", err
+	}
+	return fp, nil
+}
+
+func (t *tieredCache) PutFile(id cache.ActionID, path string) error {
+	if err := t.local.PutFile(id, path); err != nil {
+		return err
+	}
+	if err := t.remote.PutFile(id, path); err != nil {
+		t.warnf("remote cache: %v", err)
+	}
+	return nil
+}
+
+var (
+	_ artefactCache = localCache{}
+	_ artefactCache = (*tieredCache)(nil)
+)
@@ -0,0 +1,277 @@
+package gogenerate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rogpeppe/go-internal/cache"
+)
+
+func TestDirCache(t *testing.T) {
+	td, err := ioutil.TempDir("", "gogenerate-TestDirCache")
+	if err != nil {
+		t.Fatalf("failed to create TempDir: %v", err)
+	}
+	defer os.RemoveAll(td)
+
+	c, err := openDirCache(filepath.Join(td, "shared"))
+	if err != nil {
+		t.Fatalf("failed to open dir cache: %v", err)
+	}
+	testArtefactCache(t, td, c)
+}
+
+func TestFileURLCache(t *testing.T) {
+	td, err := ioutil.TempDir("", "gogenerate-TestFileURLCache")
+	if err != nil {
+		t.Fatalf("failed to create TempDir: %v", err)
+	}
+	defer os.RemoveAll(td)
+
+	shared := filepath.Join(td, "shared")
+	c, err := openRemoteCache("file://"+filepath.ToSlash(shared), td)
+	if err != nil {
+		t.Fatalf("failed to open file URL cache: %v", err)
+	}
+	id := testArtefactCache(t, td, c)
+
+	// a file URL names the same directory cache as its path
+	dc, err := openDirCache(shared)
+	if err != nil {
+		t.Fatalf("failed to open dir cache: %v", err)
+	}
+	if _, err := dc.GetFile(id); err != nil {
+		t.Fatalf("failed to get archive written via file URL from dir cache: %v", err)
+	}
+}
+
+func TestHTTPCache(t *testing.T) {
+	td, err := ioutil.TempDir("", "gogenerate-TestHTTPCache")
+	if err != nil {
+		t.Fatalf("failed to create TempDir: %v", err)
+	}
+	defer os.RemoveAll(td)
+
+	srv := httptest.NewServer(newMemCacheHandler())
+	defer srv.Close()
+
+	testArtefactCache(t, td, newHTTPCache(srv.URL+"/cache/", td))
+}
+
+func TestTieredCache(t *testing.T) {
+	td, err := ioutil.TempDir("", "gogenerate-TestTieredCache")
+	if err != nil {
+		t.Fatalf("failed to create TempDir: %v", err)
+	}
+	defer os.RemoveAll(td)
+
+	newLocal := func(name string) artefactCache {
+		lc, err := cache.Open(filepath.Join(td, name))
+		if err != nil {
+			t.Fatalf("failed to open local cache: %v", err)
+		}
+		return localCache{lc}
+	}
+	for _, n := range []string{"local1", "local2"} {
+		if err := os.Mkdir(filepath.Join(td, n), 0777); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+
+	srv := httptest.NewServer(newMemCacheHandler())
+	defer srv.Close()
+
+	// populate the remote cache via one local cache, then verify that a
+	// second, empty, local cache sees the same archive
+	c1 := &tieredCache{
+		local:  newLocal("local1"),
+		remote: newHTTPCache(srv.URL, td),
+		warnf:  t.Errorf,
+	}
+	id := testArtefactCache(t, td, c1)
+
+	local2 := newLocal("local2")
+	if _, err := local2.GetFile(id); err != errCacheMiss {
+		t.Fatalf("expected cache miss in empty local cache; got %v", err)
+	}
+	c2 := &tieredCache{
+		local:  local2,
+		remote: newHTTPCache(srv.URL, td),
+		warnf:  t.Errorf,
+	}
+	if _, err := c2.GetFile(id); err != nil {
+		t.Fatalf("failed to get archive via remote cache: %v", err)
+	}
+	if _, err := local2.GetFile(id); err != nil {
+		t.Fatalf("remote cache hit was not added to local cache: %v", err)
+	}
+}
+
+func TestTieredCacheRemoteFailure(t *testing.T) {
+	td, err := ioutil.TempDir("", "gogenerate-TestTieredCacheRemoteFailure")
+	if err != nil {
+		t.Fatalf("failed to create TempDir: %v", err)
+	}
+	defer os.RemoveAll(td)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	// a server that has been closed is unreachable
+	closed := httptest.NewServer(newMemCacheHandler())
+	closed.Close()
+
+	for i, u := range []string{failing.URL, closed.URL} {
+		dir := filepath.Join(td, fmt.Sprintf("local%v", i))
+		if err := os.Mkdir(dir, 0777); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		lc, err := cache.Open(dir)
+		if err != nil {
+			t.Fatalf("failed to open local cache: %v", err)
+		}
+
+		var warnings []string
+		c := &tieredCache{
+			local:  localCache{lc},
+			remote: newHTTPCache(u, td),
+			warnf: func(format string, args ...interface{}) {
+				warnings = append(warnings, fmt.Sprintf(format, args...))
+			},
+		}
+
+		// the failures of the remote cache are treated as misses, and the
+		// local cache continues to be used
+		testArtefactCache(t, td, c)
+
+		if len(warnings) != 2 {
+			t.Fatalf("expected warnings for the failed remote GET and PUT via %v; got %q", u, warnings)
+		}
+	}
+}
+
+// testArtefactCache verifies that an archive put into c can be retrieved
+// and extracted to restore the original files. It returns the action ID
+// used.
+func testArtefactCache(t *testing.T, td string, c artefactCache) cache.ActionID {
+	id := cache.ActionID(newHash("## test").Sum())
+	if _, err := c.GetFile(id); err != errCacheMiss {
+		t.Fatalf("expected cache miss; got %v", err)
+	}
+
+	workings := filepath.Join(td, "workings")
+	os.Mkdir(workings, 0777)
+
+	files := []struct {
+		path     string
+		contents string
+	}{
+		{filepath.Join(td, "gen_a_test.go"), "this is a"},
+		{filepath.Join(td, "gen_b_test.go"), "this is b"},
+	}
+	for _, f := range files {
+		if err := ioutil.WriteFile(f.path, []byte(f.contents), 0666); err != nil {
+			t.Fatalf("failed to write file %v: %v", f.path, err)
+		}
+	}
+	w, err := newArchiveWriter(workings, "")
+	if err != nil {
+		t.Fatalf("failed to create writer: %v", err)
+	}
+	for _, f := range files {
+		if err := w.PutFile(f.path); err != nil {
+			t.Fatalf("failed to put file %v: %v", f.path, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to close writer: %v", err)
+	}
+	if err := c.PutFile(id, w.file.Name()); err != nil {
+		t.Fatalf("failed to put archive: %v", err)
+	}
+	for _, f := range files {
+		if err := os.Remove(f.path); err != nil {
+			t.Fatalf("failed to remove %v: %v", f.path, err)
+		}
+	}
+
+	fp, err := c.GetFile(id)
+	if err != nil {
+		t.Fatalf("failed to get archive: %v", err)
+	}
+	r, err := newArchiveReader(fp)
+	if err != nil {
+		t.Fatalf("failed to create reader: %v", err)
+	}
+	for _, f := range files {
+		fn, err := r.ExtractFile()
+		if err != nil {
+			t.Fatalf("failed to extract file: %v", err)
+		}
+		if fn != f.path {
+			t.Fatalf("failed to extract correct file name; got %v, want %v", fn, f.path)
+		}
+		fc, err := ioutil.ReadFile(f.path)
+		if err != nil {
+			t.Fatalf("failed to read back file %v: %v", f.path, err)
+		}
+		if f.contents != string(fc) {
+			t.Fatalf("mismatch of contents in %v:\n%q\n%q\n", f.path, f.contents, string(fc))
+		}
+	}
+	if _, err := r.ExtractFile(); err != io.EOF {
+		t.Fatalf("expected io.EOF; got %v", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("failed to close reader: %v", err)
+	}
+	return id
+}
+
+// memCacheHandler is a minimal in-memory implementation of the protocol
+// expected by httpCache
+type memCacheHandler struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+func newMemCacheHandler() *memCacheHandler {
+	return &memCacheHandler{
+		entries: make(map[string][]byte),
+	}
+}
+
+func (m *memCacheHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	switch r.Method {
+	case http.MethodGet:
+		e, ok := m.entries[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		io.Copy(w, bytes.NewReader(e))
+	case http.MethodPut:
+		e, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		m.entries[key] = e
+	default:
+		http.Error(w, "unsupported method", http.StatusMethodNotAllowed)
+	}
+}
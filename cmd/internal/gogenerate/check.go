@@ -0,0 +1,462 @@
+package gogenerate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rogpeppe/go-internal/cache"
+
+	coregogenerate "myitcv.io/gogenerate"
+)
+
+var errStale = errors.New("generated files are not up to date")
+
+// checkResult records the outcome of checking a package in -check mode
+type checkResult struct {
+	pkg *pkg
+
+	// miss indicates there was no record of the generated files for the
+	// current inputs of the package, and hence its expected output could not
+	// be determined
+	miss bool
+
+	// current holds the generated files on disk for the package in the case
+	// of a miss, for use by checkMisses
+	current map[string][hashSize]byte
+
+	problems []checkProblem
+}
+
+type checkProblem struct {
+	// kind is one of missing, stale or extra
+	kind string
+
+	// path is the absolute path of the file
+	path string
+
+	// dirs are the directives responsible for generating path
+	dirs []*directive
+}
+
+// recordCheck records files, the paths of the files generated by w at a fixed
+// point, against ck, the check key for w. The check key is the hash of the
+// inputs to the directives of w, excluding the files they generate, and so
+// identifies the generated files expected for those inputs regardless of the
+// current state of the generated files themselves.
+func (g *gogenerate) recordCheck(w *pkg, ck cache.ActionID, files map[string][hashSize]byte) {
+	// there is nothing to do if the generated files have already been
+	// recorded for these inputs
+	if _, err := g.cache.GetFile(ck); err == nil {
+		return
+	}
+	var fns []string
+	for fn := range files {
+		fns = append(fns, fn)
+	}
+	sort.Strings(fns)
+	ar := g.newArchive()
+	for _, fn := range fns {
+		if err := ar.PutFile(fn); err != nil {
+			g.fatalf("failed to put %v into archive: %v", fn, err)
+		}
+	}
+	if err := g.cachePutArchive(ck, ar); err != nil {
+		g.fatalf("failed to write generated files of %v to cache: %v", w.ImportPath, err)
+	}
+}
+
+// checkPkg determines whether the generated files on disk for w are those that
+// would result from running its directives. This is done by looking up the
+// generated files recorded against ck, the check key for the current inputs
+// of w, and comparing them with current, the generated files on disk. Nothing
+// is written to disk. id is the action ID for the current state of w,
+// including its generated files, and ex is its explanation.
+func (g *gogenerate) checkPkg(w *pkg, ck, id cache.ActionID, ex *explanation, current map[string][hashSize]byte) {
+	res := &checkResult{pkg: w}
+	g.checkResults = append(g.checkResults, res)
+
+	fp, err := g.cache.GetFile(ck)
+	if err == errCacheMiss {
+		emitCacheEvent(actionCacheMiss, w, ck)
+		g.explain(w, id, ex, false)
+		res.miss = true
+		res.current = current
+		return
+	}
+	if err != nil {
+		g.fatalf("failed to get archive from cache: %v", err)
+	}
+	emitCacheEvent(actionCacheHit, w, ck)
+	g.explain(w, id, ex, true)
+	r, err := newArchiveReader(fp)
+	if err != nil {
+		g.fatalf("failed to open archive for %v: %v", w.ImportPath, err)
+	}
+	defer r.Close()
+	expected := make(map[string]bool)
+	for {
+		fn, _, cr, err := r.NextFile()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			g.fatalf("failed to read archive for %v: %v", w.ImportPath, err)
+		}
+		expected[fn] = true
+		want, err := ioutil.ReadAll(cr)
+		if err != nil {
+			g.fatalf("failed to read %v from archive for %v: %v", fn, w.ImportPath, err)
+		}
+		g.checkFile(res, fn, want)
+	}
+	g.checkExtra(res, current, expected)
+}
+
+// checkFile records a problem in res if fn, a file generated by res.pkg, does
+// not have the contents want.
+func (g *gogenerate) checkFile(res *checkResult, fn string, want []byte) {
+	var kind string
+	got, err := ioutil.ReadFile(fn)
+	switch {
+	case os.IsNotExist(err):
+		kind = "missing"
+	case err != nil:
+		g.fatalf("failed to read %v: %v", fn, err)
+	case !bytes.Equal(got, want):
+		kind = "stale"
+	default:
+		return
+	}
+	res.problems = append(res.problems, checkProblem{
+		kind: kind,
+		path: fn,
+		dirs: res.pkg.dirsGenerating(fn),
+	})
+}
+
+// checkExtra records a problem in res for each of current, the files on disk
+// generated by res.pkg, that is not expected.
+func (g *gogenerate) checkExtra(res *checkResult, current map[string][hashSize]byte, expected map[string]bool) {
+	for fn := range current {
+		if expected[fn] {
+			continue
+		}
+		res.problems = append(res.problems, checkProblem{
+			kind: "extra",
+			path: fn,
+			dirs: res.pkg.dirsGenerating(fn),
+		})
+	}
+}
+
+// checkMisses determines the expected generated files of the packages for
+// which checkPkg found no record in the cache, by running gogenerate on a
+// temporary copy of the main module, without its generated files, with an
+// empty cache, and compares them
+// with the generated files on disk. This is only possible in module mode, and
+// for packages that generate files within the main module; other misses are
+// left as such.
+func (g *gogenerate) checkMisses() {
+	var misses []*checkResult
+	for _, r := range g.checkResults {
+		if r.miss {
+			misses = append(misses, r)
+		}
+	}
+	if len(misses) == 0 || g.mainMod == "" || g.mainMod == os.DevNull {
+		return
+	}
+	root := filepath.Dir(g.mainMod)
+	td, err := ioutil.TempDir(g.tempDir, "check")
+	if err != nil {
+		g.fatalf("failed to create temp dir for -check: %v", err)
+	}
+	copyRoot := filepath.Join(td, "main")
+	if err := copyModule(root, copyRoot); err != nil {
+		g.fatalf("failed to copy main module to %v: %v", copyRoot, err)
+	}
+	inCopy := func(path string) (string, bool) {
+		rel, err := filepath.Rel(root, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+			return "", false
+		}
+		return filepath.Join(copyRoot, rel), true
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		g.fatalf("failed to determine working directory: %v", err)
+	}
+	cwd, ok := inCopy(wd)
+	if !ok {
+		g.fatalf("working directory %v is not within the main module %v", wd, root)
+	}
+	self := os.Args[0]
+	if strings.ContainsRune(self, os.PathSeparator) {
+		self, err = filepath.Abs(self)
+	} else {
+		self, err = exec.LookPath(self)
+	}
+	if err != nil {
+		g.fatalf("failed to resolve %v: %v", os.Args[0], err)
+	}
+	args := []string{
+		"-p", strconv.Itoa(*fWorkP),
+		"-r", strconv.Itoa(*fMaxGenIterations),
+	}
+	if *fMod != "" {
+		args = append(args, "-mod="+*fMod)
+	}
+	if len(fTags) > 0 {
+		args = append(args, "-tags="+strings.Join(fTags, ","))
+	}
+	for _, patt := range g.cliPatts {
+		if filepath.IsAbs(patt) {
+			if cp, ok := inCopy(patt); ok {
+				patt = cp
+			}
+		}
+		args = append(args, patt)
+	}
+	cmd := exec.Command(self, args...)
+	cmd.Dir = cwd
+	cmd.Env = append(os.Environ(),
+		"GOGENERATECACHE="+filepath.Join(td, "cache"),
+		"GOGENERATEREMOTECACHE=",
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		g.fatalf("failed to run %v in copy of main module %v: %v\n%s", strings.Join(cmd.Args, " "), cwd, err, out)
+	}
+
+Misses:
+	for _, r := range misses {
+		w := r.pkg
+		expected := make(map[string]bool)
+		var fns []string
+		for _, od := range w.outDirs() {
+			cod, ok := inCopy(od)
+			if !ok {
+				continue Misses
+			}
+			ls, err := ioutil.ReadDir(cod)
+			if err != nil && !os.IsNotExist(err) {
+				g.fatalf("failed to read dir %v: %v", cod, err)
+			}
+			for _, fi := range ls {
+				fn := filepath.Join(od, fi.Name())
+				if fi.IsDir() || len(w.dirsGenerating(fn)) == 0 {
+					continue
+				}
+				expected[fn] = true
+				fns = append(fns, fn)
+			}
+		}
+		r.miss = false
+		for _, fn := range fns {
+			cfn, _ := inCopy(fn)
+			want, err := ioutil.ReadFile(cfn)
+			if err != nil {
+				g.fatalf("failed to read %v: %v", cfn, err)
+			}
+			g.checkFile(r, fn, want)
+		}
+		g.checkExtra(r, r.current, expected)
+	}
+}
+
+// copyModule copies the module rooted at src to dst, without its generated
+// files. Hidden directories and the directories of other modules are not
+// copied.
+func copyModule(src, dst string) error {
+	return filepath.Walk(src, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case fi.IsDir():
+			if path != src {
+				if strings.HasPrefix(fi.Name(), ".") {
+					return filepath.SkipDir
+				}
+				if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+					return filepath.SkipDir
+				}
+			}
+			return os.MkdirAll(target, 0777)
+		case fi.Mode().IsRegular():
+			if _, _, ok := coregogenerate.AnyFileIsGenerated(fi.Name()); ok {
+				return nil
+			}
+			cts, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			return ioutil.WriteFile(target, cts, fi.Mode().Perm())
+		}
+		return nil
+	})
+}
+
+// orphanedFiles returns, for each package to be generated, the generated files
+// in its directory for which there is no longer a corresponding directive in
+// any package that generates to that directory. A package that is not to be
+// generated can nonetheless generate to the directory of one that is, hence
+// the directives of all loaded packages, and in module mode all packages in
+// the main module, are considered.
+func (g *gogenerate) orphanedFiles() map[*pkg][]string {
+	dirGens := make(map[string]map[string]bool)
+	addGens := func(p *Package) {
+		files := map[string][]string{
+			p.Name:           stringList(p.GoFiles, p.CgoFiles, p.TestGoFiles),
+			p.Name + "_test": p.XTestGoFiles,
+		}
+		for pkgName, fns := range files {
+			for _, fn := range fns {
+				ds, err := coregogenerate.ParseDirectives(pkgName, p.Dir, fn)
+				if err != nil {
+					g.fatalf("failed to walk %v%v%v for go:generate directives: %v", p.Dir, string(os.PathSeparator), fn, err)
+				}
+				for _, d := range ds {
+					if d.Form == coregogenerate.FormSpecial {
+						continue
+					}
+					for _, od := range append([]string{p.Dir}, d.OutDirs...) {
+						gens := dirGens[od]
+						if gens == nil {
+							gens = make(map[string]bool)
+							dirGens[od] = gens
+						}
+						gens[d.Name()] = true
+					}
+				}
+			}
+		}
+	}
+	seen := make(map[string]bool)
+	for _, p := range g.pkgLookup {
+		if p.Package == nil {
+			continue
+		}
+		seen[p.Dir] = true
+		addGens(p.Package)
+	}
+	if g.mainMod != "" && g.mainMod != os.DevNull {
+		patt := filepath.Join(filepath.Dir(g.mainMod), "...")
+		pkgs, err := g.list([]string{patt}, "-e")
+		if err != nil {
+			g.fatalf("failed to list packages in main module: %v", err)
+		}
+		for _, p := range pkgs {
+			if !seen[p.Dir] {
+				addGens(p)
+			}
+		}
+	}
+	res := make(map[*pkg][]string)
+	for _, p := range g.pkgLookup {
+		if !p.generate {
+			continue
+		}
+		ls, err := ioutil.ReadDir(p.Dir)
+		if err != nil {
+			g.fatalf("failed to read dir %v: %v", p.Dir, err)
+		}
+		for _, fi := range ls {
+			if fi.IsDir() {
+				continue
+			}
+			gen, _, ok := coregogenerate.AnyFileIsGenerated(fi.Name())
+			if !ok || dirGens[p.Dir][gen] {
+				continue
+			}
+			res[p] = append(res[p], filepath.Join(p.Dir, fi.Name()))
+		}
+	}
+	return res
+}
+
+// checkReport writes a report of the results of -check mode to w, returning
+// errStale if any problems were found.
+func (g *gogenerate) checkReport(w io.Writer) error {
+	byPkg := make(map[*pkg]*checkResult)
+	for _, r := range g.checkResults {
+		// results for an external test package are reported against the
+		// package under test
+		p := r.pkg
+		if p.isXTest {
+			p = g.dirLookup[p.Dir]
+		}
+		pr, ok := byPkg[p]
+		if !ok {
+			pr = &checkResult{pkg: p}
+			byPkg[p] = pr
+		}
+		pr.miss = pr.miss || r.miss
+		pr.problems = append(pr.problems, r.problems...)
+	}
+	for p, fns := range g.orphanedFiles() {
+		pr, ok := byPkg[p]
+		if !ok {
+			pr = &checkResult{pkg: p}
+			byPkg[p] = pr
+		}
+		for _, fn := range fns {
+			pr.problems = append(pr.problems, checkProblem{
+				kind: "extra",
+				path: fn,
+			})
+		}
+	}
+
+	var pkgs []*pkg
+	for p := range byPkg {
+		pkgs = append(pkgs, p)
+	}
+	sort.Slice(pkgs, func(i, j int) bool {
+		return pkgs[i].ImportPath < pkgs[j].ImportPath
+	})
+
+	var failed bool
+	for _, p := range pkgs {
+		r := byPkg[p]
+		if r.miss {
+			failed = true
+			fmt.Fprintf(w, "%v: no cache entry for the current inputs; run gogenerate to determine the expected generated files\n", p.ImportPath)
+		}
+		sort.SliceStable(r.problems, func(i, j int) bool {
+			return r.problems[i].path < r.problems[j].path
+		})
+		for _, pr := range r.problems {
+			failed = true
+			fn := pr.path
+			if rel, err := filepath.Rel(p.Dir, fn); err == nil && !strings.HasPrefix(rel, "..") {
+				fn = rel
+			}
+			if len(pr.dirs) == 0 {
+				fmt.Fprintf(w, "%v: %v %v\n", p.ImportPath, pr.kind, fn)
+				continue
+			}
+			for _, d := range pr.dirs {
+				fmt.Fprintf(w, "%v: %v:%v: %v: %v %v\n", p.ImportPath, d.file, d.line, d.gen.DirectiveName(), pr.kind, fn)
+			}
+		}
+	}
+	if failed {
+		return errStale
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package gogenerate
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// clean removes the generated files in the directories of the packages to be
+// generated for which there is no longer a corresponding directive. No
+// directives are run. With -n, the files that would be removed are instead
+// reported to w.
+func (g *gogenerate) clean(w io.Writer) {
+	logTiming("start clean")
+	g.load()
+
+	var fns []string
+	for _, pfns := range g.orphanedFiles() {
+		fns = append(fns, pfns...)
+	}
+	sort.Strings(fns)
+	for _, fn := range fns {
+		if *fCleanN {
+			fmt.Fprintf(w, "rm %v\n", fn)
+			continue
+		}
+		logTrace("remove %v", fn)
+		if err := os.Remove(fn); err != nil {
+			g.fatalf("failed to remove %v: %v", fn, err)
+		}
+	}
+}
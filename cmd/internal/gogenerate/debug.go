@@ -1,14 +1,21 @@
 package gogenerate
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
+	"sync"
 	"time"
 )
 
 // TODO tidy up the distinction between tracing and timing. Perhaps they belong
 // as a single concept
 
+// logMu guards writes to tabber and the flushing of buffered output, both of
+// which can happen concurrently when -p > 1
+var logMu sync.Mutex
+
 func debugf(format string, args ...interface{}) {
 	if format[len(format)-1] != '\n' {
 		format += "\n"
@@ -18,11 +25,20 @@ func debugf(format string, args ...interface{}) {
 	}
 }
 
+// warnf reports a problem that does not prevent gogenerate from continuing
+func warnf(format string, args ...interface{}) {
+	logMu.Lock()
+	defer logMu.Unlock()
+	fmt.Fprintf(os.Stderr, "warning: "+format+"\n", args...)
+}
+
 func logTiming(format string, args ...interface{}) {
 	ms := func(pre, post time.Time) int64 {
 		return int64(post.Sub(pre) / time.Millisecond)
 	}
 	if *fTraceTime {
+		logMu.Lock()
+		defer logMu.Unlock()
 		now := time.Now()
 		fmt.Fprintf(tabber, "%v\t %v\t - %v\n", ms(startTime, now), ms(lastTime, now), fmt.Sprintf(format, args...))
 		lastTime = now
@@ -30,10 +46,55 @@ func logTiming(format string, args ...interface{}) {
 }
 
 func logTrace(format string, args ...interface{}) {
+	logTraceTo(os.Stderr, format, args...)
+}
+
+func logTraceTo(w io.Writer, format string, args ...interface{}) {
 	if format[len(format)-1] != '\n' {
 		format += "\n"
 	}
 	if *fTrace {
-		fmt.Fprintf(os.Stderr, format, args...)
+		fmt.Fprintf(w, format, args...)
+	}
+}
+
+// pkgOutput collects the trace output for a package, along with the output of
+// its generators, so that it can be written as a single block. This keeps the
+// output for each package well defined even when packages are generated
+// concurrently. When -p is 1 output is written directly.
+type pkgOutput struct {
+	stdout io.Writer
+	stderr io.Writer
+
+	outBuf bytes.Buffer
+	errBuf bytes.Buffer
+}
+
+func newPkgOutput() *pkgOutput {
+	res := &pkgOutput{
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	}
+	if *fWorkP > 1 {
+		res.stdout = &res.outBuf
+		res.stderr = &res.errBuf
+	}
+	if *fJSON {
+		// stdout is reserved for the stream of JSON events
+		res.stdout = res.stderr
+	}
+	return res
+}
+
+func (p *pkgOutput) flush() {
+	logMu.Lock()
+	defer logMu.Unlock()
+	if *fJSON {
+		os.Stderr.Write(p.outBuf.Bytes())
+	} else {
+		os.Stdout.Write(p.outBuf.Bytes())
 	}
+	os.Stderr.Write(p.errBuf.Bytes())
+	p.outBuf.Reset()
+	p.errBuf.Reset()
 }
@@ -4,9 +4,6 @@ import (
 	"fmt"
 	"path/filepath"
 	"sort"
-	"strings"
-
-	coregogenerate "myitcv.io/gogenerate"
 )
 
 type dep interface {
@@ -89,71 +86,23 @@ func (m missingDeps) add(ip string, p *pkg) {
 	deps[p] = true
 }
 
-func parseOutDirs(dir string, args []string) ([]string, error) {
-	outDirs := make(map[string]bool)
-	for i := 0; i < len(args); i++ {
-		v := args[i]
-		if v == "--" {
-			break
-		}
-		if !strings.HasPrefix(v, "-"+coregogenerate.FlagOutDirPrefix) {
-			continue
-		}
-		v = strings.TrimPrefix(v, "-"+coregogenerate.FlagOutDirPrefix)
-		j := strings.Index(v, "=")
-		var d string
-		if j == -1 {
-			if i+1 == len(args) || args[i+1] == "--" {
-				return nil, fmt.Errorf("invalid output dir flag amongst: %v", args)
-			}
-			d = args[i+1]
-		} else {
-			d = v[j+1:]
-		}
-		if !filepath.IsAbs(d) {
-			d = filepath.Join(dir, d)
-		}
+// evalOutDirs returns the directories outDirs, as declared by a directive in
+// the package in dir, with symlinks evaluated, sorted and without duplicates.
+// dir itself is excluded.
+func evalOutDirs(dir string, outDirs []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var res []string
+	for _, d := range outDirs {
 		ed, err := filepath.EvalSymlinks(d)
 		if err != nil {
 			return nil, fmt.Errorf("failed to eval symlinks for dir %v: %v", d, err)
 		}
-		outDirs[ed] = true
-	}
-	var dirs []string
-	for d := range outDirs {
-		dirs = append(dirs, d)
-	}
-	sort.Strings(dirs)
-	return dirs, nil
-}
-
-func parseInFilePatts(dir string, args []string) ([]string, error) {
-	inFilePatts := make(map[string]bool)
-	for i := 0; i < len(args); i++ {
-		v := args[i]
-		if v == "--" {
-			break
-		}
-		if !strings.HasPrefix(v, "-"+coregogenerate.FlagInFilesPrefix) {
+		if ed == dir || seen[ed] {
 			continue
 		}
-		v = strings.TrimPrefix(v, "-"+coregogenerate.FlagInFilesPrefix)
-		j := strings.Index(v, "=")
-		var p string
-		if j == -1 {
-			if i+1 == len(args) || args[i+1] == "--" {
-				return nil, fmt.Errorf("invalid in files pattern flag amongst: %v", args)
-			}
-			p = args[i+1]
-		} else {
-			p = v[j+1:]
-		}
-		inFilePatts[p] = true
-	}
-	var patts []string
-	for p := range inFilePatts {
-		patts = append(patts, p)
+		seen[ed] = true
+		res = append(res, ed)
 	}
-	sort.Strings(patts)
-	return patts, nil
+	sort.Strings(res)
+	return res, nil
 }
@@ -0,0 +1,90 @@
+package gogenerate
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/rogpeppe/go-internal/cache"
+)
+
+// dirCache is an artefactCache backed by a directory that can be shared
+// between machines, for example over NFS. Each archive is stored in a single
+// file named by its action ID. Archives are written to a temporary file in the
+// target directory and then renamed into place, so readers only ever see
+// complete archives, and concurrent writers of the same action ID (which by
+// definition write the same content) do not interfere with one another.
+//
+// gogenerate never trims a dirCache.
+type dirCache struct {
+	dir string
+}
+
+func openDirCache(dir string) (*dirCache, error) {
+	if !filepath.IsAbs(dir) {
+		return nil, fmt.Errorf("shared cache directory %v is not absolute", dir)
+	}
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return nil, fmt.Errorf("failed to create shared cache dir %v: %v", dir, err)
+	}
+	return &dirCache{dir: dir}, nil
+}
+
+func (d *dirCache) path(id cache.ActionID) string {
+	name := fmt.Sprintf("%x", id)
+	return filepath.Join(d.dir, name[:2], name)
+}
+
+func (d *dirCache) GetFile(id cache.ActionID) (string, error) {
+	fp := d.path(id)
+	if _, err := os.Stat(fp); err != nil {
+		if os.IsNotExist(err) {
+			return "", errCacheMiss
+		}
+		return "", fmt.Errorf("failed to stat %v: %v", fp, err)
+	}
+	return fp, nil
+}
+
+func (d *dirCache) PutFile(id cache.ActionID, path string) (reterr error) {
+	fp := d.path(id)
+	dir := filepath.Dir(fp)
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return fmt.Errorf("failed to create shared cache dir %v: %v", dir, err)
+	}
+	src, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open archive %v for reading: %v", path, err)
+	}
+	defer src.Close()
+	tf, err := ioutil.TempFile(dir, ".tmp-"+filepath.Base(fp)+"-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file in %v: %v", dir, err)
+	}
+	defer func() {
+		if reterr != nil {
+			tf.Close()
+			os.Remove(tf.Name())
+		}
+	}()
+	if _, err := io.Copy(tf, src); err != nil {
+		return fmt.Errorf("failed to write archive to %v: %v", tf.Name(), err)
+	}
+	if err := tf.Sync(); err != nil {
+		return fmt.Errorf("failed to sync %v: %v", tf.Name(), err)
+	}
+	if err := tf.Close(); err != nil {
+		return fmt.Errorf("failed to close %v: %v", tf.Name(), err)
+	}
+	if err := os.Chmod(tf.Name(), 0666); err != nil {
+		return fmt.Errorf("failed to chmod %v: %v", tf.Name(), err)
+	}
+	if err := os.Rename(tf.Name(), fp); err != nil {
+		return fmt.Errorf("failed to rename %v to %v: %v", tf.Name(), fp, err)
+	}
+	return nil
+}
+
+var _ artefactCache = (*dirCache)(nil)
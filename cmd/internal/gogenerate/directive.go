@@ -1,6 +1,10 @@
 package gogenerate
 
-import "fmt"
+import (
+	"fmt"
+
+	coregogenerate "myitcv.io/gogenerate"
+)
 
 type directive struct {
 	pkgName     string
@@ -10,6 +14,9 @@ type directive struct {
 	gen         generator
 	outDirs     []string
 	inFilePatts []string
+
+	// conds are the conditions of a special gogenerate directive
+	conds []coregogenerate.Cond
 }
 
 func (d directive) String() string {
@@ -0,0 +1,220 @@
+package gogenerate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/rogpeppe/go-internal/cache"
+)
+
+// explanation records the components of a hash: the inputs that were written
+// to the hash, each reduced to a key that identifies the input and a value
+// that summarises its contents. Explanations are stored in the local cache,
+// keyed by the hash they explain, so that a change in hash between runs can
+// later be explained in terms of the components that changed.
+type explanation struct {
+	Components []component
+}
+
+type component struct {
+	Key   string
+	Value string
+
+	// Pkg is the hex-encoded hash of the package identified by Key, if any.
+	// It allows a change in a package dependency to be explained in terms of
+	// the components of that package.
+	Pkg string `json:",omitempty"`
+}
+
+func (e *explanation) add(key, value string) {
+	if e == nil {
+		return
+	}
+	e.Components = append(e.Components, component{Key: key, Value: value})
+}
+
+// addDep records the hash of the dependency d
+func (e *explanation) addDep(d dep) {
+	switch d := d.(type) {
+	case *pkg:
+		e.addPkg("pkg "+d.ImportPath, d.hash)
+	case *gobinModDep:
+		e.addPkg("gobinModDep "+d.importPath, d.pkg.hash)
+	case *gobinGlobalDep:
+		e.add("gobinGlobalDep "+d.targetPath, fmt.Sprintf("%x", d.hash))
+	case *commandDep:
+		e.add("commandDep "+d.name, fmt.Sprintf("%x", d.hash))
+	default:
+		panic(fmt.Errorf("unknown dep type %T", d))
+	}
+}
+
+func (e *explanation) addPkg(key string, h [hashSize]byte) {
+	if e == nil {
+		return
+	}
+	v := fmt.Sprintf("%x", h)
+	e.Components = append(e.Components, component{Key: key, Value: v, Pkg: v})
+}
+
+// hashFileExplain is like hashFile but also records the hash of the
+// file in ex, if ex is non-nil
+func (g *gogenerate) hashFileExplain(hw io.Writer, ex *explanation, dir, file string) {
+	if ex == nil {
+		g.hashFile(hw, dir, file)
+		return
+	}
+	fp := file
+	if !filepath.IsAbs(file) {
+		fp = filepath.Join(dir, file)
+	}
+	fh := newHash("## explain file " + fp)
+	g.hashFile(io.MultiWriter(hw, fh), dir, file)
+	ex.add("file "+fp, fmt.Sprintf("%x", fh.Sum()))
+}
+
+func explainID(kind string, name string) cache.ActionID {
+	h := newHash("## explain " + kind)
+	fmt.Fprintf(h, "## explain %v %v\n", kind, name)
+	return cache.ActionID(h.Sum())
+}
+
+func latestID(w *pkg) cache.ActionID {
+	return explainID("latest", fmt.Sprintf("%v %v", w.ImportPath, w.genCount))
+}
+
+// putExplanation stores ex as the explanation of the hash h
+func (g *gogenerate) putExplanation(h [hashSize]byte, ex *explanation) {
+	byts, err := json.Marshal(ex)
+	if err != nil {
+		g.fatalf("failed to marshal explanation: %v", err)
+	}
+	if err := g.explainCache.PutBytes(explainID("components", fmt.Sprintf("%x", h)), byts); err != nil {
+		g.fatalf("failed to write explanation to cache: %v", err)
+	}
+}
+
+// getExplanation returns the explanation of the hex-encoded hash h, or nil
+// if there is no such explanation
+func (g *gogenerate) getExplanation(h string) *explanation {
+	byts, _, err := g.explainCache.GetBytes(explainID("components", h))
+	if err != nil {
+		return nil
+	}
+	var res explanation
+	if err := json.Unmarshal(byts, &res); err != nil {
+		return nil
+	}
+	return &res
+}
+
+// recordAction records that id was the action ID that was used to generate w
+// at its current iteration, along with ex, the explanation of id. Building
+// and storing explanations is not free, hence ex is nil, and nothing is
+// recorded, unless -explain is set.
+func (g *gogenerate) recordAction(w *pkg, id cache.ActionID, ex *explanation) {
+	if ex == nil {
+		return
+	}
+	g.putExplanation(id, ex)
+	if err := g.explainCache.PutBytes(latestID(w), id[:]); err != nil {
+		g.fatalf("failed to write explanation to cache: %v", err)
+	}
+}
+
+// isExplainTarget reports whether w is the package named by -explain
+func (g *gogenerate) isExplainTarget(w *pkg) bool {
+	if *fExplain == "" {
+		return false
+	}
+	if w.isXTest {
+		w = g.dirLookup[w.Dir]
+	}
+	return w.ImportPath == *fExplain
+}
+
+// explain writes to stdout an explanation for the cache hit or miss of the
+// action ID id for w. In the case of a miss, the components of ex are
+// compared with those of the action ID previously used for w at the same
+// iteration. explain must be called before recordAction.
+func (g *gogenerate) explain(w *pkg, id cache.ActionID, ex *explanation, hit bool) {
+	if !g.isExplainTarget(w) {
+		return
+	}
+	g.explained = true
+	var buf bytes.Buffer
+	res := "cache miss"
+	if hit {
+		res = "cache hit"
+	}
+	fmt.Fprintf(&buf, "%v: iteration %v: %v\n", w.ImportPath, w.genCount, res)
+	if !hit {
+		prev, _, err := g.explainCache.GetBytes(latestID(w))
+		switch {
+		case err != nil || len(prev) != hashSize:
+			fmt.Fprintf(&buf, "\tno previous run recorded\n")
+		case bytes.Equal(prev, id[:]) && *fskipCache:
+			fmt.Fprintf(&buf, "\tinputs unchanged since previous run; cache skipped\n")
+		case bytes.Equal(prev, id[:]):
+			fmt.Fprintf(&buf, "\tinputs unchanged since previous run; cache entry has been removed\n")
+		default:
+			pex := g.getExplanation(fmt.Sprintf("%x", prev))
+			if pex == nil {
+				fmt.Fprintf(&buf, "\tno explanation for previous run\n")
+				break
+			}
+			g.diffExplanations(&buf, "\t", pex, ex)
+		}
+	}
+	logMu.Lock()
+	defer logMu.Unlock()
+	os.Stdout.Write(buf.Bytes())
+}
+
+// diffExplanations writes to w the components that differ between prev and
+// curr, recursing into the explanations of changed package components where
+// they are available.
+func (g *gogenerate) diffExplanations(w io.Writer, indent string, prev, curr *explanation) {
+	pm := make(map[string]component)
+	for _, c := range prev.Components {
+		pm[c.Key] = c
+	}
+	cm := make(map[string]component)
+	for _, c := range curr.Components {
+		cm[c.Key] = c
+	}
+	var keys []string
+	for k := range pm {
+		keys = append(keys, k)
+	}
+	for k := range cm {
+		if _, ok := pm[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		pc, inPrev := pm[k]
+		cc, inCurr := cm[k]
+		switch {
+		case !inPrev:
+			fmt.Fprintf(w, "%v%v: added\n", indent, k)
+		case !inCurr:
+			fmt.Fprintf(w, "%v%v: removed\n", indent, k)
+		case pc.Value != cc.Value:
+			fmt.Fprintf(w, "%v%v: changed\n", indent, k)
+			if pc.Pkg == "" || cc.Pkg == "" {
+				continue
+			}
+			pex, cex := g.getExplanation(pc.Pkg), g.getExplanation(cc.Pkg)
+			if pex != nil && cex != nil {
+				g.diffExplanations(w, indent+"\t", pex, cex)
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package gogenerate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// goFlags represents the flags in GOFLAGS that are understood by gogenerate,
+// along with those that are not.
+type goFlags struct {
+	// tags are the build tags, sorted and without duplicates
+	tags []string
+
+	// mod is the value of the -mod flag, if any
+	mod string
+
+	// other are the remaining flags in GOFLAGS, in the order they appeared
+	other []string
+}
+
+// parseGoFlags parses s, the value of GOFLAGS. As with the go command, s is a
+// space-separated list of flags, each of the form -flag or -flag=value.
+// Multiple build tags are comma-separated.
+func parseGoFlags(s string) (*goFlags, error) {
+	res := new(goFlags)
+	for _, f := range strings.Fields(s) {
+		if !strings.HasPrefix(f, "-") {
+			return nil, fmt.Errorf("%q is not a flag", f)
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(f, "-"), "-")
+		var val string
+		var hasVal bool
+		if i := strings.Index(name, "="); i != -1 {
+			name, val, hasVal = name[:i], name[i+1:], true
+		}
+		switch name {
+		case "tags":
+			if !hasVal {
+				return nil, fmt.Errorf("missing value for -tags")
+			}
+			res.addTags(strings.Split(val, ",")...)
+		case "mod":
+			if !hasVal {
+				return nil, fmt.Errorf("missing value for -mod")
+			}
+			res.mod = val
+		default:
+			res.other = append(res.other, f)
+		}
+	}
+	return res, nil
+}
+
+func (g *goFlags) addTags(tags ...string) {
+	seen := make(map[string]bool)
+	for _, t := range g.tags {
+		seen[t] = true
+	}
+	for _, t := range tags {
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		g.tags = append(g.tags, t)
+	}
+	sort.Strings(g.tags)
+}
+
+// String returns the value of GOFLAGS that reflects the effective values of
+// g.
+func (g *goFlags) String() string {
+	res := append([]string(nil), g.other...)
+	if g.mod != "" {
+		res = append(res, "-mod="+g.mod)
+	}
+	if len(g.tags) > 0 {
+		res = append(res, "-tags="+strings.Join(g.tags, ","))
+	}
+	return strings.Join(res, " ")
+}
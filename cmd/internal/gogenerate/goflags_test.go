@@ -0,0 +1,36 @@
+package gogenerate
+
+import "testing"
+
+func TestParseGoFlags(t *testing.T) {
+	testCases := []struct {
+		in   string
+		tags []string
+		want string
+		err  string
+	}{
+		{in: "", want: ""},
+		{in: "-tags=a", want: "-tags=a"},
+		{in: "-tags=b,a -count=1", tags: []string{"c", "a"}, want: "-count=1 -tags=a,b,c"},
+		{in: "--mod=vendor -mod=mod -v", want: "-v -mod=mod"},
+		{in: "-tags", err: "missing value for -tags"},
+		{in: "tags=a", err: `"tags=a" is not a flag`},
+	}
+	for _, tc := range testCases {
+		gf, err := parseGoFlags(tc.in)
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("parseGoFlags(%q): got error %v; want %v", tc.in, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseGoFlags(%q): unexpected error: %v", tc.in, err)
+			continue
+		}
+		gf.addTags(tc.tags...)
+		if got := gf.String(); got != tc.want {
+			t.Errorf("parseGoFlags(%q) with tags %q: got %q; want %q", tc.in, tc.tags, got, tc.want)
+		}
+	}
+}
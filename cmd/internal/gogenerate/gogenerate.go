@@ -3,7 +3,6 @@ package gogenerate
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"flag"
 	"fmt"
 	"go/parser"
@@ -38,9 +37,6 @@ const (
 	traceTime = false
 	hashDebug = false
 	skipCache = false
-
-	goGeneratePrefix = "//go:generate"
-	gogeneratePrefix = goGeneratePrefix + ":gogenerate"
 )
 
 type tagsFlag []string
@@ -50,7 +46,9 @@ func (t *tagsFlag) String() string {
 }
 
 func (t *tagsFlag) Set(s string) error {
-	parts := strings.Fields(s)
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
 	*t = append(*t, parts...)
 	return nil
 }
@@ -64,6 +62,13 @@ var (
 	fGraph            = flagSet.Bool("graph", false, "dump dependency graph")
 	fWorkP            = flagSet.Int("p", runtime.NumCPU(), "the number of bits of work that can be run in parallel")
 	fMaxGenIterations = flagSet.Int("r", 10, "maximum number of generation iterations per package")
+	fCheck            = flagSet.Bool("check", false, "check that generated files are up to date, without writing anything")
+	fJSON             = flagSet.Bool("json", false, "write a stream of JSON events to stdout")
+	fClean            = flagSet.Bool("clean", false, "remove generated files for which there is no longer a directive")
+	fCleanN           = flagSet.Bool("n", false, "with -clean, report the files that would be removed without removing them")
+	fWatch            = flagSet.Bool("watch", false, "watch for file changes and regenerate affected packages")
+	fExplain          = flagSet.String("explain", "", "explain why the package with the given import path was regenerated")
+	fMod              = flagSet.String("mod", "", "module download mode to use: readonly, vendor or mod")
 	fTags             tagsFlag
 
 	// isProgram indicates whether we are running via a testscript test or not. In case
@@ -95,6 +100,16 @@ func main1() int {
 		if err == flag.ErrHelp {
 			return 2
 		}
+		e := event{
+			Action: actionError,
+			Output: err.Error(),
+		}
+		if ge, ok := err.(gogenerateerror); ok {
+			e.Package = ge.pkg
+			e.File = ge.file
+			e.Line = ge.line
+		}
+		emitEvent(e)
 		fmt.Fprintln(os.Stderr, err)
 		return 1
 	}
@@ -151,6 +166,34 @@ func mainerr() (reterr error) {
 		return fmt.Errorf("value for -p must be at least 1")
 	}
 
+	if *fCheck && *fskipCache {
+		return fmt.Errorf("-check cannot be used with -skipCache")
+	}
+
+	if *fJSON && *fGraph {
+		return fmt.Errorf("-json cannot be used with -graph")
+	}
+
+	if *fJSON && *fCheck {
+		return fmt.Errorf("-json cannot be used with -check")
+	}
+
+	if *fJSON && *fExplain != "" {
+		return fmt.Errorf("-json cannot be used with -explain")
+	}
+
+	if *fCleanN && !*fClean {
+		return fmt.Errorf("-n can only be used with -clean")
+	}
+
+	if *fClean && (*fCheck || *fExplain != "" || *fJSON) {
+		return fmt.Errorf("-clean cannot be used with -check, -explain or -json")
+	}
+
+	if *fWatch && (*fCheck || *fClean || *fExplain != "" || *fGraph) {
+		return fmt.Errorf("-watch cannot be used with -check, -clean, -explain or -graph")
+	}
+
 	mm, err := mainMod()
 	if err != nil {
 		return fmt.Errorf("failed to determine main module: %v", err)
@@ -168,9 +211,19 @@ func mainerr() (reterr error) {
 		defer os.RemoveAll(td)
 	}
 
-	sort.Slice(fTags, func(i, j int) bool {
-		return fTags[i] < fTags[j]
-	})
+	goFlags, err := parseGoFlags(os.Getenv("GOFLAGS"))
+	if err != nil {
+		return fmt.Errorf("failed to parse GOFLAGS: %v", err)
+	}
+	goFlags.addTags(fTags...)
+	if *fMod != "" {
+		goFlags.mod = *fMod
+	}
+	switch goFlags.mod {
+	case "", "readonly", "vendor", "mod":
+	default:
+		return fmt.Errorf("-mod may only be set to readonly, vendor or mod; got %q", goFlags.mod)
+	}
 
 	artefactsCacheDir := os.Getenv("GOGENERATECACHE")
 	if artefactsCacheDir == "" {
@@ -196,7 +249,19 @@ func mainerr() (reterr error) {
 	}
 	defer os.RemoveAll(td)
 
-	var tags []string
+	var ac artefactCache = localCache{artefactsCache}
+	if rc := os.Getenv("GOGENERATEREMOTECACHE"); rc != "" {
+		remote, err := openRemoteCache(rc, td)
+		if err != nil {
+			return fmt.Errorf("failed to open remote cache %v: %v", rc, err)
+		}
+		ac = &tieredCache{
+			local:  ac,
+			remote: remote,
+			warnf:  warnf,
+		}
+	}
+
 	tagsMap := make(map[string]bool)
 	goos := os.Getenv("GOOS")
 	if goos == "" {
@@ -208,13 +273,9 @@ func mainerr() (reterr error) {
 	}
 	tagsMap[goos] = true
 	tagsMap[goarch] = true
-	for _, t := range fTags {
+	for _, t := range goFlags.tags {
 		tagsMap[t] = true
-		tags = append(tags, t)
 	}
-	// TODO add support for parsing GOFLAGS to extract -tags values
-	// TODO once we get a resolution on https://github.com/golang/go/issues/26849#issuecomment-460301061
-	// we can then set GOFLAGS for in the environment passed to each generator
 
 	gogenerate := &gogenerate{
 		pkgLookup:         make(map[string]*pkg),
@@ -226,9 +287,12 @@ func mainerr() (reterr error) {
 		GOOS:              goos,
 		GOARCH:            goarch,
 		tagsMap:           tagsMap,
-		tags:              tags,
-		cache:             artefactsCache,
+		tags:              goFlags.tags,
+		goFlags:           goFlags,
+		cache:             ac,
+		explainCache:      artefactsCache,
 		tempDir:           td,
+		stats:             newRunStats(),
 	}
 	gogenerate.cliPatts = flagSet.Args()
 	gogenerate.mainMod = mm
@@ -261,8 +325,28 @@ func mainerr() (reterr error) {
 	gogenerate.hashFile(goHash, "", gopath)
 	gogenerate.goHash = goHash.Sum()
 
+	if *fClean {
+		gogenerate.clean(os.Stdout)
+		return reterr
+	}
+
+	if *fWatch {
+		return gogenerate.watch()
+	}
+
 	gogenerate.run()
 
+	if *fExplain != "" && !gogenerate.explained {
+		return fmt.Errorf("-explain: %v is not a package with directives that was generated", *fExplain)
+	}
+
+	if *fCheck {
+		gogenerate.checkMisses()
+		if err := gogenerate.checkReport(os.Stdout); err != nil {
+			return err
+		}
+	}
+
 	return reterr
 }
 
@@ -281,6 +365,11 @@ func mainMod() (string, error) {
 }
 
 type gogenerate struct {
+	// mu guards the dependency graph and the lookup maps below. Work is
+	// executed concurrently (see -p) but only directive execution happens
+	// without mu being held.
+	mu sync.Mutex
+
 	GOOS   string
 	GOARCH string
 
@@ -309,7 +398,19 @@ type gogenerate struct {
 	// tags is just the build tags provided via GOFLAGS or -tags
 	tags []string
 
-	cache *cache.Cache
+	// goFlags are the effective GOFLAGS, i.e. GOFLAGS combined with -tags
+	// and -mod
+	goFlags *goFlags
+
+	cache artefactCache
+
+	// explainCache is the local cache in which the explanations of hashes
+	// are recorded. See explanation
+	explainCache *cache.Cache
+
+	// explained indicates whether an explanation was written for the
+	// package named by -explain
+	explained bool
 
 	tempDir string
 
@@ -320,6 +421,12 @@ type gogenerate struct {
 	// the list functionality of cmd/go, this should be sufficient. i.e. we
 	// don't need to separately hash the compile tool for example.
 	goHash [hashSize]byte
+
+	// checkResults are the results of checking packages in -check mode
+	checkResults []*checkResult
+
+	// stats records the work done by a run, for the summary in -watch mode
+	stats runStats
 }
 
 func (g *gogenerate) allDeps() []dep {
@@ -348,6 +455,7 @@ func (g *gogenerate) generate(w *pkg) (moreWork []dep) {
 			g.fatalf("hit max number of iterations (%v) for %v", *fMaxGenIterations, w.ImportPath)
 		}
 		w.genCount++
+		g.stats.pkgs[w.Dir] = true
 
 		deltaDirs := make(map[string]bool)
 		canContinue := func() bool {
@@ -396,19 +504,37 @@ func (g *gogenerate) generate(w *pkg) (moreWork []dep) {
 		}
 
 		hw := newHash("## generate " + w.ImportPath)
-		fmt.Fprintf(hw, "gogenerate %v", g.selfHash)
-		fmt.Fprintf(hw, "go %v", g.goHash)
-		fmt.Fprintf(hw, "goos %v goarch %v\n", g.GOOS, g.GOARCH)
-		// we add tags to the generate hash because we can't know a generator
-		// will use them.
-		fmt.Fprintf(hw, "tags: %v\n", fTags)
-		fmt.Fprintf(hw, "Deps:\n")
-		g.hashDeps(hw, w)
-		fmt.Fprintf(hw, "Directives:\n")
-		outDirs := make(map[string]bool)
+
+		// ck is the check key for w: the hash of the inputs to its
+		// directives, excluding the files they generate. At a fixed point the
+		// generated files are recorded against ck, for use by -check.
+		ck := newHash("## check " + w.ImportPath)
+		hck := io.MultiWriter(hw, ck)
+
+		// explanations are only recorded with -explain; see recordAction
+		var ex *explanation
+		if *fExplain != "" {
+			ex = new(explanation)
+		}
+		fmt.Fprintf(hck, "gogenerate %v", g.selfHash)
+		ex.add("gogenerate", fmt.Sprintf("%x", g.selfHash))
+		fmt.Fprintf(hck, "go %v", g.goHash)
+		ex.add("go", fmt.Sprintf("%x", g.goHash))
+		fmt.Fprintf(hck, "goos %v goarch %v\n", g.GOOS, g.GOARCH)
+		ex.add("goos/goarch", g.GOOS+"/"+g.GOARCH)
+		// we add tags and GOFLAGS to the generate hash because we can't know
+		// whether a generator will use them.
+		fmt.Fprintf(hck, "tags: %v\n", g.tags)
+		ex.add("tags", strings.Join(g.tags, " "))
+		fmt.Fprintf(hck, "GOFLAGS: %v\n", g.goFlags)
+		ex.add("GOFLAGS", g.goFlags.String())
+		fmt.Fprintf(hck, "Deps:\n")
+		g.hashDeps(hck, ex, w)
+		fmt.Fprintf(hck, "Directives:\n")
 		dirNames := make(map[string]map[generator]bool)
 		for _, d := range w.dirs {
-			fmt.Fprintf(hw, "%v\n", d.HashString())
+			fmt.Fprintf(hck, "%v\n", d.HashString())
+			ex.add(fmt.Sprintf("directive %v:%v", d.file, d.line), d.HashString())
 			if d.gen == nil {
 				// we simply hash the special gogenerate directive
 				continue
@@ -419,25 +545,28 @@ func (g *gogenerate) generate(w *pkg) (moreWork []dep) {
 				dirNames[d.gen.DirectiveName()] = gens
 			}
 			gens[d.gen] = true
-			for _, od := range d.outDirs {
-				outDirs[od] = true
-			}
-		}
-		outDirs[w.Dir] = true
-		var outDirOrder []string
-		for od := range outDirs {
-			outDirOrder = append(outDirOrder, od)
 		}
-		sort.Strings(outDirOrder)
+		outDirOrder := w.outDirs()
 		// TODO performance: in theory we could reuse the "post" from the previous round
 		// for pre. Unclear whether there would be any benefit from so doing
-		pre := g.hashOutDirs(outDirOrder, w, hw, dirNames)
+		pre := g.hashOutDirs(outDirOrder, w, hw, ck, ex, dirNames)
+
+		if *fCheck {
+			g.checkPkg(w, ck.Sum(), hw.Sum(), ex, pre)
+			break
+		}
 
 		if *fskipCache {
 			goto CacheMiss
 		}
 
-		if fp, _, err := g.cache.GetFile(hw.Sum()); err == nil {
+		if fp, err := g.cache.GetFile(hw.Sum()); err != nil && err != errCacheMiss {
+			g.fatalf("failed to get archive from cache: %v", err)
+		} else if err == nil {
+			emitCacheEvent(actionCacheHit, w, hw.Sum())
+			g.stats.hits++
+			g.explain(w, hw.Sum(), ex, true)
+			g.recordAction(w, hw.Sum(), ex)
 			r, err := newArchiveReader(fp)
 			if err != nil {
 				goto CacheMiss
@@ -457,6 +586,12 @@ func (g *gogenerate) generate(w *pkg) (moreWork []dep) {
 			}
 			if len(deltaDirs) == 0 {
 				// zero delta to apply; we are done
+				g.recordCheck(w, ck.Sum(), pre)
+				emitEvent(event{
+					Action:    actionFixedPoint,
+					Package:   w.ImportPath,
+					Iteration: w.genCount,
+				})
 				break
 			}
 
@@ -470,92 +605,15 @@ func (g *gogenerate) generate(w *pkg) (moreWork []dep) {
 		// if we get here we had a cache miss so we are going to have to run
 		// go generate
 
-		logTrace("generate %v", w)
-	RangeDirs:
-		for _, d := range w.dirs {
-			if d.gen == nil {
-				// special gogenerate directive. We know the only possible command for now
-				// is break. Verify the conditions are satisfied and break if so,
-				// else continue to the next dir
-				dirArgs := d.args
-				for strings.HasPrefix(dirArgs[0], "[") && strings.HasSuffix(dirArgs[0], "]") {
-					cond := dirArgs[0]
-					cond = cond[1 : len(cond)-1]
-					cond = strings.TrimSpace(cond)
-					dirArgs = dirArgs[1:]
-					want := true
-					if strings.HasPrefix(cond, "!") {
-						want = false
-						cond = strings.TrimSpace(cond[1:])
-					}
-					switch {
-					case strings.HasPrefix(cond, "exists:"):
-						fn := strings.TrimSpace(strings.TrimPrefix(cond, "exists:"))
-						fn = filepath.Join(w.Dir, fn)
-						_, err := os.Stat(fn)
-						if want != (err == nil) {
-							continue RangeDirs
-						}
-					case strings.HasPrefix(cond, "exec:"):
-						fn := strings.TrimSpace(strings.TrimPrefix(cond, "exec:"))
-						_, err := exec.LookPath(fn)
-						if want != (err == nil) {
-							continue RangeDirs
-						}
-					default:
-						panic("should not be here; we checked the conditions earlier")
-					}
-				}
-				break RangeDirs
-			}
-			cmd := exec.Command(d.args[0], d.args[1:]...)
-			if *fTrace {
-				cmd.Stdout = os.Stdout
-				cmd.Stderr = os.Stderr
-			}
-			cmd.Dir = w.Dir
-			cmd.Env = append(os.Environ(),
-				"GOARCH="+g.GOARCH,
-				"GOOS="+g.GOOS,
-				"GOFILE="+d.file,
-				"GOLINE="+strconv.Itoa(d.line),
-				"GOPACKAGE="+d.pkgName,
-				"DOLLAR="+"$",
-			)
-
-			var traceArgs string
-			if *fTrace || *fTraceTime {
-				var pargs []string
-				for _, a := range d.args {
-					if strings.IndexFunc(a, unicode.IsSpace) != -1 {
-						a = "'" + a + "'"
-					}
-					pargs = append(pargs, a)
-				}
-				traceArgs = strings.Join(pargs, " ")
-				line := fmt.Sprintf("run generator: %v", traceArgs)
-				logTiming(line)
-				logTrace(line)
-			}
-
-			var out []byte
-			var err error
-			if *fTrace {
-				err = cmd.Run()
-			} else {
-				out, err = cmd.CombinedOutput()
-			}
-			if err != nil {
-				g.fatalf("failed to run %v in %v: %v\n%s", strings.Join(cmd.Args, " "), w.Dir, err, out)
-			}
-			if *fTrace || *fTraceTime {
-				line := fmt.Sprintf("ran generator: %v", traceArgs)
-				logTiming(line)
-				logTrace(line)
-			}
+		if !*fskipCache {
+			emitCacheEvent(actionCacheMiss, w, hw.Sum())
 		}
+		g.stats.misses++
+		g.explain(w, hw.Sum(), ex, false)
+
+		g.stats.directives += g.runDirectives(w)
 
-		post := g.hashOutDirs(outDirOrder, w, nil, dirNames)
+		post := g.hashOutDirs(outDirOrder, w, nil, nil, nil, dirNames)
 		ar := g.newArchive()
 
 		// TODO work out if/how we handle file removals, i.e. files that got _removed_
@@ -573,6 +631,13 @@ func (g *gogenerate) generate(w *pkg) (moreWork []dep) {
 			if err := g.cachePutArchive(hw.Sum(), ar); err != nil {
 				g.fatalf("failed to put zero-length archive: %v", err)
 			}
+			g.recordAction(w, hw.Sum(), ex)
+			g.recordCheck(w, ck.Sum(), post)
+			emitEvent(event{
+				Action:    actionFixedPoint,
+				Package:   w.ImportPath,
+				Iteration: w.genCount,
+			})
 			break
 		}
 
@@ -585,6 +650,7 @@ func (g *gogenerate) generate(w *pkg) (moreWork []dep) {
 		if err := g.cachePutArchive(hw.Sum(), ar); err != nil {
 			g.fatalf("failed to write archive to cache: %v", err)
 		}
+		g.recordAction(w, hw.Sum(), ex)
 
 		if canContinue() {
 			continue
@@ -594,6 +660,96 @@ func (g *gogenerate) generate(w *pkg) (moreWork []dep) {
 	return
 }
 
+// runDirectives runs the directives of w in order. The caller must hold g.mu;
+// it is released whilst the directives run so that other work, including the
+// generation of other packages, can proceed concurrently.
+func (g *gogenerate) runDirectives(w *pkg) (count int) {
+	g.mu.Unlock()
+	defer g.mu.Lock()
+
+	out := newPkgOutput()
+	defer out.flush()
+
+	logTraceTo(out.stderr, "generate %v", w)
+RangeDirs:
+	for _, d := range w.dirs {
+		if d.gen == nil {
+			// special gogenerate directive. We know the only possible command for now
+			// is break. Verify the conditions are satisfied and break if so,
+			// else continue to the next dir
+			for _, cond := range d.conds {
+				var ok bool
+				switch cond.Kind {
+				case "exists":
+					_, err := os.Stat(filepath.Join(w.Dir, cond.Arg))
+					ok = err == nil
+				case "exec":
+					_, err := exec.LookPath(cond.Arg)
+					ok = err == nil
+				default:
+					panic("should not be here; conditions are checked when directives are parsed")
+				}
+				if ok == cond.Negate {
+					continue RangeDirs
+				}
+			}
+			break RangeDirs
+		}
+		cmd := exec.Command(d.args[0], d.args[1:]...)
+		if *fTrace {
+			cmd.Stdout = out.stdout
+			cmd.Stderr = out.stderr
+		}
+		cmd.Dir = w.Dir
+		cmd.Env = append(os.Environ(),
+			"GOARCH="+g.GOARCH,
+			"GOOS="+g.GOOS,
+			"GOFILE="+d.file,
+			"GOLINE="+strconv.Itoa(d.line),
+			"GOPACKAGE="+d.pkgName,
+			"GOFLAGS="+g.goFlags.String(),
+			"DOLLAR="+"$",
+		)
+
+		var traceArgs string
+		if *fTrace || *fTraceTime {
+			var pargs []string
+			for _, a := range d.args {
+				if strings.IndexFunc(a, unicode.IsSpace) != -1 {
+					a = "'" + a + "'"
+				}
+				pargs = append(pargs, a)
+			}
+			traceArgs = strings.Join(pargs, " ")
+			line := fmt.Sprintf("run generator: %v", traceArgs)
+			logTiming("%v", line)
+			logTraceTo(out.stderr, "%v", line)
+		}
+
+		emitDirectiveEvent(actionStart, w, d, 0, nil)
+		start := time.Now()
+
+		var cmdOut []byte
+		var err error
+		if *fTrace {
+			err = cmd.Run()
+		} else {
+			cmdOut, err = cmd.CombinedOutput()
+		}
+		if err != nil {
+			g.directiveFatalf(w, d, "failed to run %v in %v: %v\n%s", strings.Join(cmd.Args, " "), w.Dir, err, cmdOut)
+		}
+		emitDirectiveEvent(actionFinish, w, d, time.Since(start), cmdOut)
+		count++
+		if *fTrace || *fTraceTime {
+			line := fmt.Sprintf("ran generator: %v", traceArgs)
+			logTiming("%v", line)
+			logTraceTo(out.stderr, "%v", line)
+		}
+	}
+	return count
+}
+
 func (g *gogenerate) addDep(d dep, nd dep) {
 	if *fDebug {
 		var count int
@@ -667,7 +823,10 @@ func (g *gogenerate) list(patts []string, opts ...string) ([]*Package, error) {
 	}
 
 	if len(g.tags) > 0 {
-		opts = append(opts, "-tags="+strings.Join(g.tags, " "))
+		opts = append(opts, "-tags="+strings.Join(g.tags, ","))
+	}
+	if g.goFlags.mod != "" {
+		opts = append(opts, "-mod="+g.goFlags.mod)
 	}
 
 	if !hasDeps {
@@ -675,10 +834,6 @@ func (g *gogenerate) list(patts []string, opts ...string) ([]*Package, error) {
 	}
 
 	opts = append(opts, "-json")
-	opts, err := adjustTagsFlag(opts)
-	if err != nil {
-		return nil, err
-	}
 
 	cmd.Args = append(cmd.Args, opts...)
 	cmd.Args = append(cmd.Args, patts...)
@@ -722,9 +877,9 @@ func (g *gogenerate) list(patts []string, opts ...string) ([]*Package, error) {
 	return res, nil
 }
 
-// load loads the packages resolved by patts and their deps
-func (g *gogenerate) run() {
-	logTiming("start run")
+// load resolves the command line patterns to packages, and builds the
+// dependency graph of those packages and the generators of their directives.
+func (g *gogenerate) load() {
 	// Resolve patterns to pkgs
 	pkgs, err := g.list(g.cliPatts, "-deps", "-test")
 	if err != nil {
@@ -797,7 +952,17 @@ func (g *gogenerate) run() {
 	g.loadMisses(nil, gobinModMisses)
 
 	logTiming("initial loadMisses complete")
+}
+
+func (g *gogenerate) run() {
+	logTiming("start run")
+	g.load()
+	g.work()
+}
 
+// work does the work in the dependency graph that is ready but not done, and
+// all work that results, until all deps are done.
+func (g *gogenerate) work() {
 	// At this point we should have a complete dependency graph, including the generators.
 	// Find roots and start work
 	var work []dep
@@ -842,20 +1007,50 @@ func (g *gogenerate) run() {
 			sortDeps(work)
 		}
 		todo := make(map[dep]bool)
-		var haveGenerate bool
+
+		// Packages with no dependency relation between them can be generated
+		// concurrently, provided they do not write to the same directories.
+		// Work that would conflict with a package already scheduled in this
+		// round is deferred to a later round.
+		busyDirs := make(map[string]bool)
+		var deferred []dep
 		for i := 0; ; i++ {
-			if i == len(work) || haveGenerate || len(todo) == *fWorkP {
-				work = work[i:]
+			if i == len(work) || len(todo) == *fWorkP {
+				work = append(deferred, work[i:]...)
 				break
 			}
 			w := work[i]
-			if !w.Ready() || w.Done() {
+			if !w.Ready() || w.Done() || todo[w] {
 				continue
 			}
-			if p, ok := w.(*pkg); ok && p.generate {
-				haveGenerate = true
+			p, isPkg := w.(*pkg)
+			if isPkg {
+				dirs := []string{p.Dir}
+				if p.generate && len(p.dirs) > 0 {
+					dirs = p.outDirs()
+				}
+				var conflict bool
+				for _, d := range dirs {
+					if busyDirs[d] {
+						conflict = true
+						break
+					}
+				}
+				if conflict {
+					deferred = append(deferred, w)
+					continue
+				}
+				for _, d := range dirs {
+					busyDirs[d] = true
+				}
 			}
 			todo[w] = true
+			if isPkg && p.generate && len(p.dirs) > 0 {
+				emitEvent(event{
+					Action:  actionQueue,
+					Package: p.ImportPath,
+				})
+			}
 		}
 		var todoOrder []dep
 		for w := range todo {
@@ -863,14 +1058,36 @@ func (g *gogenerate) run() {
 		}
 
 		var wg sync.WaitGroup
+		var errMu sync.Mutex
+		var workErr interface{}
 
 		// TODO performance: this is not necessary
 		sortDeps(todoOrder)
 
 		for _, w := range todoOrder {
 			wg.Add(1)
-			func(w dep) {
+			go func(w dep) {
 				defer wg.Done()
+				if !*fDebug {
+					defer func() {
+						if err := recover(); err != nil {
+							errMu.Lock()
+							if workErr == nil {
+								workErr = err
+							}
+							errMu.Unlock()
+						}
+					}()
+				}
+				g.mu.Lock()
+				defer g.mu.Unlock()
+
+				// Another piece of work in this round might have caused w to
+				// become not ready. It will be rescheduled when its deps are
+				// done
+				if !w.Ready() {
+					return
+				}
 				switch w := w.(type) {
 				case *pkg:
 					if w.generate {
@@ -881,6 +1098,9 @@ func (g *gogenerate) run() {
 								work = append(work, moreWork...)
 								return
 							}
+							if !w.Ready() {
+								return
+							}
 						}
 						w.generated = true
 					}
@@ -891,10 +1111,16 @@ func (g *gogenerate) run() {
 					if !w.Standard {
 						logTrace("hash %v", w)
 					}
+					// the components of standard library packages are not
+					// recorded; a change in those is explained by a change in go
+					var ex *explanation
+					if !w.Standard && *fExplain != "" {
+						ex = new(explanation)
+					}
 					hw := newHash("## pkg " + w.ImportPath)
 					fmt.Fprintf(hw, "## pkg %v\n", w.ImportPath)
 					for _, i := range w.Imports {
-						g.hashImport(hw, i)
+						g.hashImport(hw, ex, i)
 					}
 					files := stringList(
 						w.GoFiles,
@@ -910,9 +1136,12 @@ func (g *gogenerate) run() {
 						w.SwigCXXFiles,
 					)
 					for _, fn := range files {
-						g.hashFile(hw, w.Dir, fn)
+						g.hashFileExplain(hw, ex, w.Dir, fn)
 					}
 					w.hash = hw.Sum()
+					if ex != nil {
+						g.putExplanation(w.hash, ex)
+					}
 				case *commandDep:
 					logTrace("hash commandDep %v", w)
 					hw := newHash("## commandDep " + w.name)
@@ -934,6 +1163,9 @@ func (g *gogenerate) run() {
 			}(w)
 		}
 		wg.Wait()
+		if workErr != nil {
+			panic(workErr)
+		}
 		logTiming("round complete %v", todoOrder)
 		for _, w := range todoOrder {
 			if w.Done() {
@@ -991,16 +1223,9 @@ func (g *gogenerate) newArchive() *archiveWriter {
 
 func (g *gogenerate) cachePutArchive(id cache.ActionID, ar *archiveWriter) error {
 	if err := ar.Close(); err != nil {
-		return fmt.Errorf("failed to close archive: %v", ar)
-	}
-	f, err := os.Open(ar.file.Name())
-	if err != nil {
-		return fmt.Errorf("failed to open archive %v for reading: %v", ar.file.Name(), err)
-	}
-	if _, _, err := g.cache.Put(id, f); err != nil {
-		return fmt.Errorf("failed to write archive to cache: %v", err)
+		return fmt.Errorf("failed to close archive: %v", err)
 	}
-	return nil
+	return g.cache.PutFile(id, ar.file.Name())
 }
 
 func (g *gogenerate) isSpecialImport(path string) bool {
@@ -1337,51 +1562,36 @@ func (g *gogenerate) refreshDirectiveDeps(p *pkg, misses missingDeps) {
 	}
 
 	for _, file := range files {
-		hasDirPrefix := func(buf []byte) (string, bool) {
-			for _, c := range []string{goGeneratePrefix, gogeneratePrefix} {
-				for _, v := range []string{" ", "\t"} {
-					if p := []byte(c + v); bytes.HasPrefix(buf, p) {
-						return string(p), true
-					}
-				}
-			}
-			return "", false
+		cds, err := coregogenerate.ParseDirectives(p.Name, p.Dir, file)
+		if err != nil {
+			g.fatalf("failed to walk %v%v%v for go:generate directives: %v", p.Dir, string(os.PathSeparator), file, err)
 		}
-		err := coregogenerate.DirFuncFunc(p.Name, p.Dir, file, hasDirPrefix, func(prefix string, line int, dirArgs []string) error {
+		for _, cd := range cds {
 			dir := &directive{
 				pkgName: p.Name,
 				file:    file,
-				line:    line,
-				args:    dirArgs,
+				line:    cd.Pos.Line,
+				args:    cd.Args,
+				conds:   cd.Conds,
 			}
 			p.dirs = append(p.dirs, dir)
 
-			if prefix == gogeneratePrefix {
-				return nil
+			if cd.Form == coregogenerate.FormSpecial {
+				continue
 			}
 
 			// regular go:generate directive
-			gen, err := g.resolveDir(p.Dir, dirArgs)
-			if err != nil && err != gogeneratedir {
-				return fmt.Errorf("failed to resolve directive: %v", err)
-			}
-			dir.gen = gen
-			outDirs, err := parseOutDirs(p.Dir, dirArgs)
+			gen, err := g.resolveDir(cd)
 			if err != nil {
-				return fmt.Errorf("failed to parse out dirs in %v:%v: %v", filepath.Join(p.Dir, file), line, err)
+				g.fatalf("failed to resolve directive %v: %v", cd.Pos, err)
 			}
-			inFilePatts, err := parseInFilePatts(p.Dir, dirArgs)
+			dir.gen = gen
+			outDirs, err := evalOutDirs(p.Dir, cd.OutDirs)
 			if err != nil {
-				return fmt.Errorf("failed to parse in files in %v:%v: %v", filepath.Join(p.Dir, file), line, err)
+				g.fatalf("failed to parse out dirs in %v: %v", cd.Pos, err)
 			}
 			dir.outDirs = outDirs
-			dir.inFilePatts = inFilePatts
-			for i, v := range outDirs {
-				if v == p.Dir {
-					outDirs = append(outDirs[:i], outDirs[i+1:]...)
-					break
-				}
-			}
+			dir.inFilePatts = cd.InFiles
 			switch gen := gen.(type) {
 			case *gobinModDep:
 				// If this gobinModDep does not have an underlying pkg, then we haven't previously
@@ -1402,11 +1612,6 @@ func (g *gogenerate) refreshDirectiveDeps(p *pkg, misses missingDeps) {
 					g.addDep(p, gen)
 				}
 			}
-			return nil
-		})
-
-		if err != nil {
-			g.fatalf("failed to walk %v%v%v for go:generate directives: %v", p.Dir, string(os.PathSeparator), file, err)
 		}
 	}
 
@@ -1454,11 +1659,18 @@ func stringList(vs ...[]string) (res []string) {
 	return res
 }
 
-func (g *gogenerate) hashOutDirs(outDirs []string, p *pkg, outHash io.Writer, dirNames map[string]map[generator]bool) map[string][hashSize]byte {
+// hashOutDirs returns the hashes of the files generated by the generators in
+// dirNames, keyed by path, in outDirs. Where outHash is not nil, the input
+// files of p and the generated files are also hashed to outHash; inHash, if
+// not nil, receives only those input files that are not generated.
+func (g *gogenerate) hashOutDirs(outDirs []string, p *pkg, outHash, inHash io.Writer, ex *explanation, dirNames map[string]map[generator]bool) map[string][hashSize]byte {
 	fileHash := make(map[string][hashSize]byte)
 	var seen map[string]bool
 	if outHash != nil {
 		fmt.Fprintf(outHash, "Files:\n")
+		if inHash != nil {
+			fmt.Fprintf(inHash, "Files:\n")
+		}
 		// this captures all go list known input files, including such files which
 		// are generated.
 		seen = make(map[string]bool)
@@ -1486,8 +1698,11 @@ func (g *gogenerate) hashOutDirs(outDirs []string, p *pkg, outHash io.Writer, di
 					ws = append(ws, fhw)
 				}
 			}
+			if fhw == nil && inHash != nil {
+				ws = append(ws, inHash)
+			}
 			fw := io.MultiWriter(ws...)
-			g.hashFile(fw, p.Dir, f)
+			g.hashFileExplain(fw, ex, p.Dir, f)
 			if fhw != nil {
 				fileHash[filepath.Join(p.Dir, f)] = fhw.Sum()
 			}
@@ -1522,11 +1737,13 @@ func (g *gogenerate) hashOutDirs(outDirs []string, p *pkg, outHash io.Writer, di
 				// not generated
 				continue
 			}
+			var fex *explanation
 			if outHash != nil {
 				ws = append(ws, outHash)
+				fex = ex
 			}
 			fw := io.MultiWriter(ws...)
-			g.hashFile(fw, dir, fn)
+			g.hashFileExplain(fw, fex, dir, fn)
 			if fhw != nil {
 				fileHash[filepath.Join(dir, fn)] = fhw.Sum()
 			}
@@ -1625,7 +1842,7 @@ func (g *gogenerate) shouldBuildFile(path string) bool {
 	return imports.ShouldBuild(cmts, g.tagsMap)
 }
 
-func (g *gogenerate) hashImport(hw io.Writer, path string) {
+func (g *gogenerate) hashImport(hw io.Writer, ex *explanation, path string) {
 	if g.isSpecialImport(path) {
 		return
 	}
@@ -1637,9 +1854,10 @@ func (g *gogenerate) hashImport(hw io.Writer, path string) {
 		g.fatalf("inconsistent state: dependency %v is not done", path)
 	}
 	fmt.Fprintf(hw, "import %v: %x\n", d.ImportPath, d.hash)
+	ex.addPkg("import "+d.ImportPath, d.hash)
 }
 
-func (g *gogenerate) hashDeps(hw io.Writer, d dep) {
+func (g *gogenerate) hashDeps(hw io.Writer, ex *explanation, d dep) {
 	var deps []dep
 	for dd := range d.Deps().deps {
 		if !dd.Done() {
@@ -1650,17 +1868,37 @@ func (g *gogenerate) hashDeps(hw io.Writer, d dep) {
 	sortDeps(deps)
 	for _, d := range deps {
 		fmt.Fprintf(hw, "%v\n", d.HashString())
+		ex.addDep(d)
 	}
 }
 
 func (g *gogenerate) fatalf(format string, args ...interface{}) {
-	panic(gogenerateerror(fmt.Sprintf(format, args...)))
+	panic(gogenerateerror{msg: fmt.Sprintf(format, args...)})
+}
+
+// directiveFatalf is like fatalf but records that the error relates to the
+// directive d in package p
+func (g *gogenerate) directiveFatalf(p *pkg, d *directive, format string, args ...interface{}) {
+	panic(gogenerateerror{
+		msg:  fmt.Sprintf(format, args...),
+		pkg:  p.ImportPath,
+		file: d.file,
+		line: d.line,
+	})
 }
 
-type gogenerateerror string
+type gogenerateerror struct {
+	msg string
+
+	// pkg, file and line identify the directive responsible for the error,
+	// where known
+	pkg  string
+	file string
+	line int
+}
 
 func (g gogenerateerror) Error() string {
-	return string(g)
+	return g.msg
 }
 
 func (g *gogenerate) newPkg(p *Package, createXPkg bool) *pkg {
@@ -1762,52 +2000,18 @@ func (g *gogenerate) resolveCommandDep(name string) *commandDep {
 
 var _ dep = (*pkg)(nil)
 
-func (g *gogenerate) resolveDir(dir string, dirArgs []string) (generator, error) {
-	switch a0 := dirArgs[0]; {
-	case a0 == "gobin":
-		args := dirArgs[1:]
-		mainMod, patt, err := gobinParse(args)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse gobin args %v: %v", args, err)
-		}
-		if mainMod {
-			return g.resolveGobinModDep(patt), nil
-		} else {
-			return g.resolveGobinGlobalDep(patt), nil
-		}
-	case a0 == "go":
-		return nil, fmt.Errorf("do not yet know how to handle go command-based directives")
-	case a0[0] == '[':
-		// special gogenerate directive is identified by at least one condition
-		// followed by a single command. At the moment, the single command
-		// we understand is break
-		for strings.HasPrefix(dirArgs[0], "[") && strings.HasSuffix(dirArgs[0], "]") {
-			cond := dirArgs[0]
-			cond = cond[1 : len(cond)-1]
-			cond = strings.TrimSpace(cond)
-			dirArgs = dirArgs[1:]
-			if strings.HasPrefix(cond, "!") {
-				cond = strings.TrimSpace(cond[1:])
-			}
-			switch {
-			case strings.HasPrefix(cond, "exists:"):
-			case strings.HasPrefix(cond, "exec:"):
-			default:
-				return nil, fmt.Errorf("unknown condition in special gogenerate directive")
-			}
-		}
-		if len(dirArgs) != 1 {
-			return nil, fmt.Errorf("missing command in special gogenerate directive")
-		}
-		// If we add further commands, be sure to add the corresponding logic in
-		// *gogenerate.generate (which currently assumes only break exists)
-		if dirArgs[0] != "break" {
-			return nil, fmt.Errorf("unknown command %q in special gogenerate directive", dirArgs[0])
-		}
-		return nil, gogeneratedir
+func (g *gogenerate) resolveDir(d *coregogenerate.Directive) (generator, error) {
+	switch d.Form {
+	case coregogenerate.FormGobinMainMod:
+		return g.resolveGobinModDep(d.Command), nil
+	case coregogenerate.FormGobin:
+		return g.resolveGobinGlobalDep(d.Command), nil
+	case coregogenerate.FormCommand:
+		if d.Command == "go" {
+			return nil, fmt.Errorf("do not yet know how to handle go command-based directives")
+		}
+		return g.resolveCommandDep(d.Command), nil
 	default:
-		return g.resolveCommandDep(dirArgs[0]), nil
+		return nil, fmt.Errorf("unknown directive form %v", d.Form)
 	}
 }
-
-var gogeneratedir = errors.New("directive is a special gogenerate directive")
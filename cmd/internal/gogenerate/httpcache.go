@@ -0,0 +1,92 @@
+package gogenerate
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/rogpeppe/go-internal/cache"
+)
+
+// httpCache is an artefactCache backed by an HTTP server. The archive for an
+// action ID is retrieved via a GET request, and stored via a PUT request, to
+// the URL formed by appending the hex-encoded action ID to the base URL. A 404
+// response to a GET is a cache miss.
+type httpCache struct {
+	url     string
+	client  *http.Client
+	tempDir string
+}
+
+func newHTTPCache(url string, tempDir string) *httpCache {
+	return &httpCache{
+		url:     strings.TrimSuffix(url, "/"),
+		client:  http.DefaultClient,
+		tempDir: tempDir,
+	}
+}
+
+func (h *httpCache) actionURL(id cache.ActionID) string {
+	return fmt.Sprintf("%v/%x", h.url, id)
+}
+
+func (h *httpCache) GetFile(id cache.ActionID) (string, error) {
+	u := h.actionURL(id)
+	resp, err := h.client.Get(u)
+	if err != nil {
+		return "", fmt.Errorf("failed to GET %v: %v", u, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return "", errCacheMiss
+	default:
+		return "", fmt.Errorf("failed to GET %v: %v", u, resp.Status)
+	}
+	tf, err := ioutil.TempFile(h.tempDir, "httpcache-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %v", err)
+	}
+	_, err = io.Copy(tf, resp.Body)
+	if cerr := tf.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tf.Name())
+		return "", fmt.Errorf("failed to read response from GET %v: %v", u, err)
+	}
+	return tf.Name(), nil
+}
+
+func (h *httpCache) PutFile(id cache.ActionID, path string) error {
+	u := h.actionURL(id)
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open archive %v for reading: %v", path, err)
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat %v: %v", path, err)
+	}
+	req, err := http.NewRequest(http.MethodPut, u, f)
+	if err != nil {
+		return fmt.Errorf("failed to create PUT request for %v: %v", u, err)
+	}
+	req.ContentLength = fi.Size()
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to PUT %v: %v", u, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to PUT %v: %v", u, resp.Status)
+	}
+	return nil
+}
+
+var _ artefactCache = (*httpCache)(nil)
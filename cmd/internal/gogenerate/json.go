@@ -0,0 +1,94 @@
+package gogenerate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rogpeppe/go-internal/cache"
+)
+
+// The actions of events written in -json mode
+const (
+	actionQueue      = "queue"
+	actionStart      = "start"
+	actionFinish     = "finish"
+	actionCacheHit   = "cache-hit"
+	actionCacheMiss  = "cache-miss"
+	actionFixedPoint = "fixedpoint"
+	actionError      = "error"
+)
+
+// event is a single event written in -json mode. Events are written to stdout
+// as a stream of JSON objects, one per line, in the spirit of go test -json.
+type event struct {
+	Time    time.Time
+	Action  string
+	Package string `json:",omitempty"`
+
+	// Iteration is the generation iteration for Package to which the event
+	// relates
+	Iteration int `json:",omitempty"`
+
+	// ActionID is the hex-encoded cache key for cache-hit and cache-miss
+	// events
+	ActionID string `json:",omitempty"`
+
+	// File and Line give the position of the directive (start and finish
+	// events), or the directive responsible for an error where known
+	File string `json:",omitempty"`
+	Line int    `json:",omitempty"`
+
+	// Generator is the command name of the directive, as used in the naming
+	// of generated files
+	Generator string   `json:",omitempty"`
+	Args      []string `json:",omitempty"`
+
+	// Elapsed is the time in seconds taken to run a directive
+	Elapsed float64 `json:",omitempty"`
+
+	Output string `json:",omitempty"`
+}
+
+var jsonEnc = json.NewEncoder(os.Stdout)
+
+func emitEvent(e event) {
+	if !*fJSON {
+		return
+	}
+	e.Time = time.Now()
+	logMu.Lock()
+	defer logMu.Unlock()
+	if err := jsonEnc.Encode(e); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write JSON event: %v\n", err)
+	}
+}
+
+func emitCacheEvent(action string, w *pkg, id cache.ActionID) {
+	emitEvent(event{
+		Action:    action,
+		Package:   w.ImportPath,
+		Iteration: w.genCount,
+		ActionID:  fmt.Sprintf("%x", id),
+	})
+}
+
+func emitDirectiveEvent(action string, w *pkg, d *directive, elapsed time.Duration, output []byte) {
+	e := event{
+		Action:    action,
+		Package:   w.ImportPath,
+		Iteration: w.genCount,
+		File:      d.file,
+		Line:      d.line,
+		Args:      d.args,
+		Output:    string(output),
+	}
+	if d.gen != nil {
+		e.Generator = d.gen.DirectiveName()
+	}
+	if action == actionFinish {
+		e.Elapsed = elapsed.Seconds()
+	}
+	emitEvent(e)
+}
@@ -1,10 +1,6 @@
 package gogenerate
 
-import (
-	"fmt"
-	"os"
-	"strings"
-)
+import "fmt"
 
 type Package struct {
 	Dir        string // directory containing package sources
@@ -94,33 +90,3 @@ func deriveXTest(p *Package) *Package {
 		XTestImports: p.XTestImports,
 	}
 }
-
-func adjustTagsFlag(opts []string) ([]string, error) {
-	var other, tags []string
-	if gf := os.Getenv("GOFLAGS"); gf != "" {
-		gfs := strings.Fields(gf)
-		for _, f := range gfs {
-			if strings.HasPrefix(f, "-tags=") {
-				tags = append(tags, strings.TrimPrefix(f, "-tags="))
-			}
-		}
-	}
-	for i := 0; i < len(opts); i += 1 {
-		o := opts[i]
-		if strings.HasPrefix(o, "-tags=") {
-			tags = append(tags, strings.TrimPrefix(o, "-tags="))
-		} else if o == "-tags" {
-			if i == len(opts)-1 {
-				return nil, fmt.Errorf("missing value for -tags")
-			}
-			tags = append(tags, opts[i+1])
-			i += 1
-		} else {
-			other = append(other, o)
-		}
-	}
-	if len(tags) > 0 {
-		other = append(other, "-tags="+strings.Join(tags, " "))
-	}
-	return other, nil
-}
@@ -2,6 +2,9 @@ package gogenerate
 
 import (
 	"fmt"
+	"sort"
+
+	coregogenerate "myitcv.io/gogenerate"
 )
 
 type pkg struct {
@@ -25,6 +28,37 @@ type pkg struct {
 	genCount int
 }
 
+// outDirs returns the sorted set of directories to which the directives of p
+// can write, including p.Dir itself
+func (p *pkg) outDirs() []string {
+	outDirs := map[string]bool{
+		p.Dir: true,
+	}
+	for _, d := range p.dirs {
+		for _, od := range d.outDirs {
+			outDirs[od] = true
+		}
+	}
+	var res []string
+	for od := range outDirs {
+		res = append(res, od)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// dirsGenerating returns the directives of p that generate files with the
+// same name as fn
+func (p *pkg) dirsGenerating(fn string) []*directive {
+	var res []*directive
+	for _, d := range p.dirs {
+		if d.gen != nil && coregogenerate.AnyFileGeneratedBy(fn, d.gen.DirectiveName()) {
+			res = append(res, d)
+		}
+	}
+	return res
+}
+
 func (p *pkg) Deps() depsMap {
 	return p.depsMap
 }
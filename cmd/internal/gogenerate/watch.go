@@ -0,0 +1,255 @@
+package gogenerate
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	fsnotify "gopkg.in/fsnotify/fsnotify.v1"
+
+	coregogenerate "myitcv.io/gogenerate"
+)
+
+// watchQuiet is the duration of the window within which file changes are
+// batched into a single cycle in -watch mode
+const watchQuiet = 100 * time.Millisecond
+
+// runStats records the work done by a run
+type runStats struct {
+	// pkgs is the set of directories of packages that were generated
+	pkgs       map[string]bool
+	hits       int
+	misses     int
+	directives int
+}
+
+func newRunStats() runStats {
+	return runStats{
+		pkgs: make(map[string]bool),
+	}
+}
+
+func (r runStats) String() string {
+	plural := func(n int, one, many string) string {
+		if n == 1 {
+			return fmt.Sprintf("%v %v", n, one)
+		}
+		return fmt.Sprintf("%v %v", n, many)
+	}
+	return fmt.Sprintf("%v generated (%v, %v, %v run)",
+		plural(len(r.pkgs), "package", "packages"),
+		plural(r.hits, "cache hit", "cache hits"),
+		plural(r.misses, "cache miss", "cache misses"),
+		plural(r.directives, "directive", "directives"),
+	)
+}
+
+// watch runs gogenerate, and then watches the directories of the packages in
+// the dependency graph, along with directories containing directive input
+// files, for changes. For each batch of changes, only the packages affected
+// by the change and their reverse dependencies are regenerated, using the
+// dependency graph from the previous cycle. watch returns when interrupted.
+func (g *gogenerate) watch() error {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %v", err)
+	}
+	defer fw.Close()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	watched := make(map[string]bool)
+	g.watchCycle(func() {
+		g.run()
+	})
+	for {
+		g.watchDirs(fw, watched)
+		if len(watched) == 0 {
+			return fmt.Errorf("no directories to watch")
+		}
+		changed, done := waitForChanges(fw, interrupt)
+		if done {
+			return nil
+		}
+		affected := g.affectedPkgs(changed)
+		if len(affected) == 0 {
+			continue
+		}
+		g.watchCycle(func() {
+			g.regenerate(affected)
+		})
+	}
+}
+
+// watchCycle runs f, a single cycle of generation, writing a summary of the
+// work done to stderr. An error in the cycle is reported rather than being
+// fatal. watchCycle reports whether f succeeded.
+func (g *gogenerate) watchCycle(f func()) (ok bool) {
+	start := time.Now()
+	g.stats = newRunStats()
+	defer func() {
+		if !ok {
+			err := recover()
+			ge, isGe := err.(gogenerateerror)
+			if !isGe {
+				panic(err)
+			}
+			logMu.Lock()
+			fmt.Fprintf(os.Stderr, "%v\n", ge)
+			logMu.Unlock()
+		}
+		logMu.Lock()
+		defer logMu.Unlock()
+		res := "ok"
+		if !ok {
+			res = "FAIL"
+		}
+		fmt.Fprintf(os.Stderr, "%v\t%v in %.3fs\n", res, g.stats, time.Since(start).Seconds())
+	}()
+	f()
+	return true
+}
+
+// regenerate undoes the packages in affected, refreshes their imports and
+// directives, and then does the resulting work.
+func (g *gogenerate) regenerate(affected []*pkg) {
+	for _, p := range g.pkgLookup {
+		p.genCount = 0
+		if p.x != nil {
+			p.x.genCount = 0
+		}
+	}
+	importMisses := make(missingDeps)
+	dirMisses := make(missingDeps)
+	for _, p := range affected {
+		logTrace("changed %v", p)
+		g.undo(p)
+		g.refreshImports(p, importMisses)
+		if p.x != nil {
+			g.undo(p.x)
+		}
+		if p.generate {
+			g.refreshDirectiveDeps(p, dirMisses)
+		}
+	}
+	g.loadMisses(importMisses, dirMisses)
+	g.work()
+}
+
+// watchDirs adds to fw the directories of the packages in the dependency
+// graph that are not part of the standard library or the module cache, along
+// with the directories of directive input file patterns. watched records the
+// directories already being watched.
+func (g *gogenerate) watchDirs(fw *fsnotify.Watcher, watched map[string]bool) {
+	var dirs []string
+	for _, p := range g.pkgLookup {
+		if p.Standard || (p.Module != nil && p.Module.GoMod != g.mainMod) {
+			continue
+		}
+		dirs = append(dirs, p.Dir)
+		for _, patt := range g.inFilePatts(p) {
+			dirs = append(dirs, filepath.Dir(patt))
+		}
+	}
+	for _, d := range dirs {
+		if watched[d] {
+			continue
+		}
+		if err := fw.Add(d); err != nil {
+			// the directory might since have been removed, or the pattern
+			// might have a glob in its directory part; neither is fatal
+			logTrace("failed to watch %v: %v", d, err)
+			continue
+		}
+		watched[d] = true
+	}
+}
+
+// inFilePatts returns the absolute -infiles: patterns of the directives of p
+// and its external test package
+func (g *gogenerate) inFilePatts(p *pkg) []string {
+	var res []string
+	for _, pp := range []*pkg{p, p.x} {
+		if pp == nil {
+			continue
+		}
+		for _, d := range pp.dirs {
+			for _, patt := range d.inFilePatts {
+				if !filepath.IsAbs(patt) {
+					patt = filepath.Join(p.Dir, patt)
+				}
+				res = append(res, patt)
+			}
+		}
+	}
+	return res
+}
+
+// waitForChanges blocks until there are file changes, returning the set of
+// changed paths once no further changes have happened within watchQuiet.
+// Changes to generated files, and hidden files (such as editor swap files),
+// are ignored. done is true if the wait was interrupted.
+func waitForChanges(fw *fsnotify.Watcher, interrupt chan os.Signal) (changed map[string]bool, done bool) {
+	changed = make(map[string]bool)
+	var quiet <-chan time.Time
+	for {
+		select {
+		case <-interrupt:
+			return nil, true
+		case e := <-fw.Events:
+			base := filepath.Base(e.Name)
+			if strings.HasPrefix(base, ".") {
+				continue
+			}
+			if _, _, ok := coregogenerate.AnyFileIsGenerated(base); ok {
+				continue
+			}
+			changed[e.Name] = true
+			quiet = time.After(watchQuiet)
+		case err := <-fw.Errors:
+			logTrace("file watcher error: %v", err)
+		case <-quiet:
+			return changed, false
+		}
+	}
+}
+
+// affectedPkgs returns the packages affected by the changes to the files in
+// changed: those whose directory contains a changed file, and those with a
+// directive whose input files match a changed file.
+func (g *gogenerate) affectedPkgs(changed map[string]bool) []*pkg {
+	affected := make(map[*pkg]bool)
+	for fn := range changed {
+		if p, ok := g.dirLookup[filepath.Dir(fn)]; ok {
+			affected[p] = true
+		}
+	}
+	for _, p := range g.pkgLookup {
+		if !p.generate || affected[p] {
+			continue
+		}
+	Patts:
+		for _, patt := range g.inFilePatts(p) {
+			for fn := range changed {
+				if ok, _ := filepath.Match(patt, fn); ok {
+					affected[p] = true
+					break Patts
+				}
+			}
+		}
+	}
+	var res []*pkg
+	for p := range affected {
+		res = append(res, p)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ImportPath < res[j].ImportPath
+	})
+	return res
+}
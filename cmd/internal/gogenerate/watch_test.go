@@ -0,0 +1,173 @@
+package gogenerate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	fsnotify "gopkg.in/fsnotify/fsnotify.v1"
+)
+
+// newWatchTestGraph creates in td the directories of a dependency graph of
+// packages a, b, c and d, all of which are done, and returns the packages by
+// name. b imports a; c has a directive with input files data/*.json.
+func newWatchTestGraph(t *testing.T, td string) (*gogenerate, map[string]*pkg) {
+	g := &gogenerate{
+		pkgLookup: make(map[string]*pkg),
+		dirLookup: make(map[string]*pkg),
+	}
+	pkgs := make(map[string]*pkg)
+	for _, n := range []string{"a", "b", "c", "d"} {
+		dir := filepath.Join(td, n)
+		if err := os.Mkdir(dir, 0777); err != nil {
+			t.Fatalf("failed to create %v: %v", dir, err)
+		}
+		p := g.newPkg(&Package{
+			Dir:        dir,
+			ImportPath: "mod.com/" + n,
+			Name:       n,
+		}, false)
+		p.generate = true
+		p.generated = true
+		p.hash = [hashSize]byte{1}
+		pkgs[n] = p
+	}
+	if err := os.Mkdir(filepath.Join(td, "data"), 0777); err != nil {
+		t.Fatalf("failed to create data dir: %v", err)
+	}
+	pkgs["c"].dirs = []*directive{{
+		pkgName:     "c",
+		file:        "c.go",
+		inFilePatts: []string{filepath.Join("..", "data", "*.json")},
+	}}
+	g.addDep(pkgs["b"], pkgs["a"])
+	return g, pkgs
+}
+
+func TestAffectedPkgs(t *testing.T) {
+	td, err := ioutil.TempDir("", "gogenerate-TestAffectedPkgs")
+	if err != nil {
+		t.Fatalf("failed to create TempDir: %v", err)
+	}
+	defer os.RemoveAll(td)
+
+	testCases := []struct {
+		changed  []string
+		affected []string
+		undone   []string
+	}{
+		{
+			changed:  []string{"a/a.go"},
+			affected: []string{"a"},
+			undone:   []string{"a", "b"},
+		},
+		{
+			changed:  []string{"b/b.go"},
+			affected: []string{"b"},
+			undone:   []string{"b"},
+		},
+		{
+			changed:  []string{"data/x.json"},
+			affected: []string{"c"},
+			undone:   []string{"c"},
+		},
+		{
+			changed:  []string{"data/x.json", "a/a.go", "a/other.go"},
+			affected: []string{"a", "c"},
+			undone:   []string{"a", "b", "c"},
+		},
+		{
+			changed: []string{"data/x.txt", "other/other.go"},
+		},
+	}
+
+	for i, tc := range testCases {
+		dir := filepath.Join(td, fmt.Sprint(i))
+		if err := os.Mkdir(dir, 0777); err != nil {
+			t.Fatalf("failed to create %v: %v", dir, err)
+		}
+		g, pkgs := newWatchTestGraph(t, dir)
+		changed := make(map[string]bool)
+		for _, fn := range tc.changed {
+			changed[filepath.Join(dir, filepath.FromSlash(fn))] = true
+		}
+		var affected []string
+		for _, p := range g.affectedPkgs(changed) {
+			affected = append(affected, p.Name)
+		}
+		if !reflect.DeepEqual(affected, tc.affected) {
+			t.Errorf("test %v: affected packages of %v: got %v, want %v", i, tc.changed, affected, tc.affected)
+		}
+		for _, n := range affected {
+			g.undo(pkgs[n])
+		}
+		var undone []string
+		for _, n := range []string{"a", "b", "c", "d"} {
+			if !pkgs[n].Done() {
+				undone = append(undone, n)
+			}
+		}
+		if !reflect.DeepEqual(undone, tc.undone) {
+			t.Errorf("test %v: undone packages after changes to %v: got %v, want %v", i, tc.changed, undone, tc.undone)
+		}
+	}
+}
+
+func TestWatchCycle(t *testing.T) {
+	td, err := ioutil.TempDir("", "gogenerate-TestWatchCycle")
+	if err != nil {
+		t.Fatalf("failed to create TempDir: %v", err)
+	}
+	defer os.RemoveAll(td)
+
+	g, pkgs := newWatchTestGraph(t, td)
+
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatalf("failed to create file watcher: %v", err)
+	}
+	defer fw.Close()
+
+	watched := make(map[string]bool)
+	g.watchDirs(fw, watched)
+	for _, d := range []string{"a", "b", "c", "d", "data"} {
+		if dir := filepath.Join(td, d); !watched[dir] {
+			t.Fatalf("expected %v to be watched; watching %v", dir, watched)
+		}
+	}
+
+	// changes to generated and hidden files are ignored
+	for _, fn := range []string{"a/a.go", "a/gen_a_copy1.go", "a/.a.go.swp", "d/gen_d_copy1.go"} {
+		fp := filepath.Join(td, filepath.FromSlash(fn))
+		if err := ioutil.WriteFile(fp, []byte("package a\n"), 0666); err != nil {
+			t.Fatalf("failed to write %v: %v", fp, err)
+		}
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	changed, done := waitForChanges(fw, interrupt)
+	if done {
+		t.Fatalf("waitForChanges unexpectedly reported done")
+	}
+	if want := map[string]bool{filepath.Join(td, "a", "a.go"): true}; !reflect.DeepEqual(changed, want) {
+		t.Fatalf("unexpected changes: got %v, want %v", changed, want)
+	}
+	affected := g.affectedPkgs(changed)
+	if len(affected) != 1 || affected[0] != pkgs["a"] {
+		t.Fatalf("unexpected affected packages: got %v, want [%v]", affected, pkgs["a"])
+	}
+	g.undo(affected[0])
+	for n, p := range pkgs {
+		if want := n == "c" || n == "d"; p.Done() != want {
+			t.Errorf("package %v: got done %v, want %v", n, p.Done(), want)
+		}
+	}
+
+	interrupt <- os.Interrupt
+	if _, done := waitForChanges(fw, interrupt); !done {
+		t.Fatalf("waitForChanges did not report done after interrupt")
+	}
+}
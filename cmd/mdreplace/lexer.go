@@ -2,6 +2,7 @@ package main
 
 import (
 	"crypto/sha1"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -265,7 +266,7 @@ func (l *lexer) errorf(format string, args ...interface{}) stateFn {
 	}
 
 	if panicErrors {
-		panic(fmt.Errorf(i.val))
+		panic(errors.New(i.val))
 	}
 
 	l.items <- i
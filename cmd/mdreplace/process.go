@@ -48,7 +48,7 @@ loop:
 		case itemtype.ItemEOF:
 			break loop
 		case itemtype.ItemError:
-			p.errorf(i.val)
+			p.errorf("%v", i.val)
 		case itemtype.ItemCodeFence:
 			return p.processCode
 		case itemtype.ItemTmplBlockStart:
@@ -52,7 +52,7 @@ func main() {
 		out := string(outB)
 
 		if err != nil {
-			elog.Fatalf(out)
+			elog.Fatal(out)
 		}
 
 		log.Print(out)
@@ -241,7 +241,7 @@ func mainerr() (retErr error) {
 	var tempFile string
 	var lock sync.Mutex
 
-	ctrlc := make(chan os.Signal)
+	ctrlc := make(chan os.Signal, 1)
 	signal.Notify(ctrlc, os.Interrupt)
 	go func() {
 		<-ctrlc
@@ -276,7 +276,7 @@ func mainerr() (retErr error) {
 		return sb.String()
 	}
 
-	p(`
+	buf.WriteString(`
 package main
 
 import (
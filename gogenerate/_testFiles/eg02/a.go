@@ -0,0 +1,6 @@
+package main
+
+//go:generate mygen -infiles:json=*.json -infiles:json=*.json
+//go:generate gobin -run example.com/bananaGen@v1.0.0 -outdir:x ./other -outdir:self .
+//go:generate gobin -m -run example.com/cmd/appleGen -infiles:a b.txt
+//go:generate:gogenerate [!exists:gen_x.go] [exec:go] break
@@ -0,0 +1,331 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package gogenerate
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// GogenerateMetaPrefix is the prefix of the special directives understood by
+// gogenerate (the command myitcv.io/cmd/gogenerate). See gogenerate -help.
+const GogenerateMetaPrefix = GoGeneratePrefix + ":gogenerate"
+
+// CommandForm describes how the command of a Directive is resolved
+type CommandForm int
+
+const (
+	// FormCommand is a relative or absolute PATH-resolved command:
+	//
+	//	//go:generate command ...
+	FormCommand CommandForm = iota
+
+	// FormGobin is a command resolved via gobin's global semantics:
+	//
+	//	//go:generate gobin -run main_pkg[@version] ...
+	FormGobin
+
+	// FormGobinMainMod is a command resolved via the main module:
+	//
+	//	//go:generate gobin -m -run main_pkg ...
+	FormGobinMainMod
+
+	// FormSpecial is a special gogenerate directive, which has conditions
+	// followed by a command:
+	//
+	//	//go:generate:gogenerate [cond] break
+	FormSpecial
+)
+
+func (f CommandForm) String() string {
+	switch f {
+	case FormCommand:
+		return "command"
+	case FormGobin:
+		return "gobin"
+	case FormGobinMainMod:
+		return "gobin -m"
+	case FormSpecial:
+		return "special"
+	default:
+		return fmt.Sprintf("CommandForm(%d)", int(f))
+	}
+}
+
+// Directive is a go generate directive, as understood by gogenerate
+type Directive struct {
+	// Pos is the position of the directive. Pos.Filename is absolute.
+	Pos token.Position
+
+	// Args are the arguments of the directive after quote and variable
+	// expansion, and -command substitution, as described by go generate
+	// -help
+	Args []string
+
+	// Form is the form of the directive's command
+	Form CommandForm
+
+	// Command is the command of the directive. For FormCommand this is
+	// Args[0]. For the gobin forms this is the main package pattern passed to
+	// gobin, i.e. main_pkg[@version]. For FormSpecial this is the command
+	// that follows the conditions, at present always "break".
+	Command string
+
+	// Conds are the conditions of a FormSpecial directive
+	Conds []Cond
+
+	// InFiles are the glob patterns of files the directive declares it will
+	// consume, via flags prefixed with -infiles:, sorted and without
+	// duplicates. Patterns are as written in the directive, i.e. relative
+	// patterns are relative to the directory of the directive's package.
+	InFiles []string
+
+	// OutDirs are the absolute directories, other than that of the
+	// directive's package, into which the directive declares it will generate
+	// files, via flags prefixed with -outdir:, sorted and without duplicates.
+	OutDirs []string
+}
+
+// Name returns the name of the command of d, which is used to identify the
+// files generated by d (see NameFile). Name returns "" for FormSpecial
+// directives.
+func (d *Directive) Name() string {
+	switch d.Form {
+	case FormGobin, FormGobinMainMod:
+		return path.Base(strings.Split(d.Command, "@")[0])
+	case FormSpecial:
+		return ""
+	default:
+		return filepath.Base(d.Command)
+	}
+}
+
+// Cond is a condition of a special gogenerate directive
+type Cond struct {
+	// Negate indicates the condition is negated, i.e. [!exists:file]
+	Negate bool
+
+	// Kind is the kind of condition: "exists" for whether the relative file
+	// path Arg exists, or "exec" for whether the program Arg is available for
+	// execution.
+	Kind string
+
+	// Arg is the argument of the condition
+	Arg string
+}
+
+func (c Cond) String() string {
+	neg := ""
+	if c.Negate {
+		neg = "!"
+	}
+	return fmt.Sprintf("[%v%v:%v]", neg, c.Kind, c.Arg)
+}
+
+// ParseDirectives returns the go generate directives, including special
+// gogenerate directives, found in the file named file in dir that is part of
+// the package pkg. Directives are returned in the order in which they appear
+// in the file. An error is returned if a directive cannot be parsed.
+func ParseDirectives(pkg, dir, file string) ([]*Directive, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not make absolute path from %v: %v", dir, err)
+	}
+	fn := filepath.Join(dir, file)
+
+	var res []*Directive
+	err = dirFunc(pkg, dir, file, hasDirectivePrefix, func(prefix string, line int, args []string) error {
+		d, err := parseDirective(dir, prefix, args)
+		if err != nil {
+			return err
+		}
+		d.Pos = token.Position{Filename: fn, Line: line, Column: 1}
+		res = append(res, d)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func hasDirectivePrefix(buf []byte) (string, bool) {
+	for _, c := range []string{GoGeneratePrefix, GogenerateMetaPrefix} {
+		for _, v := range []string{" ", "\t"} {
+			if p := []byte(c + v); bytes.HasPrefix(buf, p) {
+				return string(p), true
+			}
+		}
+	}
+	return "", false
+}
+
+func parseDirective(dir, prefix string, args []string) (*Directive, error) {
+	d := &Directive{
+		Args: args,
+	}
+
+	if prefix == GogenerateMetaPrefix {
+		d.Form = FormSpecial
+		conds, cmd, err := parseSpecial(args)
+		if err != nil {
+			return nil, err
+		}
+		d.Conds = conds
+		d.Command = cmd
+		return d, nil
+	}
+
+	if args[0] == "gobin" {
+		mainMod, patt, err := parseGobin(args[1:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse gobin args %v: %v", args[1:], err)
+		}
+		d.Form = FormGobin
+		if mainMod {
+			d.Form = FormGobinMainMod
+		}
+		d.Command = patt
+	} else {
+		d.Command = args[0]
+	}
+
+	var err error
+	if d.OutDirs, err = parseFlagValues(FlagOutDirPrefix, args); err != nil {
+		return nil, err
+	}
+	for i, od := range d.OutDirs {
+		if !filepath.IsAbs(od) {
+			od = filepath.Join(dir, od)
+		}
+		d.OutDirs[i] = filepath.Clean(od)
+	}
+	d.OutDirs = uniq(d.OutDirs, dir)
+	if d.InFiles, err = parseFlagValues(FlagInFilesPrefix, args); err != nil {
+		return nil, err
+	}
+	d.InFiles = uniq(d.InFiles, "")
+
+	return d, nil
+}
+
+// parseSpecial parses the arguments of a special gogenerate directive,
+// returning its conditions and command
+func parseSpecial(args []string) ([]Cond, string, error) {
+	var conds []Cond
+	for len(args) > 0 && strings.HasPrefix(args[0], "[") && strings.HasSuffix(args[0], "]") {
+		s := strings.TrimSpace(args[0][1 : len(args[0])-1])
+		args = args[1:]
+		var c Cond
+		if strings.HasPrefix(s, "!") {
+			c.Negate = true
+			s = strings.TrimSpace(s[1:])
+		}
+		i := strings.Index(s, ":")
+		if i == -1 {
+			return nil, "", fmt.Errorf("unknown condition %q in special gogenerate directive", s)
+		}
+		c.Kind, c.Arg = s[:i], strings.TrimSpace(s[i+1:])
+		switch c.Kind {
+		case "exists", "exec":
+		default:
+			return nil, "", fmt.Errorf("unknown condition %q in special gogenerate directive", s)
+		}
+		conds = append(conds, c)
+	}
+	if len(args) != 1 {
+		return nil, "", fmt.Errorf("missing command in special gogenerate directive")
+	}
+	if args[0] != "break" {
+		return nil, "", fmt.Errorf("unknown command %q in special gogenerate directive", args[0])
+	}
+	return conds, args[0], nil
+}
+
+// newGobinFlagSet returns a flag set that understands the flags of gobin,
+// along with pointers to the values of the -m and -run flags
+func newGobinFlagSet() (fs *flag.FlagSet, mainMod, run *bool) {
+	fs = flag.NewFlagSet("gobin", 0)
+	mainMod = fs.Bool("m", false, "resolve dependencies via the main module (as given by go env GOMOD)")
+	fs.String("mod", "", "provide additional control over updating and use of go.mod")
+	run = fs.Bool("run", false, "run the provided main package")
+	fs.Bool("p", false, "print gobin install cache location for main packages")
+	fs.Bool("v", false, "print the module path and version for main packages")
+	fs.Bool("d", false, "stop after installing main packages to the gobin install cache")
+	fs.Bool("u", false, "check for the latest tagged version of main packages")
+	fs.Bool("nonet", false, "prevent network access")
+	fs.Bool("debug", false, "print debug information")
+	return fs, mainMod, run
+}
+
+// parseGobin parses the arguments of a gobin-based directive that follow
+// gobin, returning whether -m was specified and the main package pattern
+// that follows -run
+func parseGobin(args []string) (mainMod bool, patt string, err error) {
+	fs, m, run := newGobinFlagSet()
+	fs.SetOutput(ioutil.Discard)
+	if err := fs.Parse(args); err != nil {
+		return false, "", fmt.Errorf("failed to parse gobin flags: %v", err)
+	}
+	if !*run {
+		return false, "", fmt.Errorf("gobin args did not specify -run")
+	}
+	if fs.NArg() == 0 {
+		return false, "", fmt.Errorf("failed to parse main package[@version]")
+	}
+	patt = fs.Arg(0)
+	if *m && strings.Contains(patt, "@") {
+		return false, "", fmt.Errorf("gobin -m directive cannot specify version: %v", patt)
+	}
+	return *m, patt, nil
+}
+
+// parseFlagValues returns the values of the flags in args with names that
+// start with prefix, e.g. -infiles:json=*.json or -infiles:json *.json.
+// Parsing stops at "--".
+func parseFlagValues(prefix string, args []string) ([]string, error) {
+	var res []string
+	for i := 0; i < len(args); i++ {
+		v := args[i]
+		if v == "--" {
+			break
+		}
+		if !strings.HasPrefix(v, "-"+prefix) {
+			continue
+		}
+		v = strings.TrimPrefix(v, "-"+prefix)
+		if j := strings.Index(v, "="); j != -1 {
+			res = append(res, v[j+1:])
+			continue
+		}
+		if i+1 == len(args) || args[i+1] == "--" {
+			return nil, fmt.Errorf("missing value for -%v flag amongst: %v", prefix, args)
+		}
+		i++
+		res = append(res, args[i])
+	}
+	return res, nil
+}
+
+// uniq returns vs sorted, without duplicates and without the value except
+func uniq(vs []string, except string) []string {
+	seen := make(map[string]bool)
+	var res []string
+	for _, v := range vs {
+		if seen[v] || v == except {
+			continue
+		}
+		seen[v] = true
+		res = append(res, v)
+	}
+	sort.Strings(res)
+	return res
+}
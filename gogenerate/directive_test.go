@@ -0,0 +1,73 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package gogenerate
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseDirectives(t *testing.T) {
+	dir, err := filepath.Abs(filepath.Join("_testFiles", "eg02"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ds, err := ParseDirectives("main", dir, "a.go")
+	if err != nil {
+		t.Fatalf("failed to parse directives: %v", err)
+	}
+
+	type check struct {
+		line    int
+		form    CommandForm
+		cmd     string
+		name    string
+		conds   []Cond
+		inFiles []string
+		outDirs []string
+	}
+
+	checks := []check{
+		{3, FormCommand, "mygen", "mygen", nil, []string{"*.json"}, nil},
+		{4, FormGobin, "example.com/bananaGen@v1.0.0", "bananaGen", nil, nil, []string{filepath.Join(dir, "other")}},
+		{5, FormGobinMainMod, "example.com/cmd/appleGen", "appleGen", nil, []string{"b.txt"}, nil},
+		{6, FormSpecial, "break", "", []Cond{{true, "exists", "gen_x.go"}, {false, "exec", "go"}}, nil, nil},
+	}
+
+	if len(ds) != len(checks) {
+		t.Fatalf("expected %v directives; got %v", len(checks), len(ds))
+	}
+
+	for i, c := range checks {
+		d := ds[i]
+		got := check{d.Pos.Line, d.Form, d.Command, d.Name(), d.Conds, d.InFiles, d.OutDirs}
+		if !reflect.DeepEqual(got, c) {
+			t.Errorf("directive %v: expected %+v; got %+v", i, c, got)
+		}
+		if fn := filepath.Join(dir, "a.go"); d.Pos.Filename != fn {
+			t.Errorf("directive %v: expected filename %v; got %v", i, fn, d.Pos.Filename)
+		}
+	}
+}
+
+func TestParseDirectiveErrors(t *testing.T) {
+	checks := []struct {
+		prefix string
+		args   []string
+	}{
+		{GoGeneratePrefix, []string{"gobin", "example.com/bananaGen"}},
+		{GoGeneratePrefix, []string{"gobin", "-m", "-run", "example.com/bananaGen@v1.0.0"}},
+		{GoGeneratePrefix, []string{"mygen", "-infiles:json"}},
+		{GogenerateMetaPrefix, []string{"[unknown:x]", "break"}},
+		{GogenerateMetaPrefix, []string{"[exists:x]", "continue"}},
+	}
+
+	for _, c := range checks {
+		if _, err := parseDirective("/", c.prefix, c.args); err == nil {
+			t.Errorf("expected error parsing %q; got none", c.args)
+		}
+	}
+}
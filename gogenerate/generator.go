@@ -0,0 +1,483 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package gogenerate
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/imports"
+)
+
+// Format is the formatting applied to the Go files written by a Generator
+type Format int
+
+const (
+	// FormatGofmt formats and simplifies Go files as gofmt -s does
+	FormatGofmt Format = iota
+
+	// FormatGoimports formats Go files as goimports does, which in addition
+	// to gofmt formatting adds missing imports and removes unused ones
+	FormatGoimports
+)
+
+// Generator takes care of the boilerplate common to go generate generators
+// that work on a package: reading the environment set by go generate,
+// determining whether this invocation should do any work, loading the
+// type-checked package, and writing the generated files.
+//
+// A generator program typically declares a Generator and calls its Main
+// method from main:
+//
+//	func main() {
+//		g := &gogenerate.Generator{
+//			ImportPath: "example.com/cmd/myGen",
+//			PerPackage: true,
+//			Generate:   generate,
+//		}
+//		os.Exit(g.Main())
+//	}
+//
+// Generated Go files are named according to NameFileFromFile, start with the
+// standard "Code generated" comment followed by any license header, and are
+// formatted according to Format. Files whose contents would not change are
+// not written, so as not to disturb their modification times.
+type Generator struct {
+	// ImportPath is the import path of the generator command. Its base name
+	// is the name of the generator, and is used to identify its directives
+	// and name the files that it generates.
+	ImportPath string
+
+	// Flags is the flag set used by Run to parse the arguments passed to the
+	// generator. If nil, flag.CommandLine is used. Run defines the flags
+	// returned by LogFlag and LicenseFileFlag in Flags.
+	Flags *flag.FlagSet
+
+	// PerPackage indicates that Generate generates for all the files in the
+	// package, as opposed to for the directive that invoked it. Only the
+	// first file (by name) in the package that contains a directive for the
+	// generator results in a call to Generate; invocations from other files
+	// do nothing. At most one directive for the generator is permitted per
+	// file.
+	PerPackage bool
+
+	// Format is the formatting applied to generated Go files
+	Format Format
+
+	// Perm is the permission bits, before the umask, with which generated
+	// files are created. If zero, 0644 is used.
+	Perm os.FileMode
+
+	// Generate is called to generate files for the package in the Context.
+	// Any non-nil error is reported by Main, in which case no files are
+	// written.
+	Generate func(c *Context) error
+
+	fLicenseFile *string
+	fLogLevel    *string
+}
+
+// Context is passed to the Generate function of a Generator. It describes
+// the package for which the generator was invoked, and collects the files to
+// be generated.
+type Context struct {
+	// Dir is the absolute path of the directory containing the package
+	Dir string
+
+	// File is the base name of the file containing the directive that invoked
+	// the generator
+	File string
+
+	// Args are the non-flag arguments passed to the generator
+	Args []string
+
+	// Pkg is the package named by GOPACKAGE in Dir, loaded with
+	// packages.LoadSyntax. Where the package has test files, Pkg is the test
+	// variant of the package. Because a generator may be run before the
+	// code it generates exists, Pkg may contain type errors.
+	Pkg *packages.Package
+
+	gen     *Generator
+	license string
+	logger  *log.Logger
+
+	// outputs maps the absolute name of a generated file to its contents;
+	// goOutputs records those that are Go files
+	outputs   map[string]*bytes.Buffer
+	goOutputs map[string]bool
+}
+
+// Name returns the name of the generator
+func (g *Generator) Name() string {
+	return path.Base(g.ImportPath)
+}
+
+// Main runs the generator as a go generate directive, calling Run for the
+// current directory with the file and package named in the environment set
+// by go generate, and the arguments in os.Args. Errors are reported to
+// stderr. Main returns an exit code suitable for passing to os.Exit.
+func (g *Generator) Main() int {
+	switch err := g.main(); err {
+	case nil:
+		return 0
+	case flag.ErrHelp:
+		return 2
+	default:
+		fmt.Fprintf(os.Stderr, "%v: %v\n", g.Name(), err)
+		return 1
+	}
+}
+
+func (g *Generator) main() error {
+	args, err := g.parseFlags(os.Args[1:])
+	if err != nil {
+		return err
+	}
+	file, ok := os.LookupEnv(GOFILE)
+	if !ok {
+		return fmt.Errorf("env not correct; missing %v", GOFILE)
+	}
+	pkgName, ok := os.LookupEnv(GOPACKAGE)
+	if !ok {
+		return fmt.Errorf("env not correct; missing %v", GOPACKAGE)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("unable to get working directory: %v", err)
+	}
+	return g.run(wd, file, pkgName, args)
+}
+
+// Run runs the generator as if it were invoked with the arguments args by a
+// directive in file, a file in the package named pkgName in dir. Where the
+// generator is not PerPackage, file and pkgName may be empty, in which case
+// Pkg is the non-test package in dir. Files are only written if Generate
+// succeeds.
+func (g *Generator) Run(dir, file, pkgName string, args []string) error {
+	args, err := g.parseFlags(args)
+	if err != nil {
+		return err
+	}
+	return g.run(dir, file, pkgName, args)
+}
+
+// parseFlags parses args using g.Flags, returning the non-flag arguments
+func (g *Generator) parseFlags(args []string) ([]string, error) {
+	fs := g.Flags
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	if g.fLicenseFile == nil {
+		g.fLicenseFile = LicenseFileFlag(fs)
+		g.fLogLevel = LogFlag(fs)
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+func (g *Generator) run(dir, file, pkgName string, args []string) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("could not make absolute path from %v: %v", dir, err)
+	}
+
+	c := &Context{
+		Dir:       dir,
+		File:      file,
+		Args:      args,
+		gen:       g,
+		logger:    log.New(os.Stderr, g.Name()+": ", 0),
+		outputs:   make(map[string]*bytes.Buffer),
+		goOutputs: make(map[string]bool),
+	}
+
+	if g.PerPackage {
+		first, err := g.isFirstFile(dir, file, pkgName)
+		if err != nil {
+			return err
+		}
+		if !first {
+			c.Infof("skipping %v; not the first file in package %v with a directive", file, pkgName)
+			return nil
+		}
+	}
+
+	if c.license, err = CommentLicenseHeader(g.fLicenseFile); err != nil {
+		return fmt.Errorf("could not comment license file: %v", err)
+	}
+
+	if c.Pkg, err = loadPackage(dir, pkgName); err != nil {
+		return err
+	}
+
+	if err := g.Generate(c); err != nil {
+		return err
+	}
+
+	return c.write()
+}
+
+// isFirstFile reports whether file is the first file in the package pkgName
+// in dir that contains a directive for g
+func (g *Generator) isFirstFile(dir, file, pkgName string) (bool, error) {
+	pkgMatches, err := filesContainingCmd(dir, g.ImportPath, buildTags())
+	if err != nil {
+		return false, fmt.Errorf("could not determine if we are the first file: %v", err)
+	}
+	matches := pkgMatches[pkgName]
+	if n := matches[file]; n > 1 {
+		return false, fmt.Errorf("expected a single occurrence of %v directive in %v; got %v", g.Name(), file, n)
+	}
+	for fn := range matches {
+		if fn < file {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// buildTags returns the build tags that apply to a generator invocation:
+// the target operating system and architecture, along with any tags given
+// via GOFLAGS
+func buildTags() map[string]bool {
+	tags := make(map[string]bool)
+
+	goos := os.Getenv(GOOS)
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+	tags[goos] = true
+
+	goarch := os.Getenv(GOARCH)
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+	tags[goarch] = true
+
+	for _, f := range strings.Fields(os.Getenv("GOFLAGS")) {
+		f = strings.TrimPrefix(strings.TrimPrefix(f, "-"), "-")
+		if !strings.HasPrefix(f, "tags=") {
+			continue
+		}
+		for _, t := range strings.Split(strings.TrimPrefix(f, "tags="), ",") {
+			if t != "" {
+				tags[t] = true
+			}
+		}
+	}
+
+	return tags
+}
+
+// forTest matches the suffix of the ID of a package that is compiled for a
+// test
+var forTest = regexp.MustCompile(` \[[^\]]+\]$`)
+
+// loadPackage loads the package named pkgName in dir. pkgName may name the
+// external test package, and where there are test files in pkgName the test
+// variant of the package is returned. If pkgName is empty, the non-test
+// package in dir is returned.
+func loadPackage(dir, pkgName string) (*packages.Package, error) {
+	config := &packages.Config{
+		Mode:  packages.LoadSyntax,
+		Dir:   dir,
+		Fset:  token.NewFileSet(),
+		Tests: true,
+	}
+
+	pkgs, err := packages.Load(config, ".")
+	if err != nil {
+		return nil, fmt.Errorf("could not load packages from dir %v: %v", dir, err)
+	}
+
+	testPkgs := make(map[string]*packages.Package)
+	var nonTestPkg *packages.Package
+
+	// Becase of https://github.com/golang/go/issues/27910 we have to
+	// apply some janky logic to find the "right" package
+	for _, p := range pkgs {
+		switch {
+		case strings.HasSuffix(p.PkgPath, ".test"):
+			// we don't ever want this package
+			continue
+		case forTest.MatchString(p.ID):
+			testPkgs[p.Name] = p
+		default:
+			nonTestPkg = p
+		}
+	}
+
+	ids := func() []string {
+		var ids []string
+		for _, p := range pkgs {
+			ids = append(ids, p.ID)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	if nonTestPkg == nil {
+		return nil, fmt.Errorf("always expect to have the actual package. Got %v", ids())
+	}
+
+	if strings.HasSuffix(pkgName, "_test") {
+		pkg := testPkgs[pkgName]
+		if pkg == nil {
+			return nil, fmt.Errorf("called with package name %v, but go/packages did not give us such a package. Got %v", pkgName, ids())
+		}
+		return pkg, nil
+	}
+
+	pkg := testPkgs[pkgName]
+	if pkg == nil {
+		pkg = nonTestPkg
+	}
+	if pkgName != "" && pkg.Name != pkgName {
+		return nil, fmt.Errorf("expected to load package %v, instead loaded %v", pkgName, pkg.Name)
+	}
+	return pkg, nil
+}
+
+// Name returns the name of the generator
+func (c *Context) Name() string {
+	return c.gen.Name()
+}
+
+// Files returns the syntax of the files in c.Pkg, excluding those generated
+// by the generator
+func (c *Context) Files() []*ast.File {
+	var res []*ast.File
+	for _, f := range c.Pkg.Syntax {
+		if FileGeneratedBy(c.Pkg.Fset.Position(f.Pos()).Filename, c.Name()) {
+			continue
+		}
+		res = append(res, f)
+	}
+	return res
+}
+
+// Infof logs a message to stderr if the log level flag defined by Run is set
+// to LogInfo
+func (c *Context) Infof(format string, args ...interface{}) {
+	if *c.gen.fLogLevel == string(LogInfo) {
+		c.logger.Printf(format, args...)
+	}
+}
+
+// Output returns the buffer for the Go file generated from file, a Go file
+// in c.Pkg. The file is named according to NameFileFromFile, and the buffer
+// is initialised with the standard "Code generated" comment followed by the
+// license header, if any; the package clause is left to the caller.
+// Subsequent calls for the same file return the same buffer. Output panics
+// if file is not a Go file.
+func (c *Context) Output(file string) *bytes.Buffer {
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(c.Dir, file)
+	}
+	fn, ok := NameFileFromFile(file, c.Name())
+	if !ok {
+		panic(fmt.Errorf("cannot name generated file for %v; not a Go file", file))
+	}
+	if buf, ok := c.outputs[fn]; ok {
+		return buf
+	}
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "// Code generated by %v. DO NOT EDIT.\n\n", c.Name())
+	buf.WriteString(c.license)
+	c.outputs[fn] = buf
+	c.goOutputs[fn] = true
+	return buf
+}
+
+// RawOutput returns the buffer for the file name in c.Dir. The contents of
+// the buffer are written as is. Subsequent calls for the same name return
+// the same buffer. RawOutput panics if name is not the name of a file
+// generated by the generator, as defined by AnyFileGeneratedBy.
+func (c *Context) RawOutput(name string) *bytes.Buffer {
+	if filepath.Base(name) != name || !AnyFileGeneratedBy(name, c.Name()) {
+		panic(fmt.Errorf("%v is not the name of a file generated by %v", name, c.Name()))
+	}
+	fn := filepath.Join(c.Dir, name)
+	if buf, ok := c.outputs[fn]; ok {
+		return buf
+	}
+	buf := new(bytes.Buffer)
+	c.outputs[fn] = buf
+	return buf
+}
+
+// write formats and writes the outputs of c. Where a Go file fails to format
+// its unformatted contents are written, to aid debugging, and an error is
+// returned.
+func (c *Context) write() error {
+	var fns []string
+	for fn := range c.outputs {
+		fns = append(fns, fn)
+	}
+	sort.Strings(fns)
+
+	perm := c.gen.Perm
+	if perm == 0 {
+		perm = 0644
+	}
+
+	var formatErr error
+	for _, fn := range fns {
+		toWrite := c.outputs[fn].Bytes()
+		if c.goOutputs[fn] {
+			res, err := c.gen.format(fn, toWrite)
+			if err == nil {
+				toWrite = res
+			} else if formatErr == nil {
+				formatErr = fmt.Errorf("failed to format %v: %v", fn, err)
+			}
+		}
+		if prev, err := ioutil.ReadFile(fn); err == nil && bytes.Equal(prev, toWrite) {
+			c.Infof("%v is unchanged", fn)
+			continue
+		}
+		if err := ioutil.WriteFile(fn, toWrite, perm); err != nil {
+			return fmt.Errorf("could not write %v: %v", fn, err)
+		}
+	}
+	return formatErr
+}
+
+func (g *Generator) format(fn string, src []byte) ([]byte, error) {
+	switch g.Format {
+	case FormatGofmt:
+		// go/format does not expose gofmt's -s simplification, hence we use
+		// gofmt itself
+		out := new(bytes.Buffer)
+		stderr := new(bytes.Buffer)
+		cmd := exec.Command("gofmt", "-s")
+		cmd.Stdin = bytes.NewReader(src)
+		cmd.Stdout = out
+		cmd.Stderr = stderr
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("failed to run %v: %v\n%s", strings.Join(cmd.Args, " "), err, stderr.Bytes())
+		}
+		return out.Bytes(), nil
+	case FormatGoimports:
+		return imports.Process(fn, src, nil)
+	default:
+		panic(fmt.Errorf("unknown format %v", g.Format))
+	}
+}
@@ -4,7 +4,8 @@
 // Package gogenerate exposes some of the unexported internals of the go generate command as a convenience
 // for the authors of go generate generators. See https://github.com/myitcv/gogenerate/wiki/Go-Generate-Notes
 // for further notes on such generators. It also exposes some convenience functions that might be useful
-// to authors of generators
+// to authors of generators, and Generator, a framework for writing generators that work on a
+// type-checked package.
 //
 package gogenerate
 
@@ -295,17 +296,38 @@ func DefaultLogLevel(f *string, ll LogLevel) {
 // PATH-based commands, the filepath.Base of each is compared. The file names
 // will, by definition, be relative to dir
 func FilesContainingCmd(dir string, command string, tags map[string]bool) (map[string]int, error) {
+	pkgMatches, err := filesContainingCmd(dir, command, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := map[string]int{}
+	for _, pm := range pkgMatches {
+		for fname, n := range pm {
+			matches[fname] += n
+		}
+	}
+
+	return matches, nil
+}
+
+// filesContainingCmd is like FilesContainingCmd except that the result is
+// keyed by package name
+func filesContainingCmd(dir string, command string, tags map[string]bool) (map[string]map[string]int, error) {
 	pkgs, err := getCandidateFiles(dir, tags)
 	if err != nil {
 		return nil, err
 	}
 
 	command = strings.TrimSpace(command)
-	matches := map[string]int{}
+	pkgMatches := map[string]map[string]int{}
 	cmdstr := filepath.Base(filepath.FromSlash(command))
 	cmdIsPathBased := string(os.PathSeparator) == "/" || strings.Index(command, string(os.PathSeparator)) == -1
 
 	for pname, files := range pkgs {
+		matches := map[string]int{}
+		pkgMatches[pname] = matches
+
 		for _, f := range files {
 			fname := filepath.Base(f)
 			checkMatch := func(line int, args []string) error {
@@ -315,16 +337,7 @@ func FilesContainingCmd(dir string, command string, tags map[string]bool) (map[s
 
 					// NOTE: Create flagset similar to the gobin cmd to parse all flags and consume
 					// output of Args() to determine if the import command is a part of the args
-					fs := flag.NewFlagSet("gobin", 0)
-					fs.Bool("m", false, "resolve dependencies via the main module (as given by go env GOMOD)")
-					fs.String("mod", "", "provide additional control over updating and use of go.mod")
-					frun := fs.Bool("run", false, "run the provided main package")
-					fs.Bool("p", false, "print gobin install cache location for main packages")
-					fs.Bool("v", false, "print the module path and version for main packages")
-					fs.Bool("d", false, "stop after installing main packages to the gobin install cache")
-					fs.Bool("u", false, "check for the latest tagged version of main packages")
-					fs.Bool("nonet", false, "prevent network access")
-					fs.Bool("debug", false, "print debug information")
+					fs, _, frun := newGobinFlagSet()
 
 					err := fs.Parse(args[1:])
 					if err != nil {
@@ -351,7 +364,7 @@ func FilesContainingCmd(dir string, command string, tags map[string]bool) (map[s
 		}
 	}
 
-	return matches, nil
+	return pkgMatches, nil
 }
 
 func getCandidateFiles(dir string, tags map[string]bool) (map[string][]string, error) {
@@ -3,6 +3,8 @@ package gogenerate
 import (
 	"flag"
 	"fmt"
+	"go/ast"
+	"go/token"
 	"io/ioutil"
 	"os"
 	"os/exec"
@@ -10,6 +12,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/rogpeppe/go-internal/gotooltest"
 	"github.com/rogpeppe/go-internal/testscript"
@@ -48,9 +51,49 @@ func TestMain(m *testing.M) {
 
 			return 0
 		},
+		"gen": genMain,
 	}))
 }
 
+// genMain is a generator built using Generator. For each type declared in
+// the package, it generates a method that returns the underlying type, and
+// writes the names of the types to gen_types_gen.txt. Each Go file also
+// declares a composite literal that gofmt -s simplifies.
+func genMain() int {
+	g := &Generator{
+		ImportPath: "mod.com/gen",
+		PerPackage: true,
+		Generate: func(c *Context) error {
+			var names []string
+			for _, f := range c.Files() {
+				var specs []*ast.TypeSpec
+				for _, d := range f.Decls {
+					if gd, ok := d.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
+						for _, s := range gd.Specs {
+							specs = append(specs, s.(*ast.TypeSpec))
+						}
+					}
+				}
+				if len(specs) == 0 {
+					continue
+				}
+				out := c.Output(c.Pkg.Fset.Position(f.Pos()).Filename)
+				fmt.Fprintf(out, "package %v\n\n", c.Pkg.Name)
+				fmt.Fprintf(out, "var _ = []struct{}{struct{}{}}\n\n")
+				for _, ts := range specs {
+					u := c.Pkg.TypesInfo.Defs[ts.Name].Type().Underlying()
+					fmt.Fprintf(out, "func (%v) Underlying() string { return %q }\n", ts.Name.Name, u)
+					names = append(names, ts.Name.Name)
+				}
+			}
+			sort.Strings(names)
+			fmt.Fprintln(c.RawOutput("gen_types_gen.txt"), strings.Join(names, " "))
+			return nil
+		},
+	}
+	return g.Main()
+}
+
 type gogenMain struct {
 	m *testing.M
 }
@@ -105,9 +148,50 @@ func TestScripts(t *testing.T) {
 		},
 	}
 
+	params.Cmds = map[string]func(ts *testscript.TestScript, neg bool, args []string){
+		"backdate":  cmdBackdate,
+		"backdated": cmdBackdated,
+	}
+
 	if err := gotooltest.Setup(&params); err != nil {
 		t.Fatal(err)
 	}
 
 	testscript.Run(t, params)
 }
+
+// backdateTime is the modification time set by the backdate command
+var backdateTime = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// cmdBackdate sets the modification time of each of the named files to
+// backdateTime, so that cmdBackdated can later check whether they have been
+// written
+func cmdBackdate(ts *testscript.TestScript, neg bool, args []string) {
+	if neg {
+		ts.Fatalf("unsupported: ! backdate")
+	}
+	if len(args) == 0 {
+		ts.Fatalf("usage: backdate file...")
+	}
+	for _, fn := range args {
+		ts.Check(os.Chtimes(ts.MkAbs(fn), backdateTime, backdateTime))
+	}
+}
+
+// cmdBackdated checks that each of the named files has the modification time
+// set by cmdBackdate, i.e. that it has not been written since
+func cmdBackdated(ts *testscript.TestScript, neg bool, args []string) {
+	if len(args) == 0 {
+		ts.Fatalf("usage: [!] backdated file...")
+	}
+	for _, fn := range args {
+		fi, err := os.Stat(ts.MkAbs(fn))
+		ts.Check(err)
+		if got := fi.ModTime().Equal(backdateTime); got == neg {
+			if neg {
+				ts.Fatalf("%v has not been written", fn)
+			}
+			ts.Fatalf("%v has been written; modification time is %v", fn, fi.ModTime())
+		}
+	}
+}
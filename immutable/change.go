@@ -0,0 +1,101 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package immutable
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ChangeKind is the kind of a Change
+type ChangeKind int
+
+const (
+	// ChangeModified indicates a value that exists in both the receiver and
+	// argument of Diff, but differs
+	ChangeModified ChangeKind = iota
+
+	// ChangeAdded indicates a map entry or slice element that exists only in
+	// the argument of Diff
+	ChangeAdded
+
+	// ChangeRemoved indicates a map entry or slice element that exists only in
+	// the receiver of Diff
+	ChangeRemoved
+)
+
+func (c ChangeKind) String() string {
+	switch c {
+	case ChangeModified:
+		return "modified"
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", int(c))
+	}
+}
+
+// Change is a difference between two immutable values, as returned by the
+// Diff method of types generated by immutableGen. For example, given:
+//
+// 	c := s1.Diff(s2)
+//
+// c[i].Old is the value in s1, and c[i].New the value in s2, at the path
+// c[i].Path.
+type Change struct {
+	// Path identifies the location of the change relative to the receiver of
+	// Diff, using Go selector and index syntax, e.g. .Name, [3] or .Map["key"].
+	// The empty path identifies the receiver itself.
+	Path string
+
+	Kind ChangeKind
+
+	// Old is the value in the receiver of Diff; it is nil for ChangeAdded.
+	Old interface{}
+
+	// New is the value in the argument of Diff; it is nil for ChangeRemoved.
+	New interface{}
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("%v: added %v", c.Path, c.New)
+	case ChangeRemoved:
+		return fmt.Sprintf("%v: removed %v", c.Path, c.Old)
+	default:
+		return fmt.Sprintf("%v: %v -> %v", c.Path, c.Old, c.New)
+	}
+}
+
+// IndexPath returns the Change path of the slice index i
+func IndexPath(i int) string {
+	return fmt.Sprintf("[%v]", i)
+}
+
+// KeyPath returns the Change path of the map key k
+func KeyPath(k interface{}) string {
+	return fmt.Sprintf("[%#v]", k)
+}
+
+// PrefixChanges prefixes the path of each of cs with prefix, returning cs.
+// It is used by the Diff method of an immutable type to incorporate the result
+// of Diff on a nested immutable value.
+func PrefixChanges(prefix string, cs []Change) []Change {
+	for i := range cs {
+		cs[i].Path = prefix + cs[i].Path
+	}
+	return cs
+}
+
+// SortChanges sorts cs by path, returning cs. It is used by the Diff method of
+// immutable maps, for which the order of iteration is not defined.
+func SortChanges(cs []Change) []Change {
+	sort.SliceStable(cs, func(i, j int) bool {
+		return cs[i].Path < cs[j].Path
+	})
+	return cs
+}
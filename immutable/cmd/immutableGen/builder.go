@@ -0,0 +1,213 @@
+package main
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+
+	"myitcv.io/immutable"
+)
+
+const (
+	// requiredTagValue is the value of the immutableGen struct tag key that
+	// marks a field of a struct template as required, e.g.:
+	//
+	// 	Name string `immutableGen:"required"`
+	//
+	requiredTagValue = "required"
+)
+
+// requiredFields returns the names of the fields of s marked as required
+func (s *immStruct) requiredFields() []string {
+	var res []string
+
+	for _, f := range s.fields {
+		if f.field.Tag == nil {
+			continue
+		}
+
+		tag, err := strconv.Unquote(f.field.Tag.Value)
+		if err != nil {
+			continue
+		}
+
+		v, ok := reflect.StructTag(tag).Lookup(immutable.CmdImmutableGen)
+		if !ok {
+			continue
+		}
+
+		if v != requiredTagValue {
+			fatalf("%v: unknown %v struct tag value %q", s.fset.Position(f.field.Pos()), immutable.CmdImmutableGen, v)
+		}
+
+		if _, ok := s.methods[f.name]; !ok {
+			fatalf("%v: required field %v is not promoted to %v", s.fset.Position(f.field.Pos()), f.name, s.name)
+		}
+
+		res = append(res, f.name)
+	}
+
+	return res
+}
+
+// genStructBuilder generates a builder type for the immutable struct s, and a
+// constructor that takes functional options in terms of that builder, if the
+// template of s is marked with builderDirective. Nothing is generated where a
+// name we would generate is already declared.
+func (o *output) genStructBuilder(s *immStruct) {
+	req := s.requiredFields()
+
+	if !s.builder {
+		if len(req) > 0 {
+			fatalf("%v: required fields are only supported in struct templates marked %v", s.fset.Position(s.syn.Pos()), builderDirective)
+		}
+		return
+	}
+
+	exp := exporter(s.name)
+
+	tmpl := struct {
+		Name     string
+		Builder  string
+		Option   string
+		Required []string
+		Field    string
+		Type     string
+	}{
+		Name:     s.name,
+		Builder:  s.name + "Builder",
+		Option:   s.name + "Option",
+		Required: req,
+	}
+
+	newName := exp["Export"].(func(string) string)("New") + capitalise(s.name)
+
+	if o.declared(tmpl.Builder) || o.declared(newName+"Builder") {
+		return
+	}
+
+	o.pt(`
+	// {{.Builder}} builds a {{.Name}} using fluent setters. Create a
+	// {{.Builder}} using {{Export "New"}}{{Capitalise .Name}}Builder.
+	type {{.Builder}} struct {
+		v *{{.Name}}
+	{{- if .Required}}
+
+		// set records which of the required fields have been set
+		set map[string]bool
+	{{- end}}
+	}
+
+	// {{Export "New"}}{{Capitalise .Name}}Builder returns a new {{.Builder}}
+	func {{Export "New"}}{{Capitalise .Name}}Builder() *{{.Builder}} {
+		return &{{.Builder}}{
+			v: new({{.Name}}).AsMutable(),
+		{{- if .Required}}
+			set: make(map[string]bool),
+		{{- end}}
+		}
+	}
+
+	// Build returns the immutable {{.Name}} built by b.
+	{{- if .Required}} Build panics if any of
+	// the required fields of {{.Name}} have not been set.
+	{{- end}} b can continue
+	// to be used after a call to Build; values already built are unaffected.
+	func (b *{{.Builder}}) Build() *{{.Name}} {
+	{{- range .Required}}
+		if !b.set["{{.}}"] {
+			panic("{{$.Builder}}: required field {{.}} has not been set")
+		}
+	{{- end}}
+		b.v = b.v.AsImmutable(nil)
+		return b.v
+	}
+	`, exp, tmpl)
+
+	required := make(map[string]bool)
+	for _, r := range tmpl.Required {
+		required[r] = true
+	}
+
+	var mns []string
+	for n := range s.methods {
+		mns = append(mns, n)
+	}
+
+	sort.Strings(mns)
+
+	for _, n := range mns {
+		tmpl.Field = n
+		tmpl.Type = s.methods[n].typ
+
+		exp := exporter(n)
+
+		o.pt(`
+		// {{Export "Set"}}{{Capitalise .Field}} sets {{Capitalise .Field}}() of the {{.Name}} being built
+		func (b *{{.Builder}}) {{Export "Set"}}{{Capitalise .Field}}(n {{.Type}}) *{{.Builder}} {
+			b.v = b.v.{{Export "Set"}}{{Capitalise .Field}}(n)
+		`, exp, tmpl)
+		if required[n] {
+			o.pt(`
+			b.set["{{.Field}}"] = true
+			`, exp, tmpl)
+		}
+		o.pt(`
+			return b
+		}
+		`, exp, tmpl)
+	}
+
+	if o.declared(tmpl.Option) || o.declared(newName) {
+		return
+	}
+
+	o.pt(`
+	// {{.Option}} is a functional option that configures the {{.Name}}
+	// constructed by {{Export "New"}}{{Capitalise .Name}}
+	type {{.Option}} func(b *{{.Builder}})
+
+	// {{Export "New"}}{{Capitalise .Name}} returns a new immutable {{.Name}} configured by opts.
+	{{- if .Required}}
+	// {{Export "New"}}{{Capitalise .Name}} panics if any of the required fields of {{.Name}} are
+	// not set by opts.
+	{{- end}}
+	func {{Export "New"}}{{Capitalise .Name}}(opts ...{{.Option}}) *{{.Name}} {
+		b := {{Export "New"}}{{Capitalise .Name}}Builder()
+		for _, o := range opts {
+			o(b)
+		}
+		return b.Build()
+	}
+	`, exp, tmpl)
+
+	for _, n := range mns {
+		tmpl.Field = n
+		tmpl.Type = s.methods[n].typ
+
+		fn := s.name + "With" + capitalise(n)
+		if o.declared(fn) {
+			continue
+		}
+
+		o.pt(`
+		// {{.Name}}With{{Capitalise .Field}} returns a {{.Option}} that sets {{Capitalise .Field}}()
+		func {{.Name}}With{{Capitalise .Field}}(n {{.Type}}) {{.Option}} {
+			return func(b *{{.Builder}}) {
+				b.{{Export "Set"}}{{Capitalise .Field}}(n)
+			}
+		}
+		`, exporter(n), tmpl)
+	}
+}
+
+// declared returns whether name is declared at package level other than by
+// immutableGen, or is the name of an immutable type we generate
+func (o *output) declared(name string) bool {
+	if o.decls[name] {
+		return true
+	}
+
+	_, ok := o.immTmpls["*"+name]
+	return ok
+}
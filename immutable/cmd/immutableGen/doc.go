@@ -1,11 +1,38 @@
 /*
 
-immutableGen is a go generate generator that creates immutable struct, map and
-slice type declarations from template type declarations.
+immutableGen is a go generate generator that creates immutable struct, map,
+slice and set type declarations from template type declarations. A set template
+is a map template with the value type myitcv.io/immutable.Set.
 
 All such generated types "implement" a common immutable "interface" as well as
 providing functions and methods specific to the immutable data structure
-(struct, map or slice).
+(struct, map, slice or set).
+
+Generated types also implement json.Marshaler and json.Unmarshaler, honouring
+json struct tags on the fields of struct templates.
+
+Generated types also have Equal and Diff methods, that compare values deeply
+and report the changes between them as a []immutable.Change.
+
+Struct templates marked with a //immutableGen:builder comment also have a
+builder type, TBuilder, and a constructor, NewT, that takes functional options.
+Fields of such templates tagged `immutableGen:"required"` must be set before the
+builder's Build method is called.
+
+Map and slice templates marked with a //immutableGen:persistent comment are
+backed by persistent data structures from myitcv.io/immutable/persistent, such
+that updates are O(log n) with structural sharing rather than O(n) copies.
+
+Map templates marked with a //immutableGen:ordered comment are backed by a
+persistent balanced tree, and so iterate (and marshal to JSON) in a
+deterministic order: the natural order of the key type by default, or that of
+insertion (//immutableGen:ordered insertion) or of a less function
+(//immutableGen:ordered lessFunc).
+
+With the -container flag, or for templates marked with a
+//immutableGen:container comment, map and slice types are generated as thin
+wrappers around the generic types in myitcv.io/immutable/container, reducing
+the size of the generated code.
 
 For more information see https://myitcv.io/immutable/wiki/immutableGen
 
@@ -0,0 +1,459 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+import (
+	"fmt"
+	"go/types"
+
+	"myitcv.io/immutable/util"
+)
+
+const (
+	equalMethod = "Equal"
+	diffMethod  = "Diff"
+)
+
+// isGenImm returns whether t, the string form of which is typ, is a pointer to
+// a type generated by immutableGen, and hence has Equal and Diff methods
+func (o *output) isGenImm(t types.Type, typ string) bool {
+	switch o.isImm(t, typ).(type) {
+	case util.ImmTypeStruct, util.ImmTypeMap, util.ImmTypeSet, util.ImmTypeSlice:
+		return true
+	}
+	return false
+}
+
+// eqExpr returns an expression that compares a and b, both of type t (the
+// string form of which is typ), for equality. Types generated by immutableGen
+// are compared using their Equal method; other comparable types (except
+// interfaces) using ==; anything else using reflect.DeepEqual.
+func (o *output) eqExpr(t types.Type, typ, a, b string) string {
+	if o.isGenImm(t, typ) {
+		return fmt.Sprintf("%v.Equal(%v)", a, b)
+	}
+	if t != nil && !typeIsInvalid(t) {
+		if _, ok := t.Underlying().(*types.Interface); !ok && types.Comparable(t) {
+			return fmt.Sprintf("%v == %v", a, b)
+		}
+	}
+	o.stdImports["reflect"] = true
+	return fmt.Sprintf("reflect.DeepEqual(%v, %v)", a, b)
+}
+
+// elemCmp describes how to compare the values of an immutable map or slice
+type elemCmp struct {
+	Name string
+
+	// KeyType is the key type of a map
+	KeyType string
+
+	// Type is the type of the values
+	Type string
+
+	// Eq compares the values a and b
+	Eq string
+
+	// Diffable indicates the values have a Diff method
+	Diffable bool
+
+	// Runtime indicates the values are held by a persistent, container or
+	// ordered type, that provides Equal and Diff methods
+	Runtime bool
+
+	// Container indicates the values are held by a container type, that also
+	// provides a Changes method
+	Container bool
+}
+
+// changeClosure declares the function change within Diff, that records the
+// change at path p of a value a (present if aok) to b (present if bok)
+const changeClosure = `
+	var res []immutable.Change
+
+	change := func(p string, a {{.Type}}, aok bool, b {{.Type}}, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		{{- if .Diffable}}
+		case a != nil && b != nil:
+			res = append(res, immutable.PrefixChanges(p, a.Diff(b))...)
+		{{- end}}
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+`
+
+// containerChanges returns the source within Diff that returns the result of
+// the Changes method of the container.Map or container.Slice in field
+func containerChanges(field string) string {
+	return `
+	return m.` + field + `.Changes(other.` + field + `, func(a, b {{.Type}}) bool {
+		return {{.Eq}}
+	}, {{if .Diffable}}func(a, b {{.Type}}) []immutable.Change {
+		if a == nil || b == nil {
+			return nil
+		}
+
+		return a.Diff(b)
+	}{{else}}nil{{end}})
+`
+}
+
+func (o *output) genMapEqual(m *immMap, keyType, valType string) {
+	c := elemCmp{
+		Name:      m.name,
+		KeyType:   keyType,
+		Type:      valType,
+		Eq:        o.eqExpr(m.typ.Elem(), valType, "a", "b"),
+		Diffable:  o.isGenImm(m.typ.Elem(), valType),
+		Runtime:   m.persistent || m.container || m.ordered,
+		Container: m.container,
+	}
+
+	exp := exporter(m.name)
+
+	if !o.declaresMethod(m.name, equalMethod) {
+		o.pt(`
+		// Equal returns whether m and other contain the same keys with equal values
+		func (m *{{.Name}}) Equal(other *{{.Name}}) bool {
+			if m == other {
+				return true
+			}
+
+			if m == nil || other == nil {
+				return false
+			}
+		{{if .Runtime}}
+			return m.theMap.Equal(other.theMap, func(a, b {{.Type}}) bool {
+				return {{.Eq}}
+			})
+		{{- else}}
+			if len(m.theMap) != len(other.theMap) {
+				return false
+			}
+
+			for k, a := range m.theMap {
+				b, ok := other.theMap[k]
+				if !ok || !({{.Eq}}) {
+					return false
+				}
+			}
+
+			return true
+		{{- end}}
+		}
+		`, exp, c)
+	}
+
+	if !o.declaresMethod(m.name, diffMethod) {
+		o.pt(`
+		// Diff returns the changes between m and other, ordered by path. The path
+		// of a change is the key of the entry that was added, removed or
+		// modified, or the key followed by the path of a change within a
+		// modified value.
+		func (m *{{.Name}}) Diff(other *{{.Name}}) []immutable.Change {
+			if m == other {
+				return nil
+			}
+
+			if m == nil || other == nil {
+				return []immutable.Change{{"{{"}}Kind: immutable.ChangeModified, Old: m, New: other{{"}}"}}
+			}
+		{{if .Container}}`+containerChanges("theMap")+`{{- else}}`+changeClosure+`
+		{{if .Runtime}}
+			m.theMap.Diff(other.theMap, func(a, b {{.Type}}) bool {
+				return {{.Eq}}
+			}, func(k {{.KeyType}}, a {{.Type}}, aok bool, b {{.Type}}, bok bool) bool {
+				change(immutable.KeyPath(k), a, aok, b, bok)
+				return true
+			})
+		{{- else}}
+			for k, a := range m.theMap {
+				b, bok := other.theMap[k]
+				if bok && ({{.Eq}}) {
+					continue
+				}
+				change(immutable.KeyPath(k), a, true, b, bok)
+			}
+
+			for k, b := range other.theMap {
+				if _, ok := m.theMap[k]; !ok {
+					var a {{.Type}}
+					change(immutable.KeyPath(k), a, false, b, true)
+				}
+			}
+		{{- end}}
+
+			return immutable.SortChanges(res)
+		{{- end}}
+		}
+		`, exp, c)
+	}
+}
+
+func (o *output) genSetEqual(name, typ string) {
+	tmpl := struct {
+		Name string
+		Type string
+	}{
+		Name: name,
+		Type: typ,
+	}
+
+	exp := exporter(name)
+
+	if !o.declaresMethod(name, equalMethod) {
+		o.pt(`
+		// Equal returns whether m and other contain the same elements
+		func (m *{{.Name}}) Equal(other *{{.Name}}) bool {
+			if m == other {
+				return true
+			}
+
+			if m == nil || other == nil {
+				return false
+			}
+
+			if len(m.theSet) != len(other.theSet) {
+				return false
+			}
+
+			for v := range m.theSet {
+				if _, ok := other.theSet[v]; !ok {
+					return false
+				}
+			}
+
+			return true
+		}
+		`, exp, tmpl)
+	}
+
+	if !o.declaresMethod(name, diffMethod) {
+		o.pt(`
+		// Diff returns the changes between m and other, ordered by path. The path
+		// of a change is the element that was added or removed.
+		func (m *{{.Name}}) Diff(other *{{.Name}}) []immutable.Change {
+			if m == other {
+				return nil
+			}
+
+			if m == nil || other == nil {
+				return []immutable.Change{{"{{"}}Kind: immutable.ChangeModified, Old: m, New: other{{"}}"}}
+			}
+
+			var res []immutable.Change
+
+			for v := range m.theSet {
+				if _, ok := other.theSet[v]; !ok {
+					res = append(res, immutable.Change{Path: immutable.KeyPath(v), Kind: immutable.ChangeRemoved, Old: v})
+				}
+			}
+
+			for v := range other.theSet {
+				if _, ok := m.theSet[v]; !ok {
+					res = append(res, immutable.Change{Path: immutable.KeyPath(v), Kind: immutable.ChangeAdded, New: v})
+				}
+			}
+
+			return immutable.SortChanges(res)
+		}
+		`, exp, tmpl)
+	}
+}
+
+func (o *output) genSliceEqual(s *immSlice, typ string) {
+	c := elemCmp{
+		Name:      s.name,
+		Type:      typ,
+		Eq:        o.eqExpr(s.typ.Elem(), typ, "a", "b"),
+		Diffable:  o.isGenImm(s.typ.Elem(), typ),
+		Runtime:   s.persistent || s.container,
+		Container: s.container,
+	}
+
+	exp := exporter(s.name)
+
+	if !o.declaresMethod(s.name, equalMethod) {
+		o.pt(`
+		// Equal returns whether m and other have the same length and equal values
+		// at each index
+		func (m *{{.Name}}) Equal(other *{{.Name}}) bool {
+			if m == other {
+				return true
+			}
+
+			if m == nil || other == nil {
+				return false
+			}
+		{{if .Runtime}}
+			return m.theSlice.Equal(other.theSlice, func(a, b {{.Type}}) bool {
+				return {{.Eq}}
+			})
+		{{- else}}
+			if len(m.theSlice) != len(other.theSlice) {
+				return false
+			}
+
+			for i, a := range m.theSlice {
+				b := other.theSlice[i]
+				if !({{.Eq}}) {
+					return false
+				}
+			}
+
+			return true
+		{{- end}}
+		}
+		`, exp, c)
+	}
+
+	if !o.declaresMethod(s.name, diffMethod) {
+		o.pt(`
+		// Diff returns the changes between m and other, in index order. The path
+		// of a change is the index that was added, removed or modified, or the
+		// index followed by the path of a change within a modified value.
+		func (m *{{.Name}}) Diff(other *{{.Name}}) []immutable.Change {
+			if m == other {
+				return nil
+			}
+
+			if m == nil || other == nil {
+				return []immutable.Change{{"{{"}}Kind: immutable.ChangeModified, Old: m, New: other{{"}}"}}
+			}
+		{{if .Container}}`+containerChanges("theSlice")+`{{- else}}`+changeClosure+`
+		{{if .Runtime}}
+			m.theSlice.Diff(other.theSlice, func(a, b {{.Type}}) bool {
+				return {{.Eq}}
+			}, func(i int, a {{.Type}}, aok bool, b {{.Type}}, bok bool) bool {
+				change(immutable.IndexPath(i), a, aok, b, bok)
+				return true
+			})
+		{{- else}}
+			for i := 0; i < len(m.theSlice) || i < len(other.theSlice); i++ {
+				var a, b {{.Type}}
+				aok, bok := i < len(m.theSlice), i < len(other.theSlice)
+				if aok {
+					a = m.theSlice[i]
+				}
+				if bok {
+					b = other.theSlice[i]
+				}
+				if aok && bok && ({{.Eq}}) {
+					continue
+				}
+				change(immutable.IndexPath(i), a, aok, b, bok)
+			}
+		{{- end}}
+
+			return res
+		{{- end}}
+		}
+		`, exp, c)
+	}
+}
+
+// fieldCmp describes how to compare a field of an immutable struct
+type fieldCmp struct {
+	// Name is the name of the field in the template
+	Name string
+
+	// Field is the name of the field in the generated struct
+	Field string
+
+	// Eq compares the field of s and other
+	Eq string
+
+	// Diffable indicates the field has a Diff method
+	Diffable bool
+}
+
+func (o *output) genStructEqual(s *immStruct) {
+	var fields []fieldCmp
+
+	for _, f := range s.fields {
+		name := fieldNamePrefix + fieldHidingPrefix + f.name
+		if f.anon {
+			name = fieldAnonPrefix + name
+		}
+
+		t := o.info.TypeOf(f.field.Type)
+		typ := o.exprString(f.field.Type)
+
+		fields = append(fields, fieldCmp{
+			Name:     f.name,
+			Field:    name,
+			Eq:       o.eqExpr(t, typ, "s."+name, "other."+name),
+			Diffable: o.isGenImm(t, typ),
+		})
+	}
+
+	tmpl := struct {
+		Name   string
+		Fields []fieldCmp
+	}{
+		Name:   s.name,
+		Fields: fields,
+	}
+
+	exp := exporter(s.name)
+
+	if !o.declaresMethod(s.name, equalMethod) {
+		o.pt(`
+		// Equal returns whether the fields of s and other are equal
+		func (s *{{.Name}}) Equal(other *{{.Name}}) bool {
+			if s == other {
+				return true
+			}
+
+			if s == nil || other == nil {
+				return false
+			}
+		{{range .Fields}}
+			if !({{.Eq}}) {
+				return false
+			}
+		{{end}}
+			return true
+		}
+		`, exp, tmpl)
+	}
+
+	if !o.declaresMethod(s.name, diffMethod) {
+		o.pt(`
+		// Diff returns the changes between s and other, in field order. The path of
+		// a change is the name of the field that was modified, or the name
+		// followed by the path of a change within the modified field.
+		func (s *{{.Name}}) Diff(other *{{.Name}}) []immutable.Change {
+			if s == other {
+				return nil
+			}
+
+			if s == nil || other == nil {
+				return []immutable.Change{{"{{"}}Kind: immutable.ChangeModified, Old: s, New: other{{"}}"}}
+			}
+
+			var res []immutable.Change
+		{{range .Fields}}
+			if !({{.Eq}}) {
+			{{- if .Diffable}}
+				if s.{{.Field}} != nil && other.{{.Field}} != nil {
+					res = append(res, immutable.PrefixChanges({{printf "%q" (printf ".%v" .Name)}}, s.{{.Field}}.Diff(other.{{.Field}}))...)
+				} else {
+					res = append(res, immutable.Change{Path: {{printf "%q" (printf ".%v" .Name)}}, Kind: immutable.ChangeModified, Old: s.{{.Field}}, New: other.{{.Field}}})
+				}
+			{{- else}}
+				res = append(res, immutable.Change{Path: {{printf "%q" (printf ".%v" .Name)}}, Kind: immutable.ChangeModified, Old: s.{{.Field}}, New: other.{{.Field}}})
+			{{- end}}
+			}
+		{{end}}
+			return res
+		}
+		`, exp, tmpl)
+	}
+}
@@ -8,12 +8,7 @@ import (
 	"go/ast"
 	"go/token"
 	"go/types"
-	"path/filepath"
-	"regexp"
-	"sort"
-	"strings"
 
-	"golang.org/x/tools/go/packages"
 	"myitcv.io/gogenerate"
 	"myitcv.io/immutable/util"
 )
@@ -24,105 +19,25 @@ const (
 	fieldAnonPrefix   = "anon"
 )
 
-func execute(dir string, envPkg string, licenseHeader string, cmds gogenCmds) {
-
-	absDir, err := filepath.Abs(dir)
-	if err != nil {
-		fatalf("could not make absolute path from %v: %v", dir, err)
-	}
-
-	config := &packages.Config{
-		Mode:  packages.LoadSyntax,
-		Fset:  token.NewFileSet(),
-		Tests: true,
-	}
-
-	pkgs, err := packages.Load(config, absDir)
-	if err != nil {
-		fatalf("could not load packages from dir %v: %v", dir, err)
-	}
-
-	forTest := regexp.MustCompile(` \[[^\]]+\]$`)
-
-	testPkgs := make(map[string]*packages.Package)
-	var nonTestPkg *packages.Package
-
-	// Becase of https://github.com/golang/go/issues/27910 we have to
-	// apply some janky logic to find the "right" package
-	for _, p := range pkgs {
-		switch {
-		case strings.HasSuffix(p.PkgPath, ".test"):
-			// we don't ever want this package
-			continue
-		case forTest.MatchString(p.ID):
-			testPkgs[p.Name] = p
-		default:
-			nonTestPkg = p
-		}
-	}
-
-	ids := func() []string {
-		var ids []string
-		for _, p := range pkgs {
-			ids = append(ids, p.ID)
-		}
-		sort.Strings(ids)
-		return ids
-	}
-
-	if nonTestPkg == nil {
-		fatalf("always expect to have the actual package. Got %v", ids())
-	}
-
-	var pkg *packages.Package
-
-	if strings.HasSuffix(envPkg, "_test") {
-		if pkg = testPkgs[envPkg]; pkg == nil {
-			fatalf("called with package name %v, but go/packages did not give us such a package. Got %v", envPkg, ids())
-		}
-	} else {
-		if pkg = testPkgs[envPkg]; pkg == nil {
-			pkg = nonTestPkg
-		}
-	}
-
-	if pkg.Name != envPkg {
-		pps := make([]string, 0, len(pkgs))
-		for _, k := range pkgs {
-			pps = append(pps, k.Name)
-		}
-		fatalf("expected to have parsed %v, instead parsed %v", envPkg, pps)
-	}
-
-	var checkFiles []*ast.File
-	var fns []string
-
-	for indx, fn := range pkg.GoFiles {
-		// skip files that we generated
-		if gogenerate.FileGeneratedBy(fn, immutableGenCmd) {
-			continue
-		}
-		f := pkg.Syntax[indx]
-		checkFiles = append(checkFiles, f)
-		fns = append(fns, fn)
-	}
+func generate(c *gogenerate.Context) error {
+	pkg := c.Pkg
 
 	out := &output{
-		dir:       dir,
+		ctx:       c,
 		info:      pkg.TypesInfo,
 		fset:      pkg.Fset,
 		pkgName:   pkg.Name,
 		pkgPath:   pkg.PkgPath,
-		license:   licenseHeader,
-		goGenCmds: cmds,
+		goGenCmds: fGoGenCmds,
 		files:     make(map[*ast.File]*fileTmpls),
 		commMaps:  make(map[*ast.File]ast.CommentMap),
 		immTypes:  make(map[string]util.ImmType),
 		immTmpls:  make(map[string]immTmpl),
-		methods:   make(map[string]string),
+		methods:   make(map[string]map[string]bool),
+		decls:     make(map[string]bool),
 	}
 
-	for _, f := range checkFiles {
+	for _, f := range c.Files() {
 		out.curFile = f
 		out.commMaps[f] = ast.NewCommentMap(pkg.Fset, f, f.Comments)
 		out.gatherImmTypes()
@@ -132,14 +47,15 @@ func execute(dir string, envPkg string, licenseHeader string, cmds gogenCmds) {
 	out.calcMethodSets()
 
 	out.genImmTypes()
+
+	return nil
 }
 
 type output struct {
-	dir       string
+	ctx       *gogenerate.Context
 	pkgName   string
 	pkgPath   string
 	fset      *token.FileSet
-	license   string
 	goGenCmds gogenCmds
 
 	// type info about the package (and its deps) we are generating against
@@ -147,15 +63,23 @@ type output struct {
 
 	output *bytes.Buffer
 
+	// stdImports are the standard library packages that the generated file
+	// currently being written must import
+	stdImports map[string]bool
+
 	immTmpls map[string]immTmpl
 
 	// a convenience map of all the imm types we will be generating in this
 	// package. The map key here is the pointer type of the generated type.
 	immTypes map[string]util.ImmType
 
-	// methods is a map of pointer type name to method name for any methods with
+	// methods is a map of pointer type name to the names of methods with
 	// pointer receivers we visit
-	methods map[string]string
+	methods map[string]map[string]bool
+
+	// decls is the set of names declared at package level in the files we
+	// visit, i.e. not those declared by immutableGen
+	decls map[string]bool
 
 	files map[*ast.File]*fileTmpls
 
@@ -171,10 +95,44 @@ type fileTmpls struct {
 	imports map[*ast.ImportSpec]struct{}
 
 	maps    []*immMap
+	sets    []*immSet
 	slices  []*immSlice
 	structs []*immStruct
 }
 
+// hasPersistent returns whether any of the map or slice templates in f are
+// marked with persistentDirective, or any of the map templates with
+// orderedDirective
+func (f *fileTmpls) hasPersistent() bool {
+	for _, m := range f.maps {
+		if m.persistent || m.ordered {
+			return true
+		}
+	}
+	for _, s := range f.slices {
+		if s.persistent {
+			return true
+		}
+	}
+	return false
+}
+
+// hasContainer returns whether any of the map or slice templates in f are
+// generated in container mode
+func (f *fileTmpls) hasContainer() bool {
+	for _, m := range f.maps {
+		if m.container {
+			return true
+		}
+	}
+	for _, s := range f.slices {
+		if s.container {
+			return true
+		}
+	}
+	return false
+}
+
 type embedded struct {
 	typ  types.Type
 	es   string
@@ -229,6 +187,7 @@ func (o *output) gatherImmTypes() {
 
 		if fd, ok := d.(*ast.FuncDecl); ok {
 			if fd.Recv == nil {
+				o.decls[fd.Name.Name] = true
 				continue
 			}
 
@@ -253,19 +212,36 @@ func (o *output) gatherImmTypes() {
 				continue
 			}
 
-			o.methods["*"+i.Name] = fd.Name.Name
+			pt := "*" + i.Name
+			if o.methods[pt] == nil {
+				o.methods[pt] = make(map[string]bool)
+			}
+			o.methods[pt][fd.Name.Name] = true
 
 			// we can't be a type decl
 			continue
 		}
 
 		gd, ok := d.(*ast.GenDecl)
-		if !ok || gd.Tok != token.TYPE {
+		if !ok {
+			continue
+		}
+
+		if gd.Tok == token.VAR || gd.Tok == token.CONST {
+			for _, s := range gd.Specs {
+				for _, n := range s.(*ast.ValueSpec).Names {
+					o.decls[n.Name] = true
+				}
+			}
+		}
+
+		if gd.Tok != token.TYPE {
 			continue
 		}
 
 		for _, s := range gd.Specs {
 			ts := s.(*ast.TypeSpec)
+			o.decls[ts.Name.Name] = true
 
 			name, ok := util.IsImmTmpl(ts)
 			if !ok {
@@ -274,7 +250,7 @@ func (o *output) gatherImmTypes() {
 
 			typ := o.info.Defs[ts.Name].Type().(*types.Named)
 
-			infof("found immutable declaration at %v: %v", fset.Position(gd.Pos()), typ)
+			o.ctx.Infof("found immutable declaration at %v: %v", fset.Position(gd.Pos()), typ)
 
 			comm := commonImm{
 				fset: fset,
@@ -283,13 +259,75 @@ func (o *output) gatherImmTypes() {
 				dec:  gd,
 			}
 
+			persistent := hasDirective(gd, ts, persistentDirective)
+			container := hasDirective(gd, ts, containerDirective)
+
+			order, ordered := directiveArg(gd, ts, orderedDirective)
+
+			if persistent && container {
+				fatalf("%v: %v and %v are mutually exclusive", fset.Position(ts.Pos()), persistentDirective, containerDirective)
+			}
+			if ordered && (persistent || container) {
+				fatalf("%v: %v is mutually exclusive with %v and %v", fset.Position(ts.Pos()), orderedDirective, persistentDirective, containerDirective)
+			}
+
+			// the -container flag does not apply to persistent or ordered
+			// templates
+			container = container || (*fContainer && !persistent && !ordered)
+
 			switch u := typ.Underlying().(type) {
 			case *types.Map:
+				if isSetElem(u.Elem()) {
+					if persistent {
+						fatalf("%v: %v is not supported for set templates", fset.Position(ts.Pos()), persistentDirective)
+					}
+					if hasDirective(gd, ts, containerDirective) {
+						fatalf("%v: %v is not supported for set templates", fset.Position(ts.Pos()), containerDirective)
+					}
+					if ordered {
+						fatalf("%v: %v is not supported for set templates", fset.Position(ts.Pos()), orderedDirective)
+					}
+
+					syn := ts.Type.(*ast.MapType)
+
+					s := &immSet{
+						commonImm: comm,
+						name:      name,
+						typ:       u,
+						syn:       syn,
+					}
+					g.sets = append(g.sets, s)
+					o.immTypes["*"+name] = util.ImmTypeSet{}
+					o.immTmpls["*"+name] = s
+
+					// the value type is always immutable.Set, which is
+					// imported regardless
+					ast.Walk(impf, syn.Key)
+
+					break
+				}
+
+				if ordered {
+					switch {
+					case order == "":
+						if !isOrdered(u.Key()) {
+							fatalf("%v: key type %v is not ordered; specify %v or a less function with %v", fset.Position(ts.Pos()), u.Key(), insertionOrder, orderedDirective)
+						}
+					case order == insertionOrder:
+					case !token.IsIdentifier(order):
+						fatalf("%v: %v argument must be %v or the name of a less function; got %q", fset.Position(ts.Pos()), orderedDirective, insertionOrder, order)
+					}
+				}
+
 				m := &immMap{
-					commonImm: comm,
-					name:      name,
-					typ:       u,
-					syn:       ts.Type.(*ast.MapType),
+					commonImm:  comm,
+					name:       name,
+					typ:        u,
+					syn:        ts.Type.(*ast.MapType),
+					persistent: persistent,
+					container:  container,
+					ordered:    ordered,
+					order:      order,
 				}
 				g.maps = append(g.maps, m)
 				o.immTypes["*"+name] = util.ImmTypeMap{}
@@ -298,12 +336,18 @@ func (o *output) gatherImmTypes() {
 				ast.Walk(impf, ts.Type)
 
 			case *types.Slice:
+				if ordered {
+					fatalf("%v: %v is only supported for map templates", fset.Position(ts.Pos()), orderedDirective)
+				}
+
 				// TODO support for arrays
 				s := &immSlice{
-					commonImm: comm,
-					name:      name,
-					typ:       u,
-					syn:       ts.Type.(*ast.ArrayType),
+					commonImm:  comm,
+					name:       name,
+					typ:        u,
+					syn:        ts.Type.(*ast.ArrayType),
+					persistent: persistent,
+					container:  container,
 				}
 
 				g.slices = append(g.slices, s)
@@ -313,6 +357,16 @@ func (o *output) gatherImmTypes() {
 				ast.Walk(impf, ts.Type)
 
 			case *types.Struct:
+				if persistent {
+					fatalf("%v: %v is only supported for map and slice templates", fset.Position(ts.Pos()), persistentDirective)
+				}
+				if hasDirective(gd, ts, containerDirective) {
+					fatalf("%v: %v is only supported for map and slice templates", fset.Position(ts.Pos()), containerDirective)
+				}
+				if ordered {
+					fatalf("%v: %v is only supported for map templates", fset.Position(ts.Pos()), orderedDirective)
+				}
+
 				astst := ts.Type.(*ast.StructType)
 
 				var fields []astField
@@ -341,6 +395,7 @@ func (o *output) gatherImmTypes() {
 					syn:       ts.Type.(*ast.StructType),
 					special:   isSpecialStruct(name, u),
 					fields:    fields,
+					builder:   hasDirective(gd, ts, builderDirective),
 				}
 
 				g.structs = append(g.structs, s)
@@ -8,27 +8,36 @@ import (
 	"go/parser"
 	"go/printer"
 	"go/token"
-	"io/ioutil"
-	"os/exec"
+	"path"
+	"sort"
+	"strconv"
 	"strings"
 
-	"myitcv.io/gogenerate"
+	"myitcv.io/immutable"
 )
 
 func (o *output) genImmTypes() {
 	for f, v := range o.files {
 		o.curFile = f
 
-		if len(v.maps) == 0 && len(v.slices) == 0 && len(v.structs) == 0 {
+		if len(v.maps) == 0 && len(v.sets) == 0 && len(v.slices) == 0 && len(v.structs) == 0 {
 			continue
 		}
 
-		o.output = bytes.NewBuffer(nil)
+		out := o.ctx.Output(o.fset.Position(f.Pos()).Filename)
 
-		o.pfln("// Code generated by %v. DO NOT EDIT.", immutableGenCmd)
-		o.pln("")
+		// generate the types first, in order that we know which standard
+		// library imports are required
+		o.output = new(bytes.Buffer)
+		o.stdImports = make(map[string]bool)
 
-		o.pf(o.license)
+		o.genImmMaps(v.maps)
+		o.genImmSets(v.sets)
+		o.genImmSlices(v.slices)
+		o.genImmStructs(v.structs)
+
+		body := o.output
+		o.output = out
 
 		o.pf("package %v\n", o.pkgName)
 
@@ -42,10 +51,30 @@ func (o *output) genImmTypes() {
 
 		o.pln("import (")
 
+		var stdImports []string
+		for p := range o.stdImports {
+			stdImports = append(stdImports, p)
+		}
+		sort.Strings(stdImports)
+		for _, p := range stdImports {
+			o.pfln("%q", p)
+		}
+		o.pln()
+
 		o.pln("\"myitcv.io/immutable\"")
+		if v.hasContainer() {
+			o.pfln("%q", immutable.ContainerPkgImportPath)
+		}
+		if v.hasPersistent() {
+			o.pfln("%q", immutable.PersistentPkgImportPath)
+		}
 		o.pln()
 
 		for i := range v.imports {
+			if p, _ := strconv.Unquote(i.Path.Value); o.stdImports[p] && (i.Name == nil || i.Name.Name == path.Base(p)) {
+				// already imported above
+				continue
+			}
 			if i.Name != nil {
 				o.pfln("%v %v", i.Name.Name, i.Path.Value)
 			} else {
@@ -57,37 +86,7 @@ func (o *output) genImmTypes() {
 
 		o.pln("")
 
-		o.genImmMaps(v.maps)
-		o.genImmSlices(v.slices)
-		o.genImmStructs(v.structs)
-
-		source := o.output.Bytes()
-
-		toWrite := source
-
-		fn := o.fset.Position(f.Pos()).Filename
-
-		// this is the file path
-		offn, ok := gogenerate.NameFileFromFile(fn, immutableGenCmd)
-		if !ok {
-			fatalf("could not name file from %v", fn)
-		}
-
-		out := bytes.NewBuffer(nil)
-		cmd := exec.Command("gofmt", "-s")
-		cmd.Stdin = o.output
-		cmd.Stdout = out
-
-		err := cmd.Run()
-		if err == nil {
-			toWrite = out.Bytes()
-		} else {
-			infof("failed to format %v: %v", fn, err)
-		}
-
-		if err := ioutil.WriteFile(offn, toWrite, 0644); err != nil {
-			fatalf("could not write %v: %v", offn, err)
-		}
+		body.WriteTo(out)
 	}
 }
 
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"go/ast"
 	"go/types"
 	"text/template"
@@ -16,6 +17,20 @@ type immMap struct {
 	name string
 	syn  *ast.MapType
 	typ  *types.Map
+
+	// persistent indicates the type is backed by a persistent data structure
+	persistent bool
+
+	// container indicates the type is a wrapper around a container.Map
+	container bool
+
+	// ordered indicates the type is backed by a persistent.Tree, and hence
+	// iterates in a deterministic order
+	ordered bool
+
+	// order is the argument to orderedDirective: empty for the natural order
+	// of the key type, insertionOrder, or the name of a less function
+	order string
 }
 
 func (o *output) genImmMaps(maps []*immMap) {
@@ -25,6 +40,13 @@ func (o *output) genImmMaps(maps []*immMap) {
 			VarName string
 			KeyType string
 			ValType string
+
+			// Less is the less function of an ordered map; nil where the map
+			// is ordered by insertion
+			Less string
+
+			// Sorted indicates an ordered map is ordered by key
+			Sorted bool
 		}{
 			Name:    m.name,
 			VarName: genVarName(m.name),
@@ -32,6 +54,19 @@ func (o *output) genImmMaps(maps []*immMap) {
 			ValType: o.exprString(m.syn.Value),
 		}
 
+		if m.ordered {
+			switch m.order {
+			case "":
+				blanks.Less = "func(a, b " + blanks.KeyType + ") bool { return a < b }"
+				blanks.Sorted = true
+			case insertionOrder:
+				blanks.Less = "nil"
+			default:
+				blanks.Less = m.order
+				blanks.Sorted = true
+			}
+		}
+
 		exp := exporter(m.name)
 
 		o.printCommentGroup(m.dec.Doc)
@@ -41,8 +76,25 @@ func (o *output) genImmMaps(maps []*immMap) {
 		o.pfln("type %v struct {", m.name)
 		o.pln("")
 
-		o.pfln("theMap map[%v]%v", blanks.KeyType, blanks.ValType)
-		o.pln("mutable bool")
+		// in container mode the mutability of the map is held by the
+		// container.Map
+		tmplStr := immMapTmpl
+		switch {
+		case m.persistent:
+			o.pfln("theMap *persistent.Map[%v, %v]", blanks.KeyType, blanks.ValType)
+			o.pln("mutable bool")
+			tmplStr = immPersistentMapTmpl
+		case m.ordered:
+			o.pfln("theMap *persistent.Tree[%v, %v]", blanks.KeyType, blanks.ValType)
+			o.pln("mutable bool")
+			tmplStr = immOrderedMapTmpl
+		case m.container:
+			o.pfln("theMap *container.Map[%v, %v]", blanks.KeyType, blanks.ValType)
+			tmplStr = immContainerMapTmpl
+		default:
+			o.pfln("theMap map[%v]%v", blanks.KeyType, blanks.ValType)
+			o.pln("mutable bool")
+		}
 		o.pfln("__tmpl *%v%v", immutable.ImmTypeTmplPrefix, m.name)
 
 		// end of struct
@@ -50,7 +102,7 @@ func (o *output) genImmMaps(maps []*immMap) {
 
 		tmpl := template.New("immmap")
 		tmpl.Funcs(exp)
-		_, err := tmpl.Parse(immMapTmpl)
+		_, err := tmpl.Parse(tmplStr)
 		if err != nil {
 			fatalf("failed to parse immutable map template: %v", err)
 		}
@@ -109,45 +161,87 @@ func (o *output) genImmMaps(maps []*immMap) {
 
 			`, exp, m.name)
 
-			switch {
-			case keyIsImmOk && valIsImmOk:
-				o.pt(`
-				for k, v := range s.theMap {
-				`, exp, m.name)
-			case keyIsImmOk:
-				o.pt(`
-				for k := range s.theMap {
-				`, exp, m.name)
-			case valIsImmOk:
-				o.pt(`
-				for _, v := range s.theMap {
-				`, exp, m.name)
+			// the persistent and ordered maps are iterated using Each
+			each := m.persistent || m.ordered
+
+			if each {
+				kn, vn := "_", "_"
+				if keyIsImmOk {
+					kn = "k"
+				}
+				if valIsImmOk {
+					vn = "v"
+				}
+				o.pfln("deep := true")
+				o.pfln("s.theMap.Each(func(%v %v, %v %v) bool {", kn, blanks.KeyType, vn, blanks.ValType)
+			} else {
+				rng := "s.theMap"
+				if m.container {
+					rng = "s.theMap.Range()"
+				}
+
+				switch {
+				case keyIsImmOk && valIsImmOk:
+					o.pfln("for k, v := range %v {", rng)
+				case keyIsImmOk:
+					o.pfln("for k := range %v {", rng)
+				case valIsImmOk:
+					o.pfln("for _, v := range %v {", rng)
+				}
+			}
+
+			// in the persistent case we are in a func literal and so record
+			// the result in deep
+			ret := "return false"
+			if each {
+				ret = "deep = false"
 			}
 
 			if keyIsImmOk {
-				o.pt(`
-				if k != nil && !k.IsDeeplyNonMutable(seen) {
-					return false
-				}
-				`, exp, m.name)
+				o.pfln("if k != nil && !k.IsDeeplyNonMutable(seen) {")
+				o.pfln("%v", ret)
+				o.pfln("}")
 			}
 
 			if valIsImmOk {
+				o.pfln("if v != nil && !v.IsDeeplyNonMutable(seen) {")
+				o.pfln("%v", ret)
+				o.pfln("}")
+			}
+
+			if each {
 				o.pt(`
-				if v != nil && !v.IsDeeplyNonMutable(seen) {
+					return deep
+				})
+
+				if !deep {
 					return false
 				}
 				`, exp, m.name)
+			} else {
+				o.pt(`
+				}
+				`, exp, m.name)
 			}
-
-			o.pt(`
-			}
-			`, exp, m.name)
 		}
 
 		o.pt(`
 			return true
 		}
 		`, exp, m.name)
+
+		o.genMapEqual(m, blanks.KeyType, blanks.ValType)
+
+		// encoding/json cannot (un)marshal maps with keys of other types,
+		// e.g. pointers, hence there are no JSON methods to generate
+		switch {
+		case !isJSONKey(m.typ.Key()):
+		case m.ordered:
+			o.genOrderedMapJSON(m.name, blanks.KeyType, blanks.ValType)
+		case m.container:
+			o.genContainerJSON(m.name, "theMap", fmt.Sprintf("container.Map[%v, %v]", blanks.KeyType, blanks.ValType), fmt.Sprintf("map[%v]%v", blanks.KeyType, blanks.ValType))
+		default:
+			o.genMapJSON(m.name, blanks.KeyType, blanks.ValType)
+		}
 	}
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"go/ast"
 	"go/types"
 	"text/template"
@@ -16,6 +17,12 @@ type immSlice struct {
 	name string
 	syn  *ast.ArrayType
 	typ  *types.Slice
+
+	// persistent indicates the type is backed by a persistent data structure
+	persistent bool
+
+	// container indicates the type is a wrapper around a container.Slice
+	container bool
 }
 
 func (o *output) genImmSlices(slices []*immSlice) {
@@ -38,8 +45,21 @@ func (o *output) genImmSlices(slices []*immSlice) {
 		o.pfln("type %v struct {", s.name)
 		o.pln("")
 
-		o.pfln("theSlice []%v", blanks.Type)
-		o.pln("mutable bool")
+		// in container mode the mutability of the slice is held by the
+		// container.Slice
+		tmplStr := immSliceTmpl
+		switch {
+		case s.persistent:
+			o.pfln("theSlice *persistent.Vector[%v]", blanks.Type)
+			o.pln("mutable bool")
+			tmplStr = immPersistentSliceTmpl
+		case s.container:
+			o.pfln("theSlice *container.Slice[%v]", blanks.Type)
+			tmplStr = immContainerSliceTmpl
+		default:
+			o.pfln("theSlice []%v", blanks.Type)
+			o.pln("mutable bool")
+		}
 		o.pfln("__tmpl *%v%v", immutable.ImmTypeTmplPrefix, s.name)
 
 		// end of struct
@@ -47,7 +67,7 @@ func (o *output) genImmSlices(slices []*immSlice) {
 
 		tmpl := template.New("immslice")
 		tmpl.Funcs(exp)
-		_, err := tmpl.Parse(immSliceTmpl)
+		_, err := tmpl.Parse(tmplStr)
 		if err != nil {
 			fatalf("failed to parse immutable slice template: %v", err)
 		}
@@ -94,23 +114,48 @@ func (o *output) genImmSlices(slices []*immSlice) {
 
 			seen[s] = true
 
-			for _, v := range s.theSlice {
 			`, exp, s.name)
 
-			o.pt(`
-				if v != nil && !v.IsDeeplyNonMutable(seen) {
+			if s.persistent {
+				o.pt(`
+				deep := true
+				s.theSlice.Each(func(_ int, v {{.}}) bool {
+					if v != nil && !v.IsDeeplyNonMutable(seen) {
+						deep = false
+					}
+					return deep
+				})
+
+				if !deep {
 					return false
 				}
-			`, exp, s.name)
+				`, exp, blanks.Type)
+			} else {
+				rng := "s.theSlice"
+				if s.container {
+					rng = "s.theSlice.Range()"
+				}
 
-			o.pt(`
+				o.pt(`
+				for _, v := range {{.}} {
+					if v != nil && !v.IsDeeplyNonMutable(seen) {
+						return false
+					}
+				}
+				`, exp, rng)
 			}
-			`, exp, s.name)
 		}
 
 		o.pt(`
 			return true
 		}
 		`, exp, s.name)
+
+		o.genSliceEqual(s, blanks.Type)
+		if s.container {
+			o.genContainerJSON(s.name, "theSlice", fmt.Sprintf("container.Slice[%v]", blanks.Type), "[]"+blanks.Type)
+		} else {
+			o.genSliceJSON(s.name, blanks.Type)
+		}
 	}
 }
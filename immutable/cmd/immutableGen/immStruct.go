@@ -42,6 +42,10 @@ type immStruct struct {
 
 	special specialType
 
+	// builder indicates a builder type and functional options constructor
+	// are generated for the type
+	builder bool
+
 	fields  []astField
 	methods map[string]*field
 }
@@ -88,7 +92,7 @@ func (o *output) genImmStructs(structs []*immStruct) {
 
 			tag := ""
 			if f.field.Tag != nil {
-				tag = f.field.Tag.Value
+				tag = withoutJSONTag(f.field.Tag.Value)
 			}
 			typ := o.exprString(f.field.Type)
 
@@ -202,7 +206,7 @@ func (o *output) genImmStructs(structs []*immStruct) {
 				continue
 			}
 			switch f.IsImm.(type) {
-			case util.ImmTypeSlice, util.ImmTypeStruct, util.ImmTypeMap, util.ImmTypeImplsIntf, util.ImmTypeSimple:
+			case util.ImmTypeSlice, util.ImmTypeStruct, util.ImmTypeMap, util.ImmTypeSet, util.ImmTypeImplsIntf, util.ImmTypeSimple:
 
 				tmpl := struct {
 					FieldName string
@@ -330,6 +334,10 @@ func (o *output) genImmStructs(structs []*immStruct) {
 				o.pln("}")
 			}
 		}
+
+		o.genStructEqual(s)
+		o.genStructJSON(s)
+		o.genStructBuilder(s)
 	}
 }
 
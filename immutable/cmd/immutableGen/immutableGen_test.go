@@ -23,12 +23,15 @@ const (
 )
 
 func TestBasic(t *testing.T) {
-	license := "// My favourite license\n\n"
 	echoCmd := `echo "hello world"` // need a command that will succeed with zero exit code
 
 	tmpl := "core.go"
 
-	execute(TestFiles, "coretest", license, gogenCmds{echoCmd})
+	args := []string{"-licenseFile", filepath.Join(TestFiles, "license.txt"), "-G", echoCmd}
+
+	if err := newGenerator().Run(TestFiles, tmpl, "coretest", args); err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
 
 	tmplFile := filepath.Join(TestFiles, tmpl)
 	genFile, ok := gogenerate.NameFileFromFile(tmplFile, immutableGenCmd)
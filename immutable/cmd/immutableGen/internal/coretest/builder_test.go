@@ -0,0 +1,72 @@
+package coretest_test
+
+import (
+	"testing"
+
+	"myitcv.io/immutable/cmd/immutableGen/internal/coretest"
+)
+
+func TestBuilder(t *testing.T) {
+	b := coretest.NewMyRequiredBuilder().SetName(paul).SetAge(age42)
+
+	s1 := b.Build()
+
+	if s1.Mutable() {
+		t.Fatalf("result of Build should be immutable")
+	}
+
+	if s1.Name() != paul || s1.Age() != age42 {
+		t.Fatalf("expected %v and %v; got %v and %v", paul, age42, s1.Name(), s1.Age())
+	}
+
+	s2 := b.SetName(peter).Build()
+
+	if s1.Name() != paul {
+		t.Fatalf("s1 should be unchanged by subsequent use of the builder; got %v", s1.Name())
+	}
+
+	if s2.Name() != peter || s2.Age() != age42 {
+		t.Fatalf("expected %v and %v; got %v and %v", peter, age42, s2.Name(), s2.Age())
+	}
+}
+
+func TestBuilderRequired(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected Build to panic when a required field is not set")
+		}
+	}()
+
+	coretest.NewMyRequiredBuilder().SetAge(age42).Build()
+}
+
+func TestBuilderEmbedded(t *testing.T) {
+	s := coretest.NewEmbed1Builder().SetName(paul).SetEmbed2(new(coretest.Embed2)).SetAge(age42).Build()
+
+	if s.Name() != paul || s.Age() != age42 {
+		t.Fatalf("expected %v and %v; got %v and %v", paul, age42, s.Name(), s.Age())
+	}
+}
+
+func TestOptions(t *testing.T) {
+	s := coretest.NewMyRequired(
+		coretest.MyRequiredWithName(paul),
+		coretest.MyRequiredWithAge(age42),
+	)
+
+	if s.Mutable() {
+		t.Fatalf("result of NewMyRequired should be immutable")
+	}
+
+	if s.Name() != paul || s.Age() != age42 {
+		t.Fatalf("expected %v and %v; got %v and %v", paul, age42, s.Name(), s.Age())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected NewMyRequired to panic when a required field is not set")
+		}
+	}()
+
+	coretest.NewMyRequired(coretest.MyRequiredWithAge(age42))
+}
@@ -0,0 +1,218 @@
+package coretest_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"myitcv.io/immutable"
+	"myitcv.io/immutable/cmd/immutableGen/internal/coretest"
+)
+
+// containerValue is the part of the API of immutable maps and slices in
+// container mode that does not depend on the type
+type containerValue interface {
+	immutable.Immutable
+	Len() int
+	json.Marshaler
+}
+
+func TestContainer(t *testing.T) {
+	a := new(coretest.A).SetName(paul)
+
+	testCases := []struct {
+		name string
+
+		// values returns a zero value, a value derived from it by updates, a
+		// value derived from the latter by mutation and the Diff of the last
+		// two values
+		values func() ([]containerValue, []immutable.Change)
+
+		// rng returns the contents of v
+		rng func(v containerValue) interface{}
+
+		// unmarshal unmarshals b into a zero value
+		unmarshal func(b []byte) (containerValue, error)
+
+		// shallow, if not nil, is a value that references a mutable value
+		shallow containerValue
+
+		ranges []interface{}
+		diff   []immutable.Change
+		json   string
+	}{
+		{
+			name: "map",
+			values: func() ([]containerValue, []immutable.Change) {
+				s0 := new(coretest.MyContainerMap)
+				s1 := s0.Set(peter, age42).Set(paul, 1)
+				s2 := s1.WithMutable(func(m *coretest.MyContainerMap) {
+					m.Set(peter, 1)
+					m.Del(paul)
+				})
+				return []containerValue{s0, s1, s2}, s1.Diff(s2)
+			},
+			rng: func(v containerValue) interface{} {
+				return v.(*coretest.MyContainerMap).Range()
+			},
+			unmarshal: func(b []byte) (containerValue, error) {
+				var v coretest.MyContainerMap
+				return &v, json.Unmarshal(b, &v)
+			},
+			ranges: []interface{}{
+				map[string]int(nil),
+				map[string]int{peter: age42, paul: 1},
+				map[string]int{peter: 1},
+			},
+			diff: []immutable.Change{
+				{Path: `["paul"]`, Kind: immutable.ChangeRemoved, Old: 1},
+				{Path: `["peter"]`, Kind: immutable.ChangeModified, Old: age42, New: 1},
+			},
+			json: `{"peter":1}`,
+		},
+		{
+			name: "slice",
+			values: func() ([]containerValue, []immutable.Change) {
+				s0 := new(coretest.MyContainerSlice)
+				s1 := s0.Append(paul, peter)
+				s2 := s1.WithMutable(func(s *coretest.MyContainerSlice) {
+					s.Set(0, peter)
+					s.Append(paul)
+				})
+				return []containerValue{s0, s1, s2}, s1.Diff(s2)
+			},
+			rng: func(v containerValue) interface{} {
+				return v.(*coretest.MyContainerSlice).Range()
+			},
+			unmarshal: func(b []byte) (containerValue, error) {
+				var v coretest.MyContainerSlice
+				return &v, json.Unmarshal(b, &v)
+			},
+			ranges: []interface{}{
+				[]string(nil),
+				[]string{paul, peter},
+				[]string{peter, peter, paul},
+			},
+			diff: []immutable.Change{
+				{Path: "[0]", Kind: immutable.ChangeModified, Old: paul, New: peter},
+				{Path: "[2]", Kind: immutable.ChangeAdded, New: paul},
+			},
+			json: `["peter","peter","paul"]`,
+		},
+		{
+			name: "slice of immutable values",
+			values: func() ([]containerValue, []immutable.Change) {
+				s0 := new(coretest.ACS)
+				s1 := s0.Append(a)
+				s2 := s1.WithMutable(func(s *coretest.ACS) {
+					s.Set(0, a.SetName(peter))
+					s.Append(nil)
+				})
+				return []containerValue{s0, s1, s2}, s1.Diff(s2)
+			},
+			rng: func(v containerValue) interface{} {
+				var names []string
+				for _, e := range v.(*coretest.ACS).Range() {
+					n := "<nil>"
+					if e != nil {
+						n = e.Name()
+					}
+					names = append(names, n)
+				}
+				return names
+			},
+			unmarshal: func(b []byte) (containerValue, error) {
+				var v coretest.ACS
+				return &v, json.Unmarshal(b, &v)
+			},
+			shallow: coretest.NewACS(a, new(coretest.A).AsMutable()),
+			ranges: []interface{}{
+				[]string(nil),
+				[]string{paul},
+				[]string{peter, "<nil>"},
+			},
+			diff: []immutable.Change{
+				{Path: "[0].Name", Kind: immutable.ChangeModified, Old: paul, New: peter},
+				{Path: "[1]", Kind: immutable.ChangeAdded, New: (*coretest.A)(nil)},
+			},
+			json: `[{"Name":"peter","A":null,"Blah":null},null]`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vs, diff := tc.values()
+
+			for i, v := range vs {
+				if got := tc.rng(v); !reflect.DeepEqual(got, tc.ranges[i]) {
+					t.Fatalf("expected s%v to contain %v; got %v", i, tc.ranges[i], got)
+				}
+				if v.Mutable() || !v.IsDeeplyNonMutable(nil) {
+					t.Fatalf("s%v should be DeeplyNonMutable", i)
+				}
+			}
+
+			if tc.shallow != nil && tc.shallow.IsDeeplyNonMutable(nil) {
+				t.Fatalf("value referencing a mutable value should not be DeeplyNonMutable")
+			}
+
+			if !reflect.DeepEqual(diff, tc.diff) {
+				t.Fatalf("expected Diff to be %v; got %v", tc.diff, diff)
+			}
+
+			b, err := json.Marshal(vs[2])
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+
+			if string(b) != tc.json {
+				t.Fatalf("expected %v; got %s", tc.json, b)
+			}
+
+			v, err := tc.unmarshal(b)
+			if err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+
+			if got := tc.rng(v); !reflect.DeepEqual(got, tc.ranges[2]) || v.Mutable() {
+				t.Fatalf("expected unmarshalled value to be immutable and contain %v; got %v", tc.ranges[2], got)
+			}
+		})
+	}
+}
+
+func TestContainerZeroValue(t *testing.T) {
+	m1 := new(coretest.MyContainerMap)
+
+	if m1.Mutable() || m1.Len() != 0 {
+		t.Fatalf("zero value should be immutable and empty")
+	}
+
+	m2 := m1.Set(peter, age42)
+
+	if m2 == m1 || m1.Len() != 0 || m2.Len() != 1 {
+		t.Fatalf("Set on the zero value should return a new map")
+	}
+
+	m3 := m2.AsMutable()
+
+	if m3 == m2 || !m3.Mutable() || m3.AsMutable() != m3 || m3.Set(paul, 1) != m3 {
+		t.Fatalf("unexpected AsMutable behaviour")
+	}
+
+	m3.WithImmutable(func(m *coretest.MyContainerMap) {
+		if m.Mutable() {
+			t.Fatalf("map should be immutable within WithImmutable")
+		}
+	})
+
+	if !m3.Mutable() || m3.AsImmutable(nil) != m3 || m3.Mutable() {
+		t.Fatalf("unexpected AsImmutable behaviour")
+	}
+
+	s1 := new(coretest.MyContainerSlice).Append(paul)
+
+	if !s1.Equal(coretest.NewMyContainerSlice(paul)) || s1.Equal(s1.Append(peter)) {
+		t.Fatalf("unexpected result of Equal")
+	}
+}
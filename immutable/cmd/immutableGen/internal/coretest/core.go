@@ -15,6 +15,60 @@ type _Imm_MyMap map[string]int
 // a comment about Slice
 type _Imm_MySlice []string
 
+// a comment about MyPersistentMap
+//
+//immutableGen:persistent
+type _Imm_MyPersistentMap map[string]int
+
+// a comment about MyPersistentSlice
+//
+//immutableGen:persistent
+type _Imm_MyPersistentSlice []string
+
+// a comment about MySet
+type _Imm_MySet map[string]immutable.Set
+
+type _Imm_KeySet map[MyStructKey]immutable.Set
+
+type _Imm_ASet map[*A]immutable.Set
+
+type _Imm_Tagged struct {
+	Name string
+	Tags *MySet
+}
+
+// a comment about MyStructMap
+type _Imm_MyStructMap map[string]*MyStruct
+
+// a comment about MyContainerMap
+//
+//immutableGen:container
+type _Imm_MyContainerMap map[string]int
+
+// a comment about MyContainerSlice
+//
+//immutableGen:container
+type _Imm_MyContainerSlice []string
+
+// a comment about MyOrderedMap
+//
+//immutableGen:ordered
+type _Imm_MyOrderedMap map[string]int
+
+//immutableGen:ordered insertion
+type _Imm_MyInsertionMap map[string]int
+
+//immutableGen:ordered byLength
+type _Imm_MyLengthMap map[string]int
+
+// byLength orders strings by their length, and then lexically
+func byLength(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
 type MyStructUuid uint64
 
 type MyStructKey struct {
@@ -41,6 +95,18 @@ type _Imm_MyStruct struct {
 	fieldWithoutTag bool
 }
 
+type _Imm_MyJSONStruct struct {
+	Name    string `json:"name"`
+	Age     int    `json:"age,omitempty"`
+	Ignored string `json:"-"`
+	hidden  string
+
+	Slice *MySlice           `json:"slice"`
+	Map   *MyPersistentMap   `json:"map"`
+	Other *MyPersistentSlice `json:"other,omitempty"`
+	*Embed2
+}
+
 type MySpecialStructKey struct {
 	Uuid        MyStructUuid
 	Version     uint64
@@ -52,6 +118,12 @@ func (m *MySpecialStructKey) BumpVersion() {
 	m.Version += 5
 }
 
+//immutableGen:builder
+type _Imm_MyRequired struct {
+	Name string `immutableGen:"required"`
+	Age  int
+}
+
 type _Imm_MySpecialStruct struct {
 	Key MySpecialStructKey
 
@@ -69,6 +141,21 @@ type _Imm_AS []*A
 
 type _Imm_AM map[*A]*A
 
+//immutableGen:persistent
+type _Imm_APS []*A
+
+//immutableGen:persistent
+type _Imm_APM map[*A]*A
+
+//immutableGen:container
+type _Imm_ACS []*A
+
+//immutableGen:container
+type _Imm_ACM map[*A]*A
+
+//immutableGen:ordered insertion
+type _Imm_AOM map[*A]*A
+
 type Blah interface {
 	immutable.Immutable
 }
@@ -101,12 +188,21 @@ func (b BlahNonMutable) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	return true
 }
 
+//immutableGen:builder
 type _Imm_Clash1 struct {
 	Clash    string
 	NoClash1 string
 }
 
+// NewClash1 clashes with the functional options constructor that would
+// otherwise be generated for Clash1
+func NewClash1(c string) *Clash1 {
+	return new(Clash1).SetClash(c)
+}
+
 // types for testing embedding
+//
+//immutableGen:builder
 type _Imm_Embed1 struct {
 	Name string
 	*Embed2
@@ -0,0 +1,139 @@
+package coretest_test
+
+import (
+	"reflect"
+	"testing"
+
+	"myitcv.io/immutable"
+	"myitcv.io/immutable/cmd/immutableGen/internal/coretest"
+)
+
+func newJSONStruct(slice ...string) *coretest.MyJSONStruct {
+	return new(coretest.MyJSONStruct).WithMutable(func(s *coretest.MyJSONStruct) {
+		s.SetName(peter)
+		s.SetSlice(coretest.NewMySlice(slice...))
+		s.SetMap(coretest.NewMyPersistentMap().Set(peter, age42))
+	})
+}
+
+func TestEqualStruct(t *testing.T) {
+	s1 := newJSONStruct(paul)
+	s2 := newJSONStruct(paul)
+
+	if !s1.Equal(s1) {
+		t.Fatalf("expected s1 to equal itself")
+	}
+
+	if !s1.Equal(s2) || !s2.Equal(s1) {
+		t.Fatalf("expected s1 and s2 to be equal")
+	}
+
+	if s1.Equal(nil) || (*coretest.MyJSONStruct)(nil).Equal(s1) {
+		t.Fatalf("expected non-nil value not to equal nil")
+	}
+
+	if !(*coretest.MyJSONStruct)(nil).Equal(nil) {
+		t.Fatalf("expected nil to equal nil")
+	}
+
+	if d := s1.Diff(s2); d != nil {
+		t.Fatalf("expected no diff; got %v", d)
+	}
+
+	s3 := s2.SetName(paul).SetEmbed2(new(coretest.Embed2).SetAge(age42))
+
+	if s1.Equal(s3) {
+		t.Fatalf("expected s1 and s3 not to be equal")
+	}
+
+	exp := []immutable.Change{
+		{Path: ".Name", Kind: immutable.ChangeModified, Old: peter, New: paul},
+		{Path: ".Embed2", Kind: immutable.ChangeModified, Old: (*coretest.Embed2)(nil), New: s3.Embed2()},
+	}
+	if got := s1.Diff(s3); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %v; got %v", exp, got)
+	}
+}
+
+func TestDiffNested(t *testing.T) {
+	s1 := newJSONStruct(paul, peter)
+	s2 := s1.SetSlice(s1.Slice().Set(1, paul).Append(peter)).SetMap(s1.Map().Set(paul, age42))
+
+	exp := []immutable.Change{
+		{Path: ".Slice[1]", Kind: immutable.ChangeModified, Old: peter, New: paul},
+		{Path: ".Slice[2]", Kind: immutable.ChangeAdded, New: peter},
+		{Path: `.Map["paul"]`, Kind: immutable.ChangeAdded, New: age42},
+	}
+	if got := s1.Diff(s2); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %v; got %v", exp, got)
+	}
+}
+
+func TestDiffMap(t *testing.T) {
+	m1 := coretest.NewMyMap().Set(paul, 1).Set(peter, age42)
+	m2 := m1.Del(paul).Set(peter, 43).Set("bill", 5)
+
+	if m1.Equal(m2) {
+		t.Fatalf("expected m1 and m2 not to be equal")
+	}
+
+	if !m1.Equal(coretest.NewMyMap().Set(peter, age42).Set(paul, 1)) {
+		t.Fatalf("expected maps with the same entries to be equal")
+	}
+
+	exp := []immutable.Change{
+		{Path: `["bill"]`, Kind: immutable.ChangeAdded, New: 5},
+		{Path: `["paul"]`, Kind: immutable.ChangeRemoved, Old: 1},
+		{Path: `["peter"]`, Kind: immutable.ChangeModified, Old: age42, New: 43},
+	}
+	if got := m1.Diff(m2); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %v; got %v", exp, got)
+	}
+
+	sm1 := coretest.NewMyStructMap().Set(peter, new(coretest.MyStruct).SetName(peter))
+	sm2 := sm1.Set(peter, new(coretest.MyStruct).SetName(paul))
+
+	exp = []immutable.Change{
+		{Path: `["peter"].Name`, Kind: immutable.ChangeModified, Old: peter, New: paul},
+	}
+	if got := sm1.Diff(sm2); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %v; got %v", exp, got)
+	}
+}
+
+func TestDiffPersistent(t *testing.T) {
+	a1 := new(coretest.A).SetName(peter)
+	a2 := new(coretest.A).SetName(paul)
+
+	s1 := coretest.NewAPS(a1, a2)
+	s2 := coretest.NewAPS(a1, new(coretest.A).SetName(paul))
+
+	if !s1.Equal(s2) {
+		t.Fatalf("expected s1 and s2 to be equal")
+	}
+
+	s3 := s1.Set(1, a1).Append(a2)
+
+	exp := []immutable.Change{
+		{Path: "[1].Name", Kind: immutable.ChangeModified, Old: paul, New: peter},
+		{Path: "[2]", Kind: immutable.ChangeAdded, New: a2},
+	}
+	if got := s1.Diff(s3); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %v; got %v", exp, got)
+	}
+
+	m1 := coretest.NewMyPersistentMap().Set(peter, age42).Set(paul, 1)
+	m2 := m1.Del(paul).Set(peter, 43)
+
+	if m1.Equal(m2) {
+		t.Fatalf("expected m1 and m2 not to be equal")
+	}
+
+	exp = []immutable.Change{
+		{Path: `["paul"]`, Kind: immutable.ChangeRemoved, Old: 1},
+		{Path: `["peter"]`, Kind: immutable.ChangeModified, Old: age42, New: 43},
+	}
+	if got := m1.Diff(m2); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %v; got %v", exp, got)
+	}
+}
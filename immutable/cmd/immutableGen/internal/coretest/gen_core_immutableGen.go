@@ -8,7 +8,13 @@ package coretest
 //immutableVet:skipFile
 
 import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
 	"myitcv.io/immutable"
+	"myitcv.io/immutable/container"
+	"myitcv.io/immutable/persistent"
 
 	"myitcv.io/immutable/cmd/immutableGen/internal/coretest/pkga"
 	"myitcv.io/immutable/cmd/immutableGen/internal/coretest/pkgb"
@@ -171,57 +177,150 @@ func (s *MyMap) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	return true
 }
 
+// Equal returns whether m and other contain the same keys with equal values
+func (m *MyMap) Equal(other *MyMap) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	if len(m.theMap) != len(other.theMap) {
+		return false
+	}
+
+	for k, a := range m.theMap {
+		b, ok := other.theMap[k]
+		if !ok || !(a == b) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the key of the entry that was added, removed or
+// modified, or the key followed by the path of a change within a
+// modified value.
+func (m *MyMap) Diff(other *MyMap) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a int, aok bool, b int, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	for k, a := range m.theMap {
+		b, bok := other.theMap[k]
+		if bok && (a == b) {
+			continue
+		}
+		change(immutable.KeyPath(k), a, true, b, bok)
+	}
+
+	for k, b := range other.theMap {
+		if _, ok := m.theMap[k]; !ok {
+			var a int
+			change(immutable.KeyPath(k), a, false, b, true)
+		}
+	}
+
+	return immutable.SortChanges(res)
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a map[string]int
+func (m *MyMap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a
+// map[string]int into m. m is left immutable.
+func (m *MyMap) UnmarshalJSON(b []byte) error {
+	var v map[string]int
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *NewMyMapCap(len(v)).WithMutable(func(mi *MyMap) {
+		for k, e := range v {
+			mi.Set(k, e)
+		}
+	})
+
+	return nil
+}
+
+// a comment about MyPersistentMap
 //
-// AM is an immutable type and has the following template:
+//immutableGen:persistent
 //
-// 	map[*A]*A
+// MyPersistentMap is an immutable type and has the following template:
 //
-type AM struct {
-	theMap  map[*A]*A
+// 	map[string]int
+//
+type MyPersistentMap struct {
+	theMap  *persistent.Map[string, int]
 	mutable bool
-	__tmpl  *_Imm_AM
+	__tmpl  *_Imm_MyPersistentMap
 }
 
-var _ immutable.Immutable = new(AM)
-var _ = new(AM).__tmpl
+var _ immutable.Immutable = new(MyPersistentMap)
+var _ = new(MyPersistentMap).__tmpl
 
-func NewAM(inits ...func(m *AM)) *AM {
-	res := NewAMCap(0)
+func NewMyPersistentMap(inits ...func(m *MyPersistentMap)) *MyPersistentMap {
+	res := NewMyPersistentMapCap(0)
 	if len(inits) == 0 {
 		return res
 	}
 
-	return res.WithMutable(func(m *AM) {
+	return res.WithMutable(func(m *MyPersistentMap) {
 		for _, i := range inits {
 			i(m)
 		}
 	})
 }
 
-func NewAMCap(l int) *AM {
-	return &AM{
-		theMap: make(map[*A]*A, l),
+func NewMyPersistentMapCap(l int) *MyPersistentMap {
+	return &MyPersistentMap{
+		theMap: persistent.NewMap[string, int](),
 	}
 }
 
-func (m *AM) Mutable() bool {
+func (m *MyPersistentMap) Mutable() bool {
 	return m.mutable
 }
 
-func (m *AM) Len() int {
+func (m *MyPersistentMap) Len() int {
 	if m == nil {
 		return 0
 	}
 
-	return len(m.theMap)
+	return m.theMap.Len()
 }
 
-func (m *AM) Get(k *A) (*A, bool) {
-	v, ok := m.theMap[k]
-	return v, ok
+func (m *MyPersistentMap) Get(k string) (int, bool) {
+	return m.theMap.Get(k)
 }
 
-func (m *AM) AsMutable() *AM {
+func (m *MyPersistentMap) AsMutable() *MyPersistentMap {
 	if m == nil {
 		return nil
 	}
@@ -236,21 +335,15 @@ func (m *AM) AsMutable() *AM {
 	return res
 }
 
-func (m *AM) dup() *AM {
-	resMap := make(map[*A]*A, len(m.theMap))
-
-	for k := range m.theMap {
-		resMap[k] = m.theMap[k]
-	}
-
-	res := &AM{
-		theMap: resMap,
+func (m *MyPersistentMap) dup() *MyPersistentMap {
+	res := &MyPersistentMap{
+		theMap: m.theMap,
 	}
 
 	return res
 }
 
-func (m *AM) AsImmutable(v *AM) *AM {
+func (m *MyPersistentMap) AsImmutable(v *MyPersistentMap) *MyPersistentMap {
 	if m == nil {
 		return nil
 	}
@@ -263,15 +356,15 @@ func (m *AM) AsImmutable(v *AM) *AM {
 	return m
 }
 
-func (m *AM) Range() map[*A]*A {
+func (m *MyPersistentMap) Range() map[string]int {
 	if m == nil {
 		return nil
 	}
 
-	return m.theMap
+	return m.theMap.ToMap()
 }
 
-func (mr *AM) WithMutable(f func(a *AM)) *AM {
+func (mr *MyPersistentMap) WithMutable(f func(m *MyPersistentMap)) *MyPersistentMap {
 	res := mr.AsMutable()
 	f(res)
 	res = res.AsImmutable(mr)
@@ -279,7 +372,7 @@ func (mr *AM) WithMutable(f func(a *AM)) *AM {
 	return res
 }
 
-func (mr *AM) WithImmutable(f func(a *AM)) *AM {
+func (mr *MyPersistentMap) WithImmutable(f func(m *MyPersistentMap)) *MyPersistentMap {
 	prev := mr.mutable
 	mr.mutable = false
 	f(mr)
@@ -288,34 +381,34 @@ func (mr *AM) WithImmutable(f func(a *AM)) *AM {
 	return mr
 }
 
-func (m *AM) Set(k *A, v *A) *AM {
+func (m *MyPersistentMap) Set(k string, v int) *MyPersistentMap {
 	if m.mutable {
-		m.theMap[k] = v
+		m.theMap = m.theMap.Set(k, v)
 		return m
 	}
 
 	res := m.dup()
-	res.theMap[k] = v
+	res.theMap = res.theMap.Set(k, v)
 
 	return res
 }
 
-func (m *AM) Del(k *A) *AM {
-	if _, ok := m.theMap[k]; !ok {
+func (m *MyPersistentMap) Del(k string) *MyPersistentMap {
+	if _, ok := m.theMap.Get(k); !ok {
 		return m
 	}
 
 	if m.mutable {
-		delete(m.theMap, k)
+		m.theMap = m.theMap.Del(k)
 		return m
 	}
 
 	res := m.dup()
-	delete(res.theMap, k)
+	res.theMap = res.theMap.Del(k)
 
 	return res
 }
-func (s *AM) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+func (s *MyPersistentMap) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	if s == nil {
 		return true
 	}
@@ -323,80 +416,135 @@ func (s *AM) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	if s.Mutable() {
 		return false
 	}
-	if s.Len() == 0 {
+	return true
+}
+
+// Equal returns whether m and other contain the same keys with equal values
+func (m *MyPersistentMap) Equal(other *MyPersistentMap) bool {
+	if m == other {
 		return true
 	}
 
-	if seen == nil {
-		return s.IsDeeplyNonMutable(make(map[interface{}]bool))
+	if m == nil || other == nil {
+		return false
 	}
 
-	if seen[s] {
-		return true
+	return m.theMap.Equal(other.theMap, func(a, b int) bool {
+		return a == b
+	})
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the key of the entry that was added, removed or
+// modified, or the key followed by the path of a change within a
+// modified value.
+func (m *MyPersistentMap) Diff(other *MyPersistentMap) []immutable.Change {
+	if m == other {
+		return nil
 	}
 
-	seen[s] = true
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
 
-	for k, v := range s.theMap {
-		if k != nil && !k.IsDeeplyNonMutable(seen) {
-			return false
-		}
-		if v != nil && !v.IsDeeplyNonMutable(seen) {
-			return false
+	var res []immutable.Change
+
+	change := func(p string, a int, aok bool, b int, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
 		}
 	}
-	return true
+
+	m.theMap.Diff(other.theMap, func(a, b int) bool {
+		return a == b
+	}, func(k string, a int, aok bool, b int, bok bool) bool {
+		change(immutable.KeyPath(k), a, aok, b, bok)
+		return true
+	})
+
+	return immutable.SortChanges(res)
 }
 
-// a comment about Slice
+// MarshalJSON implements json.Marshaler, marshalling m as a map[string]int
+func (m *MyPersistentMap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a
+// map[string]int into m. m is left immutable.
+func (m *MyPersistentMap) UnmarshalJSON(b []byte) error {
+	var v map[string]int
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *NewMyPersistentMapCap(len(v)).WithMutable(func(mi *MyPersistentMap) {
+		for k, e := range v {
+			mi.Set(k, e)
+		}
+	})
+
+	return nil
+}
+
+// a comment about MyStructMap
 //
-// MySlice is an immutable type and has the following template:
+// MyStructMap is an immutable type and has the following template:
 //
-// 	[]string
+// 	map[string]*MyStruct
 //
-type MySlice struct {
-	theSlice []string
-	mutable  bool
-	__tmpl   *_Imm_MySlice
+type MyStructMap struct {
+	theMap  map[string]*MyStruct
+	mutable bool
+	__tmpl  *_Imm_MyStructMap
 }
 
-var _ immutable.Immutable = new(MySlice)
-var _ = new(MySlice).__tmpl
-
-func NewMySlice(s ...string) *MySlice {
-	c := make([]string, len(s))
-	copy(c, s)
+var _ immutable.Immutable = new(MyStructMap)
+var _ = new(MyStructMap).__tmpl
 
-	return &MySlice{
-		theSlice: c,
+func NewMyStructMap(inits ...func(m *MyStructMap)) *MyStructMap {
+	res := NewMyStructMapCap(0)
+	if len(inits) == 0 {
+		return res
 	}
-}
 
-func NewMySliceLen(l int) *MySlice {
-	c := make([]string, l)
+	return res.WithMutable(func(m *MyStructMap) {
+		for _, i := range inits {
+			i(m)
+		}
+	})
+}
 
-	return &MySlice{
-		theSlice: c,
+func NewMyStructMapCap(l int) *MyStructMap {
+	return &MyStructMap{
+		theMap: make(map[string]*MyStruct, l),
 	}
 }
 
-func (m *MySlice) Mutable() bool {
+func (m *MyStructMap) Mutable() bool {
 	return m.mutable
 }
 
-func (m *MySlice) Len() int {
+func (m *MyStructMap) Len() int {
 	if m == nil {
 		return 0
 	}
 
-	return len(m.theSlice)
+	return len(m.theMap)
 }
 
-func (m *MySlice) Get(i int) string {
-	return m.theSlice[i]
+func (m *MyStructMap) Get(k string) (*MyStruct, bool) {
+	v, ok := m.theMap[k]
+	return v, ok
 }
 
-func (m *MySlice) AsMutable() *MySlice {
+func (m *MyStructMap) AsMutable() *MyStructMap {
 	if m == nil {
 		return nil
 	}
@@ -411,21 +559,21 @@ func (m *MySlice) AsMutable() *MySlice {
 	return res
 }
 
-func (m *MySlice) dup() *MySlice {
-	resSlice := make([]string, len(m.theSlice))
+func (m *MyStructMap) dup() *MyStructMap {
+	resMap := make(map[string]*MyStruct, len(m.theMap))
 
-	for i := range m.theSlice {
-		resSlice[i] = m.theSlice[i]
+	for k := range m.theMap {
+		resMap[k] = m.theMap[k]
 	}
 
-	res := &MySlice{
-		theSlice: resSlice,
+	res := &MyStructMap{
+		theMap: resMap,
 	}
 
 	return res
 }
 
-func (m *MySlice) AsImmutable(v *MySlice) *MySlice {
+func (m *MyStructMap) AsImmutable(v *MyStructMap) *MyStructMap {
 	if m == nil {
 		return nil
 	}
@@ -438,55 +586,59 @@ func (m *MySlice) AsImmutable(v *MySlice) *MySlice {
 	return m
 }
 
-func (m *MySlice) Range() []string {
+func (m *MyStructMap) Range() map[string]*MyStruct {
 	if m == nil {
 		return nil
 	}
 
-	return m.theSlice
+	return m.theMap
 }
 
-func (m *MySlice) WithMutable(f func(mi *MySlice)) *MySlice {
-	res := m.AsMutable()
+func (mr *MyStructMap) WithMutable(f func(m *MyStructMap)) *MyStructMap {
+	res := mr.AsMutable()
 	f(res)
-	res = res.AsImmutable(m)
+	res = res.AsImmutable(mr)
 
 	return res
 }
 
-func (m *MySlice) WithImmutable(f func(mi *MySlice)) *MySlice {
-	prev := m.mutable
-	m.mutable = false
-	f(m)
-	m.mutable = prev
+func (mr *MyStructMap) WithImmutable(f func(m *MyStructMap)) *MyStructMap {
+	prev := mr.mutable
+	mr.mutable = false
+	f(mr)
+	mr.mutable = prev
 
-	return m
+	return mr
 }
 
-func (m *MySlice) Set(i int, v string) *MySlice {
+func (m *MyStructMap) Set(k string, v *MyStruct) *MyStructMap {
 	if m.mutable {
-		m.theSlice[i] = v
+		m.theMap[k] = v
 		return m
 	}
 
 	res := m.dup()
-	res.theSlice[i] = v
+	res.theMap[k] = v
 
 	return res
 }
 
-func (m *MySlice) Append(v ...string) *MySlice {
+func (m *MyStructMap) Del(k string) *MyStructMap {
+	if _, ok := m.theMap[k]; !ok {
+		return m
+	}
+
 	if m.mutable {
-		m.theSlice = append(m.theSlice, v...)
+		delete(m.theMap, k)
 		return m
 	}
 
 	res := m.dup()
-	res.theSlice = append(res.theSlice, v...)
+	delete(res.theMap, k)
 
 	return res
 }
-func (s *MySlice) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+func (s *MyStructMap) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	if s == nil {
 		return true
 	}
@@ -494,218 +646,5401 @@ func (s *MySlice) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	if s.Mutable() {
 		return false
 	}
-	return true
-}
+	if s.Len() == 0 {
+		return true
+	}
 
-//
-// AS is an immutable type and has the following template:
-//
-// 	[]*A
-//
-type AS struct {
-	theSlice []*A
-	mutable  bool
-	__tmpl   *_Imm_AS
-}
+	if seen == nil {
+		return s.IsDeeplyNonMutable(make(map[interface{}]bool))
+	}
 
-var _ immutable.Immutable = new(AS)
-var _ = new(AS).__tmpl
+	if seen[s] {
+		return true
+	}
 
-func NewAS(s ...*A) *AS {
-	c := make([]*A, len(s))
-	copy(c, s)
+	seen[s] = true
 
-	return &AS{
-		theSlice: c,
+	for _, v := range s.theMap {
+		if v != nil && !v.IsDeeplyNonMutable(seen) {
+			return false
+		}
 	}
+	return true
 }
 
-func NewASLen(l int) *AS {
-	c := make([]*A, l)
-
-	return &AS{
-		theSlice: c,
+// Equal returns whether m and other contain the same keys with equal values
+func (m *MyStructMap) Equal(other *MyStructMap) bool {
+	if m == other {
+		return true
 	}
-}
 
-func (m *AS) Mutable() bool {
-	return m.mutable
-}
+	if m == nil || other == nil {
+		return false
+	}
 
-func (m *AS) Len() int {
-	if m == nil {
-		return 0
+	if len(m.theMap) != len(other.theMap) {
+		return false
 	}
 
-	return len(m.theSlice)
+	for k, a := range m.theMap {
+		b, ok := other.theMap[k]
+		if !ok || !(a.Equal(b)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the key of the entry that was added, removed or
+// modified, or the key followed by the path of a change within a
+// modified value.
+func (m *MyStructMap) Diff(other *MyStructMap) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a *MyStruct, aok bool, b *MyStruct, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		case a != nil && b != nil:
+			res = append(res, immutable.PrefixChanges(p, a.Diff(b))...)
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	for k, a := range m.theMap {
+		b, bok := other.theMap[k]
+		if bok && (a.Equal(b)) {
+			continue
+		}
+		change(immutable.KeyPath(k), a, true, b, bok)
+	}
+
+	for k, b := range other.theMap {
+		if _, ok := m.theMap[k]; !ok {
+			var a *MyStruct
+			change(immutable.KeyPath(k), a, false, b, true)
+		}
+	}
+
+	return immutable.SortChanges(res)
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a map[string]*MyStruct
+func (m *MyStructMap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a
+// map[string]*MyStruct into m. m is left immutable.
+func (m *MyStructMap) UnmarshalJSON(b []byte) error {
+	var v map[string]*MyStruct
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *NewMyStructMapCap(len(v)).WithMutable(func(mi *MyStructMap) {
+		for k, e := range v {
+			mi.Set(k, e)
+		}
+	})
+
+	return nil
+}
+
+// a comment about MyContainerMap
+//
+//immutableGen:container
+//
+// MyContainerMap is an immutable type and has the following template:
+//
+// 	map[string]int
+//
+type MyContainerMap struct {
+	theMap *container.Map[string, int]
+	__tmpl *_Imm_MyContainerMap
+}
+
+var _ immutable.Immutable = new(MyContainerMap)
+var _ = new(MyContainerMap).__tmpl
+
+func NewMyContainerMap(inits ...func(m *MyContainerMap)) *MyContainerMap {
+	res := NewMyContainerMapCap(0)
+	if len(inits) == 0 {
+		return res
+	}
+
+	return res.WithMutable(func(m *MyContainerMap) {
+		for _, i := range inits {
+			i(m)
+		}
+	})
+}
+
+func NewMyContainerMapCap(l int) *MyContainerMap {
+	return &MyContainerMap{
+		theMap: container.NewMap[string, int](l),
+	}
+}
+
+// wrap returns m if c is the container of m, else a new MyContainerMap around c
+func (m *MyContainerMap) wrap(c *container.Map[string, int]) *MyContainerMap {
+	if c == m.theMap {
+		return m
+	}
+
+	return &MyContainerMap{
+		theMap: c,
+	}
+}
+
+func (m *MyContainerMap) Mutable() bool {
+	return m.theMap.Mutable()
+}
+
+func (m *MyContainerMap) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theMap.Len()
+}
+
+func (m *MyContainerMap) Get(k string) (int, bool) {
+	return m.theMap.Get(k)
+}
+
+func (m *MyContainerMap) AsMutable() *MyContainerMap {
+	if m == nil {
+		return nil
+	}
+
+	return m.wrap(m.theMap.AsMutable())
+}
+
+func (m *MyContainerMap) AsImmutable(v *MyContainerMap) *MyContainerMap {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.theMap.AsImmutable()
+	return m
+}
+
+func (m *MyContainerMap) Range() map[string]int {
+	if m == nil {
+		return nil
+	}
+
+	return m.theMap.Range()
+}
+
+func (mr *MyContainerMap) WithMutable(f func(m *MyContainerMap)) *MyContainerMap {
+	res := mr.AsMutable()
+	f(res)
+	res = res.AsImmutable(mr)
+
+	return res
+}
+
+func (mr *MyContainerMap) WithImmutable(f func(m *MyContainerMap)) *MyContainerMap {
+	mr.theMap.WithImmutable(func() {
+		f(mr)
+	})
+
+	return mr
+}
+
+func (m *MyContainerMap) Set(k string, v int) *MyContainerMap {
+	return m.wrap(m.theMap.Set(k, v))
+}
+
+func (m *MyContainerMap) Del(k string) *MyContainerMap {
+	return m.wrap(m.theMap.Del(k))
+}
+func (s *MyContainerMap) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	return true
+}
+
+// Equal returns whether m and other contain the same keys with equal values
+func (m *MyContainerMap) Equal(other *MyContainerMap) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	return m.theMap.Equal(other.theMap, func(a, b int) bool {
+		return a == b
+	})
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the key of the entry that was added, removed or
+// modified, or the key followed by the path of a change within a
+// modified value.
+func (m *MyContainerMap) Diff(other *MyContainerMap) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	return m.theMap.Changes(other.theMap, func(a, b int) bool {
+		return a == b
+	}, nil)
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a map[string]int
+func (m *MyContainerMap) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+
+	return m.theMap.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a map[string]int
+// into m. m is left immutable.
+func (m *MyContainerMap) UnmarshalJSON(b []byte) error {
+	c := new(container.Map[string, int])
+	if err := c.UnmarshalJSON(b); err != nil {
+		return err
+	}
+
+	*m = MyContainerMap{theMap: c}
+
+	return nil
+}
+
+// a comment about MyOrderedMap
+//
+//immutableGen:ordered
+//
+// MyOrderedMap is an immutable type and has the following template:
+//
+// 	map[string]int
+//
+type MyOrderedMap struct {
+	theMap  *persistent.Tree[string, int]
+	mutable bool
+	__tmpl  *_Imm_MyOrderedMap
+}
+
+var _ immutable.Immutable = new(MyOrderedMap)
+var _ = new(MyOrderedMap).__tmpl
+
+func NewMyOrderedMap(inits ...func(m *MyOrderedMap)) *MyOrderedMap {
+	res := NewMyOrderedMapCap(0)
+	if len(inits) == 0 {
+		return res
+	}
+
+	return res.WithMutable(func(m *MyOrderedMap) {
+		for _, i := range inits {
+			i(m)
+		}
+	})
+}
+
+func NewMyOrderedMapCap(l int) *MyOrderedMap {
+	return &MyOrderedMap{
+		theMap: persistent.NewTree[string, int](func(a, b string) bool { return a < b }),
+	}
+}
+
+// tree returns the persistent.Tree that backs m, or an empty tree if m is nil
+// or the zero value
+func (m *MyOrderedMap) tree() *persistent.Tree[string, int] {
+	if m == nil || m.theMap == nil {
+		return persistent.NewTree[string, int](func(a, b string) bool { return a < b })
+	}
+
+	return m.theMap
+}
+
+func (m *MyOrderedMap) Mutable() bool {
+	return m.mutable
+}
+
+func (m *MyOrderedMap) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theMap.Len()
+}
+
+func (m *MyOrderedMap) Get(k string) (int, bool) {
+	return m.theMap.Get(k)
+}
+
+func (m *MyOrderedMap) AsMutable() *MyOrderedMap {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *MyOrderedMap) dup() *MyOrderedMap {
+	res := &MyOrderedMap{
+		theMap: m.theMap,
+	}
+
+	return res
+}
+
+func (m *MyOrderedMap) AsImmutable(v *MyOrderedMap) *MyOrderedMap {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+// Range returns the entries of m, in order
+func (m *MyOrderedMap) Range() []persistent.Entry[string, int] {
+	if m == nil {
+		return nil
+	}
+
+	return m.theMap.Entries()
+}
+
+// Each calls f for each entry of m, in order, until f returns false
+func (m *MyOrderedMap) Each(f func(k string, v int) bool) {
+	if m == nil {
+		return
+	}
+
+	m.theMap.Each(f)
+}
+
+// Keys returns the keys of m, in order
+func (m *MyOrderedMap) Keys() []string {
+	res := make([]string, 0, m.Len())
+	m.Each(func(k string, _ int) bool {
+		res = append(res, k)
+		return true
+	})
+
+	return res
+}
+
+// Min returns the first entry of m and true, or zero values and false if m is
+// empty
+func (m *MyOrderedMap) Min() (string, int, bool) {
+	return m.tree().Min()
+}
+
+// Max returns the last entry of m and true, or zero values and false if m is
+// empty
+func (m *MyOrderedMap) Max() (string, int, bool) {
+	return m.tree().Max()
+}
+
+// Floor returns the entry of m with the greatest key less than or equal to k
+// and true, or zero values and false if there is no such entry
+func (m *MyOrderedMap) Floor(k string) (string, int, bool) {
+	return m.tree().Floor(k)
+}
+
+// Ceil returns the entry of m with the least key greater than or equal to k
+// and true, or zero values and false if there is no such entry
+func (m *MyOrderedMap) Ceil(k string) (string, int, bool) {
+	return m.tree().Ceil(k)
+}
+
+// Between returns the entries of m with keys greater than or equal to lo, and
+// less than hi, in order
+func (m *MyOrderedMap) Between(lo, hi string) []persistent.Entry[string, int] {
+	var res []persistent.Entry[string, int]
+	m.tree().EachBetween(lo, hi, func(k string, v int) bool {
+		res = append(res, persistent.Entry[string, int]{Key: k, Value: v})
+		return true
+	})
+
+	return res
+}
+
+func (mr *MyOrderedMap) WithMutable(f func(m *MyOrderedMap)) *MyOrderedMap {
+	res := mr.AsMutable()
+	f(res)
+	res = res.AsImmutable(mr)
+
+	return res
+}
+
+func (mr *MyOrderedMap) WithImmutable(f func(m *MyOrderedMap)) *MyOrderedMap {
+	prev := mr.mutable
+	mr.mutable = false
+	f(mr)
+	mr.mutable = prev
+
+	return mr
+}
+
+func (m *MyOrderedMap) Set(k string, v int) *MyOrderedMap {
+	if m.mutable {
+		m.theMap = m.tree().Set(k, v)
+		return m
+	}
+
+	res := m.dup()
+	res.theMap = m.tree().Set(k, v)
+
+	return res
+}
+
+func (m *MyOrderedMap) Del(k string) *MyOrderedMap {
+	if _, ok := m.theMap.Get(k); !ok {
+		return m
+	}
+
+	if m.mutable {
+		m.theMap = m.theMap.Del(k)
+		return m
+	}
+
+	res := m.dup()
+	res.theMap = res.theMap.Del(k)
+
+	return res
+}
+func (s *MyOrderedMap) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	return true
+}
+
+// Equal returns whether m and other contain the same keys with equal values
+func (m *MyOrderedMap) Equal(other *MyOrderedMap) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	return m.theMap.Equal(other.theMap, func(a, b int) bool {
+		return a == b
+	})
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the key of the entry that was added, removed or
+// modified, or the key followed by the path of a change within a
+// modified value.
+func (m *MyOrderedMap) Diff(other *MyOrderedMap) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a int, aok bool, b int, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	m.theMap.Diff(other.theMap, func(a, b int) bool {
+		return a == b
+	}, func(k string, a int, aok bool, b int, bok bool) bool {
+		change(immutable.KeyPath(k), a, aok, b, bok)
+		return true
+	})
+
+	return immutable.SortChanges(res)
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a JSON object
+// the members of which are in the order of m
+func (m *MyOrderedMap) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+
+	return m.tree().MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a JSON object
+// into m, the members of which are set in the order they appear. m is
+// left immutable.
+func (m *MyOrderedMap) UnmarshalJSON(b []byte) error {
+	t, err := NewMyOrderedMapCap(0).tree().SetJSON(b)
+	if err != nil {
+		return err
+	}
+
+	*m = MyOrderedMap{theMap: t}
+
+	return nil
+}
+
+//immutableGen:ordered insertion
+//
+// MyInsertionMap is an immutable type and has the following template:
+//
+// 	map[string]int
+//
+type MyInsertionMap struct {
+	theMap  *persistent.Tree[string, int]
+	mutable bool
+	__tmpl  *_Imm_MyInsertionMap
+}
+
+var _ immutable.Immutable = new(MyInsertionMap)
+var _ = new(MyInsertionMap).__tmpl
+
+func NewMyInsertionMap(inits ...func(m *MyInsertionMap)) *MyInsertionMap {
+	res := NewMyInsertionMapCap(0)
+	if len(inits) == 0 {
+		return res
+	}
+
+	return res.WithMutable(func(m *MyInsertionMap) {
+		for _, i := range inits {
+			i(m)
+		}
+	})
+}
+
+func NewMyInsertionMapCap(l int) *MyInsertionMap {
+	return &MyInsertionMap{
+		theMap: persistent.NewTree[string, int](nil),
+	}
+}
+
+// tree returns the persistent.Tree that backs m, or an empty tree if m is nil
+// or the zero value
+func (m *MyInsertionMap) tree() *persistent.Tree[string, int] {
+	if m == nil || m.theMap == nil {
+		return persistent.NewTree[string, int](nil)
+	}
+
+	return m.theMap
+}
+
+func (m *MyInsertionMap) Mutable() bool {
+	return m.mutable
+}
+
+func (m *MyInsertionMap) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theMap.Len()
+}
+
+func (m *MyInsertionMap) Get(k string) (int, bool) {
+	return m.theMap.Get(k)
+}
+
+func (m *MyInsertionMap) AsMutable() *MyInsertionMap {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *MyInsertionMap) dup() *MyInsertionMap {
+	res := &MyInsertionMap{
+		theMap: m.theMap,
+	}
+
+	return res
+}
+
+func (m *MyInsertionMap) AsImmutable(v *MyInsertionMap) *MyInsertionMap {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+// Range returns the entries of m, in order
+func (m *MyInsertionMap) Range() []persistent.Entry[string, int] {
+	if m == nil {
+		return nil
+	}
+
+	return m.theMap.Entries()
+}
+
+// Each calls f for each entry of m, in order, until f returns false
+func (m *MyInsertionMap) Each(f func(k string, v int) bool) {
+	if m == nil {
+		return
+	}
+
+	m.theMap.Each(f)
+}
+
+// Keys returns the keys of m, in order
+func (m *MyInsertionMap) Keys() []string {
+	res := make([]string, 0, m.Len())
+	m.Each(func(k string, _ int) bool {
+		res = append(res, k)
+		return true
+	})
+
+	return res
+}
+
+// Min returns the first entry of m and true, or zero values and false if m is
+// empty
+func (m *MyInsertionMap) Min() (string, int, bool) {
+	return m.tree().Min()
+}
+
+// Max returns the last entry of m and true, or zero values and false if m is
+// empty
+func (m *MyInsertionMap) Max() (string, int, bool) {
+	return m.tree().Max()
+}
+
+func (mr *MyInsertionMap) WithMutable(f func(m *MyInsertionMap)) *MyInsertionMap {
+	res := mr.AsMutable()
+	f(res)
+	res = res.AsImmutable(mr)
+
+	return res
+}
+
+func (mr *MyInsertionMap) WithImmutable(f func(m *MyInsertionMap)) *MyInsertionMap {
+	prev := mr.mutable
+	mr.mutable = false
+	f(mr)
+	mr.mutable = prev
+
+	return mr
+}
+
+func (m *MyInsertionMap) Set(k string, v int) *MyInsertionMap {
+	if m.mutable {
+		m.theMap = m.tree().Set(k, v)
+		return m
+	}
+
+	res := m.dup()
+	res.theMap = m.tree().Set(k, v)
+
+	return res
+}
+
+func (m *MyInsertionMap) Del(k string) *MyInsertionMap {
+	if _, ok := m.theMap.Get(k); !ok {
+		return m
+	}
+
+	if m.mutable {
+		m.theMap = m.theMap.Del(k)
+		return m
+	}
+
+	res := m.dup()
+	res.theMap = res.theMap.Del(k)
+
+	return res
+}
+func (s *MyInsertionMap) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	return true
+}
+
+// Equal returns whether m and other contain the same keys with equal values
+func (m *MyInsertionMap) Equal(other *MyInsertionMap) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	return m.theMap.Equal(other.theMap, func(a, b int) bool {
+		return a == b
+	})
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the key of the entry that was added, removed or
+// modified, or the key followed by the path of a change within a
+// modified value.
+func (m *MyInsertionMap) Diff(other *MyInsertionMap) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a int, aok bool, b int, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	m.theMap.Diff(other.theMap, func(a, b int) bool {
+		return a == b
+	}, func(k string, a int, aok bool, b int, bok bool) bool {
+		change(immutable.KeyPath(k), a, aok, b, bok)
+		return true
+	})
+
+	return immutable.SortChanges(res)
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a JSON object
+// the members of which are in the order of m
+func (m *MyInsertionMap) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+
+	return m.tree().MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a JSON object
+// into m, the members of which are set in the order they appear. m is
+// left immutable.
+func (m *MyInsertionMap) UnmarshalJSON(b []byte) error {
+	t, err := NewMyInsertionMapCap(0).tree().SetJSON(b)
+	if err != nil {
+		return err
+	}
+
+	*m = MyInsertionMap{theMap: t}
+
+	return nil
+}
+
+//immutableGen:ordered byLength
+//
+// MyLengthMap is an immutable type and has the following template:
+//
+// 	map[string]int
+//
+type MyLengthMap struct {
+	theMap  *persistent.Tree[string, int]
+	mutable bool
+	__tmpl  *_Imm_MyLengthMap
+}
+
+var _ immutable.Immutable = new(MyLengthMap)
+var _ = new(MyLengthMap).__tmpl
+
+func NewMyLengthMap(inits ...func(m *MyLengthMap)) *MyLengthMap {
+	res := NewMyLengthMapCap(0)
+	if len(inits) == 0 {
+		return res
+	}
+
+	return res.WithMutable(func(m *MyLengthMap) {
+		for _, i := range inits {
+			i(m)
+		}
+	})
+}
+
+func NewMyLengthMapCap(l int) *MyLengthMap {
+	return &MyLengthMap{
+		theMap: persistent.NewTree[string, int](byLength),
+	}
+}
+
+// tree returns the persistent.Tree that backs m, or an empty tree if m is nil
+// or the zero value
+func (m *MyLengthMap) tree() *persistent.Tree[string, int] {
+	if m == nil || m.theMap == nil {
+		return persistent.NewTree[string, int](byLength)
+	}
+
+	return m.theMap
+}
+
+func (m *MyLengthMap) Mutable() bool {
+	return m.mutable
+}
+
+func (m *MyLengthMap) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theMap.Len()
+}
+
+func (m *MyLengthMap) Get(k string) (int, bool) {
+	return m.theMap.Get(k)
+}
+
+func (m *MyLengthMap) AsMutable() *MyLengthMap {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *MyLengthMap) dup() *MyLengthMap {
+	res := &MyLengthMap{
+		theMap: m.theMap,
+	}
+
+	return res
+}
+
+func (m *MyLengthMap) AsImmutable(v *MyLengthMap) *MyLengthMap {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+// Range returns the entries of m, in order
+func (m *MyLengthMap) Range() []persistent.Entry[string, int] {
+	if m == nil {
+		return nil
+	}
+
+	return m.theMap.Entries()
+}
+
+// Each calls f for each entry of m, in order, until f returns false
+func (m *MyLengthMap) Each(f func(k string, v int) bool) {
+	if m == nil {
+		return
+	}
+
+	m.theMap.Each(f)
+}
+
+// Keys returns the keys of m, in order
+func (m *MyLengthMap) Keys() []string {
+	res := make([]string, 0, m.Len())
+	m.Each(func(k string, _ int) bool {
+		res = append(res, k)
+		return true
+	})
+
+	return res
+}
+
+// Min returns the first entry of m and true, or zero values and false if m is
+// empty
+func (m *MyLengthMap) Min() (string, int, bool) {
+	return m.tree().Min()
+}
+
+// Max returns the last entry of m and true, or zero values and false if m is
+// empty
+func (m *MyLengthMap) Max() (string, int, bool) {
+	return m.tree().Max()
+}
+
+// Floor returns the entry of m with the greatest key less than or equal to k
+// and true, or zero values and false if there is no such entry
+func (m *MyLengthMap) Floor(k string) (string, int, bool) {
+	return m.tree().Floor(k)
+}
+
+// Ceil returns the entry of m with the least key greater than or equal to k
+// and true, or zero values and false if there is no such entry
+func (m *MyLengthMap) Ceil(k string) (string, int, bool) {
+	return m.tree().Ceil(k)
+}
+
+// Between returns the entries of m with keys greater than or equal to lo, and
+// less than hi, in order
+func (m *MyLengthMap) Between(lo, hi string) []persistent.Entry[string, int] {
+	var res []persistent.Entry[string, int]
+	m.tree().EachBetween(lo, hi, func(k string, v int) bool {
+		res = append(res, persistent.Entry[string, int]{Key: k, Value: v})
+		return true
+	})
+
+	return res
+}
+
+func (mr *MyLengthMap) WithMutable(f func(m *MyLengthMap)) *MyLengthMap {
+	res := mr.AsMutable()
+	f(res)
+	res = res.AsImmutable(mr)
+
+	return res
+}
+
+func (mr *MyLengthMap) WithImmutable(f func(m *MyLengthMap)) *MyLengthMap {
+	prev := mr.mutable
+	mr.mutable = false
+	f(mr)
+	mr.mutable = prev
+
+	return mr
+}
+
+func (m *MyLengthMap) Set(k string, v int) *MyLengthMap {
+	if m.mutable {
+		m.theMap = m.tree().Set(k, v)
+		return m
+	}
+
+	res := m.dup()
+	res.theMap = m.tree().Set(k, v)
+
+	return res
+}
+
+func (m *MyLengthMap) Del(k string) *MyLengthMap {
+	if _, ok := m.theMap.Get(k); !ok {
+		return m
+	}
+
+	if m.mutable {
+		m.theMap = m.theMap.Del(k)
+		return m
+	}
+
+	res := m.dup()
+	res.theMap = res.theMap.Del(k)
+
+	return res
+}
+func (s *MyLengthMap) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	return true
+}
+
+// Equal returns whether m and other contain the same keys with equal values
+func (m *MyLengthMap) Equal(other *MyLengthMap) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	return m.theMap.Equal(other.theMap, func(a, b int) bool {
+		return a == b
+	})
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the key of the entry that was added, removed or
+// modified, or the key followed by the path of a change within a
+// modified value.
+func (m *MyLengthMap) Diff(other *MyLengthMap) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a int, aok bool, b int, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	m.theMap.Diff(other.theMap, func(a, b int) bool {
+		return a == b
+	}, func(k string, a int, aok bool, b int, bok bool) bool {
+		change(immutable.KeyPath(k), a, aok, b, bok)
+		return true
+	})
+
+	return immutable.SortChanges(res)
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a JSON object
+// the members of which are in the order of m
+func (m *MyLengthMap) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+
+	return m.tree().MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a JSON object
+// into m, the members of which are set in the order they appear. m is
+// left immutable.
+func (m *MyLengthMap) UnmarshalJSON(b []byte) error {
+	t, err := NewMyLengthMapCap(0).tree().SetJSON(b)
+	if err != nil {
+		return err
+	}
+
+	*m = MyLengthMap{theMap: t}
+
+	return nil
+}
+
+//
+// AM is an immutable type and has the following template:
+//
+// 	map[*A]*A
+//
+type AM struct {
+	theMap  map[*A]*A
+	mutable bool
+	__tmpl  *_Imm_AM
+}
+
+var _ immutable.Immutable = new(AM)
+var _ = new(AM).__tmpl
+
+func NewAM(inits ...func(m *AM)) *AM {
+	res := NewAMCap(0)
+	if len(inits) == 0 {
+		return res
+	}
+
+	return res.WithMutable(func(m *AM) {
+		for _, i := range inits {
+			i(m)
+		}
+	})
+}
+
+func NewAMCap(l int) *AM {
+	return &AM{
+		theMap: make(map[*A]*A, l),
+	}
+}
+
+func (m *AM) Mutable() bool {
+	return m.mutable
+}
+
+func (m *AM) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return len(m.theMap)
+}
+
+func (m *AM) Get(k *A) (*A, bool) {
+	v, ok := m.theMap[k]
+	return v, ok
+}
+
+func (m *AM) AsMutable() *AM {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *AM) dup() *AM {
+	resMap := make(map[*A]*A, len(m.theMap))
+
+	for k := range m.theMap {
+		resMap[k] = m.theMap[k]
+	}
+
+	res := &AM{
+		theMap: resMap,
+	}
+
+	return res
+}
+
+func (m *AM) AsImmutable(v *AM) *AM {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+func (m *AM) Range() map[*A]*A {
+	if m == nil {
+		return nil
+	}
+
+	return m.theMap
+}
+
+func (mr *AM) WithMutable(f func(a *AM)) *AM {
+	res := mr.AsMutable()
+	f(res)
+	res = res.AsImmutable(mr)
+
+	return res
+}
+
+func (mr *AM) WithImmutable(f func(a *AM)) *AM {
+	prev := mr.mutable
+	mr.mutable = false
+	f(mr)
+	mr.mutable = prev
+
+	return mr
+}
+
+func (m *AM) Set(k *A, v *A) *AM {
+	if m.mutable {
+		m.theMap[k] = v
+		return m
+	}
+
+	res := m.dup()
+	res.theMap[k] = v
+
+	return res
+}
+
+func (m *AM) Del(k *A) *AM {
+	if _, ok := m.theMap[k]; !ok {
+		return m
+	}
+
+	if m.mutable {
+		delete(m.theMap, k)
+		return m
+	}
+
+	res := m.dup()
+	delete(res.theMap, k)
+
+	return res
+}
+func (s *AM) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	if s.Len() == 0 {
+		return true
+	}
+
+	if seen == nil {
+		return s.IsDeeplyNonMutable(make(map[interface{}]bool))
+	}
+
+	if seen[s] {
+		return true
+	}
+
+	seen[s] = true
+
+	for k, v := range s.theMap {
+		if k != nil && !k.IsDeeplyNonMutable(seen) {
+			return false
+		}
+		if v != nil && !v.IsDeeplyNonMutable(seen) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns whether m and other contain the same keys with equal values
+func (m *AM) Equal(other *AM) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	if len(m.theMap) != len(other.theMap) {
+		return false
+	}
+
+	for k, a := range m.theMap {
+		b, ok := other.theMap[k]
+		if !ok || !(a.Equal(b)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the key of the entry that was added, removed or
+// modified, or the key followed by the path of a change within a
+// modified value.
+func (m *AM) Diff(other *AM) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a *A, aok bool, b *A, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		case a != nil && b != nil:
+			res = append(res, immutable.PrefixChanges(p, a.Diff(b))...)
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	for k, a := range m.theMap {
+		b, bok := other.theMap[k]
+		if bok && (a.Equal(b)) {
+			continue
+		}
+		change(immutable.KeyPath(k), a, true, b, bok)
+	}
+
+	for k, b := range other.theMap {
+		if _, ok := m.theMap[k]; !ok {
+			var a *A
+			change(immutable.KeyPath(k), a, false, b, true)
+		}
+	}
+
+	return immutable.SortChanges(res)
+}
+
+//immutableGen:persistent
+//
+// APM is an immutable type and has the following template:
+//
+// 	map[*A]*A
+//
+type APM struct {
+	theMap  *persistent.Map[*A, *A]
+	mutable bool
+	__tmpl  *_Imm_APM
+}
+
+var _ immutable.Immutable = new(APM)
+var _ = new(APM).__tmpl
+
+func NewAPM(inits ...func(m *APM)) *APM {
+	res := NewAPMCap(0)
+	if len(inits) == 0 {
+		return res
+	}
+
+	return res.WithMutable(func(m *APM) {
+		for _, i := range inits {
+			i(m)
+		}
+	})
+}
+
+func NewAPMCap(l int) *APM {
+	return &APM{
+		theMap: persistent.NewMap[*A, *A](),
+	}
+}
+
+func (m *APM) Mutable() bool {
+	return m.mutable
+}
+
+func (m *APM) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theMap.Len()
+}
+
+func (m *APM) Get(k *A) (*A, bool) {
+	return m.theMap.Get(k)
+}
+
+func (m *APM) AsMutable() *APM {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *APM) dup() *APM {
+	res := &APM{
+		theMap: m.theMap,
+	}
+
+	return res
+}
+
+func (m *APM) AsImmutable(v *APM) *APM {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+func (m *APM) Range() map[*A]*A {
+	if m == nil {
+		return nil
+	}
+
+	return m.theMap.ToMap()
+}
+
+func (mr *APM) WithMutable(f func(a *APM)) *APM {
+	res := mr.AsMutable()
+	f(res)
+	res = res.AsImmutable(mr)
+
+	return res
+}
+
+func (mr *APM) WithImmutable(f func(a *APM)) *APM {
+	prev := mr.mutable
+	mr.mutable = false
+	f(mr)
+	mr.mutable = prev
+
+	return mr
+}
+
+func (m *APM) Set(k *A, v *A) *APM {
+	if m.mutable {
+		m.theMap = m.theMap.Set(k, v)
+		return m
+	}
+
+	res := m.dup()
+	res.theMap = res.theMap.Set(k, v)
+
+	return res
+}
+
+func (m *APM) Del(k *A) *APM {
+	if _, ok := m.theMap.Get(k); !ok {
+		return m
+	}
+
+	if m.mutable {
+		m.theMap = m.theMap.Del(k)
+		return m
+	}
+
+	res := m.dup()
+	res.theMap = res.theMap.Del(k)
+
+	return res
+}
+func (s *APM) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	if s.Len() == 0 {
+		return true
+	}
+
+	if seen == nil {
+		return s.IsDeeplyNonMutable(make(map[interface{}]bool))
+	}
+
+	if seen[s] {
+		return true
+	}
+
+	seen[s] = true
+
+	deep := true
+	s.theMap.Each(func(k *A, v *A) bool {
+		if k != nil && !k.IsDeeplyNonMutable(seen) {
+			deep = false
+		}
+		if v != nil && !v.IsDeeplyNonMutable(seen) {
+			deep = false
+		}
+		return deep
+	})
+
+	if !deep {
+		return false
+	}
+	return true
+}
+
+// Equal returns whether m and other contain the same keys with equal values
+func (m *APM) Equal(other *APM) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	return m.theMap.Equal(other.theMap, func(a, b *A) bool {
+		return a.Equal(b)
+	})
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the key of the entry that was added, removed or
+// modified, or the key followed by the path of a change within a
+// modified value.
+func (m *APM) Diff(other *APM) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a *A, aok bool, b *A, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		case a != nil && b != nil:
+			res = append(res, immutable.PrefixChanges(p, a.Diff(b))...)
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	m.theMap.Diff(other.theMap, func(a, b *A) bool {
+		return a.Equal(b)
+	}, func(k *A, a *A, aok bool, b *A, bok bool) bool {
+		change(immutable.KeyPath(k), a, aok, b, bok)
+		return true
+	})
+
+	return immutable.SortChanges(res)
+}
+
+//immutableGen:container
+//
+// ACM is an immutable type and has the following template:
+//
+// 	map[*A]*A
+//
+type ACM struct {
+	theMap *container.Map[*A, *A]
+	__tmpl *_Imm_ACM
+}
+
+var _ immutable.Immutable = new(ACM)
+var _ = new(ACM).__tmpl
+
+func NewACM(inits ...func(m *ACM)) *ACM {
+	res := NewACMCap(0)
+	if len(inits) == 0 {
+		return res
+	}
+
+	return res.WithMutable(func(m *ACM) {
+		for _, i := range inits {
+			i(m)
+		}
+	})
+}
+
+func NewACMCap(l int) *ACM {
+	return &ACM{
+		theMap: container.NewMap[*A, *A](l),
+	}
+}
+
+// wrap returns m if c is the container of m, else a new ACM around c
+func (m *ACM) wrap(c *container.Map[*A, *A]) *ACM {
+	if c == m.theMap {
+		return m
+	}
+
+	return &ACM{
+		theMap: c,
+	}
+}
+
+func (m *ACM) Mutable() bool {
+	return m.theMap.Mutable()
+}
+
+func (m *ACM) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theMap.Len()
+}
+
+func (m *ACM) Get(k *A) (*A, bool) {
+	return m.theMap.Get(k)
+}
+
+func (m *ACM) AsMutable() *ACM {
+	if m == nil {
+		return nil
+	}
+
+	return m.wrap(m.theMap.AsMutable())
+}
+
+func (m *ACM) AsImmutable(v *ACM) *ACM {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.theMap.AsImmutable()
+	return m
+}
+
+func (m *ACM) Range() map[*A]*A {
+	if m == nil {
+		return nil
+	}
+
+	return m.theMap.Range()
+}
+
+func (mr *ACM) WithMutable(f func(a *ACM)) *ACM {
+	res := mr.AsMutable()
+	f(res)
+	res = res.AsImmutable(mr)
+
+	return res
+}
+
+func (mr *ACM) WithImmutable(f func(a *ACM)) *ACM {
+	mr.theMap.WithImmutable(func() {
+		f(mr)
+	})
+
+	return mr
+}
+
+func (m *ACM) Set(k *A, v *A) *ACM {
+	return m.wrap(m.theMap.Set(k, v))
+}
+
+func (m *ACM) Del(k *A) *ACM {
+	return m.wrap(m.theMap.Del(k))
+}
+func (s *ACM) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	if s.Len() == 0 {
+		return true
+	}
+
+	if seen == nil {
+		return s.IsDeeplyNonMutable(make(map[interface{}]bool))
+	}
+
+	if seen[s] {
+		return true
+	}
+
+	seen[s] = true
+
+	for k, v := range s.theMap.Range() {
+		if k != nil && !k.IsDeeplyNonMutable(seen) {
+			return false
+		}
+		if v != nil && !v.IsDeeplyNonMutable(seen) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns whether m and other contain the same keys with equal values
+func (m *ACM) Equal(other *ACM) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	return m.theMap.Equal(other.theMap, func(a, b *A) bool {
+		return a.Equal(b)
+	})
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the key of the entry that was added, removed or
+// modified, or the key followed by the path of a change within a
+// modified value.
+func (m *ACM) Diff(other *ACM) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	return m.theMap.Changes(other.theMap, func(a, b *A) bool {
+		return a.Equal(b)
+	}, func(a, b *A) []immutable.Change {
+		if a == nil || b == nil {
+			return nil
+		}
+
+		return a.Diff(b)
+	})
+}
+
+//immutableGen:ordered insertion
+//
+// AOM is an immutable type and has the following template:
+//
+// 	map[*A]*A
+//
+type AOM struct {
+	theMap  *persistent.Tree[*A, *A]
+	mutable bool
+	__tmpl  *_Imm_AOM
+}
+
+var _ immutable.Immutable = new(AOM)
+var _ = new(AOM).__tmpl
+
+func NewAOM(inits ...func(m *AOM)) *AOM {
+	res := NewAOMCap(0)
+	if len(inits) == 0 {
+		return res
+	}
+
+	return res.WithMutable(func(m *AOM) {
+		for _, i := range inits {
+			i(m)
+		}
+	})
+}
+
+func NewAOMCap(l int) *AOM {
+	return &AOM{
+		theMap: persistent.NewTree[*A, *A](nil),
+	}
+}
+
+// tree returns the persistent.Tree that backs m, or an empty tree if m is nil
+// or the zero value
+func (m *AOM) tree() *persistent.Tree[*A, *A] {
+	if m == nil || m.theMap == nil {
+		return persistent.NewTree[*A, *A](nil)
+	}
+
+	return m.theMap
+}
+
+func (m *AOM) Mutable() bool {
+	return m.mutable
+}
+
+func (m *AOM) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theMap.Len()
+}
+
+func (m *AOM) Get(k *A) (*A, bool) {
+	return m.theMap.Get(k)
+}
+
+func (m *AOM) AsMutable() *AOM {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *AOM) dup() *AOM {
+	res := &AOM{
+		theMap: m.theMap,
+	}
+
+	return res
+}
+
+func (m *AOM) AsImmutable(v *AOM) *AOM {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+// Range returns the entries of m, in order
+func (m *AOM) Range() []persistent.Entry[*A, *A] {
+	if m == nil {
+		return nil
+	}
+
+	return m.theMap.Entries()
+}
+
+// Each calls f for each entry of m, in order, until f returns false
+func (m *AOM) Each(f func(k *A, v *A) bool) {
+	if m == nil {
+		return
+	}
+
+	m.theMap.Each(f)
+}
+
+// Keys returns the keys of m, in order
+func (m *AOM) Keys() []*A {
+	res := make([]*A, 0, m.Len())
+	m.Each(func(k *A, _ *A) bool {
+		res = append(res, k)
+		return true
+	})
+
+	return res
+}
+
+// Min returns the first entry of m and true, or zero values and false if m is
+// empty
+func (m *AOM) Min() (*A, *A, bool) {
+	return m.tree().Min()
+}
+
+// Max returns the last entry of m and true, or zero values and false if m is
+// empty
+func (m *AOM) Max() (*A, *A, bool) {
+	return m.tree().Max()
+}
+
+func (mr *AOM) WithMutable(f func(a *AOM)) *AOM {
+	res := mr.AsMutable()
+	f(res)
+	res = res.AsImmutable(mr)
+
+	return res
+}
+
+func (mr *AOM) WithImmutable(f func(a *AOM)) *AOM {
+	prev := mr.mutable
+	mr.mutable = false
+	f(mr)
+	mr.mutable = prev
+
+	return mr
+}
+
+func (m *AOM) Set(k *A, v *A) *AOM {
+	if m.mutable {
+		m.theMap = m.tree().Set(k, v)
+		return m
+	}
+
+	res := m.dup()
+	res.theMap = m.tree().Set(k, v)
+
+	return res
+}
+
+func (m *AOM) Del(k *A) *AOM {
+	if _, ok := m.theMap.Get(k); !ok {
+		return m
+	}
+
+	if m.mutable {
+		m.theMap = m.theMap.Del(k)
+		return m
+	}
+
+	res := m.dup()
+	res.theMap = res.theMap.Del(k)
+
+	return res
+}
+func (s *AOM) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	if s.Len() == 0 {
+		return true
+	}
+
+	if seen == nil {
+		return s.IsDeeplyNonMutable(make(map[interface{}]bool))
+	}
+
+	if seen[s] {
+		return true
+	}
+
+	seen[s] = true
+
+	deep := true
+	s.theMap.Each(func(k *A, v *A) bool {
+		if k != nil && !k.IsDeeplyNonMutable(seen) {
+			deep = false
+		}
+		if v != nil && !v.IsDeeplyNonMutable(seen) {
+			deep = false
+		}
+		return deep
+	})
+
+	if !deep {
+		return false
+	}
+	return true
+}
+
+// Equal returns whether m and other contain the same keys with equal values
+func (m *AOM) Equal(other *AOM) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	return m.theMap.Equal(other.theMap, func(a, b *A) bool {
+		return a.Equal(b)
+	})
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the key of the entry that was added, removed or
+// modified, or the key followed by the path of a change within a
+// modified value.
+func (m *AOM) Diff(other *AOM) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a *A, aok bool, b *A, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		case a != nil && b != nil:
+			res = append(res, immutable.PrefixChanges(p, a.Diff(b))...)
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	m.theMap.Diff(other.theMap, func(a, b *A) bool {
+		return a.Equal(b)
+	}, func(k *A, a *A, aok bool, b *A, bok bool) bool {
+		change(immutable.KeyPath(k), a, aok, b, bok)
+		return true
+	})
+
+	return immutable.SortChanges(res)
+}
+
+// a comment about MySet
+//
+// MySet is an immutable type and has the following template:
+//
+// 	map[string]immutable.Set
+//
+type MySet struct {
+	theSet  map[string]struct{}
+	mutable bool
+	__tmpl  *_Imm_MySet
+}
+
+var _ immutable.Immutable = new(MySet)
+var _ = new(MySet).__tmpl
+
+func NewMySet(vs ...string) *MySet {
+	res := NewMySetCap(len(vs))
+
+	for _, v := range vs {
+		res.theSet[v] = struct{}{}
+	}
+
+	return res
+}
+
+func NewMySetCap(l int) *MySet {
+	return &MySet{
+		theSet: make(map[string]struct{}, l),
+	}
+}
+
+func (m *MySet) Mutable() bool {
+	return m.mutable
+}
+
+func (m *MySet) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return len(m.theSet)
+}
+
+func (m *MySet) Contains(v string) bool {
+	_, ok := m.theSet[v]
+	return ok
+}
+
+func (m *MySet) AsMutable() *MySet {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *MySet) dup() *MySet {
+	resSet := make(map[string]struct{}, len(m.theSet))
+
+	for v := range m.theSet {
+		resSet[v] = struct{}{}
+	}
+
+	res := &MySet{
+		theSet: resSet,
+	}
+
+	return res
+}
+
+func (m *MySet) AsImmutable(v *MySet) *MySet {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+// Range returns the elements of m as the keys of a map, that must not be
+// modified. The order of iteration over the map is not defined; see
+// Sorted and SortedFunc for ordered iteration.
+func (m *MySet) Range() map[string]struct{} {
+	if m == nil {
+		return nil
+	}
+
+	return m.theSet
+}
+
+// Sorted returns a newly allocated slice of the elements of m in ascending
+// order.
+func (m *MySet) Sorted() []string {
+	return m.SortedFunc(func(a, b string) bool {
+		return a < b
+	})
+}
+
+// SortedFunc returns a newly allocated slice of the elements of m, sorted
+// according to less.
+func (m *MySet) SortedFunc(less func(a, b string) bool) []string {
+	res := make([]string, 0, m.Len())
+
+	for v := range m.theSet {
+		res = append(res, v)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return less(res[i], res[j])
+	})
+
+	return res
+}
+
+func (mr *MySet) WithMutable(f func(m *MySet)) *MySet {
+	res := mr.AsMutable()
+	f(res)
+	res = res.AsImmutable(mr)
+
+	return res
+}
+
+func (mr *MySet) WithImmutable(f func(m *MySet)) *MySet {
+	prev := mr.mutable
+	mr.mutable = false
+	f(mr)
+	mr.mutable = prev
+
+	return mr
+}
+
+// Add returns a set that contains the elements of m and vs. If m already
+// contains all of vs, m is returned.
+func (m *MySet) Add(vs ...string) *MySet {
+	if m.mutable {
+		for _, v := range vs {
+			m.theSet[v] = struct{}{}
+		}
+		return m
+	}
+
+	for _, v := range vs {
+		if _, ok := m.theSet[v]; !ok {
+			res := m.dup()
+			for _, v := range vs {
+				res.theSet[v] = struct{}{}
+			}
+			return res
+		}
+	}
+
+	return m
+}
+
+// Remove returns a set that contains the elements of m that are not in vs. If
+// m contains none of vs, m is returned.
+func (m *MySet) Remove(vs ...string) *MySet {
+	if m.mutable {
+		for _, v := range vs {
+			delete(m.theSet, v)
+		}
+		return m
+	}
+
+	for _, v := range vs {
+		if _, ok := m.theSet[v]; ok {
+			res := m.dup()
+			for _, v := range vs {
+				delete(res.theSet, v)
+			}
+			return res
+		}
+	}
+
+	return m
+}
+
+// Union returns a set that contains the elements of m and o. If m already
+// contains all the elements of o, m is returned.
+func (m *MySet) Union(o *MySet) *MySet {
+	if m.mutable {
+		for v := range o.theSet {
+			m.theSet[v] = struct{}{}
+		}
+		return m
+	}
+
+	for v := range o.theSet {
+		if _, ok := m.theSet[v]; !ok {
+			res := m.dup()
+			for v := range o.theSet {
+				res.theSet[v] = struct{}{}
+			}
+			return res
+		}
+	}
+
+	return m
+}
+
+// Intersect returns a set that contains the elements of m that are also in o.
+// If all the elements of m are in o, m is returned.
+func (m *MySet) Intersect(o *MySet) *MySet {
+	return m.filter(func(v string) bool {
+		_, ok := o.theSet[v]
+		return ok
+	})
+}
+
+// Difference returns a set that contains the elements of m that are not in o.
+// If none of the elements of m are in o, m is returned.
+func (m *MySet) Difference(o *MySet) *MySet {
+	return m.filter(func(v string) bool {
+		_, ok := o.theSet[v]
+		return !ok
+	})
+}
+
+// filter returns a set that contains the elements of m for which keep returns
+// true. If keep returns true for all the elements of m, m is returned.
+func (m *MySet) filter(keep func(v string) bool) *MySet {
+	if m.mutable {
+		for v := range m.theSet {
+			if !keep(v) {
+				delete(m.theSet, v)
+			}
+		}
+		return m
+	}
+
+	for v := range m.theSet {
+		if !keep(v) {
+			res := NewMySetCap(len(m.theSet))
+			for v := range m.theSet {
+				if keep(v) {
+					res.theSet[v] = struct{}{}
+				}
+			}
+			return res
+		}
+	}
+
+	return m
+}
+func (s *MySet) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	return true
+}
+
+// Equal returns whether m and other contain the same elements
+func (m *MySet) Equal(other *MySet) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	if len(m.theSet) != len(other.theSet) {
+		return false
+	}
+
+	for v := range m.theSet {
+		if _, ok := other.theSet[v]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the element that was added or removed.
+func (m *MySet) Diff(other *MySet) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	for v := range m.theSet {
+		if _, ok := other.theSet[v]; !ok {
+			res = append(res, immutable.Change{Path: immutable.KeyPath(v), Kind: immutable.ChangeRemoved, Old: v})
+		}
+	}
+
+	for v := range other.theSet {
+		if _, ok := m.theSet[v]; !ok {
+			res = append(res, immutable.Change{Path: immutable.KeyPath(v), Kind: immutable.ChangeAdded, New: v})
+		}
+	}
+
+	return immutable.SortChanges(res)
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a []string in ascending order
+func (m *MySet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Sorted())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []string
+// into m. m is left immutable.
+func (m *MySet) UnmarshalJSON(b []byte) error {
+	var v []string
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *NewMySet(v...)
+
+	return nil
+}
+
+//
+// KeySet is an immutable type and has the following template:
+//
+// 	map[MyStructKey]immutable.Set
+//
+type KeySet struct {
+	theSet  map[MyStructKey]struct{}
+	mutable bool
+	__tmpl  *_Imm_KeySet
+}
+
+var _ immutable.Immutable = new(KeySet)
+var _ = new(KeySet).__tmpl
+
+func NewKeySet(vs ...MyStructKey) *KeySet {
+	res := NewKeySetCap(len(vs))
+
+	for _, v := range vs {
+		res.theSet[v] = struct{}{}
+	}
+
+	return res
+}
+
+func NewKeySetCap(l int) *KeySet {
+	return &KeySet{
+		theSet: make(map[MyStructKey]struct{}, l),
+	}
+}
+
+func (m *KeySet) Mutable() bool {
+	return m.mutable
+}
+
+func (m *KeySet) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return len(m.theSet)
+}
+
+func (m *KeySet) Contains(v MyStructKey) bool {
+	_, ok := m.theSet[v]
+	return ok
+}
+
+func (m *KeySet) AsMutable() *KeySet {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *KeySet) dup() *KeySet {
+	resSet := make(map[MyStructKey]struct{}, len(m.theSet))
+
+	for v := range m.theSet {
+		resSet[v] = struct{}{}
+	}
+
+	res := &KeySet{
+		theSet: resSet,
+	}
+
+	return res
+}
+
+func (m *KeySet) AsImmutable(v *KeySet) *KeySet {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+// Range returns the elements of m as the keys of a map, that must not be
+// modified. The order of iteration over the map is not defined; see
+// Sorted or SortedFunc for ordered iteration.
+func (m *KeySet) Range() map[MyStructKey]struct{} {
+	if m == nil {
+		return nil
+	}
+
+	return m.theSet
+}
+
+// SortedFunc returns a newly allocated slice of the elements of m, sorted
+// according to less.
+func (m *KeySet) SortedFunc(less func(a, b MyStructKey) bool) []MyStructKey {
+	res := make([]MyStructKey, 0, m.Len())
+
+	for v := range m.theSet {
+		res = append(res, v)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return less(res[i], res[j])
+	})
+
+	return res
+}
+
+func (mr *KeySet) WithMutable(f func(k *KeySet)) *KeySet {
+	res := mr.AsMutable()
+	f(res)
+	res = res.AsImmutable(mr)
+
+	return res
+}
+
+func (mr *KeySet) WithImmutable(f func(k *KeySet)) *KeySet {
+	prev := mr.mutable
+	mr.mutable = false
+	f(mr)
+	mr.mutable = prev
+
+	return mr
+}
+
+// Add returns a set that contains the elements of m and vs. If m already
+// contains all of vs, m is returned.
+func (m *KeySet) Add(vs ...MyStructKey) *KeySet {
+	if m.mutable {
+		for _, v := range vs {
+			m.theSet[v] = struct{}{}
+		}
+		return m
+	}
+
+	for _, v := range vs {
+		if _, ok := m.theSet[v]; !ok {
+			res := m.dup()
+			for _, v := range vs {
+				res.theSet[v] = struct{}{}
+			}
+			return res
+		}
+	}
+
+	return m
+}
+
+// Remove returns a set that contains the elements of m that are not in vs. If
+// m contains none of vs, m is returned.
+func (m *KeySet) Remove(vs ...MyStructKey) *KeySet {
+	if m.mutable {
+		for _, v := range vs {
+			delete(m.theSet, v)
+		}
+		return m
+	}
+
+	for _, v := range vs {
+		if _, ok := m.theSet[v]; ok {
+			res := m.dup()
+			for _, v := range vs {
+				delete(res.theSet, v)
+			}
+			return res
+		}
+	}
+
+	return m
+}
+
+// Union returns a set that contains the elements of m and o. If m already
+// contains all the elements of o, m is returned.
+func (m *KeySet) Union(o *KeySet) *KeySet {
+	if m.mutable {
+		for v := range o.theSet {
+			m.theSet[v] = struct{}{}
+		}
+		return m
+	}
+
+	for v := range o.theSet {
+		if _, ok := m.theSet[v]; !ok {
+			res := m.dup()
+			for v := range o.theSet {
+				res.theSet[v] = struct{}{}
+			}
+			return res
+		}
+	}
+
+	return m
+}
+
+// Intersect returns a set that contains the elements of m that are also in o.
+// If all the elements of m are in o, m is returned.
+func (m *KeySet) Intersect(o *KeySet) *KeySet {
+	return m.filter(func(v MyStructKey) bool {
+		_, ok := o.theSet[v]
+		return ok
+	})
+}
+
+// Difference returns a set that contains the elements of m that are not in o.
+// If none of the elements of m are in o, m is returned.
+func (m *KeySet) Difference(o *KeySet) *KeySet {
+	return m.filter(func(v MyStructKey) bool {
+		_, ok := o.theSet[v]
+		return !ok
+	})
+}
+
+// filter returns a set that contains the elements of m for which keep returns
+// true. If keep returns true for all the elements of m, m is returned.
+func (m *KeySet) filter(keep func(v MyStructKey) bool) *KeySet {
+	if m.mutable {
+		for v := range m.theSet {
+			if !keep(v) {
+				delete(m.theSet, v)
+			}
+		}
+		return m
+	}
+
+	for v := range m.theSet {
+		if !keep(v) {
+			res := NewKeySetCap(len(m.theSet))
+			for v := range m.theSet {
+				if keep(v) {
+					res.theSet[v] = struct{}{}
+				}
+			}
+			return res
+		}
+	}
+
+	return m
+}
+func (s *KeySet) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	return true
+}
+
+// Equal returns whether m and other contain the same elements
+func (m *KeySet) Equal(other *KeySet) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	if len(m.theSet) != len(other.theSet) {
+		return false
+	}
+
+	for v := range m.theSet {
+		if _, ok := other.theSet[v]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the element that was added or removed.
+func (m *KeySet) Diff(other *KeySet) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	for v := range m.theSet {
+		if _, ok := other.theSet[v]; !ok {
+			res = append(res, immutable.Change{Path: immutable.KeyPath(v), Kind: immutable.ChangeRemoved, Old: v})
+		}
+	}
+
+	for v := range other.theSet {
+		if _, ok := m.theSet[v]; !ok {
+			res = append(res, immutable.Change{Path: immutable.KeyPath(v), Kind: immutable.ChangeAdded, New: v})
+		}
+	}
+
+	return immutable.SortChanges(res)
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a []MyStructKey
+func (m *KeySet) MarshalJSON() ([]byte, error) {
+	v := make([]MyStructKey, 0, m.Len())
+
+	for e := range m.Range() {
+		v = append(v, e)
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []MyStructKey
+// into m. m is left immutable.
+func (m *KeySet) UnmarshalJSON(b []byte) error {
+	var v []MyStructKey
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *NewKeySet(v...)
+
+	return nil
+}
+
+//
+// ASet is an immutable type and has the following template:
+//
+// 	map[*A]immutable.Set
+//
+type ASet struct {
+	theSet  map[*A]struct{}
+	mutable bool
+	__tmpl  *_Imm_ASet
+}
+
+var _ immutable.Immutable = new(ASet)
+var _ = new(ASet).__tmpl
+
+func NewASet(vs ...*A) *ASet {
+	res := NewASetCap(len(vs))
+
+	for _, v := range vs {
+		res.theSet[v] = struct{}{}
+	}
+
+	return res
+}
+
+func NewASetCap(l int) *ASet {
+	return &ASet{
+		theSet: make(map[*A]struct{}, l),
+	}
+}
+
+func (m *ASet) Mutable() bool {
+	return m.mutable
+}
+
+func (m *ASet) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return len(m.theSet)
+}
+
+func (m *ASet) Contains(v *A) bool {
+	_, ok := m.theSet[v]
+	return ok
+}
+
+func (m *ASet) AsMutable() *ASet {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *ASet) dup() *ASet {
+	resSet := make(map[*A]struct{}, len(m.theSet))
+
+	for v := range m.theSet {
+		resSet[v] = struct{}{}
+	}
+
+	res := &ASet{
+		theSet: resSet,
+	}
+
+	return res
+}
+
+func (m *ASet) AsImmutable(v *ASet) *ASet {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+// Range returns the elements of m as the keys of a map, that must not be
+// modified. The order of iteration over the map is not defined; see
+// Sorted or SortedFunc for ordered iteration.
+func (m *ASet) Range() map[*A]struct{} {
+	if m == nil {
+		return nil
+	}
+
+	return m.theSet
+}
+
+// SortedFunc returns a newly allocated slice of the elements of m, sorted
+// according to less.
+func (m *ASet) SortedFunc(less func(a, b *A) bool) []*A {
+	res := make([]*A, 0, m.Len())
+
+	for v := range m.theSet {
+		res = append(res, v)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return less(res[i], res[j])
+	})
+
+	return res
+}
+
+func (mr *ASet) WithMutable(f func(a *ASet)) *ASet {
+	res := mr.AsMutable()
+	f(res)
+	res = res.AsImmutable(mr)
+
+	return res
+}
+
+func (mr *ASet) WithImmutable(f func(a *ASet)) *ASet {
+	prev := mr.mutable
+	mr.mutable = false
+	f(mr)
+	mr.mutable = prev
+
+	return mr
+}
+
+// Add returns a set that contains the elements of m and vs. If m already
+// contains all of vs, m is returned.
+func (m *ASet) Add(vs ...*A) *ASet {
+	if m.mutable {
+		for _, v := range vs {
+			m.theSet[v] = struct{}{}
+		}
+		return m
+	}
+
+	for _, v := range vs {
+		if _, ok := m.theSet[v]; !ok {
+			res := m.dup()
+			for _, v := range vs {
+				res.theSet[v] = struct{}{}
+			}
+			return res
+		}
+	}
+
+	return m
+}
+
+// Remove returns a set that contains the elements of m that are not in vs. If
+// m contains none of vs, m is returned.
+func (m *ASet) Remove(vs ...*A) *ASet {
+	if m.mutable {
+		for _, v := range vs {
+			delete(m.theSet, v)
+		}
+		return m
+	}
+
+	for _, v := range vs {
+		if _, ok := m.theSet[v]; ok {
+			res := m.dup()
+			for _, v := range vs {
+				delete(res.theSet, v)
+			}
+			return res
+		}
+	}
+
+	return m
+}
+
+// Union returns a set that contains the elements of m and o. If m already
+// contains all the elements of o, m is returned.
+func (m *ASet) Union(o *ASet) *ASet {
+	if m.mutable {
+		for v := range o.theSet {
+			m.theSet[v] = struct{}{}
+		}
+		return m
+	}
+
+	for v := range o.theSet {
+		if _, ok := m.theSet[v]; !ok {
+			res := m.dup()
+			for v := range o.theSet {
+				res.theSet[v] = struct{}{}
+			}
+			return res
+		}
+	}
+
+	return m
+}
+
+// Intersect returns a set that contains the elements of m that are also in o.
+// If all the elements of m are in o, m is returned.
+func (m *ASet) Intersect(o *ASet) *ASet {
+	return m.filter(func(v *A) bool {
+		_, ok := o.theSet[v]
+		return ok
+	})
+}
+
+// Difference returns a set that contains the elements of m that are not in o.
+// If none of the elements of m are in o, m is returned.
+func (m *ASet) Difference(o *ASet) *ASet {
+	return m.filter(func(v *A) bool {
+		_, ok := o.theSet[v]
+		return !ok
+	})
+}
+
+// filter returns a set that contains the elements of m for which keep returns
+// true. If keep returns true for all the elements of m, m is returned.
+func (m *ASet) filter(keep func(v *A) bool) *ASet {
+	if m.mutable {
+		for v := range m.theSet {
+			if !keep(v) {
+				delete(m.theSet, v)
+			}
+		}
+		return m
+	}
+
+	for v := range m.theSet {
+		if !keep(v) {
+			res := NewASetCap(len(m.theSet))
+			for v := range m.theSet {
+				if keep(v) {
+					res.theSet[v] = struct{}{}
+				}
+			}
+			return res
+		}
+	}
+
+	return m
+}
+func (s *ASet) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	if s.Len() == 0 {
+		return true
+	}
+
+	if seen == nil {
+		return s.IsDeeplyNonMutable(make(map[interface{}]bool))
+	}
+
+	if seen[s] {
+		return true
+	}
+
+	seen[s] = true
+
+	for v := range s.theSet {
+		if v != nil && !v.IsDeeplyNonMutable(seen) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns whether m and other contain the same elements
+func (m *ASet) Equal(other *ASet) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	if len(m.theSet) != len(other.theSet) {
+		return false
+	}
+
+	for v := range m.theSet {
+		if _, ok := other.theSet[v]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the element that was added or removed.
+func (m *ASet) Diff(other *ASet) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	for v := range m.theSet {
+		if _, ok := other.theSet[v]; !ok {
+			res = append(res, immutable.Change{Path: immutable.KeyPath(v), Kind: immutable.ChangeRemoved, Old: v})
+		}
+	}
+
+	for v := range other.theSet {
+		if _, ok := m.theSet[v]; !ok {
+			res = append(res, immutable.Change{Path: immutable.KeyPath(v), Kind: immutable.ChangeAdded, New: v})
+		}
+	}
+
+	return immutable.SortChanges(res)
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a []*A
+func (m *ASet) MarshalJSON() ([]byte, error) {
+	v := make([]*A, 0, m.Len())
+
+	for e := range m.Range() {
+		v = append(v, e)
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []*A
+// into m. m is left immutable.
+func (m *ASet) UnmarshalJSON(b []byte) error {
+	var v []*A
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *NewASet(v...)
+
+	return nil
+}
+
+// a comment about Slice
+//
+// MySlice is an immutable type and has the following template:
+//
+// 	[]string
+//
+type MySlice struct {
+	theSlice []string
+	mutable  bool
+	__tmpl   *_Imm_MySlice
+}
+
+var _ immutable.Immutable = new(MySlice)
+var _ = new(MySlice).__tmpl
+
+func NewMySlice(s ...string) *MySlice {
+	c := make([]string, len(s))
+	copy(c, s)
+
+	return &MySlice{
+		theSlice: c,
+	}
+}
+
+func NewMySliceLen(l int) *MySlice {
+	c := make([]string, l)
+
+	return &MySlice{
+		theSlice: c,
+	}
+}
+
+func (m *MySlice) Mutable() bool {
+	return m.mutable
+}
+
+func (m *MySlice) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return len(m.theSlice)
+}
+
+func (m *MySlice) Get(i int) string {
+	return m.theSlice[i]
+}
+
+func (m *MySlice) AsMutable() *MySlice {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *MySlice) dup() *MySlice {
+	resSlice := make([]string, len(m.theSlice))
+
+	for i := range m.theSlice {
+		resSlice[i] = m.theSlice[i]
+	}
+
+	res := &MySlice{
+		theSlice: resSlice,
+	}
+
+	return res
+}
+
+func (m *MySlice) AsImmutable(v *MySlice) *MySlice {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+func (m *MySlice) Range() []string {
+	if m == nil {
+		return nil
+	}
+
+	return m.theSlice
+}
+
+func (m *MySlice) WithMutable(f func(mi *MySlice)) *MySlice {
+	res := m.AsMutable()
+	f(res)
+	res = res.AsImmutable(m)
+
+	return res
+}
+
+func (m *MySlice) WithImmutable(f func(mi *MySlice)) *MySlice {
+	prev := m.mutable
+	m.mutable = false
+	f(m)
+	m.mutable = prev
+
+	return m
+}
+
+func (m *MySlice) Set(i int, v string) *MySlice {
+	if m.mutable {
+		m.theSlice[i] = v
+		return m
+	}
+
+	res := m.dup()
+	res.theSlice[i] = v
+
+	return res
+}
+
+func (m *MySlice) Append(v ...string) *MySlice {
+	if m.mutable {
+		m.theSlice = append(m.theSlice, v...)
+		return m
+	}
+
+	res := m.dup()
+	res.theSlice = append(res.theSlice, v...)
+
+	return res
+}
+func (s *MySlice) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	return true
+}
+
+// Equal returns whether m and other have the same length and equal values
+// at each index
+func (m *MySlice) Equal(other *MySlice) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	if len(m.theSlice) != len(other.theSlice) {
+		return false
+	}
+
+	for i, a := range m.theSlice {
+		b := other.theSlice[i]
+		if !(a == b) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, in index order. The path
+// of a change is the index that was added, removed or modified, or the
+// index followed by the path of a change within a modified value.
+func (m *MySlice) Diff(other *MySlice) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a string, aok bool, b string, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	for i := 0; i < len(m.theSlice) || i < len(other.theSlice); i++ {
+		var a, b string
+		aok, bok := i < len(m.theSlice), i < len(other.theSlice)
+		if aok {
+			a = m.theSlice[i]
+		}
+		if bok {
+			b = other.theSlice[i]
+		}
+		if aok && bok && (a == b) {
+			continue
+		}
+		change(immutable.IndexPath(i), a, aok, b, bok)
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a []string
+func (m *MySlice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []string
+// into m. m is left immutable.
+func (m *MySlice) UnmarshalJSON(b []byte) error {
+	var v []string
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *NewMySlice(v...)
+
+	return nil
+}
+
+// a comment about MyPersistentSlice
+//
+//immutableGen:persistent
+//
+// MyPersistentSlice is an immutable type and has the following template:
+//
+// 	[]string
+//
+type MyPersistentSlice struct {
+	theSlice *persistent.Vector[string]
+	mutable  bool
+	__tmpl   *_Imm_MyPersistentSlice
+}
+
+var _ immutable.Immutable = new(MyPersistentSlice)
+var _ = new(MyPersistentSlice).__tmpl
+
+func NewMyPersistentSlice(s ...string) *MyPersistentSlice {
+	return &MyPersistentSlice{
+		theSlice: persistent.NewVector(s...),
+	}
+}
+
+func NewMyPersistentSliceLen(l int) *MyPersistentSlice {
+	return &MyPersistentSlice{
+		theSlice: persistent.NewVector(make([]string, l)...),
+	}
+}
+
+func (m *MyPersistentSlice) Mutable() bool {
+	return m.mutable
+}
+
+func (m *MyPersistentSlice) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theSlice.Len()
+}
+
+func (m *MyPersistentSlice) Get(i int) string {
+	return m.theSlice.Get(i)
+}
+
+func (m *MyPersistentSlice) AsMutable() *MyPersistentSlice {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *MyPersistentSlice) dup() *MyPersistentSlice {
+	res := &MyPersistentSlice{
+		theSlice: m.theSlice,
+	}
+
+	return res
+}
+
+func (m *MyPersistentSlice) AsImmutable(v *MyPersistentSlice) *MyPersistentSlice {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+func (m *MyPersistentSlice) Range() []string {
+	if m == nil {
+		return nil
+	}
+
+	return m.theSlice.ToSlice()
+}
+
+func (m *MyPersistentSlice) WithMutable(f func(mi *MyPersistentSlice)) *MyPersistentSlice {
+	res := m.AsMutable()
+	f(res)
+	res = res.AsImmutable(m)
+
+	return res
+}
+
+func (m *MyPersistentSlice) WithImmutable(f func(mi *MyPersistentSlice)) *MyPersistentSlice {
+	prev := m.mutable
+	m.mutable = false
+	f(m)
+	m.mutable = prev
+
+	return m
+}
+
+func (m *MyPersistentSlice) Set(i int, v string) *MyPersistentSlice {
+	if m.mutable {
+		m.theSlice = m.theSlice.Set(i, v)
+		return m
+	}
+
+	res := m.dup()
+	res.theSlice = res.theSlice.Set(i, v)
+
+	return res
+}
+
+func (m *MyPersistentSlice) Append(v ...string) *MyPersistentSlice {
+	if m.mutable {
+		m.theSlice = m.theSlice.Append(v...)
+		return m
+	}
+
+	res := m.dup()
+	res.theSlice = res.theSlice.Append(v...)
+
+	return res
+}
+func (s *MyPersistentSlice) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	return true
+}
+
+// Equal returns whether m and other have the same length and equal values
+// at each index
+func (m *MyPersistentSlice) Equal(other *MyPersistentSlice) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	return m.theSlice.Equal(other.theSlice, func(a, b string) bool {
+		return a == b
+	})
+}
+
+// Diff returns the changes between m and other, in index order. The path
+// of a change is the index that was added, removed or modified, or the
+// index followed by the path of a change within a modified value.
+func (m *MyPersistentSlice) Diff(other *MyPersistentSlice) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a string, aok bool, b string, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	m.theSlice.Diff(other.theSlice, func(a, b string) bool {
+		return a == b
+	}, func(i int, a string, aok bool, b string, bok bool) bool {
+		change(immutable.IndexPath(i), a, aok, b, bok)
+		return true
+	})
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a []string
+func (m *MyPersistentSlice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []string
+// into m. m is left immutable.
+func (m *MyPersistentSlice) UnmarshalJSON(b []byte) error {
+	var v []string
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *NewMyPersistentSlice(v...)
+
+	return nil
+}
+
+// a comment about MyContainerSlice
+//
+//immutableGen:container
+//
+// MyContainerSlice is an immutable type and has the following template:
+//
+// 	[]string
+//
+type MyContainerSlice struct {
+	theSlice *container.Slice[string]
+	__tmpl   *_Imm_MyContainerSlice
+}
+
+var _ immutable.Immutable = new(MyContainerSlice)
+var _ = new(MyContainerSlice).__tmpl
+
+func NewMyContainerSlice(s ...string) *MyContainerSlice {
+	return &MyContainerSlice{
+		theSlice: container.NewSlice(s...),
+	}
+}
+
+func NewMyContainerSliceLen(l int) *MyContainerSlice {
+	return &MyContainerSlice{
+		theSlice: container.NewSliceLen[string](l),
+	}
+}
+
+// wrap returns m if c is the container of m, else a new MyContainerSlice around c
+func (m *MyContainerSlice) wrap(c *container.Slice[string]) *MyContainerSlice {
+	if c == m.theSlice {
+		return m
+	}
+
+	return &MyContainerSlice{
+		theSlice: c,
+	}
+}
+
+func (m *MyContainerSlice) Mutable() bool {
+	return m.theSlice.Mutable()
+}
+
+func (m *MyContainerSlice) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theSlice.Len()
+}
+
+func (m *MyContainerSlice) Get(i int) string {
+	return m.theSlice.Get(i)
+}
+
+func (m *MyContainerSlice) AsMutable() *MyContainerSlice {
+	if m == nil {
+		return nil
+	}
+
+	return m.wrap(m.theSlice.AsMutable())
+}
+
+func (m *MyContainerSlice) AsImmutable(v *MyContainerSlice) *MyContainerSlice {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.theSlice.AsImmutable()
+	return m
+}
+
+func (m *MyContainerSlice) Range() []string {
+	if m == nil {
+		return nil
+	}
+
+	return m.theSlice.Range()
+}
+
+func (m *MyContainerSlice) WithMutable(f func(mi *MyContainerSlice)) *MyContainerSlice {
+	res := m.AsMutable()
+	f(res)
+	res = res.AsImmutable(m)
+
+	return res
+}
+
+func (m *MyContainerSlice) WithImmutable(f func(mi *MyContainerSlice)) *MyContainerSlice {
+	m.theSlice.WithImmutable(func() {
+		f(m)
+	})
+
+	return m
+}
+
+func (m *MyContainerSlice) Set(i int, v string) *MyContainerSlice {
+	return m.wrap(m.theSlice.Set(i, v))
+}
+
+func (m *MyContainerSlice) Append(v ...string) *MyContainerSlice {
+	return m.wrap(m.theSlice.Append(v...))
+}
+func (s *MyContainerSlice) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	return true
+}
+
+// Equal returns whether m and other have the same length and equal values
+// at each index
+func (m *MyContainerSlice) Equal(other *MyContainerSlice) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	return m.theSlice.Equal(other.theSlice, func(a, b string) bool {
+		return a == b
+	})
+}
+
+// Diff returns the changes between m and other, in index order. The path
+// of a change is the index that was added, removed or modified, or the
+// index followed by the path of a change within a modified value.
+func (m *MyContainerSlice) Diff(other *MyContainerSlice) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	return m.theSlice.Changes(other.theSlice, func(a, b string) bool {
+		return a == b
+	}, nil)
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a []string
+func (m *MyContainerSlice) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+
+	return m.theSlice.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []string
+// into m. m is left immutable.
+func (m *MyContainerSlice) UnmarshalJSON(b []byte) error {
+	c := new(container.Slice[string])
+	if err := c.UnmarshalJSON(b); err != nil {
+		return err
+	}
+
+	*m = MyContainerSlice{theSlice: c}
+
+	return nil
+}
+
+//
+// AS is an immutable type and has the following template:
+//
+// 	[]*A
+//
+type AS struct {
+	theSlice []*A
+	mutable  bool
+	__tmpl   *_Imm_AS
+}
+
+var _ immutable.Immutable = new(AS)
+var _ = new(AS).__tmpl
+
+func NewAS(s ...*A) *AS {
+	c := make([]*A, len(s))
+	copy(c, s)
+
+	return &AS{
+		theSlice: c,
+	}
+}
+
+func NewASLen(l int) *AS {
+	c := make([]*A, l)
+
+	return &AS{
+		theSlice: c,
+	}
+}
+
+func (m *AS) Mutable() bool {
+	return m.mutable
+}
+
+func (m *AS) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return len(m.theSlice)
+}
+
+func (m *AS) Get(i int) *A {
+	return m.theSlice[i]
+}
+
+func (m *AS) AsMutable() *AS {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *AS) dup() *AS {
+	resSlice := make([]*A, len(m.theSlice))
+
+	for i := range m.theSlice {
+		resSlice[i] = m.theSlice[i]
+	}
+
+	res := &AS{
+		theSlice: resSlice,
+	}
+
+	return res
+}
+
+func (m *AS) AsImmutable(v *AS) *AS {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+func (m *AS) Range() []*A {
+	if m == nil {
+		return nil
+	}
+
+	return m.theSlice
+}
+
+func (m *AS) WithMutable(f func(mi *AS)) *AS {
+	res := m.AsMutable()
+	f(res)
+	res = res.AsImmutable(m)
+
+	return res
+}
+
+func (m *AS) WithImmutable(f func(mi *AS)) *AS {
+	prev := m.mutable
+	m.mutable = false
+	f(m)
+	m.mutable = prev
+
+	return m
+}
+
+func (m *AS) Set(i int, v *A) *AS {
+	if m.mutable {
+		m.theSlice[i] = v
+		return m
+	}
+
+	res := m.dup()
+	res.theSlice[i] = v
+
+	return res
+}
+
+func (m *AS) Append(v ...*A) *AS {
+	if m.mutable {
+		m.theSlice = append(m.theSlice, v...)
+		return m
+	}
+
+	res := m.dup()
+	res.theSlice = append(res.theSlice, v...)
+
+	return res
+}
+func (s *AS) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	if s.Len() == 0 {
+		return true
+	}
+
+	if seen == nil {
+		return s.IsDeeplyNonMutable(make(map[interface{}]bool))
+	}
+
+	if seen[s] {
+		return true
+	}
+
+	seen[s] = true
+
+	for _, v := range s.theSlice {
+		if v != nil && !v.IsDeeplyNonMutable(seen) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns whether m and other have the same length and equal values
+// at each index
+func (m *AS) Equal(other *AS) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	if len(m.theSlice) != len(other.theSlice) {
+		return false
+	}
+
+	for i, a := range m.theSlice {
+		b := other.theSlice[i]
+		if !(a.Equal(b)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, in index order. The path
+// of a change is the index that was added, removed or modified, or the
+// index followed by the path of a change within a modified value.
+func (m *AS) Diff(other *AS) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a *A, aok bool, b *A, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		case a != nil && b != nil:
+			res = append(res, immutable.PrefixChanges(p, a.Diff(b))...)
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	for i := 0; i < len(m.theSlice) || i < len(other.theSlice); i++ {
+		var a, b *A
+		aok, bok := i < len(m.theSlice), i < len(other.theSlice)
+		if aok {
+			a = m.theSlice[i]
+		}
+		if bok {
+			b = other.theSlice[i]
+		}
+		if aok && bok && (a.Equal(b)) {
+			continue
+		}
+		change(immutable.IndexPath(i), a, aok, b, bok)
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a []*A
+func (m *AS) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []*A
+// into m. m is left immutable.
+func (m *AS) UnmarshalJSON(b []byte) error {
+	var v []*A
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *NewAS(v...)
+
+	return nil
+}
+
+//immutableGen:persistent
+//
+// APS is an immutable type and has the following template:
+//
+// 	[]*A
+//
+type APS struct {
+	theSlice *persistent.Vector[*A]
+	mutable  bool
+	__tmpl   *_Imm_APS
+}
+
+var _ immutable.Immutable = new(APS)
+var _ = new(APS).__tmpl
+
+func NewAPS(s ...*A) *APS {
+	return &APS{
+		theSlice: persistent.NewVector(s...),
+	}
+}
+
+func NewAPSLen(l int) *APS {
+	return &APS{
+		theSlice: persistent.NewVector(make([]*A, l)...),
+	}
+}
+
+func (m *APS) Mutable() bool {
+	return m.mutable
+}
+
+func (m *APS) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theSlice.Len()
+}
+
+func (m *APS) Get(i int) *A {
+	return m.theSlice.Get(i)
+}
+
+func (m *APS) AsMutable() *APS {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *APS) dup() *APS {
+	res := &APS{
+		theSlice: m.theSlice,
+	}
+
+	return res
+}
+
+func (m *APS) AsImmutable(v *APS) *APS {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+func (m *APS) Range() []*A {
+	if m == nil {
+		return nil
+	}
+
+	return m.theSlice.ToSlice()
+}
+
+func (m *APS) WithMutable(f func(mi *APS)) *APS {
+	res := m.AsMutable()
+	f(res)
+	res = res.AsImmutable(m)
+
+	return res
+}
+
+func (m *APS) WithImmutable(f func(mi *APS)) *APS {
+	prev := m.mutable
+	m.mutable = false
+	f(m)
+	m.mutable = prev
+
+	return m
+}
+
+func (m *APS) Set(i int, v *A) *APS {
+	if m.mutable {
+		m.theSlice = m.theSlice.Set(i, v)
+		return m
+	}
+
+	res := m.dup()
+	res.theSlice = res.theSlice.Set(i, v)
+
+	return res
+}
+
+func (m *APS) Append(v ...*A) *APS {
+	if m.mutable {
+		m.theSlice = m.theSlice.Append(v...)
+		return m
+	}
+
+	res := m.dup()
+	res.theSlice = res.theSlice.Append(v...)
+
+	return res
+}
+func (s *APS) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	if s.Len() == 0 {
+		return true
+	}
+
+	if seen == nil {
+		return s.IsDeeplyNonMutable(make(map[interface{}]bool))
+	}
+
+	if seen[s] {
+		return true
+	}
+
+	seen[s] = true
+
+	deep := true
+	s.theSlice.Each(func(_ int, v *A) bool {
+		if v != nil && !v.IsDeeplyNonMutable(seen) {
+			deep = false
+		}
+		return deep
+	})
+
+	if !deep {
+		return false
+	}
+	return true
+}
+
+// Equal returns whether m and other have the same length and equal values
+// at each index
+func (m *APS) Equal(other *APS) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	return m.theSlice.Equal(other.theSlice, func(a, b *A) bool {
+		return a.Equal(b)
+	})
+}
+
+// Diff returns the changes between m and other, in index order. The path
+// of a change is the index that was added, removed or modified, or the
+// index followed by the path of a change within a modified value.
+func (m *APS) Diff(other *APS) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a *A, aok bool, b *A, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		case a != nil && b != nil:
+			res = append(res, immutable.PrefixChanges(p, a.Diff(b))...)
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	m.theSlice.Diff(other.theSlice, func(a, b *A) bool {
+		return a.Equal(b)
+	}, func(i int, a *A, aok bool, b *A, bok bool) bool {
+		change(immutable.IndexPath(i), a, aok, b, bok)
+		return true
+	})
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a []*A
+func (m *APS) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []*A
+// into m. m is left immutable.
+func (m *APS) UnmarshalJSON(b []byte) error {
+	var v []*A
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *NewAPS(v...)
+
+	return nil
+}
+
+//immutableGen:container
+//
+// ACS is an immutable type and has the following template:
+//
+// 	[]*A
+//
+type ACS struct {
+	theSlice *container.Slice[*A]
+	__tmpl   *_Imm_ACS
+}
+
+var _ immutable.Immutable = new(ACS)
+var _ = new(ACS).__tmpl
+
+func NewACS(s ...*A) *ACS {
+	return &ACS{
+		theSlice: container.NewSlice(s...),
+	}
+}
+
+func NewACSLen(l int) *ACS {
+	return &ACS{
+		theSlice: container.NewSliceLen[*A](l),
+	}
+}
+
+// wrap returns m if c is the container of m, else a new ACS around c
+func (m *ACS) wrap(c *container.Slice[*A]) *ACS {
+	if c == m.theSlice {
+		return m
+	}
+
+	return &ACS{
+		theSlice: c,
+	}
+}
+
+func (m *ACS) Mutable() bool {
+	return m.theSlice.Mutable()
+}
+
+func (m *ACS) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theSlice.Len()
+}
+
+func (m *ACS) Get(i int) *A {
+	return m.theSlice.Get(i)
+}
+
+func (m *ACS) AsMutable() *ACS {
+	if m == nil {
+		return nil
+	}
+
+	return m.wrap(m.theSlice.AsMutable())
+}
+
+func (m *ACS) AsImmutable(v *ACS) *ACS {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.theSlice.AsImmutable()
+	return m
+}
+
+func (m *ACS) Range() []*A {
+	if m == nil {
+		return nil
+	}
+
+	return m.theSlice.Range()
+}
+
+func (m *ACS) WithMutable(f func(mi *ACS)) *ACS {
+	res := m.AsMutable()
+	f(res)
+	res = res.AsImmutable(m)
+
+	return res
+}
+
+func (m *ACS) WithImmutable(f func(mi *ACS)) *ACS {
+	m.theSlice.WithImmutable(func() {
+		f(m)
+	})
+
+	return m
+}
+
+func (m *ACS) Set(i int, v *A) *ACS {
+	return m.wrap(m.theSlice.Set(i, v))
+}
+
+func (m *ACS) Append(v ...*A) *ACS {
+	return m.wrap(m.theSlice.Append(v...))
+}
+func (s *ACS) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	if s.Len() == 0 {
+		return true
+	}
+
+	if seen == nil {
+		return s.IsDeeplyNonMutable(make(map[interface{}]bool))
+	}
+
+	if seen[s] {
+		return true
+	}
+
+	seen[s] = true
+
+	for _, v := range s.theSlice.Range() {
+		if v != nil && !v.IsDeeplyNonMutable(seen) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns whether m and other have the same length and equal values
+// at each index
+func (m *ACS) Equal(other *ACS) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	return m.theSlice.Equal(other.theSlice, func(a, b *A) bool {
+		return a.Equal(b)
+	})
+}
+
+// Diff returns the changes between m and other, in index order. The path
+// of a change is the index that was added, removed or modified, or the
+// index followed by the path of a change within a modified value.
+func (m *ACS) Diff(other *ACS) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	return m.theSlice.Changes(other.theSlice, func(a, b *A) bool {
+		return a.Equal(b)
+	}, func(a, b *A) []immutable.Change {
+		if a == nil || b == nil {
+			return nil
+		}
+
+		return a.Diff(b)
+	})
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a []*A
+func (m *ACS) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+
+	return m.theSlice.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []*A
+// into m. m is left immutable.
+func (m *ACS) UnmarshalJSON(b []byte) error {
+	c := new(container.Slice[*A])
+	if err := c.UnmarshalJSON(b); err != nil {
+		return err
+	}
+
+	*m = ACS{theSlice: c}
+
+	return nil
+}
+
+//
+// Tagged is an immutable type and has the following template:
+//
+// 	struct {
+// 		Name	string
+// 		Tags	*MySet
+// 	}
+//
+type Tagged struct {
+	field_Name string
+	field_Tags *MySet
+
+	mutable bool
+	__tmpl  *_Imm_Tagged
+}
+
+var _ immutable.Immutable = new(Tagged)
+var _ = new(Tagged).__tmpl
+
+func (s *Tagged) AsMutable() *Tagged {
+	if s.Mutable() {
+		return s
+	}
+
+	res := *s
+	res.mutable = true
+	return &res
+}
+
+func (s *Tagged) AsImmutable(v *Tagged) *Tagged {
+	if s == nil {
+		return nil
+	}
+
+	if s == v {
+		return s
+	}
+
+	s.mutable = false
+	return s
+}
+
+func (s *Tagged) Mutable() bool {
+	return s.mutable
+}
+
+func (s *Tagged) WithMutable(f func(si *Tagged)) *Tagged {
+	res := s.AsMutable()
+	f(res)
+	res = res.AsImmutable(s)
+
+	return res
+}
+
+func (s *Tagged) WithImmutable(f func(si *Tagged)) *Tagged {
+	prev := s.mutable
+	s.mutable = false
+	f(s)
+	s.mutable = prev
+
+	return s
+}
+
+func (s *Tagged) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+
+	if seen == nil {
+		return s.IsDeeplyNonMutable(make(map[interface{}]bool))
+	}
+
+	if seen[s] {
+		return true
+	}
+
+	seen[s] = true
+	{
+		v := s.field_Tags
+
+		if v != nil && !v.IsDeeplyNonMutable(seen) {
+			return false
+		}
+	}
+	return true
+}
+func (s *Tagged) Name() string {
+	return s.field_Name
+}
+
+// SetName is the setter for Name()
+func (s *Tagged) SetName(n string) *Tagged {
+	if s.mutable {
+		s.field_Name = n
+		return s
+	}
+
+	res := *s
+	res.field_Name = n
+	return &res
+}
+func (s *Tagged) Tags() *MySet {
+	return s.field_Tags
+}
+
+// SetTags is the setter for Tags()
+func (s *Tagged) SetTags(n *MySet) *Tagged {
+	if s.mutable {
+		s.field_Tags = n
+		return s
+	}
+
+	res := *s
+	res.field_Tags = n
+	return &res
+}
+
+// Equal returns whether the fields of s and other are equal
+func (s *Tagged) Equal(other *Tagged) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_Name == other.field_Name) {
+		return false
+	}
+
+	if !(s.field_Tags.Equal(other.field_Tags)) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *Tagged) Diff(other *Tagged) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_Name == other.field_Name) {
+		res = append(res, immutable.Change{Path: ".Name", Kind: immutable.ChangeModified, Old: s.field_Name, New: other.field_Name})
+	}
+
+	if !(s.field_Tags.Equal(other.field_Tags)) {
+		if s.field_Tags != nil && other.field_Tags != nil {
+			res = append(res, immutable.PrefixChanges(".Tags", s.field_Tags.Diff(other.field_Tags))...)
+		} else {
+			res = append(res, immutable.Change{Path: ".Tags", Kind: immutable.ChangeModified, Old: s.field_Tags, New: other.field_Tags})
+		}
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *Tagged) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		Name string
+		Tags *MySet
+	}{
+		Name: s.field_Name,
+		Tags: s.field_Tags,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *Tagged) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Name string
+		Tags *MySet
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = Tagged{
+		field_Name: v.Name,
+		field_Tags: v.Tags,
+	}
+
+	return nil
+}
+
+// a comment about myStruct
+//
+// MyStruct is an immutable type and has the following template:
+//
+// 	struct {
+// 		Key	MyStructKey
+//
+// 		Name, surname	string
+// 		age		int
+//
+// 		string
+//
+// 		fieldWithoutTag	bool
+// 	}
+//
+type MyStruct struct {
+	field_Key             MyStructKey
+	field_Name            string `tag:"value"`
+	field_surname         string `tag:"value"`
+	field_age             int    `tag:"age"`
+	anonfield_string      string
+	field_fieldWithoutTag bool
+
+	mutable bool
+	__tmpl  *_Imm_MyStruct
+}
+
+var _ immutable.Immutable = new(MyStruct)
+var _ = new(MyStruct).__tmpl
+
+func (s *MyStruct) AsMutable() *MyStruct {
+	if s.Mutable() {
+		return s
+	}
+
+	res := *s
+	res.field_Key.Version++
+	res.mutable = true
+	return &res
+}
+
+func (s *MyStruct) AsImmutable(v *MyStruct) *MyStruct {
+	if s == nil {
+		return nil
+	}
+
+	if s == v {
+		return s
+	}
+
+	s.mutable = false
+	return s
+}
+
+func (s *MyStruct) Mutable() bool {
+	return s.mutable
+}
+
+func (s *MyStruct) WithMutable(f func(si *MyStruct)) *MyStruct {
+	res := s.AsMutable()
+	f(res)
+	res = res.AsImmutable(s)
+
+	return res
+}
+
+func (s *MyStruct) WithImmutable(f func(si *MyStruct)) *MyStruct {
+	prev := s.mutable
+	s.mutable = false
+	f(s)
+	s.mutable = prev
+
+	return s
+}
+
+func (s *MyStruct) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+
+	if seen == nil {
+		return s.IsDeeplyNonMutable(make(map[interface{}]bool))
+	}
+
+	if seen[s] {
+		return true
+	}
+
+	seen[s] = true
+	return true
+}
+func (s *MyStruct) Key() MyStructKey {
+	return s.field_Key
+}
+
+// SetKey is the setter for Key()
+func (s *MyStruct) SetKey(n MyStructKey) *MyStruct {
+	if s.mutable {
+		s.field_Key = n
+		return s
+	}
+
+	res := *s
+	res.field_Key.Version++
+	res.field_Key = n
+	return &res
+}
+
+// my field comment
+//somethingspecial
+/*
+
+	Heelo
+
+*/
+func (s *MyStruct) Name() string {
+	return s.field_Name
+}
+
+// SetName is the setter for Name()
+func (s *MyStruct) SetName(n string) *MyStruct {
+	if s.mutable {
+		s.field_Name = n
+		return s
+	}
+
+	res := *s
+	res.field_Key.Version++
+	res.field_Name = n
+	return &res
+}
+func (s *MyStruct) age() int {
+	return s.field_age
+}
+
+// setAge is the setter for Age()
+func (s *MyStruct) setAge(n int) *MyStruct {
+	if s.mutable {
+		s.field_age = n
+		return s
+	}
+
+	res := *s
+	res.field_Key.Version++
+	res.field_age = n
+	return &res
+}
+func (s *MyStruct) fieldWithoutTag() bool {
+	return s.field_fieldWithoutTag
+}
+
+// setFieldWithoutTag is the setter for FieldWithoutTag()
+func (s *MyStruct) setFieldWithoutTag(n bool) *MyStruct {
+	if s.mutable {
+		s.field_fieldWithoutTag = n
+		return s
+	}
+
+	res := *s
+	res.field_Key.Version++
+	res.field_fieldWithoutTag = n
+	return &res
+}
+func (s *MyStruct) string() string {
+	return s.anonfield_string
+}
+
+// setString is the setter for String()
+func (s *MyStruct) setString(n string) *MyStruct {
+	if s.mutable {
+		s.anonfield_string = n
+		return s
+	}
+
+	res := *s
+	res.field_Key.Version++
+	res.anonfield_string = n
+	return &res
+}
+
+// my field comment
+//somethingspecial
+/*
+
+	Heelo
+
+*/
+func (s *MyStruct) surname() string {
+	return s.field_surname
+}
+
+// setSurname is the setter for Surname()
+func (s *MyStruct) setSurname(n string) *MyStruct {
+	if s.mutable {
+		s.field_surname = n
+		return s
+	}
+
+	res := *s
+	res.field_Key.Version++
+	res.field_surname = n
+	return &res
+}
+
+// Equal returns whether the fields of s and other are equal
+func (s *MyStruct) Equal(other *MyStruct) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_Key == other.field_Key) {
+		return false
+	}
+
+	if !(s.field_Name == other.field_Name) {
+		return false
+	}
+
+	if !(s.field_surname == other.field_surname) {
+		return false
+	}
+
+	if !(s.field_age == other.field_age) {
+		return false
+	}
+
+	if !(s.anonfield_string == other.anonfield_string) {
+		return false
+	}
+
+	if !(s.field_fieldWithoutTag == other.field_fieldWithoutTag) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *MyStruct) Diff(other *MyStruct) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_Key == other.field_Key) {
+		res = append(res, immutable.Change{Path: ".Key", Kind: immutable.ChangeModified, Old: s.field_Key, New: other.field_Key})
+	}
+
+	if !(s.field_Name == other.field_Name) {
+		res = append(res, immutable.Change{Path: ".Name", Kind: immutable.ChangeModified, Old: s.field_Name, New: other.field_Name})
+	}
+
+	if !(s.field_surname == other.field_surname) {
+		res = append(res, immutable.Change{Path: ".surname", Kind: immutable.ChangeModified, Old: s.field_surname, New: other.field_surname})
+	}
+
+	if !(s.field_age == other.field_age) {
+		res = append(res, immutable.Change{Path: ".age", Kind: immutable.ChangeModified, Old: s.field_age, New: other.field_age})
+	}
+
+	if !(s.anonfield_string == other.anonfield_string) {
+		res = append(res, immutable.Change{Path: ".string", Kind: immutable.ChangeModified, Old: s.anonfield_string, New: other.anonfield_string})
+	}
+
+	if !(s.field_fieldWithoutTag == other.field_fieldWithoutTag) {
+		res = append(res, immutable.Change{Path: ".fieldWithoutTag", Kind: immutable.ChangeModified, Old: s.field_fieldWithoutTag, New: other.field_fieldWithoutTag})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *MyStruct) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		Key  MyStructKey
+		Name string `tag:"value"`
+	}{
+		Key:  s.field_Key,
+		Name: s.field_Name,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *MyStruct) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Key  MyStructKey
+		Name string `tag:"value"`
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = MyStruct{
+		field_Key:  v.Key,
+		field_Name: v.Name,
+	}
+
+	return nil
+}
+
+//
+// MyJSONStruct is an immutable type and has the following template:
+//
+// 	struct {
+// 		Name	string
+// 		Age	int
+// 		Ignored	string
+// 		hidden	string
+//
+// 		Slice	*MySlice
+// 		Map	*MyPersistentMap
+// 		Other	*MyPersistentSlice
+// 		*Embed2
+// 	}
+//
+type MyJSONStruct struct {
+	field_Name       string
+	field_Age        int
+	field_Ignored    string
+	field_hidden     string
+	field_Slice      *MySlice
+	field_Map        *MyPersistentMap
+	field_Other      *MyPersistentSlice
+	anonfield_Embed2 *Embed2
+
+	mutable bool
+	__tmpl  *_Imm_MyJSONStruct
+}
+
+var _ immutable.Immutable = new(MyJSONStruct)
+var _ = new(MyJSONStruct).__tmpl
+
+func (s *MyJSONStruct) AsMutable() *MyJSONStruct {
+	if s.Mutable() {
+		return s
+	}
+
+	res := *s
+	res.mutable = true
+	return &res
+}
+
+func (s *MyJSONStruct) AsImmutable(v *MyJSONStruct) *MyJSONStruct {
+	if s == nil {
+		return nil
+	}
+
+	if s == v {
+		return s
+	}
+
+	s.mutable = false
+	return s
+}
+
+func (s *MyJSONStruct) Mutable() bool {
+	return s.mutable
+}
+
+func (s *MyJSONStruct) WithMutable(f func(si *MyJSONStruct)) *MyJSONStruct {
+	res := s.AsMutable()
+	f(res)
+	res = res.AsImmutable(s)
+
+	return res
+}
+
+func (s *MyJSONStruct) WithImmutable(f func(si *MyJSONStruct)) *MyJSONStruct {
+	prev := s.mutable
+	s.mutable = false
+	f(s)
+	s.mutable = prev
+
+	return s
+}
+
+func (s *MyJSONStruct) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+
+	if seen == nil {
+		return s.IsDeeplyNonMutable(make(map[interface{}]bool))
+	}
+
+	if seen[s] {
+		return true
+	}
+
+	seen[s] = true
+	{
+		v := s.field_Slice
+
+		if v != nil && !v.IsDeeplyNonMutable(seen) {
+			return false
+		}
+	}
+	{
+		v := s.field_Map
+
+		if v != nil && !v.IsDeeplyNonMutable(seen) {
+			return false
+		}
+	}
+	{
+		v := s.field_Other
+
+		if v != nil && !v.IsDeeplyNonMutable(seen) {
+			return false
+		}
+	}
+	{
+		v := s.anonfield_Embed2
+
+		if v != nil && !v.IsDeeplyNonMutable(seen) {
+			return false
+		}
+	}
+	return true
+}
+func (s *MyJSONStruct) Age() int {
+	return s.field_Age
+}
+
+// SetAge is the setter for Age()
+func (s *MyJSONStruct) SetAge(n int) *MyJSONStruct {
+	if s.mutable {
+		s.field_Age = n
+		return s
+	}
+
+	res := *s
+	res.field_Age = n
+	return &res
+}
+func (s *MyJSONStruct) Embed2() *Embed2 {
+	return s.anonfield_Embed2
+}
+
+// SetEmbed2 is the setter for Embed2()
+func (s *MyJSONStruct) SetEmbed2(n *Embed2) *MyJSONStruct {
+	if s.mutable {
+		s.anonfield_Embed2 = n
+		return s
+	}
+
+	res := *s
+	res.anonfield_Embed2 = n
+	return &res
 }
-
-func (m *AS) Get(i int) *A {
-	return m.theSlice[i]
+func (s *MyJSONStruct) Ignored() string {
+	return s.field_Ignored
 }
 
-func (m *AS) AsMutable() *AS {
-	if m == nil {
-		return nil
+// SetIgnored is the setter for Ignored()
+func (s *MyJSONStruct) SetIgnored(n string) *MyJSONStruct {
+	if s.mutable {
+		s.field_Ignored = n
+		return s
 	}
 
-	if m.Mutable() {
-		return m
+	res := *s
+	res.field_Ignored = n
+	return &res
+}
+func (s *MyJSONStruct) Map() *MyPersistentMap {
+	return s.field_Map
+}
+
+// SetMap is the setter for Map()
+func (s *MyJSONStruct) SetMap(n *MyPersistentMap) *MyJSONStruct {
+	if s.mutable {
+		s.field_Map = n
+		return s
 	}
 
-	res := m.dup()
-	res.mutable = true
+	res := *s
+	res.field_Map = n
+	return &res
+}
+func (s *MyJSONStruct) Name() string {
+	return s.field_Name
+}
 
-	return res
+// SetName is the setter for Name()
+func (s *MyJSONStruct) SetName(n string) *MyJSONStruct {
+	if s.mutable {
+		s.field_Name = n
+		return s
+	}
+
+	res := *s
+	res.field_Name = n
+	return &res
+}
+func (s *MyJSONStruct) Other() *MyPersistentSlice {
+	return s.field_Other
 }
 
-func (m *AS) dup() *AS {
-	resSlice := make([]*A, len(m.theSlice))
+// SetOther is the setter for Other()
+func (s *MyJSONStruct) SetOther(n *MyPersistentSlice) *MyJSONStruct {
+	if s.mutable {
+		s.field_Other = n
+		return s
+	}
 
-	for i := range m.theSlice {
-		resSlice[i] = m.theSlice[i]
+	res := *s
+	res.field_Other = n
+	return &res
+}
+func (s *MyJSONStruct) Slice() *MySlice {
+	return s.field_Slice
+}
+
+// SetSlice is the setter for Slice()
+func (s *MyJSONStruct) SetSlice(n *MySlice) *MyJSONStruct {
+	if s.mutable {
+		s.field_Slice = n
+		return s
 	}
 
-	res := &AS{
-		theSlice: resSlice,
+	res := *s
+	res.field_Slice = n
+	return &res
+}
+func (s *MyJSONStruct) hidden() string {
+	return s.field_hidden
+}
+
+// setHidden is the setter for Hidden()
+func (s *MyJSONStruct) setHidden(n string) *MyJSONStruct {
+	if s.mutable {
+		s.field_hidden = n
+		return s
 	}
 
-	return res
+	res := *s
+	res.field_hidden = n
+	return &res
+}
+func (s *MyJSONStruct) otherdetails() string {
+	return s.Embed2().otherdetails()
+}
+func (s *MyJSONStruct) setOtherdetails(n string) *MyJSONStruct {
+	v1 := s.Embed2().setOtherdetails(n)
+	v0 := s.SetEmbed2(v1)
+	return v0
 }
 
-func (m *AS) AsImmutable(v *AS) *AS {
-	if m == nil {
-		return nil
+// Equal returns whether the fields of s and other are equal
+func (s *MyJSONStruct) Equal(other *MyJSONStruct) bool {
+	if s == other {
+		return true
 	}
 
-	if v == m {
-		return m
+	if s == nil || other == nil {
+		return false
 	}
 
-	m.mutable = false
-	return m
+	if !(s.field_Name == other.field_Name) {
+		return false
+	}
+
+	if !(s.field_Age == other.field_Age) {
+		return false
+	}
+
+	if !(s.field_Ignored == other.field_Ignored) {
+		return false
+	}
+
+	if !(s.field_hidden == other.field_hidden) {
+		return false
+	}
+
+	if !(s.field_Slice.Equal(other.field_Slice)) {
+		return false
+	}
+
+	if !(s.field_Map.Equal(other.field_Map)) {
+		return false
+	}
+
+	if !(s.field_Other.Equal(other.field_Other)) {
+		return false
+	}
+
+	if !(s.anonfield_Embed2.Equal(other.anonfield_Embed2)) {
+		return false
+	}
+
+	return true
 }
 
-func (m *AS) Range() []*A {
-	if m == nil {
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *MyJSONStruct) Diff(other *MyJSONStruct) []immutable.Change {
+	if s == other {
 		return nil
 	}
 
-	return m.theSlice
-}
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
 
-func (m *AS) WithMutable(f func(mi *AS)) *AS {
-	res := m.AsMutable()
-	f(res)
-	res = res.AsImmutable(m)
+	var res []immutable.Change
 
-	return res
-}
+	if !(s.field_Name == other.field_Name) {
+		res = append(res, immutable.Change{Path: ".Name", Kind: immutable.ChangeModified, Old: s.field_Name, New: other.field_Name})
+	}
 
-func (m *AS) WithImmutable(f func(mi *AS)) *AS {
-	prev := m.mutable
-	m.mutable = false
-	f(m)
-	m.mutable = prev
+	if !(s.field_Age == other.field_Age) {
+		res = append(res, immutable.Change{Path: ".Age", Kind: immutable.ChangeModified, Old: s.field_Age, New: other.field_Age})
+	}
 
-	return m
-}
+	if !(s.field_Ignored == other.field_Ignored) {
+		res = append(res, immutable.Change{Path: ".Ignored", Kind: immutable.ChangeModified, Old: s.field_Ignored, New: other.field_Ignored})
+	}
 
-func (m *AS) Set(i int, v *A) *AS {
-	if m.mutable {
-		m.theSlice[i] = v
-		return m
+	if !(s.field_hidden == other.field_hidden) {
+		res = append(res, immutable.Change{Path: ".hidden", Kind: immutable.ChangeModified, Old: s.field_hidden, New: other.field_hidden})
 	}
 
-	res := m.dup()
-	res.theSlice[i] = v
+	if !(s.field_Slice.Equal(other.field_Slice)) {
+		if s.field_Slice != nil && other.field_Slice != nil {
+			res = append(res, immutable.PrefixChanges(".Slice", s.field_Slice.Diff(other.field_Slice))...)
+		} else {
+			res = append(res, immutable.Change{Path: ".Slice", Kind: immutable.ChangeModified, Old: s.field_Slice, New: other.field_Slice})
+		}
+	}
 
-	return res
-}
+	if !(s.field_Map.Equal(other.field_Map)) {
+		if s.field_Map != nil && other.field_Map != nil {
+			res = append(res, immutable.PrefixChanges(".Map", s.field_Map.Diff(other.field_Map))...)
+		} else {
+			res = append(res, immutable.Change{Path: ".Map", Kind: immutable.ChangeModified, Old: s.field_Map, New: other.field_Map})
+		}
+	}
 
-func (m *AS) Append(v ...*A) *AS {
-	if m.mutable {
-		m.theSlice = append(m.theSlice, v...)
-		return m
+	if !(s.field_Other.Equal(other.field_Other)) {
+		if s.field_Other != nil && other.field_Other != nil {
+			res = append(res, immutable.PrefixChanges(".Other", s.field_Other.Diff(other.field_Other))...)
+		} else {
+			res = append(res, immutable.Change{Path: ".Other", Kind: immutable.ChangeModified, Old: s.field_Other, New: other.field_Other})
+		}
 	}
 
-	res := m.dup()
-	res.theSlice = append(res.theSlice, v...)
+	if !(s.anonfield_Embed2.Equal(other.anonfield_Embed2)) {
+		if s.anonfield_Embed2 != nil && other.anonfield_Embed2 != nil {
+			res = append(res, immutable.PrefixChanges(".Embed2", s.anonfield_Embed2.Diff(other.anonfield_Embed2))...)
+		} else {
+			res = append(res, immutable.Change{Path: ".Embed2", Kind: immutable.ChangeModified, Old: s.anonfield_Embed2, New: other.anonfield_Embed2})
+		}
+	}
 
 	return res
 }
-func (s *AS) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *MyJSONStruct) MarshalJSON() ([]byte, error) {
 	if s == nil {
-		return true
+		return []byte("null"), nil
 	}
 
-	if s.Mutable() {
-		return false
-	}
-	if s.Len() == 0 {
-		return true
+	v := struct {
+		Name    string             `json:"name"`
+		Age     int                `json:"age,omitempty"`
+		Ignored string             `json:"-"`
+		Slice   *MySlice           `json:"slice"`
+		Map     *MyPersistentMap   `json:"map"`
+		Other   *MyPersistentSlice `json:"other,omitempty"`
+		Embed2  *Embed2
+	}{
+		Name:    s.field_Name,
+		Age:     s.field_Age,
+		Ignored: s.field_Ignored,
+		Slice:   s.field_Slice,
+		Map:     s.field_Map,
+		Other:   s.field_Other,
+		Embed2:  s.anonfield_Embed2,
 	}
 
-	if seen == nil {
-		return s.IsDeeplyNonMutable(make(map[interface{}]bool))
-	}
+	return json.Marshal(v)
+}
 
-	if seen[s] {
-		return true
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *MyJSONStruct) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Name    string             `json:"name"`
+		Age     int                `json:"age,omitempty"`
+		Ignored string             `json:"-"`
+		Slice   *MySlice           `json:"slice"`
+		Map     *MyPersistentMap   `json:"map"`
+		Other   *MyPersistentSlice `json:"other,omitempty"`
+		Embed2  *Embed2
 	}
 
-	seen[s] = true
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
 
-	for _, v := range s.theSlice {
-		if v != nil && !v.IsDeeplyNonMutable(seen) {
-			return false
-		}
+	*s = MyJSONStruct{
+		field_Name:       v.Name,
+		field_Age:        v.Age,
+		field_Ignored:    v.Ignored,
+		field_Slice:      v.Slice,
+		field_Map:        v.Map,
+		field_Other:      v.Other,
+		anonfield_Embed2: v.Embed2,
 	}
-	return true
+
+	return nil
 }
 
-// a comment about myStruct
+//immutableGen:builder
 //
-// MyStruct is an immutable type and has the following template:
+// MyRequired is an immutable type and has the following template:
 //
 // 	struct {
-// 		Key	MyStructKey
-//
-// 		Name, surname	string
-// 		age		int
-//
-// 		string
-//
-// 		fieldWithoutTag	bool
+// 		Name	string
+// 		Age	int
 // 	}
 //
-type MyStruct struct {
-	field_Key             MyStructKey
-	field_Name            string `tag:"value"`
-	field_surname         string `tag:"value"`
-	field_age             int    `tag:"age"`
-	anonfield_string      string
-	field_fieldWithoutTag bool
+type MyRequired struct {
+	field_Name string `immutableGen:"required"`
+	field_Age  int
 
 	mutable bool
-	__tmpl  *_Imm_MyStruct
+	__tmpl  *_Imm_MyRequired
 }
 
-var _ immutable.Immutable = new(MyStruct)
-var _ = new(MyStruct).__tmpl
+var _ immutable.Immutable = new(MyRequired)
+var _ = new(MyRequired).__tmpl
 
-func (s *MyStruct) AsMutable() *MyStruct {
+func (s *MyRequired) AsMutable() *MyRequired {
 	if s.Mutable() {
 		return s
 	}
 
 	res := *s
-	res.field_Key.Version++
 	res.mutable = true
 	return &res
 }
 
-func (s *MyStruct) AsImmutable(v *MyStruct) *MyStruct {
+func (s *MyRequired) AsImmutable(v *MyRequired) *MyRequired {
 	if s == nil {
 		return nil
 	}
@@ -718,11 +6053,11 @@ func (s *MyStruct) AsImmutable(v *MyStruct) *MyStruct {
 	return s
 }
 
-func (s *MyStruct) Mutable() bool {
+func (s *MyRequired) Mutable() bool {
 	return s.mutable
 }
 
-func (s *MyStruct) WithMutable(f func(si *MyStruct)) *MyStruct {
+func (s *MyRequired) WithMutable(f func(si *MyRequired)) *MyRequired {
 	res := s.AsMutable()
 	f(res)
 	res = res.AsImmutable(s)
@@ -730,7 +6065,7 @@ func (s *MyStruct) WithMutable(f func(si *MyStruct)) *MyStruct {
 	return res
 }
 
-func (s *MyStruct) WithImmutable(f func(si *MyStruct)) *MyStruct {
+func (s *MyRequired) WithImmutable(f func(si *MyRequired)) *MyRequired {
 	prev := s.mutable
 	s.mutable = false
 	f(s)
@@ -739,7 +6074,7 @@ func (s *MyStruct) WithImmutable(f func(si *MyStruct)) *MyStruct {
 	return s
 }
 
-func (s *MyStruct) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+func (s *MyRequired) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	if s == nil {
 		return true
 	}
@@ -759,117 +6094,190 @@ func (s *MyStruct) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	seen[s] = true
 	return true
 }
-func (s *MyStruct) Key() MyStructKey {
-	return s.field_Key
+func (s *MyRequired) Age() int {
+	return s.field_Age
 }
 
-// SetKey is the setter for Key()
-func (s *MyStruct) SetKey(n MyStructKey) *MyStruct {
+// SetAge is the setter for Age()
+func (s *MyRequired) SetAge(n int) *MyRequired {
 	if s.mutable {
-		s.field_Key = n
+		s.field_Age = n
 		return s
 	}
 
 	res := *s
-	res.field_Key.Version++
-	res.field_Key = n
+	res.field_Age = n
 	return &res
 }
+func (s *MyRequired) Name() string {
+	return s.field_Name
+}
 
-// my field comment
-//somethingspecial
-/*
+// SetName is the setter for Name()
+func (s *MyRequired) SetName(n string) *MyRequired {
+	if s.mutable {
+		s.field_Name = n
+		return s
+	}
 
-	Heelo
+	res := *s
+	res.field_Name = n
+	return &res
+}
 
-*/
-func (s *MyStruct) Name() string {
-	return s.field_Name
+// Equal returns whether the fields of s and other are equal
+func (s *MyRequired) Equal(other *MyRequired) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_Name == other.field_Name) {
+		return false
+	}
+
+	if !(s.field_Age == other.field_Age) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *MyRequired) Diff(other *MyRequired) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_Name == other.field_Name) {
+		res = append(res, immutable.Change{Path: ".Name", Kind: immutable.ChangeModified, Old: s.field_Name, New: other.field_Name})
+	}
+
+	if !(s.field_Age == other.field_Age) {
+		res = append(res, immutable.Change{Path: ".Age", Kind: immutable.ChangeModified, Old: s.field_Age, New: other.field_Age})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *MyRequired) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		Name string `immutableGen:"required"`
+		Age  int
+	}{
+		Name: s.field_Name,
+		Age:  s.field_Age,
+	}
+
+	return json.Marshal(v)
 }
 
-// SetName is the setter for Name()
-func (s *MyStruct) SetName(n string) *MyStruct {
-	if s.mutable {
-		s.field_Name = n
-		return s
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *MyRequired) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Name string `immutableGen:"required"`
+		Age  int
 	}
 
-	res := *s
-	res.field_Key.Version++
-	res.field_Name = n
-	return &res
-}
-func (s *MyStruct) age() int {
-	return s.field_age
-}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
 
-// setAge is the setter for Age()
-func (s *MyStruct) setAge(n int) *MyStruct {
-	if s.mutable {
-		s.field_age = n
-		return s
+	*s = MyRequired{
+		field_Name: v.Name,
+		field_Age:  v.Age,
 	}
 
-	res := *s
-	res.field_Key.Version++
-	res.field_age = n
-	return &res
-}
-func (s *MyStruct) fieldWithoutTag() bool {
-	return s.field_fieldWithoutTag
+	return nil
 }
 
-// setFieldWithoutTag is the setter for FieldWithoutTag()
-func (s *MyStruct) setFieldWithoutTag(n bool) *MyStruct {
-	if s.mutable {
-		s.field_fieldWithoutTag = n
-		return s
-	}
+// MyRequiredBuilder builds a MyRequired using fluent setters. Create a
+// MyRequiredBuilder using NewMyRequiredBuilder.
+type MyRequiredBuilder struct {
+	v *MyRequired
 
-	res := *s
-	res.field_Key.Version++
-	res.field_fieldWithoutTag = n
-	return &res
+	// set records which of the required fields have been set
+	set map[string]bool
 }
-func (s *MyStruct) string() string {
-	return s.anonfield_string
+
+// NewMyRequiredBuilder returns a new MyRequiredBuilder
+func NewMyRequiredBuilder() *MyRequiredBuilder {
+	return &MyRequiredBuilder{
+		v:   new(MyRequired).AsMutable(),
+		set: make(map[string]bool),
+	}
 }
 
-// setString is the setter for String()
-func (s *MyStruct) setString(n string) *MyStruct {
-	if s.mutable {
-		s.anonfield_string = n
-		return s
+// Build returns the immutable MyRequired built by b. Build panics if any of
+// the required fields of MyRequired have not been set. b can continue
+// to be used after a call to Build; values already built are unaffected.
+func (b *MyRequiredBuilder) Build() *MyRequired {
+	if !b.set["Name"] {
+		panic("MyRequiredBuilder: required field Name has not been set")
 	}
+	b.v = b.v.AsImmutable(nil)
+	return b.v
+}
 
-	res := *s
-	res.field_Key.Version++
-	res.anonfield_string = n
-	return &res
+// SetAge sets Age() of the MyRequired being built
+func (b *MyRequiredBuilder) SetAge(n int) *MyRequiredBuilder {
+	b.v = b.v.SetAge(n)
+	return b
 }
 
-// my field comment
-//somethingspecial
-/*
+// SetName sets Name() of the MyRequired being built
+func (b *MyRequiredBuilder) SetName(n string) *MyRequiredBuilder {
+	b.v = b.v.SetName(n)
+	b.set["Name"] = true
+	return b
+}
 
-	Heelo
+// MyRequiredOption is a functional option that configures the MyRequired
+// constructed by NewMyRequired
+type MyRequiredOption func(b *MyRequiredBuilder)
 
-*/
-func (s *MyStruct) surname() string {
-	return s.field_surname
+// NewMyRequired returns a new immutable MyRequired configured by opts.
+// NewMyRequired panics if any of the required fields of MyRequired are
+// not set by opts.
+func NewMyRequired(opts ...MyRequiredOption) *MyRequired {
+	b := NewMyRequiredBuilder()
+	for _, o := range opts {
+		o(b)
+	}
+	return b.Build()
 }
 
-// setSurname is the setter for Surname()
-func (s *MyStruct) setSurname(n string) *MyStruct {
-	if s.mutable {
-		s.field_surname = n
-		return s
+// MyRequiredWithAge returns a MyRequiredOption that sets Age()
+func MyRequiredWithAge(n int) MyRequiredOption {
+	return func(b *MyRequiredBuilder) {
+		b.SetAge(n)
 	}
+}
 
-	res := *s
-	res.field_Key.Version++
-	res.field_surname = n
-	return &res
+// MyRequiredWithName returns a MyRequiredOption that sets Name()
+func MyRequiredWithName(n string) MyRequiredOption {
+	return func(b *MyRequiredBuilder) {
+		b.SetName(n)
+	}
 }
 
 //
@@ -990,6 +6398,91 @@ func (s *MySpecialStruct) SetName(n string) *MySpecialStruct {
 	return &res
 }
 
+// Equal returns whether the fields of s and other are equal
+func (s *MySpecialStruct) Equal(other *MySpecialStruct) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_Key == other.field_Key) {
+		return false
+	}
+
+	if !(s.field_Name == other.field_Name) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *MySpecialStruct) Diff(other *MySpecialStruct) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_Key == other.field_Key) {
+		res = append(res, immutable.Change{Path: ".Key", Kind: immutable.ChangeModified, Old: s.field_Key, New: other.field_Key})
+	}
+
+	if !(s.field_Name == other.field_Name) {
+		res = append(res, immutable.Change{Path: ".Name", Kind: immutable.ChangeModified, Old: s.field_Name, New: other.field_Name})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *MySpecialStruct) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		Key  MySpecialStructKey
+		Name string
+	}{
+		Key:  s.field_Key,
+		Name: s.field_Name,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *MySpecialStruct) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Key  MySpecialStructKey
+		Name string
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = MySpecialStruct{
+		field_Key:  v.Key,
+		field_Name: v.Name,
+	}
+
+	return nil
+}
+
 //
 // A is an immutable type and has the following template:
 //
@@ -1136,6 +6629,107 @@ func (s *A) SetName(n string) *A {
 	return &res
 }
 
+// Equal returns whether the fields of s and other are equal
+func (s *A) Equal(other *A) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_Name == other.field_Name) {
+		return false
+	}
+
+	if !(s.field_A.Equal(other.field_A)) {
+		return false
+	}
+
+	if !(reflect.DeepEqual(s.anonfield_Blah, other.anonfield_Blah)) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *A) Diff(other *A) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_Name == other.field_Name) {
+		res = append(res, immutable.Change{Path: ".Name", Kind: immutable.ChangeModified, Old: s.field_Name, New: other.field_Name})
+	}
+
+	if !(s.field_A.Equal(other.field_A)) {
+		if s.field_A != nil && other.field_A != nil {
+			res = append(res, immutable.PrefixChanges(".A", s.field_A.Diff(other.field_A))...)
+		} else {
+			res = append(res, immutable.Change{Path: ".A", Kind: immutable.ChangeModified, Old: s.field_A, New: other.field_A})
+		}
+	}
+
+	if !(reflect.DeepEqual(s.anonfield_Blah, other.anonfield_Blah)) {
+		res = append(res, immutable.Change{Path: ".Blah", Kind: immutable.ChangeModified, Old: s.anonfield_Blah, New: other.anonfield_Blah})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *A) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		Name string
+		A    *A
+		Blah Blah
+	}{
+		Name: s.field_Name,
+		A:    s.field_A,
+		Blah: s.anonfield_Blah,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *A) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Name string
+		A    *A
+		Blah Blah
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = A{
+		field_Name:     v.Name,
+		field_A:        v.A,
+		anonfield_Blah: v.Blah,
+	}
+
+	return nil
+}
+
 //
 // BlahUse is an immutable type and has the following template:
 //
@@ -1240,6 +6834,80 @@ func (s *BlahUse) SetBlah(n Blah) *BlahUse {
 	return &res
 }
 
+// Equal returns whether the fields of s and other are equal
+func (s *BlahUse) Equal(other *BlahUse) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(reflect.DeepEqual(s.anonfield_Blah, other.anonfield_Blah)) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *BlahUse) Diff(other *BlahUse) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(reflect.DeepEqual(s.anonfield_Blah, other.anonfield_Blah)) {
+		res = append(res, immutable.Change{Path: ".Blah", Kind: immutable.ChangeModified, Old: s.anonfield_Blah, New: other.anonfield_Blah})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *BlahUse) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		Blah Blah
+	}{
+		Blah: s.anonfield_Blah,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *BlahUse) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Blah Blah
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = BlahUse{
+		anonfield_Blah: v.Blah,
+	}
+
+	return nil
+}
+
+//immutableGen:builder
 //
 // Clash1 is an immutable type and has the following template:
 //
@@ -1349,13 +7017,132 @@ func (s *Clash1) SetNoClash1(n string) *Clash1 {
 		return s
 	}
 
-	res := *s
-	res.field_NoClash1 = n
-	return &res
+	res := *s
+	res.field_NoClash1 = n
+	return &res
+}
+
+// Equal returns whether the fields of s and other are equal
+func (s *Clash1) Equal(other *Clash1) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_Clash == other.field_Clash) {
+		return false
+	}
+
+	if !(s.field_NoClash1 == other.field_NoClash1) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *Clash1) Diff(other *Clash1) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_Clash == other.field_Clash) {
+		res = append(res, immutable.Change{Path: ".Clash", Kind: immutable.ChangeModified, Old: s.field_Clash, New: other.field_Clash})
+	}
+
+	if !(s.field_NoClash1 == other.field_NoClash1) {
+		res = append(res, immutable.Change{Path: ".NoClash1", Kind: immutable.ChangeModified, Old: s.field_NoClash1, New: other.field_NoClash1})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *Clash1) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		Clash    string
+		NoClash1 string
+	}{
+		Clash:    s.field_Clash,
+		NoClash1: s.field_NoClash1,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *Clash1) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Clash    string
+		NoClash1 string
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = Clash1{
+		field_Clash:    v.Clash,
+		field_NoClash1: v.NoClash1,
+	}
+
+	return nil
+}
+
+// Clash1Builder builds a Clash1 using fluent setters. Create a
+// Clash1Builder using NewClash1Builder.
+type Clash1Builder struct {
+	v *Clash1
+}
+
+// NewClash1Builder returns a new Clash1Builder
+func NewClash1Builder() *Clash1Builder {
+	return &Clash1Builder{
+		v: new(Clash1).AsMutable(),
+	}
+}
+
+// Build returns the immutable Clash1 built by b. b can continue
+// to be used after a call to Build; values already built are unaffected.
+func (b *Clash1Builder) Build() *Clash1 {
+	b.v = b.v.AsImmutable(nil)
+	return b.v
+}
+
+// SetClash sets Clash() of the Clash1 being built
+func (b *Clash1Builder) SetClash(n string) *Clash1Builder {
+	b.v = b.v.SetClash(n)
+	return b
+}
+
+// SetNoClash1 sets NoClash1() of the Clash1 being built
+func (b *Clash1Builder) SetNoClash1(n string) *Clash1Builder {
+	b.v = b.v.SetNoClash1(n)
+	return b
 }
 
 // types for testing embedding
 //
+//immutableGen:builder
+//
 // Embed1 is an immutable type and has the following template:
 //
 // 	struct {
@@ -1533,166 +7320,620 @@ func (s *Embed1) SetEmbed2(n *Embed2) *Embed1 {
 		return s
 	}
 
-	res := *s
-	res.anonfield_Embed2 = n
-	return &res
+	res := *s
+	res.anonfield_Embed2 = n
+	return &res
+}
+func (s *Embed1) Name() string {
+	return s.field_Name
+}
+
+// SetName is the setter for Name()
+func (s *Embed1) SetName(n string) *Embed1 {
+	if s.mutable {
+		s.field_Name = n
+		return s
+	}
+
+	res := *s
+	res.field_Name = n
+	return &res
+}
+func (s *Embed1) NoClash1() string {
+	return s.Clash1().NoClash1()
+}
+func (s *Embed1) SetNoClash1(n string) *Embed1 {
+	v1 := s.Clash1().SetNoClash1(n)
+	v0 := s.SetClash1(v1)
+	return v0
+}
+func (s *Embed1) NoClash2() string {
+	return s.Clash2().NoClash2()
+}
+func (s *Embed1) SetNoClash2(n string) *Embed1 {
+	v1 := s.Clash2().SetNoClash2(n)
+	v0 := s.SetClash2(v1)
+	return v0
+}
+func (s *Embed1) NonImmStruct() NonImmStruct {
+	return s.anonfield_NonImmStruct
+}
+
+// SetNonImmStruct is the setter for NonImmStruct()
+func (s *Embed1) SetNonImmStruct(n NonImmStruct) *Embed1 {
+	if s.mutable {
+		s.anonfield_NonImmStruct = n
+		return s
+	}
+
+	res := *s
+	res.anonfield_NonImmStruct = n
+	return &res
+}
+func (s *Embed1) NonImmStructA() pkga.NonImmStructA {
+	return s.anonfield_NonImmStructA
+}
+
+// SetNonImmStructA is the setter for NonImmStructA()
+func (s *Embed1) SetNonImmStructA(n pkga.NonImmStructA) *Embed1 {
+	if s.mutable {
+		s.anonfield_NonImmStructA = n
+		return s
+	}
+
+	res := *s
+	res.anonfield_NonImmStructA = n
+	return &res
+}
+func (s *Embed1) Now() time.Time {
+	return s.NonImmStruct().Now
+}
+func (s *Embed1) SetNow(n time.Time) *Embed1 {
+	v1 := s.NonImmStruct()
+	v1.Now = n
+	v0 := s.SetNonImmStruct(v1)
+	return v0
+}
+func (s *Embed1) NowA() time.Time {
+	return s.NonImmStructA().NowA
+}
+func (s *Embed1) SetNowA(n time.Time) *Embed1 {
+	v1 := s.NonImmStructA()
+	v1.NowA = n
+	v0 := s.SetNonImmStructA(v1)
+	return v0
+}
+func (s *Embed1) Other() *Other {
+	return s.NonImmStruct().Other
+}
+func (s *Embed1) SetOther(n *Other) *Embed1 {
+	v1 := s.NonImmStruct()
+	v1.Other = n
+	v0 := s.SetNonImmStruct(v1)
+	return v0
+}
+func (s *Embed1) OtherA() *pkga.OtherA {
+	return s.NonImmStructA().OtherA
+}
+func (s *Embed1) SetOtherA(n *pkga.OtherA) *Embed1 {
+	v1 := s.NonImmStructA()
+	v1.OtherA = n
+	v0 := s.SetNonImmStructA(v1)
+	return v0
+}
+func (s *Embed1) OtherName() string {
+	return s.NonImmStruct().Other.OtherName()
+}
+func (s *Embed1) SetOtherName(n string) *Embed1 {
+	v2 := s.NonImmStruct().Other.SetOtherName(n)
+	v1 := s.NonImmStruct()
+	v1.Other = v2
+	v0 := s.SetNonImmStruct(v1)
+	return v0
+}
+func (s *Embed1) OtherNameA() string {
+	return s.NonImmStructA().OtherA.OtherNameA()
+}
+func (s *Embed1) SetOtherNameA(n string) *Embed1 {
+	v2 := s.NonImmStructA().OtherA.SetOtherNameA(n)
+	v1 := s.NonImmStructA()
+	v1.OtherA = v2
+	v0 := s.SetNonImmStructA(v1)
+	return v0
+}
+func (s *Embed1) PkgA() *pkga.PkgA {
+	return s.anonfield_PkgA
+}
+
+// SetPkgA is the setter for PkgA()
+func (s *Embed1) SetPkgA(n *pkga.PkgA) *Embed1 {
+	if s.mutable {
+		s.anonfield_PkgA = n
+		return s
+	}
+
+	res := *s
+	res.anonfield_PkgA = n
+	return &res
+}
+func (s *Embed1) PkgB() *pkgb.PkgB {
+	return s.PkgA().PkgB()
+}
+func (s *Embed1) SetPkgB(n *pkgb.PkgB) *Embed1 {
+	v1 := s.PkgA().SetPkgB(n)
+	v0 := s.SetPkgA(v1)
+	return v0
+}
+func (s *Embed1) Postcode() string {
+	return s.PkgA().PkgB().Postcode()
+}
+func (s *Embed1) SetPostcode(n string) *Embed1 {
+	v2 := s.PkgA().PkgB().SetPostcode(n)
+	v1 := s.PkgA().SetPkgB(v2)
+	v0 := s.SetPkgA(v1)
+	return v0
+}
+func (s *Embed1) otherdetails() string {
+	return s.Embed2().otherdetails()
+}
+func (s *Embed1) setOtherdetails(n string) *Embed1 {
+	v1 := s.Embed2().setOtherdetails(n)
+	v0 := s.SetEmbed2(v1)
+	return v0
+}
+
+// Equal returns whether the fields of s and other are equal
+func (s *Embed1) Equal(other *Embed1) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_Name == other.field_Name) {
+		return false
+	}
+
+	if !(s.anonfield_Embed2.Equal(other.anonfield_Embed2)) {
+		return false
+	}
+
+	if !(s.anonfield_PkgA.Equal(other.anonfield_PkgA)) {
+		return false
+	}
+
+	if !(s.anonfield_Clash1.Equal(other.anonfield_Clash1)) {
+		return false
+	}
+
+	if !(s.anonfield_Clash2.Equal(other.anonfield_Clash2)) {
+		return false
+	}
+
+	if !(s.anonfield_NonImmStruct == other.anonfield_NonImmStruct) {
+		return false
+	}
+
+	if !(s.anonfield_NonImmStructA == other.anonfield_NonImmStructA) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *Embed1) Diff(other *Embed1) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_Name == other.field_Name) {
+		res = append(res, immutable.Change{Path: ".Name", Kind: immutable.ChangeModified, Old: s.field_Name, New: other.field_Name})
+	}
+
+	if !(s.anonfield_Embed2.Equal(other.anonfield_Embed2)) {
+		if s.anonfield_Embed2 != nil && other.anonfield_Embed2 != nil {
+			res = append(res, immutable.PrefixChanges(".Embed2", s.anonfield_Embed2.Diff(other.anonfield_Embed2))...)
+		} else {
+			res = append(res, immutable.Change{Path: ".Embed2", Kind: immutable.ChangeModified, Old: s.anonfield_Embed2, New: other.anonfield_Embed2})
+		}
+	}
+
+	if !(s.anonfield_PkgA.Equal(other.anonfield_PkgA)) {
+		if s.anonfield_PkgA != nil && other.anonfield_PkgA != nil {
+			res = append(res, immutable.PrefixChanges(".PkgA", s.anonfield_PkgA.Diff(other.anonfield_PkgA))...)
+		} else {
+			res = append(res, immutable.Change{Path: ".PkgA", Kind: immutable.ChangeModified, Old: s.anonfield_PkgA, New: other.anonfield_PkgA})
+		}
+	}
+
+	if !(s.anonfield_Clash1.Equal(other.anonfield_Clash1)) {
+		if s.anonfield_Clash1 != nil && other.anonfield_Clash1 != nil {
+			res = append(res, immutable.PrefixChanges(".Clash1", s.anonfield_Clash1.Diff(other.anonfield_Clash1))...)
+		} else {
+			res = append(res, immutable.Change{Path: ".Clash1", Kind: immutable.ChangeModified, Old: s.anonfield_Clash1, New: other.anonfield_Clash1})
+		}
+	}
+
+	if !(s.anonfield_Clash2.Equal(other.anonfield_Clash2)) {
+		if s.anonfield_Clash2 != nil && other.anonfield_Clash2 != nil {
+			res = append(res, immutable.PrefixChanges(".Clash2", s.anonfield_Clash2.Diff(other.anonfield_Clash2))...)
+		} else {
+			res = append(res, immutable.Change{Path: ".Clash2", Kind: immutable.ChangeModified, Old: s.anonfield_Clash2, New: other.anonfield_Clash2})
+		}
+	}
+
+	if !(s.anonfield_NonImmStruct == other.anonfield_NonImmStruct) {
+		res = append(res, immutable.Change{Path: ".NonImmStruct", Kind: immutable.ChangeModified, Old: s.anonfield_NonImmStruct, New: other.anonfield_NonImmStruct})
+	}
+
+	if !(s.anonfield_NonImmStructA == other.anonfield_NonImmStructA) {
+		res = append(res, immutable.Change{Path: ".NonImmStructA", Kind: immutable.ChangeModified, Old: s.anonfield_NonImmStructA, New: other.anonfield_NonImmStructA})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *Embed1) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		Name          string
+		Embed2        *Embed2
+		PkgA          *pkga.PkgA
+		Clash1        *Clash1
+		Clash2        *pkga.Clash2
+		NonImmStruct  NonImmStruct
+		NonImmStructA pkga.NonImmStructA
+	}{
+		Name:          s.field_Name,
+		Embed2:        s.anonfield_Embed2,
+		PkgA:          s.anonfield_PkgA,
+		Clash1:        s.anonfield_Clash1,
+		Clash2:        s.anonfield_Clash2,
+		NonImmStruct:  s.anonfield_NonImmStruct,
+		NonImmStructA: s.anonfield_NonImmStructA,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *Embed1) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Name          string
+		Embed2        *Embed2
+		PkgA          *pkga.PkgA
+		Clash1        *Clash1
+		Clash2        *pkga.Clash2
+		NonImmStruct  NonImmStruct
+		NonImmStructA pkga.NonImmStructA
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = Embed1{
+		field_Name:              v.Name,
+		anonfield_Embed2:        v.Embed2,
+		anonfield_PkgA:          v.PkgA,
+		anonfield_Clash1:        v.Clash1,
+		anonfield_Clash2:        v.Clash2,
+		anonfield_NonImmStruct:  v.NonImmStruct,
+		anonfield_NonImmStructA: v.NonImmStructA,
+	}
+
+	return nil
+}
+
+// Embed1Builder builds a Embed1 using fluent setters. Create a
+// Embed1Builder using NewEmbed1Builder.
+type Embed1Builder struct {
+	v *Embed1
+}
+
+// NewEmbed1Builder returns a new Embed1Builder
+func NewEmbed1Builder() *Embed1Builder {
+	return &Embed1Builder{
+		v: new(Embed1).AsMutable(),
+	}
+}
+
+// Build returns the immutable Embed1 built by b. b can continue
+// to be used after a call to Build; values already built are unaffected.
+func (b *Embed1Builder) Build() *Embed1 {
+	b.v = b.v.AsImmutable(nil)
+	return b.v
+}
+
+// SetAddress sets Address() of the Embed1 being built
+func (b *Embed1Builder) SetAddress(n string) *Embed1Builder {
+	b.v = b.v.SetAddress(n)
+	return b
+}
+
+// SetAge sets Age() of the Embed1 being built
+func (b *Embed1Builder) SetAge(n int) *Embed1Builder {
+	b.v = b.v.SetAge(n)
+	return b
+}
+
+// SetClash1 sets Clash1() of the Embed1 being built
+func (b *Embed1Builder) SetClash1(n *Clash1) *Embed1Builder {
+	b.v = b.v.SetClash1(n)
+	return b
+}
+
+// SetClash2 sets Clash2() of the Embed1 being built
+func (b *Embed1Builder) SetClash2(n *pkga.Clash2) *Embed1Builder {
+	b.v = b.v.SetClash2(n)
+	return b
+}
+
+// SetEmbed2 sets Embed2() of the Embed1 being built
+func (b *Embed1Builder) SetEmbed2(n *Embed2) *Embed1Builder {
+	b.v = b.v.SetEmbed2(n)
+	return b
+}
+
+// SetName sets Name() of the Embed1 being built
+func (b *Embed1Builder) SetName(n string) *Embed1Builder {
+	b.v = b.v.SetName(n)
+	return b
 }
-func (s *Embed1) Name() string {
-	return s.field_Name
+
+// SetNoClash1 sets NoClash1() of the Embed1 being built
+func (b *Embed1Builder) SetNoClash1(n string) *Embed1Builder {
+	b.v = b.v.SetNoClash1(n)
+	return b
 }
 
-// SetName is the setter for Name()
-func (s *Embed1) SetName(n string) *Embed1 {
-	if s.mutable {
-		s.field_Name = n
-		return s
-	}
+// SetNoClash2 sets NoClash2() of the Embed1 being built
+func (b *Embed1Builder) SetNoClash2(n string) *Embed1Builder {
+	b.v = b.v.SetNoClash2(n)
+	return b
+}
 
-	res := *s
-	res.field_Name = n
-	return &res
+// SetNonImmStruct sets NonImmStruct() of the Embed1 being built
+func (b *Embed1Builder) SetNonImmStruct(n NonImmStruct) *Embed1Builder {
+	b.v = b.v.SetNonImmStruct(n)
+	return b
 }
-func (s *Embed1) NoClash1() string {
-	return s.Clash1().NoClash1()
+
+// SetNonImmStructA sets NonImmStructA() of the Embed1 being built
+func (b *Embed1Builder) SetNonImmStructA(n pkga.NonImmStructA) *Embed1Builder {
+	b.v = b.v.SetNonImmStructA(n)
+	return b
 }
-func (s *Embed1) SetNoClash1(n string) *Embed1 {
-	v1 := s.Clash1().SetNoClash1(n)
-	v0 := s.SetClash1(v1)
-	return v0
+
+// SetNow sets Now() of the Embed1 being built
+func (b *Embed1Builder) SetNow(n time.Time) *Embed1Builder {
+	b.v = b.v.SetNow(n)
+	return b
 }
-func (s *Embed1) NoClash2() string {
-	return s.Clash2().NoClash2()
+
+// SetNowA sets NowA() of the Embed1 being built
+func (b *Embed1Builder) SetNowA(n time.Time) *Embed1Builder {
+	b.v = b.v.SetNowA(n)
+	return b
 }
-func (s *Embed1) SetNoClash2(n string) *Embed1 {
-	v1 := s.Clash2().SetNoClash2(n)
-	v0 := s.SetClash2(v1)
-	return v0
+
+// SetOther sets Other() of the Embed1 being built
+func (b *Embed1Builder) SetOther(n *Other) *Embed1Builder {
+	b.v = b.v.SetOther(n)
+	return b
 }
-func (s *Embed1) NonImmStruct() NonImmStruct {
-	return s.anonfield_NonImmStruct
+
+// SetOtherA sets OtherA() of the Embed1 being built
+func (b *Embed1Builder) SetOtherA(n *pkga.OtherA) *Embed1Builder {
+	b.v = b.v.SetOtherA(n)
+	return b
 }
 
-// SetNonImmStruct is the setter for NonImmStruct()
-func (s *Embed1) SetNonImmStruct(n NonImmStruct) *Embed1 {
-	if s.mutable {
-		s.anonfield_NonImmStruct = n
-		return s
-	}
+// SetOtherName sets OtherName() of the Embed1 being built
+func (b *Embed1Builder) SetOtherName(n string) *Embed1Builder {
+	b.v = b.v.SetOtherName(n)
+	return b
+}
 
-	res := *s
-	res.anonfield_NonImmStruct = n
-	return &res
+// SetOtherNameA sets OtherNameA() of the Embed1 being built
+func (b *Embed1Builder) SetOtherNameA(n string) *Embed1Builder {
+	b.v = b.v.SetOtherNameA(n)
+	return b
 }
-func (s *Embed1) NonImmStructA() pkga.NonImmStructA {
-	return s.anonfield_NonImmStructA
+
+// SetPkgA sets PkgA() of the Embed1 being built
+func (b *Embed1Builder) SetPkgA(n *pkga.PkgA) *Embed1Builder {
+	b.v = b.v.SetPkgA(n)
+	return b
 }
 
-// SetNonImmStructA is the setter for NonImmStructA()
-func (s *Embed1) SetNonImmStructA(n pkga.NonImmStructA) *Embed1 {
-	if s.mutable {
-		s.anonfield_NonImmStructA = n
-		return s
-	}
+// SetPkgB sets PkgB() of the Embed1 being built
+func (b *Embed1Builder) SetPkgB(n *pkgb.PkgB) *Embed1Builder {
+	b.v = b.v.SetPkgB(n)
+	return b
+}
 
-	res := *s
-	res.anonfield_NonImmStructA = n
-	return &res
+// SetPostcode sets Postcode() of the Embed1 being built
+func (b *Embed1Builder) SetPostcode(n string) *Embed1Builder {
+	b.v = b.v.SetPostcode(n)
+	return b
 }
-func (s *Embed1) Now() time.Time {
-	return s.NonImmStruct().Now
+
+// setOtherdetails sets Otherdetails() of the Embed1 being built
+func (b *Embed1Builder) setOtherdetails(n string) *Embed1Builder {
+	b.v = b.v.setOtherdetails(n)
+	return b
 }
-func (s *Embed1) SetNow(n time.Time) *Embed1 {
-	v1 := s.NonImmStruct()
-	v1.Now = n
-	v0 := s.SetNonImmStruct(v1)
-	return v0
+
+// Embed1Option is a functional option that configures the Embed1
+// constructed by NewEmbed1
+type Embed1Option func(b *Embed1Builder)
+
+// NewEmbed1 returns a new immutable Embed1 configured by opts.
+func NewEmbed1(opts ...Embed1Option) *Embed1 {
+	b := NewEmbed1Builder()
+	for _, o := range opts {
+		o(b)
+	}
+	return b.Build()
 }
-func (s *Embed1) NowA() time.Time {
-	return s.NonImmStructA().NowA
+
+// Embed1WithAddress returns a Embed1Option that sets Address()
+func Embed1WithAddress(n string) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetAddress(n)
+	}
 }
-func (s *Embed1) SetNowA(n time.Time) *Embed1 {
-	v1 := s.NonImmStructA()
-	v1.NowA = n
-	v0 := s.SetNonImmStructA(v1)
-	return v0
+
+// Embed1WithAge returns a Embed1Option that sets Age()
+func Embed1WithAge(n int) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetAge(n)
+	}
 }
-func (s *Embed1) Other() *Other {
-	return s.NonImmStruct().Other
+
+// Embed1WithClash1 returns a Embed1Option that sets Clash1()
+func Embed1WithClash1(n *Clash1) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetClash1(n)
+	}
 }
-func (s *Embed1) SetOther(n *Other) *Embed1 {
-	v1 := s.NonImmStruct()
-	v1.Other = n
-	v0 := s.SetNonImmStruct(v1)
-	return v0
+
+// Embed1WithClash2 returns a Embed1Option that sets Clash2()
+func Embed1WithClash2(n *pkga.Clash2) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetClash2(n)
+	}
 }
-func (s *Embed1) OtherA() *pkga.OtherA {
-	return s.NonImmStructA().OtherA
+
+// Embed1WithEmbed2 returns a Embed1Option that sets Embed2()
+func Embed1WithEmbed2(n *Embed2) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetEmbed2(n)
+	}
 }
-func (s *Embed1) SetOtherA(n *pkga.OtherA) *Embed1 {
-	v1 := s.NonImmStructA()
-	v1.OtherA = n
-	v0 := s.SetNonImmStructA(v1)
-	return v0
+
+// Embed1WithName returns a Embed1Option that sets Name()
+func Embed1WithName(n string) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetName(n)
+	}
 }
-func (s *Embed1) OtherName() string {
-	return s.NonImmStruct().Other.OtherName()
+
+// Embed1WithNoClash1 returns a Embed1Option that sets NoClash1()
+func Embed1WithNoClash1(n string) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetNoClash1(n)
+	}
 }
-func (s *Embed1) SetOtherName(n string) *Embed1 {
-	v2 := s.NonImmStruct().Other.SetOtherName(n)
-	v1 := s.NonImmStruct()
-	v1.Other = v2
-	v0 := s.SetNonImmStruct(v1)
-	return v0
+
+// Embed1WithNoClash2 returns a Embed1Option that sets NoClash2()
+func Embed1WithNoClash2(n string) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetNoClash2(n)
+	}
 }
-func (s *Embed1) OtherNameA() string {
-	return s.NonImmStructA().OtherA.OtherNameA()
+
+// Embed1WithNonImmStruct returns a Embed1Option that sets NonImmStruct()
+func Embed1WithNonImmStruct(n NonImmStruct) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetNonImmStruct(n)
+	}
 }
-func (s *Embed1) SetOtherNameA(n string) *Embed1 {
-	v2 := s.NonImmStructA().OtherA.SetOtherNameA(n)
-	v1 := s.NonImmStructA()
-	v1.OtherA = v2
-	v0 := s.SetNonImmStructA(v1)
-	return v0
+
+// Embed1WithNonImmStructA returns a Embed1Option that sets NonImmStructA()
+func Embed1WithNonImmStructA(n pkga.NonImmStructA) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetNonImmStructA(n)
+	}
 }
-func (s *Embed1) PkgA() *pkga.PkgA {
-	return s.anonfield_PkgA
+
+// Embed1WithNow returns a Embed1Option that sets Now()
+func Embed1WithNow(n time.Time) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetNow(n)
+	}
 }
 
-// SetPkgA is the setter for PkgA()
-func (s *Embed1) SetPkgA(n *pkga.PkgA) *Embed1 {
-	if s.mutable {
-		s.anonfield_PkgA = n
-		return s
+// Embed1WithNowA returns a Embed1Option that sets NowA()
+func Embed1WithNowA(n time.Time) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetNowA(n)
 	}
+}
 
-	res := *s
-	res.anonfield_PkgA = n
-	return &res
+// Embed1WithOther returns a Embed1Option that sets Other()
+func Embed1WithOther(n *Other) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetOther(n)
+	}
 }
-func (s *Embed1) PkgB() *pkgb.PkgB {
-	return s.PkgA().PkgB()
+
+// Embed1WithOtherA returns a Embed1Option that sets OtherA()
+func Embed1WithOtherA(n *pkga.OtherA) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetOtherA(n)
+	}
 }
-func (s *Embed1) SetPkgB(n *pkgb.PkgB) *Embed1 {
-	v1 := s.PkgA().SetPkgB(n)
-	v0 := s.SetPkgA(v1)
-	return v0
+
+// Embed1WithOtherName returns a Embed1Option that sets OtherName()
+func Embed1WithOtherName(n string) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetOtherName(n)
+	}
 }
-func (s *Embed1) Postcode() string {
-	return s.PkgA().PkgB().Postcode()
+
+// Embed1WithOtherNameA returns a Embed1Option that sets OtherNameA()
+func Embed1WithOtherNameA(n string) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetOtherNameA(n)
+	}
 }
-func (s *Embed1) SetPostcode(n string) *Embed1 {
-	v2 := s.PkgA().PkgB().SetPostcode(n)
-	v1 := s.PkgA().SetPkgB(v2)
-	v0 := s.SetPkgA(v1)
-	return v0
+
+// Embed1WithPkgA returns a Embed1Option that sets PkgA()
+func Embed1WithPkgA(n *pkga.PkgA) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetPkgA(n)
+	}
 }
-func (s *Embed1) otherdetails() string {
-	return s.Embed2().otherdetails()
+
+// Embed1WithPkgB returns a Embed1Option that sets PkgB()
+func Embed1WithPkgB(n *pkgb.PkgB) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetPkgB(n)
+	}
 }
-func (s *Embed1) setOtherdetails(n string) *Embed1 {
-	v1 := s.Embed2().setOtherdetails(n)
-	v0 := s.SetEmbed2(v1)
-	return v0
+
+// Embed1WithPostcode returns a Embed1Option that sets Postcode()
+func Embed1WithPostcode(n string) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.SetPostcode(n)
+	}
+}
+
+// Embed1WithOtherdetails returns a Embed1Option that sets Otherdetails()
+func Embed1WithOtherdetails(n string) Embed1Option {
+	return func(b *Embed1Builder) {
+		b.setOtherdetails(n)
+	}
 }
 
 //
@@ -1809,6 +8050,87 @@ func (s *Embed2) setOtherdetails(n string) *Embed2 {
 	return &res
 }
 
+// Equal returns whether the fields of s and other are equal
+func (s *Embed2) Equal(other *Embed2) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_Age == other.field_Age) {
+		return false
+	}
+
+	if !(s.field_otherdetails == other.field_otherdetails) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *Embed2) Diff(other *Embed2) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_Age == other.field_Age) {
+		res = append(res, immutable.Change{Path: ".Age", Kind: immutable.ChangeModified, Old: s.field_Age, New: other.field_Age})
+	}
+
+	if !(s.field_otherdetails == other.field_otherdetails) {
+		res = append(res, immutable.Change{Path: ".otherdetails", Kind: immutable.ChangeModified, Old: s.field_otherdetails, New: other.field_otherdetails})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *Embed2) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		Age int
+	}{
+		Age: s.field_Age,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *Embed2) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Age int
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = Embed2{
+		field_Age: v.Age,
+	}
+
+	return nil
+}
+
 //
 // Other is an immutable type and has the following template:
 //
@@ -1905,3 +8227,76 @@ func (s *Other) SetOtherName(n string) *Other {
 	res.field_OtherName = n
 	return &res
 }
+
+// Equal returns whether the fields of s and other are equal
+func (s *Other) Equal(other *Other) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_OtherName == other.field_OtherName) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *Other) Diff(other *Other) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_OtherName == other.field_OtherName) {
+		res = append(res, immutable.Change{Path: ".OtherName", Kind: immutable.ChangeModified, Old: s.field_OtherName, New: other.field_OtherName})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *Other) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		OtherName string
+	}{
+		OtherName: s.field_OtherName,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *Other) UnmarshalJSON(b []byte) error {
+	var v struct {
+		OtherName string
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = Other{
+		field_OtherName: v.OtherName,
+	}
+
+	return nil
+}
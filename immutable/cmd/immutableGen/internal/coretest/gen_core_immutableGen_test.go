@@ -8,6 +8,8 @@ package coretest_test
 //immutableVet:skipFile
 
 import (
+	"encoding/json"
+
 	"myitcv.io/immutable"
 )
 
@@ -141,6 +143,91 @@ func (s *xtestA) setAge(n int) *xtestA {
 	return &res
 }
 
+// Equal returns whether the fields of s and other are equal
+func (s *xtestA) Equal(other *xtestA) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.anonfield_XTestB.Equal(other.anonfield_XTestB)) {
+		return false
+	}
+
+	if !(s.field_age == other.field_age) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *xtestA) Diff(other *xtestA) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.anonfield_XTestB.Equal(other.anonfield_XTestB)) {
+		if s.anonfield_XTestB != nil && other.anonfield_XTestB != nil {
+			res = append(res, immutable.PrefixChanges(".XTestB", s.anonfield_XTestB.Diff(other.anonfield_XTestB))...)
+		} else {
+			res = append(res, immutable.Change{Path: ".XTestB", Kind: immutable.ChangeModified, Old: s.anonfield_XTestB, New: other.anonfield_XTestB})
+		}
+	}
+
+	if !(s.field_age == other.field_age) {
+		res = append(res, immutable.Change{Path: ".age", Kind: immutable.ChangeModified, Old: s.field_age, New: other.field_age})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *xtestA) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		XTestB *XTestB
+	}{
+		XTestB: s.anonfield_XTestB,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *xtestA) UnmarshalJSON(b []byte) error {
+	var v struct {
+		XTestB *XTestB
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = xtestA{
+		anonfield_XTestB: v.XTestB,
+	}
+
+	return nil
+}
+
 //
 // XTestB is an immutable type and has the following template:
 //
@@ -237,3 +324,76 @@ func (s *XTestB) SetName(n string) *XTestB {
 	res.field_Name = n
 	return &res
 }
+
+// Equal returns whether the fields of s and other are equal
+func (s *XTestB) Equal(other *XTestB) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_Name == other.field_Name) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *XTestB) Diff(other *XTestB) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_Name == other.field_Name) {
+		res = append(res, immutable.Change{Path: ".Name", Kind: immutable.ChangeModified, Old: s.field_Name, New: other.field_Name})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *XTestB) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		Name string
+	}{
+		Name: s.field_Name,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *XTestB) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Name string
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = XTestB{
+		field_Name: v.Name,
+	}
+
+	return nil
+}
@@ -8,6 +8,8 @@ package coretest
 //immutableVet:skipFile
 
 import (
+	"encoding/json"
+
 	"myitcv.io/immutable"
 )
 
@@ -167,6 +169,97 @@ func (s *MyTestMap) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	return true
 }
 
+// Equal returns whether m and other contain the same keys with equal values
+func (m *MyTestMap) Equal(other *MyTestMap) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	if len(m.theMap) != len(other.theMap) {
+		return false
+	}
+
+	for k, a := range m.theMap {
+		b, ok := other.theMap[k]
+		if !ok || !(a == b) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the key of the entry that was added, removed or
+// modified, or the key followed by the path of a change within a
+// modified value.
+func (m *MyTestMap) Diff(other *MyTestMap) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a int, aok bool, b int, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	for k, a := range m.theMap {
+		b, bok := other.theMap[k]
+		if bok && (a == b) {
+			continue
+		}
+		change(immutable.KeyPath(k), a, true, b, bok)
+	}
+
+	for k, b := range other.theMap {
+		if _, ok := m.theMap[k]; !ok {
+			var a int
+			change(immutable.KeyPath(k), a, false, b, true)
+		}
+	}
+
+	return immutable.SortChanges(res)
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a map[string]int
+func (m *MyTestMap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a
+// map[string]int into m. m is left immutable.
+func (m *MyTestMap) UnmarshalJSON(b []byte) error {
+	var v map[string]int
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *NewMyTestMapCap(len(v)).WithMutable(func(mi *MyTestMap) {
+		for k, e := range v {
+			mi.Set(k, e)
+		}
+	})
+
+	return nil
+}
+
 // a comment about Slice
 //
 // MyTestSlice is an immutable type and has the following template:
@@ -316,6 +409,93 @@ func (s *MyTestSlice) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	return true
 }
 
+// Equal returns whether m and other have the same length and equal values
+// at each index
+func (m *MyTestSlice) Equal(other *MyTestSlice) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	if len(m.theSlice) != len(other.theSlice) {
+		return false
+	}
+
+	for i, a := range m.theSlice {
+		b := other.theSlice[i]
+		if !(a == b) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, in index order. The path
+// of a change is the index that was added, removed or modified, or the
+// index followed by the path of a change within a modified value.
+func (m *MyTestSlice) Diff(other *MyTestSlice) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a *string, aok bool, b *string, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	for i := 0; i < len(m.theSlice) || i < len(other.theSlice); i++ {
+		var a, b *string
+		aok, bok := i < len(m.theSlice), i < len(other.theSlice)
+		if aok {
+			a = m.theSlice[i]
+		}
+		if bok {
+			b = other.theSlice[i]
+		}
+		if aok && bok && (a == b) {
+			continue
+		}
+		change(immutable.IndexPath(i), a, aok, b, bok)
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a []*string
+func (m *MyTestSlice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []*string
+// into m. m is left immutable.
+func (m *MyTestSlice) UnmarshalJSON(b []byte) error {
+	var v []*string
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *NewMyTestSlice(v...)
+
+	return nil
+}
+
 // a comment about myStruct
 //
 // MyTestStruct is an immutable type and has the following template:
@@ -482,3 +662,100 @@ func (s *MyTestStruct) setSurname(n string) *MyTestStruct {
 	res.field_surname = n
 	return &res
 }
+
+// Equal returns whether the fields of s and other are equal
+func (s *MyTestStruct) Equal(other *MyTestStruct) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_Name == other.field_Name) {
+		return false
+	}
+
+	if !(s.field_surname == other.field_surname) {
+		return false
+	}
+
+	if !(s.field_age == other.field_age) {
+		return false
+	}
+
+	if !(s.field_fieldWithoutTag == other.field_fieldWithoutTag) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *MyTestStruct) Diff(other *MyTestStruct) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_Name == other.field_Name) {
+		res = append(res, immutable.Change{Path: ".Name", Kind: immutable.ChangeModified, Old: s.field_Name, New: other.field_Name})
+	}
+
+	if !(s.field_surname == other.field_surname) {
+		res = append(res, immutable.Change{Path: ".surname", Kind: immutable.ChangeModified, Old: s.field_surname, New: other.field_surname})
+	}
+
+	if !(s.field_age == other.field_age) {
+		res = append(res, immutable.Change{Path: ".age", Kind: immutable.ChangeModified, Old: s.field_age, New: other.field_age})
+	}
+
+	if !(s.field_fieldWithoutTag == other.field_fieldWithoutTag) {
+		res = append(res, immutable.Change{Path: ".fieldWithoutTag", Kind: immutable.ChangeModified, Old: s.field_fieldWithoutTag, New: other.field_fieldWithoutTag})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *MyTestStruct) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		Name string `tag:"value"`
+	}{
+		Name: s.field_Name,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *MyTestStruct) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Name string `tag:"value"`
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = MyTestStruct{
+		field_Name: v.Name,
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package coretest_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"myitcv.io/immutable/cmd/immutableGen/internal/coretest"
+)
+
+func TestJSONStruct(t *testing.T) {
+	s1 := new(coretest.MyJSONStruct).WithMutable(func(s *coretest.MyJSONStruct) {
+		s.SetName(peter)
+		s.SetIgnored("ignored")
+		s.SetSlice(coretest.NewMySlice(paul))
+		s.SetMap(coretest.NewMyPersistentMap().Set(peter, age42))
+		s.SetEmbed2(new(coretest.Embed2).SetAge(age42))
+	})
+
+	b, err := json.Marshal(s1)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	exp := `{"name":"peter","slice":["paul"],"map":{"peter":42},"Embed2":{"Age":42}}`
+	if string(b) != exp {
+		t.Fatalf("expected %v; got %v", exp, string(b))
+	}
+
+	var s2 *coretest.MyJSONStruct
+	if err := json.Unmarshal(b, &s2); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if !s2.IsDeeplyNonMutable(nil) {
+		t.Fatalf("unmarshalled value should be DeeplyNonMutable")
+	}
+
+	if s2.Name() != peter || s2.Ignored() != "" || s2.Embed2().Age() != age42 {
+		t.Fatalf("unexpected unmarshalled value: %s", b)
+	}
+
+	if exp, got := []string{paul}, s2.Slice().Range(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected Slice() to be %v; got %v", exp, got)
+	}
+
+	if v, ok := s2.Map().Get(peter); !ok || v != age42 {
+		t.Fatalf("expected Map().Get(peter) to be (%v, true); got (%v, %v)", age42, v, ok)
+	}
+
+	if s2.Other() != nil {
+		t.Fatalf("expected Other() to be nil")
+	}
+}
+
+func TestJSONMapAndSlice(t *testing.T) {
+	m1 := coretest.NewMyMap().Set(peter, age42)
+
+	b, err := json.Marshal(m1)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	var m2 *coretest.MyMap
+	if err := json.Unmarshal(b, &m2); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if m2.Mutable() || !reflect.DeepEqual(m2.Range(), m1.Range()) {
+		t.Fatalf("expected immutable %v; got %v (mutable: %v)", m1.Range(), m2.Range(), m2.Mutable())
+	}
+
+	s1 := coretest.NewMyPersistentSlice(peter, paul)
+
+	b, err = json.Marshal(s1)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	var s2 *coretest.MyPersistentSlice
+	if err := json.Unmarshal(b, &s2); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if s2.Mutable() || !reflect.DeepEqual(s2.Range(), s1.Range()) {
+		t.Fatalf("expected immutable %v; got %v (mutable: %v)", s1.Range(), s2.Range(), s2.Mutable())
+	}
+
+	if err := json.Unmarshal([]byte(`{"a":"b"}`), &m2); err == nil {
+		t.Fatalf("expected error unmarshalling string value into MyMap")
+	}
+}
+
+func TestJSONUnmarshalDerived(t *testing.T) {
+	s1 := new(coretest.MyJSONStruct).WithMutable(func(s *coretest.MyJSONStruct) {
+		s.SetName(peter)
+		s.SetSlice(coretest.NewMySlice(paul))
+		s.SetMap(coretest.NewMyPersistentMap().Set(peter, age42))
+		s.SetEmbed2(new(coretest.Embed2).SetAge(age42))
+	})
+
+	// s2 shares the values of the fields of s1 other than Name
+	s2 := s1.SetName(paul)
+
+	b := []byte(`{"name":"paul","slice":["peter"],"map":{"paul":1},"Embed2":{"Age":1}}`)
+	if err := json.Unmarshal(b, s2); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if exp, got := []string{peter}, s2.Slice().Range(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected s2.Slice() to be %v; got %v", exp, got)
+	}
+
+	if s2.Embed2().Age() != 1 || s2.Map().Len() != 1 {
+		t.Fatalf("unexpected unmarshalled value: %s", b)
+	}
+
+	if s1.Name() != peter || s1.Embed2().Age() != age42 {
+		t.Fatalf("expected s1 to be unchanged; got name %v, age %v", s1.Name(), s1.Embed2().Age())
+	}
+
+	if exp, got := []string{paul}, s1.Slice().Range(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected s1.Slice() to be unchanged as %v; got %v", exp, got)
+	}
+
+	if v, ok := s1.Map().Get(peter); !ok || v != age42 || s1.Map().Len() != 1 {
+		t.Fatalf("expected s1.Map() to be unchanged")
+	}
+
+	m1 := coretest.NewMyMap().Set(peter, age42)
+	m2 := m1.Set(paul, age42)
+
+	if err := json.Unmarshal([]byte(`{"paul":1}`), m2); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if exp := map[string]int{paul: 1}; !reflect.DeepEqual(m2.Range(), exp) {
+		t.Fatalf("expected m2 to be %v; got %v", exp, m2.Range())
+	}
+
+	if exp := map[string]int{peter: age42}; !reflect.DeepEqual(m1.Range(), exp) {
+		t.Fatalf("expected m1 to be unchanged as %v; got %v", exp, m1.Range())
+	}
+}
+
+func TestJSONUnsupportedMapKey(t *testing.T) {
+	// encoding/json cannot (un)marshal maps with pointer keys
+	for _, v := range []interface{}{new(coretest.AM), new(coretest.APM), new(coretest.ACM), new(coretest.AOM)} {
+		if _, ok := v.(json.Marshaler); ok {
+			t.Errorf("expected %T not to implement json.Marshaler", v)
+		}
+		if _, ok := v.(json.Unmarshaler); ok {
+			t.Errorf("expected %T not to implement json.Unmarshaler", v)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package coretest_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"myitcv.io/immutable/cmd/immutableGen/internal/coretest"
+)
+
+func TestMyOrderedMap(t *testing.T) {
+	s1 := coretest.NewMyOrderedMap()
+	s2 := s1.Set(peter, age42).Set(paul, 1).Set("mary", 2)
+	s3 := s2.Del(paul)
+
+	if s1.Len() != 0 {
+		t.Fatalf("s1 should be empty; has length %v", s1.Len())
+	}
+
+	if exp, got := []string{"mary", paul, peter}, s2.Keys(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected s2.Keys() to be %v; got %v", exp, got)
+	}
+
+	if exp, got := []string{"mary", peter}, s3.Keys(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected s3.Keys() to be %v; got %v", exp, got)
+	}
+
+	if k, v, ok := s2.Min(); !ok || k != "mary" || v != 2 {
+		t.Fatalf("expected s2.Min() to be (mary, 2, true); got (%v, %v, %v)", k, v, ok)
+	}
+
+	if k, _, ok := s2.Floor("peta"); !ok || k != paul {
+		t.Fatalf("expected s2.Floor(peta) to be %v; got (%v, %v)", paul, k, ok)
+	}
+
+	if k, _, ok := s2.Ceil("peta"); !ok || k != peter {
+		t.Fatalf("expected s2.Ceil(peta) to be %v; got (%v, %v)", peter, k, ok)
+	}
+
+	if got := s2.Between("n", peter); len(got) != 1 || got[0].Key != paul {
+		t.Fatalf("expected s2.Between(n, peter) to contain only %v; got %v", paul, got)
+	}
+
+	var zero *coretest.MyOrderedMap
+	if _, _, ok := zero.Max(); ok {
+		t.Fatalf("expected Max() of a nil map to fail")
+	}
+
+	if !s3.Equal(s2.Del(paul)) {
+		t.Fatalf("expected s3 to equal s2 with paul deleted")
+	}
+}
+
+func TestMyInsertionMap(t *testing.T) {
+	s1 := coretest.NewMyInsertionMap(func(m *coretest.MyInsertionMap) {
+		m.Set(peter, 1)
+		m.Set(paul, 2)
+		m.Set("mary", 3)
+	})
+	s2 := s1.Del(peter).Set(peter, 4).Set(paul, 5)
+
+	if exp, got := []string{peter, paul, "mary"}, s1.Keys(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected s1.Keys() to be %v; got %v", exp, got)
+	}
+
+	if exp, got := []string{paul, "mary", peter}, s2.Keys(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected s2.Keys() to be %v; got %v", exp, got)
+	}
+
+	if v, _ := s2.Get(paul); v != 5 {
+		t.Fatalf("expected s2.Get(paul) to be 5; got %v", v)
+	}
+}
+
+func TestMyLengthMap(t *testing.T) {
+	s := coretest.NewMyLengthMap().Set(peter, 1).Set("al", 2).Set(paul, 3)
+
+	if exp, got := []string{"al", paul, peter}, s.Keys(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected s.Keys() to be %v; got %v", exp, got)
+	}
+}
+
+func TestOrderedMapJSON(t *testing.T) {
+	s1 := coretest.NewMyInsertionMap().Set(peter, 1).Set(paul, 2)
+
+	b, err := json.Marshal(s1)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	if exp := `{"peter":1,"paul":2}`; string(b) != exp {
+		t.Fatalf("expected %v; got %s", exp, b)
+	}
+
+	var s2 *coretest.MyInsertionMap
+	if err := json.Unmarshal(b, &s2); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if s2.Mutable() {
+		t.Fatalf("result of unmarshalling should be immutable")
+	}
+
+	if exp, got := s1.Keys(), s2.Keys(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected keys %v; got %v", exp, got)
+	}
+}
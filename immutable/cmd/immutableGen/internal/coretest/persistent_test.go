@@ -0,0 +1,107 @@
+package coretest_test
+
+import (
+	"reflect"
+	"testing"
+
+	"myitcv.io/immutable/cmd/immutableGen/internal/coretest"
+)
+
+func TestMyPersistentMap(t *testing.T) {
+	s1 := coretest.NewMyPersistentMap()
+	s2 := s1.Set(peter, age42)
+	s3 := s2.Set(paul, 1)
+	s4 := s3.Del(peter)
+
+	if s1.Len() != 0 {
+		t.Fatalf("s1 should be empty; has length %v", s1.Len())
+	}
+
+	if v, ok := s2.Get(peter); !ok || v != age42 {
+		t.Fatalf("expected s2.Get(peter) to be (%v, true); got (%v, %v)", age42, v, ok)
+	}
+
+	if exp, got := map[string]int{peter: age42, paul: 1}, s3.Range(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected s3.Range() to be %v; got %v", exp, got)
+	}
+
+	if _, ok := s4.Get(peter); ok {
+		t.Fatalf("s4 should not contain peter")
+	}
+
+	if s4.Del(peter) != s4 {
+		t.Fatalf("deleting a missing key should return the receiver")
+	}
+
+	s5 := s3.WithMutable(func(s *coretest.MyPersistentMap) {
+		s.Set(peter, 1)
+		s.Del(paul)
+	})
+
+	if exp, got := map[string]int{peter: 1}, s5.Range(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected s5.Range() to be %v; got %v", exp, got)
+	}
+
+	// s3 must be unaffected by the mutations that derived s5
+	if exp, got := map[string]int{peter: age42, paul: 1}, s3.Range(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected s3.Range() to be %v; got %v", exp, got)
+	}
+
+	if s5.Mutable() {
+		t.Fatalf("s5 should not be mutable")
+	}
+}
+
+func TestMyPersistentSlice(t *testing.T) {
+	s1 := coretest.NewMyPersistentSlice(paul)
+	s2 := s1.Append(peter)
+	s3 := s2.Set(0, peter)
+
+	if exp, got := []string{paul}, s1.Range(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected s1.Range() to be %v; got %v", exp, got)
+	}
+
+	if exp, got := []string{paul, peter}, s2.Range(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected s2.Range() to be %v; got %v", exp, got)
+	}
+
+	if exp, got := []string{peter, peter}, s3.Range(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected s3.Range() to be %v; got %v", exp, got)
+	}
+
+	s4 := coretest.NewMyPersistentSliceLen(100).WithMutable(func(s *coretest.MyPersistentSlice) {
+		for i := 0; i < s.Len(); i++ {
+			s.Set(i, peter)
+		}
+		s.Append(paul)
+	})
+
+	if s4.Len() != 101 || s4.Get(99) != peter || s4.Get(100) != paul {
+		t.Fatalf("unexpected s4: %v", s4.Range())
+	}
+}
+
+func TestPersistentDeeplyNonMutable(t *testing.T) {
+	aimm := new(coretest.A)
+	amut := new(coretest.A).AsMutable()
+
+	if !coretest.NewAPS(aimm).IsDeeplyNonMutable(nil) {
+		t.Fatalf("slice of immutable values should be DeeplyNonMutable")
+	}
+
+	if coretest.NewAPS(aimm, amut).IsDeeplyNonMutable(nil) {
+		t.Fatalf("slice referencing a mutable value should not be DeeplyNonMutable")
+	}
+
+	if !coretest.NewAPM().Set(aimm, aimm).IsDeeplyNonMutable(nil) {
+		t.Fatalf("map of immutable values should be DeeplyNonMutable")
+	}
+
+	if coretest.NewAPM().Set(amut, nil).IsDeeplyNonMutable(nil) {
+		t.Fatalf("map with a mutable key should not be DeeplyNonMutable")
+	}
+
+	if coretest.NewAPM().Set(aimm, amut).IsDeeplyNonMutable(nil) {
+		t.Fatalf("map with a mutable value should not be DeeplyNonMutable")
+	}
+}
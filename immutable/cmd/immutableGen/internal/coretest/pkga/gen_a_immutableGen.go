@@ -5,6 +5,8 @@ package pkga
 //immutableVet:skipFile
 
 import (
+	"encoding/json"
+
 	"myitcv.io/immutable"
 
 	"myitcv.io/immutable/cmd/immutableGen/internal/coretest/pkgb"
@@ -139,6 +141,95 @@ func (s *PkgA) SetPostcode(n string) *PkgA {
 	return v0
 }
 
+// Equal returns whether the fields of s and other are equal
+func (s *PkgA) Equal(other *PkgA) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.anonfield_PkgB.Equal(other.anonfield_PkgB)) {
+		return false
+	}
+
+	if !(s.field_Address == other.field_Address) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *PkgA) Diff(other *PkgA) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.anonfield_PkgB.Equal(other.anonfield_PkgB)) {
+		if s.anonfield_PkgB != nil && other.anonfield_PkgB != nil {
+			res = append(res, immutable.PrefixChanges(".PkgB", s.anonfield_PkgB.Diff(other.anonfield_PkgB))...)
+		} else {
+			res = append(res, immutable.Change{Path: ".PkgB", Kind: immutable.ChangeModified, Old: s.anonfield_PkgB, New: other.anonfield_PkgB})
+		}
+	}
+
+	if !(s.field_Address == other.field_Address) {
+		res = append(res, immutable.Change{Path: ".Address", Kind: immutable.ChangeModified, Old: s.field_Address, New: other.field_Address})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *PkgA) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		PkgB    *pkgb.PkgB
+		Address string
+	}{
+		PkgB:    s.anonfield_PkgB,
+		Address: s.field_Address,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *PkgA) UnmarshalJSON(b []byte) error {
+	var v struct {
+		PkgB    *pkgb.PkgB
+		Address string
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = PkgA{
+		anonfield_PkgB: v.PkgB,
+		field_Address:  v.Address,
+	}
+
+	return nil
+}
+
 //
 // Clash2 is an immutable type and has the following template:
 //
@@ -253,6 +344,91 @@ func (s *Clash2) SetNoClash2(n string) *Clash2 {
 	return &res
 }
 
+// Equal returns whether the fields of s and other are equal
+func (s *Clash2) Equal(other *Clash2) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_Clash == other.field_Clash) {
+		return false
+	}
+
+	if !(s.field_NoClash2 == other.field_NoClash2) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *Clash2) Diff(other *Clash2) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_Clash == other.field_Clash) {
+		res = append(res, immutable.Change{Path: ".Clash", Kind: immutable.ChangeModified, Old: s.field_Clash, New: other.field_Clash})
+	}
+
+	if !(s.field_NoClash2 == other.field_NoClash2) {
+		res = append(res, immutable.Change{Path: ".NoClash2", Kind: immutable.ChangeModified, Old: s.field_NoClash2, New: other.field_NoClash2})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *Clash2) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		Clash    string
+		NoClash2 string
+	}{
+		Clash:    s.field_Clash,
+		NoClash2: s.field_NoClash2,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *Clash2) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Clash    string
+		NoClash2 string
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = Clash2{
+		field_Clash:    v.Clash,
+		field_NoClash2: v.NoClash2,
+	}
+
+	return nil
+}
+
 //
 // OtherA is an immutable type and has the following template:
 //
@@ -349,3 +525,76 @@ func (s *OtherA) SetOtherNameA(n string) *OtherA {
 	res.field_OtherNameA = n
 	return &res
 }
+
+// Equal returns whether the fields of s and other are equal
+func (s *OtherA) Equal(other *OtherA) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_OtherNameA == other.field_OtherNameA) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *OtherA) Diff(other *OtherA) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_OtherNameA == other.field_OtherNameA) {
+		res = append(res, immutable.Change{Path: ".OtherNameA", Kind: immutable.ChangeModified, Old: s.field_OtherNameA, New: other.field_OtherNameA})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *OtherA) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		OtherNameA string
+	}{
+		OtherNameA: s.field_OtherNameA,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *OtherA) UnmarshalJSON(b []byte) error {
+	var v struct {
+		OtherNameA string
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = OtherA{
+		field_OtherNameA: v.OtherNameA,
+	}
+
+	return nil
+}
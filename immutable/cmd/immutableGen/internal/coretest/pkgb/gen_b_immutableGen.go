@@ -5,6 +5,8 @@ package pkgb
 //immutableVet:skipFile
 
 import (
+	"encoding/json"
+
 	"myitcv.io/immutable"
 )
 
@@ -104,3 +106,76 @@ func (s *PkgB) SetPostcode(n string) *PkgB {
 	res.field_Postcode = n
 	return &res
 }
+
+// Equal returns whether the fields of s and other are equal
+func (s *PkgB) Equal(other *PkgB) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_Postcode == other.field_Postcode) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *PkgB) Diff(other *PkgB) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_Postcode == other.field_Postcode) {
+		res = append(res, immutable.Change{Path: ".Postcode", Kind: immutable.ChangeModified, Old: s.field_Postcode, New: other.field_Postcode})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *PkgB) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		Postcode string
+	}{
+		Postcode: s.field_Postcode,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *PkgB) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Postcode string
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = PkgB{
+		field_Postcode: v.Postcode,
+	}
+
+	return nil
+}
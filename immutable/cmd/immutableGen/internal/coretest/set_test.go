@@ -0,0 +1,172 @@
+package coretest_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"myitcv.io/immutable"
+	"myitcv.io/immutable/cmd/immutableGen/internal/coretest"
+)
+
+func TestMySetZeroValue(t *testing.T) {
+	s1 := new(coretest.MySet)
+
+	if s1.Mutable() {
+		t.Fatalf("zero value should be immutable")
+	}
+
+	if s1.Len() != 0 {
+		t.Fatalf("zero value should have zero length")
+	}
+
+	if s1.Contains(paul) {
+		t.Fatalf("zero value should not contain %v", paul)
+	}
+
+	s2 := s1.Add(paul)
+
+	if s1 == s2 {
+		t.Fatalf("Add on an immutable set should return a new set")
+	}
+
+	if s1.Len() != 0 || !s2.Contains(paul) {
+		t.Fatalf("expected only s2 to contain %v", paul)
+	}
+}
+
+func TestMySetAddRemove(t *testing.T) {
+	s1 := coretest.NewMySet(paul)
+
+	if s2 := s1.Add(paul); s2 != s1 {
+		t.Fatalf("adding an existing element should return the same set")
+	}
+
+	if s2 := s1.Remove(peter); s2 != s1 {
+		t.Fatalf("removing a missing element should return the same set")
+	}
+
+	s2 := s1.Add(peter)
+
+	if exp, got := []string{paul, peter}, s2.Sorted(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %v; got %v", exp, got)
+	}
+
+	if exp, got := []string{paul}, s1.Sorted(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("s1 should be unchanged; expected %v; got %v", exp, got)
+	}
+
+	s3 := s2.Remove(paul)
+
+	if s3.Contains(paul) || !s3.Contains(peter) || !s2.Contains(paul) {
+		t.Fatalf("unexpected result of Remove: %v (s2 %v)", s3.Sorted(), s2.Sorted())
+	}
+
+	s4 := s2.WithMutable(func(s *coretest.MySet) {
+		s.Add("bill")
+		s.Remove(peter)
+	})
+
+	if exp, got := []string{"bill", paul}, s4.Sorted(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %v; got %v", exp, got)
+	}
+
+	if s4.Mutable() {
+		t.Fatalf("result of WithMutable should be immutable")
+	}
+
+	if exp, got := []string{paul, "bill"}, s4.SortedFunc(func(a, b string) bool { return a > b }); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %v; got %v", exp, got)
+	}
+}
+
+func TestMySetOps(t *testing.T) {
+	s1 := coretest.NewMySet(paul, peter)
+	s2 := coretest.NewMySet(peter, "bill")
+
+	check := func(name string, got *coretest.MySet, exp ...string) {
+		t.Helper()
+		if got := got.Sorted(); !reflect.DeepEqual(got, exp) {
+			t.Fatalf("expected %v to be %v; got %v", name, exp, got)
+		}
+	}
+
+	check("Union", s1.Union(s2), "bill", paul, peter)
+	check("Intersect", s1.Intersect(s2), peter)
+	check("Difference", s1.Difference(s2), paul)
+	check("s1", s1, paul, peter)
+	check("s2", s2, "bill", peter)
+
+	if s1.Union(coretest.NewMySet(paul)) != s1 {
+		t.Fatalf("Union with a subset should return the same set")
+	}
+
+	if s1.Intersect(s1.Add("bill")) != s1 {
+		t.Fatalf("Intersect with a superset should return the same set")
+	}
+
+	if s1.Difference(coretest.NewMySet("bill")) != s1 {
+		t.Fatalf("Difference with a disjoint set should return the same set")
+	}
+}
+
+func TestMySetEqualDiff(t *testing.T) {
+	s1 := coretest.NewMySet(paul, peter)
+	s2 := coretest.NewMySet(peter, "bill")
+
+	if !s1.Equal(coretest.NewMySet(peter, paul)) {
+		t.Fatalf("expected sets with the same elements to be equal")
+	}
+
+	if s1.Equal(s2) {
+		t.Fatalf("expected s1 and s2 not to be equal")
+	}
+
+	t1 := new(coretest.Tagged).SetName(paul).SetTags(s1)
+	t2 := t1.SetTags(s2)
+
+	exp := []immutable.Change{
+		{Path: `.Tags["bill"]`, Kind: immutable.ChangeAdded, New: "bill"},
+		{Path: `.Tags["paul"]`, Kind: immutable.ChangeRemoved, Old: paul},
+	}
+	if got := t1.Diff(t2); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %v; got %v", exp, got)
+	}
+}
+
+func TestMySetDeeplyNonMutable(t *testing.T) {
+	a1 := new(coretest.A).AsMutable()
+	s1 := coretest.NewASet(a1)
+
+	if s1.IsDeeplyNonMutable(nil) {
+		t.Fatalf("set containing a mutable value should not be deeply non-mutable")
+	}
+
+	a1.AsImmutable(nil)
+
+	if !s1.IsDeeplyNonMutable(nil) {
+		t.Fatalf("set should be deeply non-mutable")
+	}
+}
+
+func TestMySetJSON(t *testing.T) {
+	s1 := coretest.NewMySet(peter, paul)
+
+	b, err := json.Marshal(s1)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	if exp := `["paul","peter"]`; string(b) != exp {
+		t.Fatalf("expected %v; got %s", exp, b)
+	}
+
+	var s2 *coretest.MySet
+	if err := json.Unmarshal(b, &s2); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if !s1.Equal(s2) || s2.Mutable() {
+		t.Fatalf("expected immutable %v; got %v", s1.Sorted(), s2.Sorted())
+	}
+}
@@ -0,0 +1,455 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+import (
+	"go/ast"
+	"strconv"
+	"strings"
+)
+
+const (
+	marshalJSONMethod   = "MarshalJSON"
+	unmarshalJSONMethod = "UnmarshalJSON"
+)
+
+// declaresMethod returns whether the package declares the method m on the
+// pointer type of the immutable type name, in which case we must not generate
+// it
+func (o *output) declaresMethod(name, m string) bool {
+	return o.methods["*"+name][m]
+}
+
+// genMapJSON generates MarshalJSON and UnmarshalJSON methods for an immutable
+// map. The map is (un)marshalled as a Go map of the template type; the result of
+// unmarshalling is immutable.
+func (o *output) genMapJSON(name, keyType, valType string) {
+	exp := exporter(name)
+
+	tmpl := struct {
+		Name    string
+		KeyType string
+		ValType string
+	}{
+		Name:    name,
+		KeyType: keyType,
+		ValType: valType,
+	}
+
+	if !o.declaresMethod(name, marshalJSONMethod) {
+		o.stdImports["encoding/json"] = true
+		o.pt(`
+		// MarshalJSON implements json.Marshaler, marshalling m as a map[{{.KeyType}}]{{.ValType}}
+		func (m *{{.Name}}) MarshalJSON() ([]byte, error) {
+			return json.Marshal(m.Range())
+		}
+		`, exp, tmpl)
+	}
+
+	if !o.declaresMethod(name, unmarshalJSONMethod) {
+		o.stdImports["encoding/json"] = true
+		o.pt(`
+		// UnmarshalJSON implements json.Unmarshaler, unmarshalling a
+		// map[{{.KeyType}}]{{.ValType}} into m. m is left immutable.
+		func (m *{{.Name}}) UnmarshalJSON(b []byte) error {
+			var v map[{{.KeyType}}]{{.ValType}}
+
+			if err := json.Unmarshal(b, &v); err != nil {
+				return err
+			}
+
+			*m = *{{Export "New"}}{{Capitalise .Name}}Cap(len(v)).WithMutable(func(mi *{{.Name}}) {
+				for k, e := range v {
+					mi.Set(k, e)
+				}
+			})
+
+			return nil
+		}
+		`, exp, tmpl)
+	}
+}
+
+// genOrderedMapJSON generates MarshalJSON and UnmarshalJSON methods for an
+// immutable ordered map. The map is (un)marshalled as a JSON object, the
+// members of which are in the order of the map; members are set in the order
+// they appear when unmarshalling. The result of unmarshalling is immutable.
+func (o *output) genOrderedMapJSON(name, keyType, valType string) {
+	exp := exporter(name)
+
+	tmpl := struct {
+		Name    string
+		KeyType string
+		ValType string
+	}{
+		Name:    name,
+		KeyType: keyType,
+		ValType: valType,
+	}
+
+	if !o.declaresMethod(name, marshalJSONMethod) {
+		o.pt(`
+		// MarshalJSON implements json.Marshaler, marshalling m as a JSON object
+		// the members of which are in the order of m
+		func (m *{{.Name}}) MarshalJSON() ([]byte, error) {
+			if m == nil {
+				return []byte("null"), nil
+			}
+
+			return m.tree().MarshalJSON()
+		}
+		`, exp, tmpl)
+	}
+
+	if !o.declaresMethod(name, unmarshalJSONMethod) {
+		o.pt(`
+		// UnmarshalJSON implements json.Unmarshaler, unmarshalling a JSON object
+		// into m, the members of which are set in the order they appear. m is
+		// left immutable.
+		func (m *{{.Name}}) UnmarshalJSON(b []byte) error {
+			t, err := {{Export "New"}}{{Capitalise .Name}}Cap(0).tree().SetJSON(b)
+			if err != nil {
+				return err
+			}
+
+			*m = {{.Name}}{theMap: t}
+
+			return nil
+		}
+		`, exp, tmpl)
+	}
+}
+
+// genContainerJSON generates MarshalJSON and UnmarshalJSON methods for an
+// immutable map or slice in container mode, that delegate to the container
+// type ctyp held in field. The value is (un)marshalled as a Go value of the
+// template type typ; the result of unmarshalling is immutable.
+func (o *output) genContainerJSON(name, field, ctyp, typ string) {
+	exp := exporter(name)
+
+	tmpl := struct {
+		Name      string
+		Field     string
+		Container string
+		Type      string
+	}{
+		Name:      name,
+		Field:     field,
+		Container: ctyp,
+		Type:      typ,
+	}
+
+	if !o.declaresMethod(name, marshalJSONMethod) {
+		o.pt(`
+		// MarshalJSON implements json.Marshaler, marshalling m as a {{.Type}}
+		func (m *{{.Name}}) MarshalJSON() ([]byte, error) {
+			if m == nil {
+				return []byte("null"), nil
+			}
+
+			return m.{{.Field}}.MarshalJSON()
+		}
+		`, exp, tmpl)
+	}
+
+	if !o.declaresMethod(name, unmarshalJSONMethod) {
+		o.pt(`
+		// UnmarshalJSON implements json.Unmarshaler, unmarshalling a {{.Type}}
+		// into m. m is left immutable.
+		func (m *{{.Name}}) UnmarshalJSON(b []byte) error {
+			c := new({{.Container}})
+			if err := c.UnmarshalJSON(b); err != nil {
+				return err
+			}
+
+			*m = {{.Name}}{ {{- .Field}}: c}
+
+			return nil
+		}
+		`, exp, tmpl)
+	}
+}
+
+// genSetJSON generates MarshalJSON and UnmarshalJSON methods for an immutable
+// set. The set is (un)marshalled as a Go slice of its element type, sorted in
+// the case the element type is ordered; the result of unmarshalling is
+// immutable.
+func (o *output) genSetJSON(name, typ string, ordered bool) {
+	exp := exporter(name)
+
+	tmpl := struct {
+		Name    string
+		Type    string
+		Ordered bool
+	}{
+		Name:    name,
+		Type:    typ,
+		Ordered: ordered,
+	}
+
+	if !o.declaresMethod(name, marshalJSONMethod) {
+		o.stdImports["encoding/json"] = true
+		o.pt(`
+		// MarshalJSON implements json.Marshaler, marshalling m as a []{{.Type}}
+		{{- if .Ordered}} in ascending order{{end}}
+		func (m *{{.Name}}) MarshalJSON() ([]byte, error) {
+		{{- if .Ordered}}
+			return json.Marshal(m.Sorted())
+		{{- else}}
+			v := make([]{{.Type}}, 0, m.Len())
+
+			for e := range m.Range() {
+				v = append(v, e)
+			}
+
+			return json.Marshal(v)
+		{{- end}}
+		}
+		`, exp, tmpl)
+	}
+
+	if !o.declaresMethod(name, unmarshalJSONMethod) {
+		o.stdImports["encoding/json"] = true
+		o.pt(`
+		// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []{{.Type}}
+		// into m. m is left immutable.
+		func (m *{{.Name}}) UnmarshalJSON(b []byte) error {
+			var v []{{.Type}}
+
+			if err := json.Unmarshal(b, &v); err != nil {
+				return err
+			}
+
+			*m = *{{Export "New"}}{{Capitalise .Name}}(v...)
+
+			return nil
+		}
+		`, exp, tmpl)
+	}
+}
+
+// genSliceJSON generates MarshalJSON and UnmarshalJSON methods for an
+// immutable slice. The slice is (un)marshalled as a Go slice of the template
+// type; the result of unmarshalling is immutable.
+func (o *output) genSliceJSON(name, typ string) {
+	exp := exporter(name)
+
+	tmpl := struct {
+		Name string
+		Type string
+	}{
+		Name: name,
+		Type: typ,
+	}
+
+	if !o.declaresMethod(name, marshalJSONMethod) {
+		o.stdImports["encoding/json"] = true
+		o.pt(`
+		// MarshalJSON implements json.Marshaler, marshalling m as a []{{.Type}}
+		func (m *{{.Name}}) MarshalJSON() ([]byte, error) {
+			return json.Marshal(m.Range())
+		}
+		`, exp, tmpl)
+	}
+
+	if !o.declaresMethod(name, unmarshalJSONMethod) {
+		o.stdImports["encoding/json"] = true
+		o.pt(`
+		// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []{{.Type}}
+		// into m. m is left immutable.
+		func (m *{{.Name}}) UnmarshalJSON(b []byte) error {
+			var v []{{.Type}}
+
+			if err := json.Unmarshal(b, &v); err != nil {
+				return err
+			}
+
+			*m = *{{Export "New"}}{{Capitalise .Name}}(v...)
+
+			return nil
+		}
+		`, exp, tmpl)
+	}
+}
+
+// jsonField is a field of the template of an immutable struct that takes part
+// in JSON (un)marshalling
+type jsonField struct {
+	// Name is the name of the field in the template
+	Name string
+
+	// Field is the name of the field in the generated struct
+	Field string
+
+	Type string
+	Tag  string
+}
+
+// genStructJSON generates MarshalJSON and UnmarshalJSON methods for an
+// immutable struct. The struct is (un)marshalled as if it were the template
+// struct, with the following exception: the fields of embedded structs are
+// not promoted; instead, an exported embedded field is (un)marshalled as a
+// field named for its type. Unexported fields are ignored, as are fields
+// tagged json:"-". The result of unmarshalling is immutable. Unmarshalling
+// starts from a zero value, never the value of the receiver, because the
+// values of the receiver's fields, e.g. pointers to other immutable values,
+// can be shared with other values.
+func (o *output) genStructJSON(s *immStruct) {
+	var fields []jsonField
+
+	for _, f := range s.fields {
+		if !ast.IsExported(f.name) {
+			continue
+		}
+
+		name := fieldNamePrefix + fieldHidingPrefix + f.name
+		if f.anon {
+			name = fieldAnonPrefix + name
+		}
+
+		tag := ""
+		if f.field.Tag != nil {
+			tag = f.field.Tag.Value
+		}
+
+		fields = append(fields, jsonField{
+			Name:  f.name,
+			Field: name,
+			Type:  o.exprString(f.field.Type),
+			Tag:   tag,
+		})
+	}
+
+	var sb strings.Builder
+	sb.WriteString("struct {\n")
+	for _, f := range fields {
+		sb.WriteString(f.Name + " " + f.Type + " " + f.Tag + "\n")
+	}
+	sb.WriteString("}")
+
+	tmpl := struct {
+		Name   string
+		Struct string
+		Fields []jsonField
+	}{
+		Name:   s.name,
+		Struct: sb.String(),
+		Fields: fields,
+	}
+
+	exp := exporter(s.name)
+
+	if !o.declaresMethod(s.name, marshalJSONMethod) {
+		o.stdImports["encoding/json"] = true
+		o.pt(`
+		// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+		// value of its template type
+		func (s *{{.Name}}) MarshalJSON() ([]byte, error) {
+			if s == nil {
+				return []byte("null"), nil
+			}
+
+			v := {{.Struct}}{
+				{{range .Fields -}}
+				{{.Name}}: s.{{.Field}},
+				{{end -}}
+			}
+
+			return json.Marshal(v)
+		}
+		`, exp, tmpl)
+	}
+
+	if !o.declaresMethod(s.name, unmarshalJSONMethod) {
+		o.stdImports["encoding/json"] = true
+		o.pt(`
+		// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+		// value of the template type of s, from which s is then set. s is left
+		// immutable.
+		func (s *{{.Name}}) UnmarshalJSON(b []byte) error {
+			var v {{.Struct}}
+
+			if err := json.Unmarshal(b, &v); err != nil {
+				return err
+			}
+
+			*s = {{.Name}}{
+				{{range .Fields -}}
+				{{.Field}}: v.{{.Name}},
+				{{end -}}
+			}
+
+			return nil
+		}
+		`, exp, tmpl)
+	}
+}
+
+// withoutJSONTag returns the struct tag literal lit without any json key. The
+// tags of template fields are copied to the unexported fields of the
+// generated struct, where a json key is meaningless (JSON is handled by the
+// generated MarshalJSON and UnmarshalJSON methods) and upsets go vet.
+func withoutJSONTag(lit string) string {
+	tag, err := strconv.Unquote(lit)
+	if err != nil {
+		return lit
+	}
+
+	var keep []string
+	changed := false
+
+	// parsing per reflect.StructTag.Lookup
+	for tag != "" {
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			// malformed; leave well alone
+			return lit
+		}
+		name := tag[:i]
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			return lit
+		}
+		pair := name + ":" + tag[:i+1]
+		tag = tag[i+1:]
+
+		if name == "json" {
+			changed = true
+			continue
+		}
+		keep = append(keep, pair)
+	}
+
+	if !changed {
+		return lit
+	}
+	if len(keep) == 0 {
+		return ""
+	}
+	res := strings.Join(keep, " ")
+	if strings.Contains(res, "`") {
+		return strconv.Quote(res)
+	}
+	return "`" + res + "`"
+}
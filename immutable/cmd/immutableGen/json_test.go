@@ -0,0 +1,26 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+import "testing"
+
+func TestWithoutJSONTag(t *testing.T) {
+	checks := []struct {
+		in  string
+		out string
+	}{
+		{"`tag:\"value\"`", "`tag:\"value\"`"},
+		{"`json:\"name\"`", ""},
+		{"`json:\"name,omitempty\" tag:\"value\"`", "`tag:\"value\"`"},
+		{"`a:\"1\" json:\"-\" b:\"2\"`", "`a:\"1\" b:\"2\"`"},
+		{"\"json:\\\"x\\\" a:\\\"`\\\"\"", "\"a:\\\"`\\\"\""},
+		{"`malformed json`", "`malformed json`"},
+	}
+
+	for _, c := range checks {
+		if got := withoutJSONTag(c.in); got != c.out {
+			t.Errorf("withoutJSONTag(%v): expected %v; got %v", c.in, c.out, got)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+// immContainerMapTmpl is the equivalent of immMapTmpl in container mode. The
+// map is a thin wrapper around a *container.Map, which holds both the entries
+// and the mutability of the map.
+const immContainerMapTmpl = `
+var _ immutable.Immutable = new({{.Name}})
+var _ = new({{.Name}}).__tmpl
+
+func {{Export "New"}}{{Capitalise .Name}}(inits ...func(m *{{.Name}})) *{{.Name}} {
+	res := {{Export "New"}}{{Capitalise .Name}}Cap(0)
+	if len(inits) == 0 {
+		return res
+	}
+
+	return res.WithMutable(func (m *{{.Name}}) {
+		for _, i := range inits {
+			i(m)
+		}
+	})
+}
+
+func {{Export "New"}}{{Capitalise .Name}}Cap(l int) *{{.Name}} {
+	return &{{.Name}}{
+		theMap: container.NewMap[{{.KeyType}}, {{.ValType}}](l),
+	}
+}
+
+// wrap returns m if c is the container of m, else a new {{.Name}} around c
+func (m *{{.Name}}) wrap(c *container.Map[{{.KeyType}}, {{.ValType}}]) *{{.Name}} {
+	if c == m.theMap {
+		return m
+	}
+
+	return &{{.Name}}{
+		theMap: c,
+	}
+}
+
+func (m *{{.Name}}) Mutable() bool {
+	return m.theMap.Mutable()
+}
+
+func (m *{{.Name}}) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theMap.Len()
+}
+
+func (m *{{.Name}}) Get(k {{.KeyType}}) ({{.ValType}}, bool) {
+	return m.theMap.Get(k)
+}
+
+func (m *{{.Name}}) AsMutable() *{{.Name}} {
+	if m == nil {
+		return nil
+	}
+
+	return m.wrap(m.theMap.AsMutable())
+}
+
+func (m *{{.Name}}) AsImmutable(v *{{.Name}}) *{{.Name}} {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.theMap.AsImmutable()
+	return m
+}
+
+func (m *{{.Name}}) Range() map[{{.KeyType}}]{{.ValType}} {
+	if m == nil {
+		return nil
+	}
+
+	return m.theMap.Range()
+}
+
+func (mr *{{.Name}}) WithMutable(f func({{.VarName}} *{{.Name}})) *{{.Name}} {
+	res := mr.AsMutable()
+	f(res)
+	res = res.AsImmutable(mr)
+
+	return res
+}
+
+func (mr *{{.Name}}) WithImmutable(f func({{.VarName}} *{{.Name}})) *{{.Name}} {
+	mr.theMap.WithImmutable(func() {
+		f(mr)
+	})
+
+	return mr
+}
+
+func (m *{{.Name}}) Set(k {{.KeyType}}, v {{.ValType}}) *{{.Name}} {
+	return m.wrap(m.theMap.Set(k, v))
+}
+
+func (m *{{.Name}}) Del(k {{.KeyType}}) *{{.Name}} {
+	return m.wrap(m.theMap.Del(k))
+}
+`
@@ -0,0 +1,104 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+// immContainerSliceTmpl is the equivalent of immSliceTmpl in container mode.
+// The slice is a thin wrapper around a *container.Slice, which holds both the
+// values and the mutability of the slice.
+const immContainerSliceTmpl = `
+var _ immutable.Immutable = new({{.Name}})
+var _ = new({{.Name}}).__tmpl
+
+func {{Export "New"}}{{Capitalise .Name}}(s ...{{.Type}}) *{{.Name}} {
+	return &{{.Name}}{
+		theSlice: container.NewSlice(s...),
+	}
+}
+
+func {{Export "New"}}{{Capitalise .Name}}Len(l int) *{{.Name}} {
+	return &{{.Name}}{
+		theSlice: container.NewSliceLen[{{.Type}}](l),
+	}
+}
+
+// wrap returns m if c is the container of m, else a new {{.Name}} around c
+func (m *{{.Name}}) wrap(c *container.Slice[{{.Type}}]) *{{.Name}} {
+	if c == m.theSlice {
+		return m
+	}
+
+	return &{{.Name}}{
+		theSlice: c,
+	}
+}
+
+func (m *{{.Name}}) Mutable() bool {
+	return m.theSlice.Mutable()
+}
+
+func (m *{{.Name}}) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theSlice.Len()
+}
+
+func (m *{{.Name}}) Get(i int) {{.Type}} {
+	return m.theSlice.Get(i)
+}
+
+func (m *{{.Name}}) AsMutable() *{{.Name}} {
+	if m == nil {
+		return nil
+	}
+
+	return m.wrap(m.theSlice.AsMutable())
+}
+
+func (m *{{.Name}}) AsImmutable(v *{{.Name}}) *{{.Name}} {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.theSlice.AsImmutable()
+	return m
+}
+
+func (m *{{.Name}}) Range() []{{.Type}} {
+	if m == nil {
+		return nil
+	}
+
+	return m.theSlice.Range()
+}
+
+func (m *{{.Name}}) WithMutable(f func(mi *{{.Name}})) *{{.Name}} {
+	res := m.AsMutable()
+	f(res)
+	res = res.AsImmutable(m)
+
+	return res
+}
+
+func (m *{{.Name}}) WithImmutable(f func(mi *{{.Name}})) *{{.Name}} {
+	m.theSlice.WithImmutable(func() {
+		f(m)
+	})
+
+	return m
+}
+
+func (m *{{.Name}}) Set(i int, v {{.Type}}) *{{.Name}} {
+	return m.wrap(m.theSlice.Set(i, v))
+}
+
+func (m *{{.Name}}) Append(v ...{{.Type}}) *{{.Name}} {
+	return m.wrap(m.theSlice.Append(v...))
+}
+`
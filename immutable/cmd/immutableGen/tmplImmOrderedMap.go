@@ -0,0 +1,207 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+// immOrderedMapTmpl is the equivalent of immPersistentMapTmpl for templates
+// marked with orderedDirective. The map is backed by a *persistent.Tree,
+// hence dup() is O(1) and Set and Del are O(log n). Range() returns the
+// entries of the map in order. Floor, Ceil and Between are only generated for
+// maps ordered by key, i.e. not by insertion.
+const immOrderedMapTmpl = `
+var _ immutable.Immutable = new({{.Name}})
+var _ = new({{.Name}}).__tmpl
+
+func {{Export "New"}}{{Capitalise .Name}}(inits ...func(m *{{.Name}})) *{{.Name}} {
+	res := {{Export "New"}}{{Capitalise .Name}}Cap(0)
+	if len(inits) == 0 {
+		return res
+	}
+
+	return res.WithMutable(func (m *{{.Name}}) {
+		for _, i := range inits {
+			i(m)
+		}
+	})
+}
+
+func {{Export "New"}}{{Capitalise .Name}}Cap(l int) *{{.Name}} {
+	return &{{.Name}}{
+		theMap: persistent.NewTree[{{.KeyType}}, {{.ValType}}]({{.Less}}),
+	}
+}
+
+// tree returns the persistent.Tree that backs m, or an empty tree if m is nil
+// or the zero value
+func (m *{{.Name}}) tree() *persistent.Tree[{{.KeyType}}, {{.ValType}}] {
+	if m == nil || m.theMap == nil {
+		return persistent.NewTree[{{.KeyType}}, {{.ValType}}]({{.Less}})
+	}
+
+	return m.theMap
+}
+
+func (m *{{.Name}})Mutable() bool {
+	return m.mutable
+}
+
+func (m *{{.Name}}) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theMap.Len()
+}
+
+func (m *{{.Name}}) Get(k {{.KeyType}}) ({{.ValType}}, bool) {
+	return m.theMap.Get(k)
+}
+
+func (m *{{.Name}}) AsMutable() *{{.Name}} {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *{{.Name}}) dup() *{{.Name}} {
+	res := &{{.Name}}{
+		theMap: m.theMap,
+	}
+
+	return res
+}
+
+func (m *{{.Name}}) AsImmutable(v *{{.Name}}) *{{.Name}} {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+// Range returns the entries of m, in order
+func (m *{{.Name}}) Range() []persistent.Entry[{{.KeyType}}, {{.ValType}}] {
+	if m == nil {
+		return nil
+	}
+
+	return m.theMap.Entries()
+}
+
+// Each calls f for each entry of m, in order, until f returns false
+func (m *{{.Name}}) Each(f func(k {{.KeyType}}, v {{.ValType}}) bool) {
+	if m == nil {
+		return
+	}
+
+	m.theMap.Each(f)
+}
+
+// Keys returns the keys of m, in order
+func (m *{{.Name}}) Keys() []{{.KeyType}} {
+	res := make([]{{.KeyType}}, 0, m.Len())
+	m.Each(func(k {{.KeyType}}, _ {{.ValType}}) bool {
+		res = append(res, k)
+		return true
+	})
+
+	return res
+}
+
+// Min returns the first entry of m and true, or zero values and false if m is
+// empty
+func (m *{{.Name}}) Min() ({{.KeyType}}, {{.ValType}}, bool) {
+	return m.tree().Min()
+}
+
+// Max returns the last entry of m and true, or zero values and false if m is
+// empty
+func (m *{{.Name}}) Max() ({{.KeyType}}, {{.ValType}}, bool) {
+	return m.tree().Max()
+}
+{{- if .Sorted}}
+
+// Floor returns the entry of m with the greatest key less than or equal to k
+// and true, or zero values and false if there is no such entry
+func (m *{{.Name}}) Floor(k {{.KeyType}}) ({{.KeyType}}, {{.ValType}}, bool) {
+	return m.tree().Floor(k)
+}
+
+// Ceil returns the entry of m with the least key greater than or equal to k
+// and true, or zero values and false if there is no such entry
+func (m *{{.Name}}) Ceil(k {{.KeyType}}) ({{.KeyType}}, {{.ValType}}, bool) {
+	return m.tree().Ceil(k)
+}
+
+// Between returns the entries of m with keys greater than or equal to lo, and
+// less than hi, in order
+func (m *{{.Name}}) Between(lo, hi {{.KeyType}}) []persistent.Entry[{{.KeyType}}, {{.ValType}}] {
+	var res []persistent.Entry[{{.KeyType}}, {{.ValType}}]
+	m.tree().EachBetween(lo, hi, func(k {{.KeyType}}, v {{.ValType}}) bool {
+		res = append(res, persistent.Entry[{{.KeyType}}, {{.ValType}}]{Key: k, Value: v})
+		return true
+	})
+
+	return res
+}
+{{- end}}
+
+func (mr *{{.Name}}) WithMutable(f func({{.VarName}} *{{.Name}})) *{{.Name}} {
+	res := mr.AsMutable()
+	f(res)
+	res = res.AsImmutable(mr)
+
+	return res
+}
+
+func (mr *{{.Name}}) WithImmutable(f func({{.VarName}} *{{.Name}})) *{{.Name}} {
+	prev := mr.mutable
+	mr.mutable = false
+	f(mr)
+	mr.mutable = prev
+
+	return mr
+}
+
+func (m *{{.Name}}) Set(k {{.KeyType}}, v {{.ValType}}) *{{.Name}} {
+	if m.mutable {
+		m.theMap = m.tree().Set(k, v)
+		return m
+	}
+
+	res := m.dup()
+	res.theMap = m.tree().Set(k, v)
+
+	return res
+}
+
+func (m *{{.Name}}) Del(k {{.KeyType}}) *{{.Name}} {
+	if _, ok := m.theMap.Get(k); !ok {
+		return m
+	}
+
+	if m.mutable {
+		m.theMap = m.theMap.Del(k)
+		return m
+	}
+
+	res := m.dup()
+	res.theMap = res.theMap.Del(k)
+
+	return res
+}
+`
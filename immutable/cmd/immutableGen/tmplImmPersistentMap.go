@@ -0,0 +1,137 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+// immPersistentMapTmpl is the equivalent of immMapTmpl for templates marked
+// with persistentDirective. The map is backed by a *persistent.Map, hence
+// dup() is O(1) and Set and Del are O(log n). Range() materialises a Go map
+// and is O(n).
+const immPersistentMapTmpl = `
+var _ immutable.Immutable = new({{.Name}})
+var _ = new({{.Name}}).__tmpl
+
+func {{Export "New"}}{{Capitalise .Name}}(inits ...func(m *{{.Name}})) *{{.Name}} {
+	res := {{Export "New"}}{{Capitalise .Name}}Cap(0)
+	if len(inits) == 0 {
+		return res
+	}
+
+	return res.WithMutable(func (m *{{.Name}}) {
+		for _, i := range inits {
+			i(m)
+		}
+	})
+}
+
+func {{Export "New"}}{{Capitalise .Name}}Cap(l int) *{{.Name}} {
+	return &{{.Name}}{
+		theMap: persistent.NewMap[{{.KeyType}}, {{.ValType}}](),
+	}
+}
+
+func (m *{{.Name}})Mutable() bool {
+	return m.mutable
+}
+
+func (m *{{.Name}}) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theMap.Len()
+}
+
+func (m *{{.Name}}) Get(k {{.KeyType}}) ({{.ValType}}, bool) {
+	return m.theMap.Get(k)
+}
+
+func (m *{{.Name}}) AsMutable() *{{.Name}} {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *{{.Name}}) dup() *{{.Name}} {
+	res := &{{.Name}}{
+		theMap: m.theMap,
+	}
+
+	return res
+}
+
+func (m *{{.Name}}) AsImmutable(v *{{.Name}}) *{{.Name}} {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+func (m *{{.Name}}) Range() map[{{.KeyType}}]{{.ValType}} {
+	if m == nil {
+		return nil
+	}
+
+	return m.theMap.ToMap()
+}
+
+func (mr *{{.Name}}) WithMutable(f func({{.VarName}} *{{.Name}})) *{{.Name}} {
+	res := mr.AsMutable()
+	f(res)
+	res = res.AsImmutable(mr)
+
+	return res
+}
+
+func (mr *{{.Name}}) WithImmutable(f func({{.VarName}} *{{.Name}})) *{{.Name}} {
+	prev := mr.mutable
+	mr.mutable = false
+	f(mr)
+	mr.mutable = prev
+
+	return mr
+}
+
+func (m *{{.Name}}) Set(k {{.KeyType}}, v {{.ValType}}) *{{.Name}} {
+	if m.mutable {
+		m.theMap = m.theMap.Set(k, v)
+		return m
+	}
+
+	res := m.dup()
+	res.theMap = res.theMap.Set(k, v)
+
+	return res
+}
+
+func (m *{{.Name}}) Del(k {{.KeyType}}) *{{.Name}} {
+	if _, ok := m.theMap.Get(k); !ok {
+		return m
+	}
+
+	if m.mutable {
+		m.theMap = m.theMap.Del(k)
+		return m
+	}
+
+	res := m.dup()
+	res.theMap = res.theMap.Del(k)
+
+	return res
+}
+`
@@ -0,0 +1,126 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+// immPersistentSliceTmpl is the equivalent of immSliceTmpl for templates
+// marked with persistentDirective. The slice is backed by a
+// *persistent.Vector, hence dup() is O(1), Set is O(log n) and Append is
+// amortised O(1) per value. Range() materialises a Go slice and is O(n).
+const immPersistentSliceTmpl = `
+var _ immutable.Immutable = new({{.Name}})
+var _ = new({{.Name}}).__tmpl
+
+func {{Export "New"}}{{Capitalise .Name}}(s ...{{.Type}}) *{{.Name}} {
+	return &{{.Name}}{
+		theSlice: persistent.NewVector(s...),
+	}
+}
+
+func {{Export "New"}}{{Capitalise .Name}}Len(l int) *{{.Name}} {
+	return &{{.Name}}{
+		theSlice: persistent.NewVector(make([]{{.Type}}, l)...),
+	}
+}
+
+func (m *{{.Name}})Mutable() bool {
+	return m.mutable
+}
+
+func (m *{{.Name}}) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theSlice.Len()
+}
+
+func (m *{{.Name}}) Get(i int) {{.Type}} {
+	return m.theSlice.Get(i)
+}
+
+func (m *{{.Name}}) AsMutable() *{{.Name}} {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *{{.Name}}) dup() *{{.Name}} {
+	res := &{{.Name}}{
+		theSlice: m.theSlice,
+	}
+
+	return res
+}
+
+func (m *{{.Name}}) AsImmutable(v *{{.Name}}) *{{.Name}} {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+func (m *{{.Name}}) Range() []{{.Type}} {
+	if m == nil {
+		return nil
+	}
+
+	return m.theSlice.ToSlice()
+}
+
+func (m *{{.Name}}) WithMutable(f func(mi *{{.Name}})) *{{.Name}} {
+	res := m.AsMutable()
+	f(res)
+	res = res.AsImmutable(m)
+
+	return res
+}
+
+func (m *{{.Name}}) WithImmutable(f func(mi *{{.Name}})) *{{.Name}} {
+	prev := m.mutable
+	m.mutable = false
+	f(m)
+	m.mutable = prev
+
+	return m
+}
+
+func (m *{{.Name}}) Set(i int, v {{.Type}}) *{{.Name}} {
+	if m.mutable {
+		m.theSlice = m.theSlice.Set(i, v)
+		return m
+	}
+
+	res := m.dup()
+	res.theSlice = res.theSlice.Set(i, v)
+
+	return res
+}
+
+func (m *{{.Name}}) Append(v ...{{.Type}}) *{{.Name}} {
+	if m.mutable {
+		m.theSlice = m.theSlice.Append(v...)
+		return m
+	}
+
+	res := m.dup()
+	res.theSlice = res.theSlice.Append(v...)
+
+	return res
+}
+`
@@ -0,0 +1,250 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+const immSetTmpl = `
+var _ immutable.Immutable = new({{.Name}})
+var _ = new({{.Name}}).__tmpl
+
+func {{Export "New"}}{{Capitalise .Name}}(vs ...{{.ElemType}}) *{{.Name}} {
+	res := {{Export "New"}}{{Capitalise .Name}}Cap(len(vs))
+
+	for _, v := range vs {
+		res.theSet[v] = struct{}{}
+	}
+
+	return res
+}
+
+func {{Export "New"}}{{Capitalise .Name}}Cap(l int) *{{.Name}} {
+	return &{{.Name}}{
+		theSet: make(map[{{.ElemType}}]struct{}, l),
+	}
+}
+
+func (m *{{.Name}}) Mutable() bool {
+	return m.mutable
+}
+
+func (m *{{.Name}}) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return len(m.theSet)
+}
+
+func (m *{{.Name}}) Contains(v {{.ElemType}}) bool {
+	_, ok := m.theSet[v]
+	return ok
+}
+
+func (m *{{.Name}}) AsMutable() *{{.Name}} {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *{{.Name}}) dup() *{{.Name}} {
+	resSet := make(map[{{.ElemType}}]struct{}, len(m.theSet))
+
+	for v := range m.theSet {
+		resSet[v] = struct{}{}
+	}
+
+	res := &{{.Name}}{
+		theSet: resSet,
+	}
+
+	return res
+}
+
+func (m *{{.Name}}) AsImmutable(v *{{.Name}}) *{{.Name}} {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+// Range returns the elements of m as the keys of a map, that must not be
+// modified. The order of iteration over the map is not defined; see
+// Sorted{{if .Ordered}} and{{else}} or{{end}} SortedFunc for ordered iteration.
+func (m *{{.Name}}) Range() map[{{.ElemType}}]struct{} {
+	if m == nil {
+		return nil
+	}
+
+	return m.theSet
+}
+{{if .Ordered}}
+// Sorted returns a newly allocated slice of the elements of m in ascending
+// order.
+func (m *{{.Name}}) Sorted() []{{.ElemType}} {
+	return m.SortedFunc(func(a, b {{.ElemType}}) bool {
+		return a < b
+	})
+}
+{{end}}
+// SortedFunc returns a newly allocated slice of the elements of m, sorted
+// according to less.
+func (m *{{.Name}}) SortedFunc(less func(a, b {{.ElemType}}) bool) []{{.ElemType}} {
+	res := make([]{{.ElemType}}, 0, m.Len())
+
+	for v := range m.theSet {
+		res = append(res, v)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return less(res[i], res[j])
+	})
+
+	return res
+}
+
+func (mr *{{.Name}}) WithMutable(f func({{.VarName}} *{{.Name}})) *{{.Name}} {
+	res := mr.AsMutable()
+	f(res)
+	res = res.AsImmutable(mr)
+
+	return res
+}
+
+func (mr *{{.Name}}) WithImmutable(f func({{.VarName}} *{{.Name}})) *{{.Name}} {
+	prev := mr.mutable
+	mr.mutable = false
+	f(mr)
+	mr.mutable = prev
+
+	return mr
+}
+
+// Add returns a set that contains the elements of m and vs. If m already
+// contains all of vs, m is returned.
+func (m *{{.Name}}) Add(vs ...{{.ElemType}}) *{{.Name}} {
+	if m.mutable {
+		for _, v := range vs {
+			m.theSet[v] = struct{}{}
+		}
+		return m
+	}
+
+	for _, v := range vs {
+		if _, ok := m.theSet[v]; !ok {
+			res := m.dup()
+			for _, v := range vs {
+				res.theSet[v] = struct{}{}
+			}
+			return res
+		}
+	}
+
+	return m
+}
+
+// Remove returns a set that contains the elements of m that are not in vs. If
+// m contains none of vs, m is returned.
+func (m *{{.Name}}) Remove(vs ...{{.ElemType}}) *{{.Name}} {
+	if m.mutable {
+		for _, v := range vs {
+			delete(m.theSet, v)
+		}
+		return m
+	}
+
+	for _, v := range vs {
+		if _, ok := m.theSet[v]; ok {
+			res := m.dup()
+			for _, v := range vs {
+				delete(res.theSet, v)
+			}
+			return res
+		}
+	}
+
+	return m
+}
+
+// Union returns a set that contains the elements of m and o. If m already
+// contains all the elements of o, m is returned.
+func (m *{{.Name}}) Union(o *{{.Name}}) *{{.Name}} {
+	if m.mutable {
+		for v := range o.theSet {
+			m.theSet[v] = struct{}{}
+		}
+		return m
+	}
+
+	for v := range o.theSet {
+		if _, ok := m.theSet[v]; !ok {
+			res := m.dup()
+			for v := range o.theSet {
+				res.theSet[v] = struct{}{}
+			}
+			return res
+		}
+	}
+
+	return m
+}
+
+// Intersect returns a set that contains the elements of m that are also in o.
+// If all the elements of m are in o, m is returned.
+func (m *{{.Name}}) Intersect(o *{{.Name}}) *{{.Name}} {
+	return m.filter(func(v {{.ElemType}}) bool {
+		_, ok := o.theSet[v]
+		return ok
+	})
+}
+
+// Difference returns a set that contains the elements of m that are not in o.
+// If none of the elements of m are in o, m is returned.
+func (m *{{.Name}}) Difference(o *{{.Name}}) *{{.Name}} {
+	return m.filter(func(v {{.ElemType}}) bool {
+		_, ok := o.theSet[v]
+		return !ok
+	})
+}
+
+// filter returns a set that contains the elements of m for which keep returns
+// true. If keep returns true for all the elements of m, m is returned.
+func (m *{{.Name}}) filter(keep func(v {{.ElemType}}) bool) *{{.Name}} {
+	if m.mutable {
+		for v := range m.theSet {
+			if !keep(v) {
+				delete(m.theSet, v)
+			}
+		}
+		return m
+	}
+
+	for v := range m.theSet {
+		if !keep(v) {
+			res := {{Export "New"}}{{Capitalise .Name}}Cap(len(m.theSet))
+			for v := range m.theSet {
+				if keep(v) {
+					res.theSet[v] = struct{}{}
+				}
+			}
+			return res
+		}
+	}
+
+	return m
+}
+`
@@ -8,8 +8,95 @@ import (
 	"go/types"
 	"strings"
 	"text/template"
+
+	"myitcv.io/immutable"
 )
 
+// persistentDirective is the comment directive that marks an immutable map or
+// slice template as persistent, i.e. backed by a myitcv.io/immutable/persistent
+// Map or Vector
+const persistentDirective = "//immutableGen:persistent"
+
+// containerDirective is the comment directive that marks an immutable map or
+// slice template as generated in container mode, i.e. as a thin wrapper around
+// a myitcv.io/immutable/container Map or Slice. The -container flag makes
+// container mode the default for all map and slice templates.
+const containerDirective = "//immutableGen:container"
+
+// orderedDirective is the comment directive that marks an immutable map
+// template as ordered, i.e. backed by a myitcv.io/immutable/persistent Tree.
+// The directive takes an optional argument: insertionOrder, or the name of a
+// less function, func(a, b K) bool, of the key type K. The default is the
+// natural order of K, which must then be an ordered type.
+const orderedDirective = "//immutableGen:ordered"
+
+// builderDirective is the comment directive that marks an immutable struct
+// template as having a builder type and a functional options constructor
+// generated for it
+const builderDirective = "//immutableGen:builder"
+
+// insertionOrder is the argument of orderedDirective for a map ordered by
+// the insertion of its keys
+const insertionOrder = "insertion"
+
+// hasDirective returns whether the template type spec ts, declared by gd, is
+// marked with the comment directive d. Because gofmt does not recognise our
+// directives as such (they are not all lower case), a space after the // is
+// permitted.
+func hasDirective(gd *ast.GenDecl, ts *ast.TypeSpec, d string) bool {
+	_, ok := directiveArg(gd, ts, d)
+	return ok
+}
+
+// directiveArg returns the (space separated) argument of the comment
+// directive d, if the template type spec ts, declared by gd, is marked with d.
+func directiveArg(gd *ast.GenDecl, ts *ast.TypeSpec, d string) (string, bool) {
+	for _, cg := range []*ast.CommentGroup{gd.Doc, ts.Doc} {
+		if cg == nil {
+			continue
+		}
+		for _, c := range cg.List {
+			t := "//" + strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+			if t == d {
+				return "", true
+			}
+			if strings.HasPrefix(t, d+" ") {
+				return strings.TrimSpace(strings.TrimPrefix(t, d)), true
+			}
+		}
+	}
+	return "", false
+}
+
+// isSetElem returns whether t, the value type of a map template, is
+// myitcv.io/immutable.Set, in which case the template declares an immutable
+// set
+func isSetElem(t types.Type) bool {
+	nt, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := nt.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == immutable.PkgImportPath && obj.Name() == "Set"
+}
+
+// isOrdered returns whether the operator < is defined on values of type t
+func isOrdered(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsOrdered != 0
+}
+
+// isJSONKey returns whether encoding/json can (un)marshal a map with keys of
+// type t: t must be a string or integer type, or implement both
+// encoding.TextMarshaler and encoding.TextUnmarshaler
+func isJSONKey(t types.Type) bool {
+	if b, ok := t.Underlying().(*types.Basic); ok && b.Info()&(types.IsString|types.IsInteger) != 0 {
+		return true
+	}
+	ms := types.NewMethodSet(types.NewPointer(t))
+	return ms.Lookup(nil, "MarshalText") != nil && ms.Lookup(nil, "UnmarshalText") != nil
+}
+
 type specialType int
 
 const (
@@ -5,6 +5,9 @@ package _testFiles
 //immutableVet:skipFile
 
 import (
+	"encoding/json"
+	"reflect"
+
 	"myitcv.io/immutable"
 )
 
@@ -156,6 +159,93 @@ func (s *intS) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	return true
 }
 
+// Equal returns whether m and other have the same length and equal values
+// at each index
+func (m *intS) Equal(other *intS) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	if len(m.theSlice) != len(other.theSlice) {
+		return false
+	}
+
+	for i, a := range m.theSlice {
+		b := other.theSlice[i]
+		if !(a == b) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, in index order. The path
+// of a change is the index that was added, removed or modified, or the
+// index followed by the path of a change within a modified value.
+func (m *intS) Diff(other *intS) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a int, aok bool, b int, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	for i := 0; i < len(m.theSlice) || i < len(other.theSlice); i++ {
+		var a, b int
+		aok, bok := i < len(m.theSlice), i < len(other.theSlice)
+		if aok {
+			a = m.theSlice[i]
+		}
+		if bok {
+			b = other.theSlice[i]
+		}
+		if aok && bok && (a == b) {
+			continue
+		}
+		change(immutable.IndexPath(i), a, aok, b, bok)
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a []int
+func (m *intS) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []int
+// into m. m is left immutable.
+func (m *intS) UnmarshalJSON(b []byte) error {
+	var v []int
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *newIntS(v...)
+
+	return nil
+}
+
 //
 // Dummy is an immutable type and has the following template:
 //
@@ -253,6 +343,79 @@ func (s *Dummy) SetName(n string) *Dummy {
 	return &res
 }
 
+// Equal returns whether the fields of s and other are equal
+func (s *Dummy) Equal(other *Dummy) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_Name == other.field_Name) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *Dummy) Diff(other *Dummy) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_Name == other.field_Name) {
+		res = append(res, immutable.Change{Path: ".Name", Kind: immutable.ChangeModified, Old: s.field_Name, New: other.field_Name})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *Dummy) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		Name string
+	}{
+		Name: s.field_Name,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *Dummy) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Name string
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = Dummy{
+		field_Name: v.Name,
+	}
+
+	return nil
+}
+
 //
 // Dummy2 is an immutable type and has the following template:
 //
@@ -408,6 +571,101 @@ func (s *Dummy2) setOther(n *Dummy3) *Dummy2 {
 	return &res
 }
 
+// Equal returns whether the fields of s and other are equal
+func (s *Dummy2) Equal(other *Dummy2) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(reflect.DeepEqual(s.field_name, other.field_name)) {
+		return false
+	}
+
+	if !(s.field_other.Equal(other.field_other)) {
+		return false
+	}
+
+	if !(reflect.DeepEqual(s.field_mine, other.field_mine)) {
+		return false
+	}
+
+	if !(reflect.DeepEqual(s.field_another, other.field_another)) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *Dummy2) Diff(other *Dummy2) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(reflect.DeepEqual(s.field_name, other.field_name)) {
+		res = append(res, immutable.Change{Path: ".name", Kind: immutable.ChangeModified, Old: s.field_name, New: other.field_name})
+	}
+
+	if !(s.field_other.Equal(other.field_other)) {
+		if s.field_other != nil && other.field_other != nil {
+			res = append(res, immutable.PrefixChanges(".other", s.field_other.Diff(other.field_other))...)
+		} else {
+			res = append(res, immutable.Change{Path: ".other", Kind: immutable.ChangeModified, Old: s.field_other, New: other.field_other})
+		}
+	}
+
+	if !(reflect.DeepEqual(s.field_mine, other.field_mine)) {
+		res = append(res, immutable.Change{Path: ".mine", Kind: immutable.ChangeModified, Old: s.field_mine, New: other.field_mine})
+	}
+
+	if !(reflect.DeepEqual(s.field_another, other.field_another)) {
+		res = append(res, immutable.Change{Path: ".another", Kind: immutable.ChangeModified, Old: s.field_another, New: other.field_another})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *Dummy2) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+	}{}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *Dummy2) UnmarshalJSON(b []byte) error {
+	var v struct {
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = Dummy2{}
+
+	return nil
+}
+
 //
 // Dummy3 is an immutable type and has the following template:
 //
@@ -511,3 +769,74 @@ func (s *Dummy3) setOther(n *Dummy2) *Dummy3 {
 	res.field_other = n
 	return &res
 }
+
+// Equal returns whether the fields of s and other are equal
+func (s *Dummy3) Equal(other *Dummy3) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_other.Equal(other.field_other)) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *Dummy3) Diff(other *Dummy3) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_other.Equal(other.field_other)) {
+		if s.field_other != nil && other.field_other != nil {
+			res = append(res, immutable.PrefixChanges(".other", s.field_other.Diff(other.field_other))...)
+		} else {
+			res = append(res, immutable.Change{Path: ".other", Kind: immutable.ChangeModified, Old: s.field_other, New: other.field_other})
+		}
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *Dummy3) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+	}{}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *Dummy3) UnmarshalJSON(b []byte) error {
+	var v struct {
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = Dummy3{}
+
+	return nil
+}
@@ -53,7 +53,7 @@ func main() {
 	}
 
 	y := *x
-	print(y)
+	use(y)
 
 	_ = append([]int{}, x.Range()...)
 
@@ -73,3 +73,57 @@ var _ MyIntf = MyType([]string{})
 var _ = Dummy{} // ERROR
 
 type Blah = Dummy // ok; use of alias
+
+var leaked *Dummy
+
+func escapes() *Dummy {
+	var inner *Dummy
+
+	d := new(Dummy).WithMutable(func(v *Dummy) {
+		v.SetName("a")
+		inner = v               // ERROR
+		leaked = v.SetName("b") // ERROR
+		local := v
+		local.SetName("c")
+		leaked = local // ERROR
+		func() {
+			leaked = v // ERROR
+		}()
+	})
+
+	leaked = d.AsMutable() // ERROR
+
+	m := d.AsMutable()
+	m.SetName("d")
+
+	ch := make(chan *Dummy, 1)
+	ch <- m // ERROR
+
+	if inner != nil {
+		return m // ERROR
+	}
+
+	m.AsImmutable(nil)
+	m.SetName("e") // ERROR
+	m = m.SetName("f")
+	leaked = m
+
+	return m
+}
+
+func use(interface{}) {}
+
+func escapesDirectly(d *Dummy) *Dummy {
+	if d.Name() == "" {
+		return d.AsMutable() // ERROR
+	}
+
+	if d.Name() == "a" {
+		return d.AsMutable().SetName("b") // ERROR
+	}
+
+	m := d.AsMutable().SetName("c")
+	m.SetName("d")
+
+	return m // ERROR
+}
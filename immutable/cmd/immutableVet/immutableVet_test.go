@@ -2,14 +2,15 @@ package main
 
 import (
 	"bytes"
-	"fmt"
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"syscall"
 	"testing"
-
-	"github.com/kisielk/gotool"
 )
 
 func TestImmutableVetter(t *testing.T) {
@@ -36,33 +37,150 @@ _testFiles/test.go:39:10: non-pointer value of immutable type *myitcv.io/immutab
 _testFiles/test.go:46:8: should not be using field_Name of *myitcv.io/immutable/cmd/immutableVet/_testFiles.Dummy immutable type
 _testFiles/test.go:50:8: Range() of immutable type must appear in a range statement or used with an ellipsis as the second argument to append
 _testFiles/test.go:55:7: non-pointer value of immutable type *myitcv.io/immutable/cmd/immutableVet/_testFiles.intS found
-_testFiles/test.go:56:8: non-pointer value of immutable type *myitcv.io/immutable/cmd/immutableVet/_testFiles.intS found
+_testFiles/test.go:56:6: non-pointer value of immutable type *myitcv.io/immutable/cmd/immutableVet/_testFiles.intS found
 _testFiles/test.go:73:9: non-pointer value of immutable type *myitcv.io/immutable/cmd/immutableVet/_testFiles.Dummy found
 _testFiles/test.go:73:9: type should be *myitcv.io/immutable/cmd/immutableVet/_testFiles.Dummy
+_testFiles/test.go:84:11: mutable value v must not escape the function passed to WithMutable
+_testFiles/test.go:85:12: mutable value v must not escape the function passed to WithMutable
+_testFiles/test.go:88:12: mutable value local must not escape the function passed to WithMutable
+_testFiles/test.go:90:13: mutable value v must not escape the function passed to WithMutable
+_testFiles/test.go:94:11: mutable value d.AsMutable() from AsMutable escapes; call AsImmutable first
+_testFiles/test.go:100:8: mutable value m from AsMutable escapes; call AsImmutable first
+_testFiles/test.go:103:10: mutable value m from AsMutable escapes; call AsImmutable first
+_testFiles/test.go:107:2: m is immutable following the call to AsImmutable; the result of SetName is discarded
+_testFiles/test.go:118:10: mutable value d.AsMutable() from AsMutable escapes; call AsImmutable first
+_testFiles/test.go:122:10: mutable value d.AsMutable().SetName("b") from AsMutable escapes; call AsImmutable first
+_testFiles/test.go:128:9: mutable value m from AsMutable escapes; call AsImmutable first
 `
-
 	wd, err := os.Getwd()
 	if err != nil {
 		panic(err)
 	}
 
-	specs := gotool.ImportPaths([]string{
-		"myitcv.io/immutable/cmd/immutableVet/_testFiles",
+	// diagnostics are reported with absolute file names, in no particular
+	// order
+	out, code := runImmutableVet(t, "./_testFiles")
+	if code == 0 {
+		t.Errorf("expected non-zero exit code")
+	}
+
+	var lines []string
+	for _, l := range strings.Split(strings.TrimSpace(out), "\n") {
+		lines = append(lines, strings.TrimPrefix(l, wd+string(os.PathSeparator)))
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		return lessDiag(lines[i], lines[j])
 	})
 
-	emsgs := vet(wd, specs)
+	diff := strDiff(expected, strings.Join(lines, "\n")+"\n")
+	if diff != "" {
+		t.Errorf("Expected no diff; got:\n%v", diff)
+	}
+}
+
+func TestImmutableVetterFix(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
 
-	bfr := bytes.NewBuffer(nil)
+	// -fix rewrites files in place, so we work on a copy of _testFiles. The
+	// copy must be within the module, hence we create it in wd
+	td, err := ioutil.TempDir(wd, "_testFilesFix")
+	if err != nil {
+		t.Fatalf("failed to create TempDir: %v", err)
+	}
+	defer os.RemoveAll(td)
 
-	for _, msg := range emsgs {
-		fmt.Fprintf(bfr, "%v\n", msg)
+	fis, err := ioutil.ReadDir("_testFiles")
+	if err != nil {
+		t.Fatalf("failed to read _testFiles: %v", err)
+	}
+	for _, fi := range fis {
+		src, err := ioutil.ReadFile(filepath.Join("_testFiles", fi.Name()))
+		if err != nil {
+			t.Fatalf("failed to read %v: %v", fi.Name(), err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(td, fi.Name()), src, 0666); err != nil {
+			t.Fatalf("failed to write %v: %v", fi.Name(), err)
+		}
 	}
 
-	diff := strDiff(expected, bfr.String())
-	if diff != "" {
-		fmt.Println(bfr.String())
-		t.Errorf("Expected no diff; got:\n%v", diff)
+	runImmutableVet(t, "-fix", "."+string(os.PathSeparator)+filepath.Base(td))
+
+	src, err := ioutil.ReadFile(filepath.Join(td, "test.go"))
+	if err != nil {
+		t.Fatalf("failed to read fixed test.go: %v", err)
+	}
+
+	// only empty composite literals are fixed; other uses of a non-pointer
+	// immutable type are left as they are
+	for _, exp := range []string{
+		"return new(Dummy) // ERROR",
+		"var _ = new(Dummy) // ERROR",
+		"var _ Dummy ",
+		"var _ map[Dummy]string",
+		"var _ []Dummy",
+		"func Eg(d Dummy) Dummy {",
+		"type another Dummy",
+	} {
+		if !strings.Contains(string(src), exp) {
+			t.Errorf("expected fixed source to contain %q; got:\n%s", exp, src)
+		}
+	}
+
+	if strings.Contains(string(src), "Dummy{}") {
+		t.Errorf("expected fixed source not to contain Dummy{}; got:\n%s", src)
+	}
+}
+
+// runImmutableVet builds and runs immutableVet with args, returning its
+// stderr and exit code
+func runImmutableVet(t *testing.T, args ...string) (string, int) {
+	td, err := ioutil.TempDir("", "immutableVet")
+	if err != nil {
+		t.Fatalf("failed to create TempDir: %v", err)
+	}
+	defer os.RemoveAll(td)
+
+	bin := filepath.Join(td, "immutableVet")
+	if out, err := exec.Command("go", "build", "-o", bin, ".").CombinedOutput(); err != nil {
+		t.Fatalf("failed to build immutableVet: %v\n%s", err, out)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(bin, args...)
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	if err == nil {
+		return stderr.String(), 0
+	}
+	ee, ok := err.(*exec.ExitError)
+	if !ok {
+		t.Fatalf("failed to run immutableVet: %v", err)
+	}
+	return stderr.String(), ee.Sys().(syscall.WaitStatus).ExitStatus()
+}
+
+// lessDiag orders diagnostics of the form file:line:col: msg by position,
+// then message
+func lessDiag(l, r string) bool {
+	lp := strings.SplitN(l, ":", 4)
+	rp := strings.SplitN(r, ":", 4)
+	if len(lp) != 4 || len(rp) != 4 {
+		return l < r
+	}
+	if lp[0] != rp[0] {
+		return lp[0] < rp[0]
+	}
+	for i := 1; i < 3; i++ {
+		li, _ := strconv.Atoi(lp[i])
+		ri, _ := strconv.Atoi(rp[i])
+		if li != ri {
+			return li < ri
+		}
 	}
+	return lp[3] < rp[3]
 }
 
 func mustTmpFile(dir string, prefix string) *os.File {
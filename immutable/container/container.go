@@ -0,0 +1,39 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+// Package container provides generic immutable maps and slices. The types
+// have the same semantics as the map and slice types generated by
+// myitcv.io/immutable/cmd/immutableGen: a mutable value is updated in place,
+// whereas an update to an immutable value returns an updated copy.
+//
+// The package backs the immutable map and slice types generated by
+// immutableGen in container mode, which are thin wrappers around the types in
+// this package. It is not intended for direct use.
+//
+// A nil *Map or *Slice is a valid, empty, immutable value.
+package container
+
+import (
+	"myitcv.io/immutable"
+)
+
+// change returns the Change at path p of the value a (present if aok) to b
+// (present if bok). If diff is not nil and a and b are both present, the
+// changes within the modified value are those returned by diff, prefixed with
+// p, unless diff returns none.
+func change[T any](p string, a T, aok bool, b T, bok bool, diff func(a, b T) []immutable.Change) []immutable.Change {
+	switch {
+	case !aok:
+		return []immutable.Change{{Path: p, Kind: immutable.ChangeAdded, New: b}}
+	case !bok:
+		return []immutable.Change{{Path: p, Kind: immutable.ChangeRemoved, Old: a}}
+	}
+
+	if diff != nil {
+		if cs := diff(a, b); len(cs) != 0 {
+			return immutable.PrefixChanges(p, cs)
+		}
+	}
+
+	return []immutable.Change{{Path: p, Kind: immutable.ChangeModified, Old: a, New: b}}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package container
+
+import (
+	"encoding/json"
+
+	"myitcv.io/immutable"
+)
+
+// Map is an immutable map backed by a Go map.
+type Map[K comparable, V any] struct {
+	theMap  map[K]V
+	mutable bool
+}
+
+// NewMap returns an immutable Map with capacity l.
+func NewMap[K comparable, V any](l int) *Map[K, V] {
+	return &Map[K, V]{
+		theMap: make(map[K]V, l),
+	}
+}
+
+// Mutable returns whether m is mutable.
+func (m *Map[K, V]) Mutable() bool {
+	return m != nil && m.mutable
+}
+
+// Len returns the number of entries in m.
+func (m *Map[K, V]) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return len(m.theMap)
+}
+
+// Get returns the value for the key k and true if m contains k, or the zero
+// value of V and false otherwise.
+func (m *Map[K, V]) Get(k K) (V, bool) {
+	v, ok := m.Range()[k]
+	return v, ok
+}
+
+// Range returns the Go map that backs m, which must not be modified.
+func (m *Map[K, V]) Range() map[K]V {
+	if m == nil {
+		return nil
+	}
+
+	return m.theMap
+}
+
+// AsMutable returns m if m is mutable, else a mutable copy of m.
+func (m *Map[K, V]) AsMutable() *Map[K, V] {
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+// AsImmutable marks m as immutable and returns m.
+func (m *Map[K, V]) AsImmutable() *Map[K, V] {
+	if m != nil {
+		m.mutable = false
+	}
+
+	return m
+}
+
+// WithImmutable calls f with m marked as immutable, restoring the mutability
+// of m when f returns.
+func (m *Map[K, V]) WithImmutable(f func()) {
+	if m == nil {
+		f()
+		return
+	}
+
+	prev := m.mutable
+	m.mutable = false
+	f()
+	m.mutable = prev
+}
+
+// Set returns a Map that contains the entries of m with the key k set to v.
+// If m is mutable it is updated in place and returned.
+func (m *Map[K, V]) Set(k K, v V) *Map[K, V] {
+	if m.Mutable() {
+		m.theMap[k] = v
+		return m
+	}
+
+	res := m.dup()
+	res.theMap[k] = v
+
+	return res
+}
+
+// Del returns a Map that contains the entries of m without the key k. If m is
+// mutable it is updated in place and returned; if m does not contain k, m is
+// returned.
+func (m *Map[K, V]) Del(k K) *Map[K, V] {
+	if _, ok := m.Get(k); !ok {
+		return m
+	}
+
+	if m.mutable {
+		delete(m.theMap, k)
+		return m
+	}
+
+	res := m.dup()
+	delete(res.theMap, k)
+
+	return res
+}
+
+// Equal returns whether m and o contain the same keys with values that are
+// equal according to eq.
+func (m *Map[K, V]) Equal(o *Map[K, V], eq func(a, b V) bool) bool {
+	if m == o {
+		return true
+	}
+
+	if m.Len() != o.Len() {
+		return false
+	}
+
+	for k, a := range m.Range() {
+		b, ok := o.Get(k)
+		if !ok || !eq(a, b) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff calls f for each key that is in only one of m and o, or whose values in
+// m and o are not equal according to eq, until f returns false. a and aok are
+// the value of the key in m and whether m contains the key; likewise b and bok
+// for o. Keys are visited in an unspecified order.
+func (m *Map[K, V]) Diff(o *Map[K, V], eq func(a, b V) bool, f func(k K, a V, aok bool, b V, bok bool) bool) {
+	if m == o {
+		return
+	}
+
+	for k, a := range m.Range() {
+		b, bok := o.Get(k)
+		if bok && eq(a, b) {
+			continue
+		}
+		if !f(k, a, true, b, bok) {
+			return
+		}
+	}
+
+	for k, b := range o.Range() {
+		if _, ok := m.Get(k); !ok {
+			var a V
+			if !f(k, a, false, b, true) {
+				return
+			}
+		}
+	}
+}
+
+// Changes returns the changes between m and o, ordered by path, as reported by
+// the Diff method of an immutable map. The path of a change is the key of the
+// entry that was added, removed or modified. If diff is not nil, a modified
+// value is instead reported as the changes within it returned by diff, with
+// their paths prefixed by the key.
+func (m *Map[K, V]) Changes(o *Map[K, V], eq func(a, b V) bool, diff func(a, b V) []immutable.Change) []immutable.Change {
+	var res []immutable.Change
+
+	m.Diff(o, eq, func(k K, a V, aok bool, b V, bok bool) bool {
+		res = append(res, change(immutable.KeyPath(k), a, aok, b, bok, diff)...)
+		return true
+	})
+
+	return immutable.SortChanges(res)
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a Go map.
+func (m *Map[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a Go map into m.
+// m is left immutable.
+func (m *Map[K, V]) UnmarshalJSON(b []byte) error {
+	var v map[K]V
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if v == nil {
+		v = make(map[K]V)
+	}
+
+	m.theMap = v
+	m.mutable = false
+
+	return nil
+}
+
+func (m *Map[K, V]) dup() *Map[K, V] {
+	res := NewMap[K, V](m.Len())
+
+	for k, v := range m.Range() {
+		res.theMap[k] = v
+	}
+
+	return res
+}
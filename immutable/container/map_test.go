@@ -0,0 +1,137 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package container
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"myitcv.io/immutable"
+)
+
+func TestMap(t *testing.T) {
+	var m0 *Map[string, int]
+
+	if m0.Mutable() || m0.Len() != 0 {
+		t.Fatalf("nil Map should be immutable and empty")
+	}
+
+	if _, ok := m0.Get("a"); ok {
+		t.Fatalf("nil Map should not contain a")
+	}
+
+	m1 := m0.Set("a", 1)
+	m2 := m1.Set("b", 2)
+
+	if exp, got := map[string]int{"a": 1}, m1.Range(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected m1 to be %v; got %v", exp, got)
+	}
+
+	if exp, got := map[string]int{"a": 1, "b": 2}, m2.Range(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected m2 to be %v; got %v", exp, got)
+	}
+
+	if m2.Del("c") != m2 {
+		t.Fatalf("deleting a missing key should return the same Map")
+	}
+
+	m3 := m2.AsMutable()
+
+	if m3 == m2 || !m3.Mutable() || m2.Mutable() {
+		t.Fatalf("AsMutable should return a mutable copy")
+	}
+
+	if m3.Set("c", 3) != m3 || m3.Del("a") != m3 {
+		t.Fatalf("updates to a mutable Map should be in place")
+	}
+
+	m3.WithImmutable(func() {
+		if m3.Mutable() {
+			t.Fatalf("Map should be immutable within WithImmutable")
+		}
+	})
+
+	if m3.AsMutable() != m3 || m3.AsImmutable() != m3 || m3.Mutable() {
+		t.Fatalf("unexpected mutability of m3")
+	}
+
+	if exp, got := map[string]int{"b": 2, "c": 3}, m3.Range(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected m3 to be %v; got %v", exp, got)
+	}
+
+	if exp, got := map[string]int{"a": 1, "b": 2}, m2.Range(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("m2 should be unchanged; expected %v; got %v", exp, got)
+	}
+}
+
+func TestMapDiff(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+
+	m1 := NewMap[string, int](0).Set("a", 1).Set("b", 2)
+	m2 := m1.Del("a").Set("b", 3).Set("c", 4)
+
+	if !m1.Equal(NewMap[string, int](0).Set("b", 2).Set("a", 1), eq) {
+		t.Fatalf("expected Maps with the same entries to be equal")
+	}
+
+	if m1.Equal(m2, eq) {
+		t.Fatalf("expected m1 and m2 not to be equal")
+	}
+
+	type change struct {
+		a, b     int
+		aok, bok bool
+	}
+
+	got := make(map[string]change)
+	m1.Diff(m2, eq, func(k string, a int, aok bool, b int, bok bool) bool {
+		got[k] = change{a, b, aok, bok}
+		return true
+	})
+
+	exp := map[string]change{
+		"a": {1, 0, true, false},
+		"b": {2, 3, true, true},
+		"c": {0, 4, false, true},
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %v; got %v", exp, got)
+	}
+
+	expc := []immutable.Change{
+		{Path: `["a"]`, Kind: immutable.ChangeRemoved, Old: 1},
+		{Path: `["b"]`, Kind: immutable.ChangeModified, Old: 2, New: 3},
+		{Path: `["c"]`, Kind: immutable.ChangeAdded, New: 4},
+	}
+	if gotc := m1.Changes(m2, eq, nil); !reflect.DeepEqual(gotc, expc) {
+		t.Fatalf("expected changes %v; got %v", expc, gotc)
+	}
+}
+
+func TestMapJSON(t *testing.T) {
+	m1 := NewMap[string, int](0).Set("a", 1)
+
+	b, err := json.Marshal(m1)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	if exp := `{"a":1}`; string(b) != exp {
+		t.Fatalf("expected %v; got %s", exp, b)
+	}
+
+	var m2 *Map[string, int]
+	if err := json.Unmarshal(b, &m2); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if m2.Mutable() || !reflect.DeepEqual(m2.Range(), m1.Range()) {
+		t.Fatalf("expected immutable %v; got %v", m1.Range(), m2.Range())
+	}
+
+	if m3 := m2.AsMutable().Set("b", 2); m3.Len() != 2 || m2.Len() != 1 {
+		t.Fatalf("unexpected result of updating unmarshalled Map")
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package container
+
+import (
+	"encoding/json"
+
+	"myitcv.io/immutable"
+)
+
+// Slice is an immutable slice backed by a Go slice.
+type Slice[T any] struct {
+	theSlice []T
+	mutable  bool
+}
+
+// NewSlice returns an immutable Slice that contains a copy of vs.
+func NewSlice[T any](vs ...T) *Slice[T] {
+	c := make([]T, len(vs))
+	copy(c, vs)
+
+	return &Slice[T]{
+		theSlice: c,
+	}
+}
+
+// NewSliceLen returns an immutable Slice of length l.
+func NewSliceLen[T any](l int) *Slice[T] {
+	return &Slice[T]{
+		theSlice: make([]T, l),
+	}
+}
+
+// Mutable returns whether s is mutable.
+func (s *Slice[T]) Mutable() bool {
+	return s != nil && s.mutable
+}
+
+// Len returns the length of s.
+func (s *Slice[T]) Len() int {
+	if s == nil {
+		return 0
+	}
+
+	return len(s.theSlice)
+}
+
+// Get returns the value at index i. Get panics if i is out of range.
+func (s *Slice[T]) Get(i int) T {
+	return s.Range()[i]
+}
+
+// Range returns the Go slice that backs s, which must not be modified.
+func (s *Slice[T]) Range() []T {
+	if s == nil {
+		return nil
+	}
+
+	return s.theSlice
+}
+
+// AsMutable returns s if s is mutable, else a mutable copy of s.
+func (s *Slice[T]) AsMutable() *Slice[T] {
+	if s.Mutable() {
+		return s
+	}
+
+	res := s.dup()
+	res.mutable = true
+
+	return res
+}
+
+// AsImmutable marks s as immutable and returns s.
+func (s *Slice[T]) AsImmutable() *Slice[T] {
+	if s != nil {
+		s.mutable = false
+	}
+
+	return s
+}
+
+// WithImmutable calls f with s marked as immutable, restoring the mutability
+// of s when f returns.
+func (s *Slice[T]) WithImmutable(f func()) {
+	if s == nil {
+		f()
+		return
+	}
+
+	prev := s.mutable
+	s.mutable = false
+	f()
+	s.mutable = prev
+}
+
+// Set returns a Slice that contains the values of s with the value at index i
+// set to v. If s is mutable it is updated in place and returned. Set panics if
+// i is out of range.
+func (s *Slice[T]) Set(i int, v T) *Slice[T] {
+	if s.Mutable() {
+		s.theSlice[i] = v
+		return s
+	}
+
+	res := s.dup()
+	res.theSlice[i] = v
+
+	return res
+}
+
+// Append returns a Slice that contains the values of s followed by vs. If s is
+// mutable it is updated in place and returned.
+func (s *Slice[T]) Append(vs ...T) *Slice[T] {
+	if s.Mutable() {
+		s.theSlice = append(s.theSlice, vs...)
+		return s
+	}
+
+	res := s.dup()
+	res.theSlice = append(res.theSlice, vs...)
+
+	return res
+}
+
+// Equal returns whether s and o have the same length and values that are
+// equal according to eq at each index.
+func (s *Slice[T]) Equal(o *Slice[T], eq func(a, b T) bool) bool {
+	if s == o {
+		return true
+	}
+
+	if s.Len() != o.Len() {
+		return false
+	}
+
+	os := o.Range()
+	for i, a := range s.Range() {
+		if !eq(a, os[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff calls f, in index order, for each index that is in only one of s and o,
+// or whose values in s and o are not equal according to eq, until f returns
+// false. a and aok are the value at the index in s and whether the index is in
+// range for s; likewise b and bok for o.
+func (s *Slice[T]) Diff(o *Slice[T], eq func(a, b T) bool, f func(i int, a T, aok bool, b T, bok bool) bool) {
+	if s == o {
+		return
+	}
+
+	ss, os := s.Range(), o.Range()
+
+	for i := 0; i < len(ss) || i < len(os); i++ {
+		var a, b T
+		aok, bok := i < len(ss), i < len(os)
+		if aok {
+			a = ss[i]
+		}
+		if bok {
+			b = os[i]
+		}
+		if aok && bok && eq(a, b) {
+			continue
+		}
+		if !f(i, a, aok, b, bok) {
+			return
+		}
+	}
+}
+
+// Changes returns the changes between s and o, in index order, as reported by
+// the Diff method of an immutable slice. The path of a change is the index
+// that was added, removed or modified. If diff is not nil, a modified value is
+// instead reported as the changes within it returned by diff, with their paths
+// prefixed by the index.
+func (s *Slice[T]) Changes(o *Slice[T], eq func(a, b T) bool, diff func(a, b T) []immutable.Change) []immutable.Change {
+	var res []immutable.Change
+
+	s.Diff(o, eq, func(i int, a T, aok bool, b T, bok bool) bool {
+		res = append(res, change(immutable.IndexPath(i), a, aok, b, bok, diff)...)
+		return true
+	})
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as a Go slice.
+func (s *Slice[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a Go slice into s.
+// s is left immutable.
+func (s *Slice[T]) UnmarshalJSON(b []byte) error {
+	var v []T
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	s.theSlice = v
+	s.mutable = false
+
+	return nil
+}
+
+func (s *Slice[T]) dup() *Slice[T] {
+	return NewSlice(s.Range()...)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package container
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"myitcv.io/immutable"
+)
+
+func TestSlice(t *testing.T) {
+	var s0 *Slice[int]
+
+	if s0.Mutable() || s0.Len() != 0 {
+		t.Fatalf("nil Slice should be immutable and empty")
+	}
+
+	vs := []int{1, 2}
+	s1 := NewSlice(vs...)
+	vs[0] = 5
+
+	if exp, got := []int{1, 2}, s1.Range(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("NewSlice should copy its arguments; expected %v; got %v", exp, got)
+	}
+
+	s2 := s1.Set(0, 3).Append(4)
+
+	if exp, got := []int{3, 2, 4}, s2.Range(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected s2 to be %v; got %v", exp, got)
+	}
+
+	if s1.Get(0) != 1 || s1.Len() != 2 {
+		t.Fatalf("s1 should be unchanged; got %v", s1.Range())
+	}
+
+	s3 := s2.AsMutable()
+
+	if s3 == s2 || !s3.Mutable() || s2.Mutable() {
+		t.Fatalf("AsMutable should return a mutable copy")
+	}
+
+	if s3.Set(0, 1) != s3 || s3.Append(5) != s3 {
+		t.Fatalf("updates to a mutable Slice should be in place")
+	}
+
+	if s3.AsImmutable() != s3 || s3.Mutable() {
+		t.Fatalf("AsImmutable should mark s3 immutable")
+	}
+
+	if exp, got := []int{1, 2, 4, 5}, s3.Range(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected s3 to be %v; got %v", exp, got)
+	}
+
+	if exp, got := NewSliceLen[int](2).Range(), []int{0, 0}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %v; got %v", exp, got)
+	}
+}
+
+func TestSliceDiff(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+
+	s1 := NewSlice(1, 2, 3)
+	s2 := NewSlice(1, 4)
+
+	if !s1.Equal(NewSlice(1, 2, 3), eq) || s1.Equal(s2, eq) {
+		t.Fatalf("unexpected result of Equal")
+	}
+
+	var got []int
+	s1.Diff(s2, eq, func(i int, a int, aok bool, b int, bok bool) bool {
+		got = append(got, i)
+		return true
+	})
+
+	if exp := []int{1, 2}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected changes at %v; got %v", exp, got)
+	}
+
+	diff := func(a, b int) []immutable.Change {
+		return []immutable.Change{{Path: ".v", Kind: immutable.ChangeModified, Old: a, New: b}}
+	}
+	expc := []immutable.Change{
+		{Path: "[1].v", Kind: immutable.ChangeModified, Old: 2, New: 4},
+		{Path: "[2]", Kind: immutable.ChangeRemoved, Old: 3},
+	}
+	if gotc := s1.Changes(s2, eq, diff); !reflect.DeepEqual(gotc, expc) {
+		t.Fatalf("expected changes %v; got %v", expc, gotc)
+	}
+}
+
+func TestSliceJSON(t *testing.T) {
+	s1 := NewSlice(1, 2)
+
+	b, err := json.Marshal(s1)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	if exp := `[1,2]`; string(b) != exp {
+		t.Fatalf("expected %v; got %s", exp, b)
+	}
+
+	var s2 *Slice[int]
+	if err := json.Unmarshal(b, &s2); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if s2.Mutable() || !reflect.DeepEqual(s2.Range(), s1.Range()) {
+		t.Fatalf("expected immutable %v; got %v", s1.Range(), s2.Range())
+	}
+}
@@ -5,6 +5,8 @@ package example
 //immutableVet:skipFile
 
 import (
+	"encoding/json"
+
 	"myitcv.io/immutable"
 )
 
@@ -183,6 +185,99 @@ func (s *MyMap) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	return true
 }
 
+// Equal returns whether m and other contain the same keys with equal values
+func (m *MyMap) Equal(other *MyMap) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	if len(m.theMap) != len(other.theMap) {
+		return false
+	}
+
+	for k, a := range m.theMap {
+		b, ok := other.theMap[k]
+		if !ok || !(a.Equal(b)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the key of the entry that was added, removed or
+// modified, or the key followed by the path of a change within a
+// modified value.
+func (m *MyMap) Diff(other *MyMap) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a *MySlice, aok bool, b *MySlice, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		case a != nil && b != nil:
+			res = append(res, immutable.PrefixChanges(p, a.Diff(b))...)
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	for k, a := range m.theMap {
+		b, bok := other.theMap[k]
+		if bok && (a.Equal(b)) {
+			continue
+		}
+		change(immutable.KeyPath(k), a, true, b, bok)
+	}
+
+	for k, b := range other.theMap {
+		if _, ok := m.theMap[k]; !ok {
+			var a *MySlice
+			change(immutable.KeyPath(k), a, false, b, true)
+		}
+	}
+
+	return immutable.SortChanges(res)
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a map[string]*MySlice
+func (m *MyMap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a
+// map[string]*MySlice into m. m is left immutable.
+func (m *MyMap) UnmarshalJSON(b []byte) error {
+	var v map[string]*MySlice
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *NewMyMapCap(len(v)).WithMutable(func(mi *MyMap) {
+		for k, e := range v {
+			mi.Set(k, e)
+		}
+	})
+
+	return nil
+}
+
 // MySlice will be exported
 //
 // MySlice is an immutable type and has the following template:
@@ -351,6 +446,95 @@ func (s *MySlice) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	return true
 }
 
+// Equal returns whether m and other have the same length and equal values
+// at each index
+func (m *MySlice) Equal(other *MySlice) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	if len(m.theSlice) != len(other.theSlice) {
+		return false
+	}
+
+	for i, a := range m.theSlice {
+		b := other.theSlice[i]
+		if !(a.Equal(b)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, in index order. The path
+// of a change is the index that was added, removed or modified, or the
+// index followed by the path of a change within a modified value.
+func (m *MySlice) Diff(other *MySlice) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a *MyMap, aok bool, b *MyMap, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		case a != nil && b != nil:
+			res = append(res, immutable.PrefixChanges(p, a.Diff(b))...)
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	for i := 0; i < len(m.theSlice) || i < len(other.theSlice); i++ {
+		var a, b *MyMap
+		aok, bok := i < len(m.theSlice), i < len(other.theSlice)
+		if aok {
+			a = m.theSlice[i]
+		}
+		if bok {
+			b = other.theSlice[i]
+		}
+		if aok && bok && (a.Equal(b)) {
+			continue
+		}
+		change(immutable.IndexPath(i), a, aok, b, bok)
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a []*MyMap
+func (m *MySlice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []*MyMap
+// into m. m is left immutable.
+func (m *MySlice) UnmarshalJSON(b []byte) error {
+	var v []*MyMap
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *NewMySlice(v...)
+
+	return nil
+}
+
 // MyStruct will be exported.
 //
 // It is a special type.
@@ -517,3 +701,104 @@ func (s *MyStruct) setSurname(n string) *MyStruct {
 	res.field_surname = n
 	return &res
 }
+
+// Equal returns whether the fields of s and other are equal
+func (s *MyStruct) Equal(other *MyStruct) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_Name == other.field_Name) {
+		return false
+	}
+
+	if !(s.field_surname == other.field_surname) {
+		return false
+	}
+
+	if !(s.field_self.Equal(other.field_self)) {
+		return false
+	}
+
+	if !(s.field_age == other.field_age) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *MyStruct) Diff(other *MyStruct) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_Name == other.field_Name) {
+		res = append(res, immutable.Change{Path: ".Name", Kind: immutable.ChangeModified, Old: s.field_Name, New: other.field_Name})
+	}
+
+	if !(s.field_surname == other.field_surname) {
+		res = append(res, immutable.Change{Path: ".surname", Kind: immutable.ChangeModified, Old: s.field_surname, New: other.field_surname})
+	}
+
+	if !(s.field_self.Equal(other.field_self)) {
+		if s.field_self != nil && other.field_self != nil {
+			res = append(res, immutable.PrefixChanges(".self", s.field_self.Diff(other.field_self))...)
+		} else {
+			res = append(res, immutable.Change{Path: ".self", Kind: immutable.ChangeModified, Old: s.field_self, New: other.field_self})
+		}
+	}
+
+	if !(s.field_age == other.field_age) {
+		res = append(res, immutable.Change{Path: ".age", Kind: immutable.ChangeModified, Old: s.field_age, New: other.field_age})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *MyStruct) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		Name string `tag:"value"`
+	}{
+		Name: s.field_Name,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *MyStruct) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Name string `tag:"value"`
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = MyStruct{
+		field_Name: v.Name,
+	}
+
+	return nil
+}
@@ -5,6 +5,8 @@ package example
 //immutableVet:skipFile
 
 import (
+	"encoding/json"
+
 	"myitcv.io/immutable"
 )
 
@@ -162,3 +164,94 @@ func (s *myTestMap) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	}
 	return true
 }
+
+// Equal returns whether m and other contain the same keys with equal values
+func (m *myTestMap) Equal(other *myTestMap) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	if len(m.theMap) != len(other.theMap) {
+		return false
+	}
+
+	for k, a := range m.theMap {
+		b, ok := other.theMap[k]
+		if !ok || !(a == b) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the key of the entry that was added, removed or
+// modified, or the key followed by the path of a change within a
+// modified value.
+func (m *myTestMap) Diff(other *myTestMap) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a int, aok bool, b int, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	for k, a := range m.theMap {
+		b, bok := other.theMap[k]
+		if bok && (a == b) {
+			continue
+		}
+		change(immutable.KeyPath(k), a, true, b, bok)
+	}
+
+	for k, b := range other.theMap {
+		if _, ok := m.theMap[k]; !ok {
+			var a int
+			change(immutable.KeyPath(k), a, false, b, true)
+		}
+	}
+
+	return immutable.SortChanges(res)
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a map[string]int
+func (m *myTestMap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a
+// map[string]int into m. m is left immutable.
+func (m *myTestMap) UnmarshalJSON(b []byte) error {
+	var v map[string]int
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *newMyTestMapCap(len(v)).WithMutable(func(mi *myTestMap) {
+		for k, e := range v {
+			mi.Set(k, e)
+		}
+	})
+
+	return nil
+}
@@ -5,6 +5,8 @@ package example
 //immutableVet:skipFile
 
 import (
+	"encoding/json"
+
 	"myitcv.io/immutable"
 )
 
@@ -123,3 +125,88 @@ func (s *Person) SetName(n string) *Person {
 	res.field_Name = n
 	return &res
 }
+
+// Equal returns whether the fields of s and other are equal
+func (s *Person) Equal(other *Person) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_Name == other.field_Name) {
+		return false
+	}
+
+	if !(s.field_Age == other.field_Age) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *Person) Diff(other *Person) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_Name == other.field_Name) {
+		res = append(res, immutable.Change{Path: ".Name", Kind: immutable.ChangeModified, Old: s.field_Name, New: other.field_Name})
+	}
+
+	if !(s.field_Age == other.field_Age) {
+		res = append(res, immutable.Change{Path: ".Age", Kind: immutable.ChangeModified, Old: s.field_Age, New: other.field_Age})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *Person) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		Name string
+		Age  int
+	}{
+		Name: s.field_Name,
+		Age:  s.field_Age,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *Person) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Name string
+		Age  int
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = Person{
+		field_Name: v.Name,
+		field_Age:  v.Age,
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package immutable
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrMutable is the error returned by History.Push for a value that is not
+// deeply non-mutable
+var ErrMutable = errors.New("immutable: value is not deeply non-mutable")
+
+// History records successive versions of an immutable value, typically the
+// root of an application's state, in support of undo and redo. Because the
+// versions are immutable, and share structure with the versions from which
+// they were derived, recording a version is cheap.
+//
+// A History holds at most Limit versions; when a version is pushed to a full
+// History, the oldest version is dropped. Named snapshots of versions are held
+// independently of the versions themselves, and so are not dropped.
+//
+// The zero value of History is an empty History without a limit.
+type History[T Immutable] struct {
+	versions []T
+
+	// cur is the index of the current version in versions; versions after cur
+	// can be redone
+	cur int
+
+	limit     int
+	snapshots map[string]T
+}
+
+// NewHistory returns an empty History that holds at most limit versions. A
+// limit <= 0 indicates no limit.
+func NewHistory[T Immutable](limit int) *History[T] {
+	return &History[T]{
+		limit: limit,
+	}
+}
+
+// Limit returns the maximum number of versions held by h, or 0 if there is no
+// limit.
+func (h *History[T]) Limit() int {
+	return max(h.limit, 0)
+}
+
+// Len returns the number of versions held by h, including those that can be
+// redone.
+func (h *History[T]) Len() int {
+	return len(h.versions)
+}
+
+// Push records v as the current version of h, discarding any versions that
+// could have been redone. If h is full, the oldest version is dropped. Push
+// returns ErrMutable, and leaves h unchanged, if v is not deeply non-mutable.
+func (h *History[T]) Push(v T) error {
+	if !v.IsDeeplyNonMutable(nil) {
+		return ErrMutable
+	}
+
+	if len(h.versions) > 0 {
+		h.versions = h.versions[:h.cur+1]
+	}
+
+	h.versions = append(h.versions, v)
+
+	if h.limit > 0 && len(h.versions) > h.limit {
+		// copy rather than reslice so that the dropped versions can be
+		// garbage collected
+		n := len(h.versions) - h.limit
+		h.versions = append(h.versions[:0:0], h.versions[n:]...)
+	}
+
+	h.cur = len(h.versions) - 1
+
+	return nil
+}
+
+// Current returns the current version of h and true, or the zero value of T
+// and false if h is empty.
+func (h *History[T]) Current() (T, bool) {
+	if len(h.versions) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return h.versions[h.cur], true
+}
+
+// CanUndo returns whether there is a version before the current version of h.
+func (h *History[T]) CanUndo() bool {
+	return h.cur > 0
+}
+
+// CanRedo returns whether there is a version after the current version of h.
+func (h *History[T]) CanRedo() bool {
+	return h.cur < len(h.versions)-1
+}
+
+// Undo makes the version before the current version of h current, returning
+// it and true, or returns the zero value of T and false if there is no such
+// version.
+func (h *History[T]) Undo() (T, bool) {
+	if !h.CanUndo() {
+		var zero T
+		return zero, false
+	}
+
+	h.cur--
+
+	return h.versions[h.cur], true
+}
+
+// Redo makes the version after the current version of h current, returning it
+// and true, or returns the zero value of T and false if there is no such
+// version.
+func (h *History[T]) Redo() (T, bool) {
+	if !h.CanRedo() {
+		var zero T
+		return zero, false
+	}
+
+	h.cur++
+
+	return h.versions[h.cur], true
+}
+
+// Branch returns a new History with the same limit and snapshots as h, whose
+// versions are those of h up to and including the current version. The
+// returned History and h are independent: subsequent changes to one do not
+// affect the other.
+func (h *History[T]) Branch() *History[T] {
+	res := &History[T]{
+		limit: h.limit,
+		cur:   h.cur,
+	}
+
+	if len(h.versions) > 0 {
+		res.versions = append([]T(nil), h.versions[:h.cur+1]...)
+	}
+
+	for n, v := range h.snapshots {
+		res.setSnapshot(n, v)
+	}
+
+	return res
+}
+
+// Snapshot records the current version of h as the snapshot name, replacing
+// any existing snapshot with that name. Snapshot returns false, and records
+// nothing, if h is empty.
+func (h *History[T]) Snapshot(name string) bool {
+	v, ok := h.Current()
+	if !ok {
+		return false
+	}
+
+	h.setSnapshot(name, v)
+
+	return true
+}
+
+// Snapshots returns the names of the snapshots of h, in lexical order.
+func (h *History[T]) Snapshots() []string {
+	res := make([]string, 0, len(h.snapshots))
+	for n := range h.snapshots {
+		res = append(res, n)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// GetSnapshot returns the version recorded as the snapshot name and true, or
+// the zero value of T and false if there is no such snapshot.
+func (h *History[T]) GetSnapshot(name string) (T, bool) {
+	v, ok := h.snapshots[name]
+	return v, ok
+}
+
+// DelSnapshot removes the snapshot name from h, if it exists.
+func (h *History[T]) DelSnapshot(name string) {
+	delete(h.snapshots, name)
+}
+
+// Restore pushes the version recorded as the snapshot name as the current
+// version of h, such that the restore can itself be undone. Restore returns
+// the version and true, or the zero value of T and false if there is no such
+// snapshot.
+func (h *History[T]) Restore(name string) (T, bool) {
+	v, ok := h.snapshots[name]
+	if !ok {
+		return v, false
+	}
+
+	// v was deeply non-mutable when it was pushed, and by definition remains
+	// so; hence Push cannot fail
+	h.Push(v)
+
+	return v, true
+}
+
+func (h *History[T]) setSnapshot(name string, v T) {
+	if h.snapshots == nil {
+		h.snapshots = make(map[string]T)
+	}
+
+	h.snapshots[name] = v
+}
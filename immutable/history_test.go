@@ -0,0 +1,156 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package immutable_test
+
+import (
+	"reflect"
+	"testing"
+
+	"myitcv.io/immutable"
+)
+
+// version is a minimal implementation of immutable.Immutable
+type version struct {
+	n       int
+	mutable bool
+}
+
+func (v *version) Mutable() bool {
+	return v.mutable
+}
+
+func (v *version) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	return !v.mutable
+}
+
+func push(t *testing.T, h *immutable.History[*version], ns ...int) {
+	t.Helper()
+
+	for _, n := range ns {
+		if err := h.Push(&version{n: n}); err != nil {
+			t.Fatalf("failed to push %v: %v", n, err)
+		}
+	}
+}
+
+func current(h *immutable.History[*version]) int {
+	v, ok := h.Current()
+	if !ok {
+		return -1
+	}
+	return v.n
+}
+
+func TestHistoryUndoRedo(t *testing.T) {
+	var h immutable.History[*version]
+
+	if _, ok := h.Undo(); ok {
+		t.Fatalf("expected Undo on an empty History to fail")
+	}
+
+	push(t, &h, 1, 2, 3)
+
+	if v, ok := h.Undo(); !ok || v.n != 2 {
+		t.Fatalf("expected Undo to return 2; got %v, %v", v, ok)
+	}
+	if v, ok := h.Undo(); !ok || v.n != 1 {
+		t.Fatalf("expected Undo to return 1; got %v, %v", v, ok)
+	}
+	if _, ok := h.Undo(); ok {
+		t.Fatalf("expected Undo of the first version to fail")
+	}
+	if v, ok := h.Redo(); !ok || v.n != 2 {
+		t.Fatalf("expected Redo to return 2; got %v, %v", v, ok)
+	}
+
+	// pushing discards the versions that could have been redone
+	push(t, &h, 4)
+
+	if h.CanRedo() {
+		t.Fatalf("expected no versions to redo after Push")
+	}
+	if h.Len() != 3 || current(&h) != 4 {
+		t.Fatalf("expected 3 versions with 4 current; got %v versions with %v current", h.Len(), current(&h))
+	}
+}
+
+func TestHistoryLimit(t *testing.T) {
+	h := immutable.NewHistory[*version](3)
+
+	push(t, h, 1, 2, 3, 4, 5)
+
+	var got []int
+	for ok := true; ok; _, ok = h.Undo() {
+		got = append(got, current(h))
+	}
+
+	if exp := []int{5, 4, 3}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected versions %v; got %v", exp, got)
+	}
+}
+
+func TestHistoryMutable(t *testing.T) {
+	h := immutable.NewHistory[*version](0)
+
+	push(t, h, 1)
+
+	if err := h.Push(&version{n: 2, mutable: true}); err != immutable.ErrMutable {
+		t.Fatalf("expected ErrMutable; got %v", err)
+	}
+
+	if h.Len() != 1 || current(h) != 1 {
+		t.Fatalf("expected a refused Push to leave the History unchanged")
+	}
+}
+
+func TestHistoryBranch(t *testing.T) {
+	h := immutable.NewHistory[*version](0)
+
+	push(t, h, 1, 2, 3)
+	h.Undo()
+
+	b := h.Branch()
+	push(t, b, 4)
+
+	if v, ok := h.Redo(); !ok || v.n != 3 {
+		t.Fatalf("expected the original History to be unaffected by the branch; got %v, %v", v, ok)
+	}
+
+	if v, ok := b.Undo(); !ok || v.n != 2 {
+		t.Fatalf("expected Undo on the branch to return 2; got %v, %v", v, ok)
+	}
+}
+
+func TestHistorySnapshots(t *testing.T) {
+	h := immutable.NewHistory[*version](2)
+
+	if h.Snapshot("empty") {
+		t.Fatalf("expected Snapshot of an empty History to fail")
+	}
+
+	push(t, h, 1)
+	h.Snapshot("one")
+	push(t, h, 2, 3, 4)
+	h.Snapshot("four")
+
+	if exp, got := []string{"four", "one"}, h.Snapshots(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected snapshots %v; got %v", exp, got)
+	}
+
+	// the version 1 has been dropped from the versions, but the snapshot
+	// remains
+	if v, ok := h.Restore("one"); !ok || v.n != 1 || current(h) != 1 {
+		t.Fatalf("expected Restore to make 1 current; got %v, %v", v, ok)
+	}
+
+	if v, ok := h.Undo(); !ok || v.n != 4 {
+		t.Fatalf("expected Restore to be undoable; got %v, %v", v, ok)
+	}
+
+	h.DelSnapshot("one")
+
+	if _, ok := h.Restore("one"); ok {
+		t.Fatalf("expected Restore of a deleted snapshot to fail")
+	}
+}
@@ -4,6 +4,9 @@
 // Package immutable is a helper package for the immutable data structures
 // generated by myitcv.io/immutable/cmd/immutableGen.
 //
+// History records successive versions of an immutable value, in support of
+// undo, redo and named snapshots.
+//
 package immutable
 
 const (
@@ -17,6 +20,14 @@ const (
 
 	// Pkg is the import path of this package
 	PkgImportPath = "myitcv.io/immutable"
+
+	// PersistentPkgImportPath is the import path of the package that backs
+	// persistent immutable maps and slices
+	PersistentPkgImportPath = "myitcv.io/immutable/persistent"
+
+	// ContainerPkgImportPath is the import path of the package that backs
+	// immutable maps and slices generated in container mode
+	ContainerPkgImportPath = "myitcv.io/immutable/container"
 )
 
 // Immutable is the interface implemented by all immutable types. If Go had generics the interface would
@@ -36,3 +47,11 @@ type Immutable interface {
 	Mutable() bool
 	IsDeeplyNonMutable(seen map[interface{}]bool) bool
 }
+
+// Set is the value type of a map template that declares an immutable set,
+// rather than an immutable map. For example:
+//
+// 	type _Imm_TagSet map[string]immutable.Set
+//
+// declares the immutable set type TagSet, the elements of which are strings.
+type Set struct{}
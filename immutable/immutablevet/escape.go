@@ -0,0 +1,420 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package immutablevet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"myitcv.io/gogenerate"
+	"myitcv.io/immutable"
+	"myitcv.io/immutable/util"
+)
+
+const (
+	asMutableMethod   = "AsMutable"
+	asImmutableMethod = "AsImmutable"
+	withMutableMethod = "WithMutable"
+)
+
+// flow tracks, in source order, the local variables of a function that hold
+// mutable immutable values. The analysis is deliberately simple: it does not
+// distinguish between branches, and treats passing a mutable value to a
+// function as safe, because that is how WithMutable itself works.
+type flow struct {
+	iv *immutableVetter
+
+	// scope is the node within which variables are considered local
+	scope ast.Node
+
+	// mutable maps the variables that hold a mutable value to the method via
+	// which that value was obtained, i.e. WithMutable or AsMutable
+	mutable map[types.Object]string
+
+	// frozen is the set of variables on which AsImmutable has been called
+	frozen map[types.Object]bool
+
+	// trackAsMutable indicates whether the results of calls to AsMutable
+	// should be tracked
+	trackAsMutable bool
+
+	// nested is the depth of function literals within scope
+	nested int
+}
+
+// vetEscapes reports mutable values that escape the scope in which they are
+// mutable, and the use of mutating methods on values after AsImmutable has
+// been called on them
+func (iv *immutableVetter) vetEscapes() {
+	for _, file := range iv.pass.Files {
+		fn := iv.pass.Fset.Position(file.Pos()).Filename
+
+		if iv.skipFiles[fn] || gogenerate.FileGeneratedBy(fn, immutable.CmdImmutableGen) {
+			continue
+		}
+
+		for _, d := range file.Decls {
+			ast.Inspect(d, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.FuncDecl:
+					if n.Body != nil {
+						iv.newFlow(n, true).walk(n.Body)
+					}
+					return false
+				case *ast.FuncLit:
+					// a function literal outside of a function declaration,
+					// e.g. in a package-level var declaration
+					iv.newFlow(n, true).walk(n.Body)
+					return false
+				}
+				return true
+			})
+
+			ast.Inspect(d, func(n ast.Node) bool {
+				if ce, ok := n.(*ast.CallExpr); ok {
+					iv.vetWithMutable(ce)
+				}
+				return true
+			})
+		}
+	}
+}
+
+func (iv *immutableVetter) newFlow(scope ast.Node, trackAsMutable bool) *flow {
+	return &flow{
+		iv:             iv,
+		scope:          scope,
+		mutable:        make(map[types.Object]string),
+		frozen:         make(map[types.Object]bool),
+		trackAsMutable: trackAsMutable,
+	}
+}
+
+// vetWithMutable checks that the parameter of a function literal passed to
+// WithMutable does not escape that function literal
+func (iv *immutableVetter) vetWithMutable(ce *ast.CallExpr) {
+	if len(ce.Args) != 1 || iv.immMethod(ce) != withMutableMethod {
+		return
+	}
+
+	fl, ok := ce.Args[0].(*ast.FuncLit)
+	if !ok {
+		return
+	}
+
+	ps := fl.Type.Params.List
+	if len(ps) != 1 || len(ps[0].Names) != 1 {
+		return
+	}
+
+	o := iv.pass.TypesInfo.Defs[ps[0].Names[0]]
+	if o == nil {
+		return
+	}
+
+	f := iv.newFlow(fl, false)
+	f.mutable[o] = withMutableMethod
+	f.walk(fl.Body)
+}
+
+// immMethod returns the name of the method called by ce if the receiver is of
+// an immutable type, else the empty string
+func (iv *immutableVetter) immMethod(ce *ast.CallExpr) string {
+	se, ok := ce.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+
+	sel, ok := iv.pass.TypesInfo.Selections[se]
+	if !ok || sel.Kind() != types.MethodVal {
+		return ""
+	}
+
+	if util.IsImmType(sel.Recv()) == nil {
+		return ""
+	}
+
+	return se.Sel.Name
+}
+
+func (f *flow) walk(body ast.Node) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			f.nested++
+			f.walk(n.Body)
+			f.nested--
+			return false
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for i := range n.Lhs {
+					f.assign(n.Lhs[i], n.Rhs[i])
+				}
+			} else {
+				for _, l := range n.Lhs {
+					f.forget(l)
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == len(n.Values) {
+				for i := range n.Names {
+					f.assign(n.Names[i], n.Values[i])
+				}
+			}
+		case *ast.ReturnStmt:
+			// a return from a nested function literal is only an escape if
+			// the function literal itself escapes, which we check elsewhere
+			if f.nested > 0 {
+				break
+			}
+			for _, r := range n.Results {
+				f.checkEscape(r)
+			}
+		case *ast.SendStmt:
+			f.checkEscape(n.Value)
+		case *ast.ExprStmt:
+			if ce, ok := n.X.(*ast.CallExpr); ok {
+				f.call(ce)
+			}
+		}
+		return true
+	})
+}
+
+// call handles a call whose result, if any, is discarded
+func (f *flow) call(ce *ast.CallExpr) {
+	m := f.iv.immMethod(ce)
+	if m == "" {
+		return
+	}
+
+	x := ce.Fun.(*ast.SelectorExpr).X
+
+	o := f.localVar(x)
+	if o == nil {
+		return
+	}
+
+	if m == asImmutableMethod {
+		delete(f.mutable, o)
+		f.frozen[o] = true
+		return
+	}
+
+	if f.frozen[o] && f.returnsRecvType(ce) {
+		f.iv.errorf(nil, ce.Pos(), "%v is immutable following the call to %v; the result of %v is discarded", o.Name(), asImmutableMethod, m)
+	}
+}
+
+func (f *flow) assign(lhs, rhs ast.Expr) {
+	rhs = ast.Unparen(rhs)
+
+	if ce, ok := rhs.(*ast.CallExpr); ok {
+		switch f.iv.immMethod(ce) {
+		case asImmutableMethod:
+			if o := f.localVar(ce.Fun.(*ast.SelectorExpr).X); o != nil {
+				delete(f.mutable, o)
+				f.frozen[o] = true
+			}
+			if o := f.forget(lhs); o != nil {
+				f.frozen[o] = true
+			}
+			return
+		}
+	}
+
+	if f.asMutable(rhs) {
+		if !f.isLocal(lhs) {
+			f.escapes(rhs, asMutableMethod, f.iv.exprString(rhs))
+			return
+		}
+		if o := f.forget(lhs); o != nil {
+			f.mutable[o] = asMutableMethod
+		}
+		return
+	}
+
+	r := f.refersTo(rhs)
+
+	if r != nil && !f.isLocal(lhs) {
+		f.escapes(rhs, f.mutable[r], r.Name())
+		return
+	}
+
+	o := f.forget(lhs)
+
+	if r != nil && o != nil {
+		// lhs is now an alias of r
+		f.mutable[o] = f.mutable[r]
+	}
+}
+
+// checkEscape reports e, a value that escapes the scope, if it is mutable
+func (f *flow) checkEscape(e ast.Expr) {
+	if o := f.refersTo(e); o != nil {
+		f.escapes(e, f.mutable[o], o.Name())
+	} else if f.asMutable(e) {
+		f.escapes(e, asMutableMethod, f.iv.exprString(e))
+	}
+}
+
+// asMutable reports whether e is a call to AsMutable, or to a setter or the
+// like on the result of such a call, where such calls are tracked
+func (f *flow) asMutable(e ast.Expr) bool {
+	if !f.trackAsMutable {
+		return false
+	}
+
+	ce, ok := ast.Unparen(e).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+
+	switch m := f.iv.immMethod(ce); {
+	case m == asMutableMethod:
+		return true
+	case m != "" && m != asImmutableMethod && f.returnsRecvType(ce):
+		return f.asMutable(ce.Fun.(*ast.SelectorExpr).X)
+	}
+
+	return false
+}
+
+// forget clears any state held about the variable e, returning the
+// variable, if e is one
+func (f *flow) forget(e ast.Expr) types.Object {
+	id, ok := ast.Unparen(e).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	o := f.iv.pass.TypesInfo.ObjectOf(id)
+	if o == nil {
+		return nil
+	}
+
+	delete(f.mutable, o)
+	delete(f.frozen, o)
+
+	return o
+}
+
+// escapes reports that e, the mutable value name obtained via the method
+// origin, escapes
+func (f *flow) escapes(e ast.Expr, origin, name string) {
+	switch origin {
+	case withMutableMethod:
+		f.iv.errorf(nil, e.Pos(), "mutable value %v must not escape the function passed to %v", name, withMutableMethod)
+	default:
+		f.iv.errorf(nil, e.Pos(), "mutable value %v from %v escapes; call %v first", name, asMutableMethod, asImmutableMethod)
+	}
+}
+
+// refersTo returns the tracked mutable variable to which the value of e
+// refers, if any
+func (f *flow) refersTo(e ast.Expr) types.Object {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		if o := f.iv.pass.TypesInfo.Uses[e]; o != nil && f.mutable[o] != "" {
+			return o
+		}
+	case *ast.CallExpr:
+		if id, ok := e.Fun.(*ast.Ident); ok {
+			if _, ok := f.iv.pass.TypesInfo.Uses[id].(*types.Builtin); ok && id.Name == "append" {
+				for _, a := range e.Args[1:] {
+					if o := f.refersTo(a); o != nil {
+						return o
+					}
+				}
+			}
+			break
+		}
+
+		// the setters and the like of a mutable value return the receiver
+		if m := f.iv.immMethod(e); m != "" && m != asImmutableMethod && f.returnsRecvType(e) {
+			return f.refersTo(e.Fun.(*ast.SelectorExpr).X)
+		}
+	case *ast.CompositeLit:
+		for _, elt := range e.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			if o := f.refersTo(elt); o != nil {
+				return o
+			}
+		}
+	case *ast.UnaryExpr:
+		return f.refersTo(e.X)
+	case *ast.FuncLit:
+		// a function literal that captures a mutable value
+		var res types.Object
+		ast.Inspect(e.Body, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok && res == nil {
+				if o := f.iv.pass.TypesInfo.Uses[id]; o != nil && f.mutable[o] != "" {
+					res = o
+				}
+			}
+			return res == nil
+		})
+		return res
+	}
+
+	return nil
+}
+
+// returnsRecvType reports whether ce, a method call, returns a single value
+// of the type of its receiver
+func (f *flow) returnsRecvType(ce *ast.CallExpr) bool {
+	se := ce.Fun.(*ast.SelectorExpr)
+	sig, ok := f.iv.pass.TypesInfo.Types[se].Type.(*types.Signature)
+	if !ok || sig.Results().Len() != 1 {
+		return false
+	}
+
+	return types.Identical(sig.Results().At(0).Type(), f.iv.pass.TypesInfo.Types[se.X].Type)
+}
+
+// localVar returns the local variable denoted by e, if e is one
+func (f *flow) localVar(e ast.Expr) types.Object {
+	id, ok := ast.Unparen(e).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	o, ok := f.iv.pass.TypesInfo.ObjectOf(id).(*types.Var)
+	if !ok || !f.declaredInScope(o) {
+		return nil
+	}
+
+	return o
+}
+
+// isLocal reports whether an assignment to e is an assignment to (part of) a
+// variable declared within scope, or to the blank identifier
+func (f *flow) isLocal(e ast.Expr) bool {
+	for {
+		switch v := ast.Unparen(e).(type) {
+		case *ast.Ident:
+			if v.Name == "_" {
+				return true
+			}
+			o := f.iv.pass.TypesInfo.ObjectOf(v)
+			return o != nil && f.declaredInScope(o)
+		case *ast.SelectorExpr:
+			e = v.X
+		case *ast.IndexExpr:
+			e = v.X
+		case *ast.StarExpr:
+			e = v.X
+		default:
+			return false
+		}
+	}
+}
+
+func (f *flow) declaredInScope(o types.Object) bool {
+	p := o.Pos()
+	return p != token.NoPos && f.scope.Pos() <= p && p < f.scope.End()
+}
@@ -0,0 +1,539 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+// Package immutablevet defines an Analyzer that checks the correct use of the
+// immutable types generated by myitcv.io/immutable/cmd/immutableGen.
+//
+// The Analyzer reports:
+//
+//   - immutable struct templates with fields that are not of immutable types
+//   - non-pointer values and types of immutable types
+//   - immutable values constructed using composite literals, rather than
+//     new() or the generated constructors
+//   - uses of immutable template types outside of generated code
+//   - uses of the fields of immutable types
+//   - uses of the Range() method of immutable maps, sets and slices other than
+//     in a range statement or as the ellipsis argument to append, because
+//     the result of Range() must not be mutated
+//   - mutable values that escape the function literal passed to WithMutable,
+//     e.g. by assignment to a variable declared outside the function literal
+//   - the results of AsMutable() that escape the function in which they are
+//     obtained, via a return, send or assignment, before AsImmutable is
+//     called on them
+//   - calls to methods that would mutate a value were it mutable, e.g.
+//     setters, the results of which are discarded, following a call to
+//     AsImmutable on that value
+//
+// Files that contain a //immutableVet:skipFile comment are not checked.
+//
+// The only fix the Analyzer suggests, via the SuggestedFixes of the
+// diagnostic, is rewriting MyStruct{} or &MyStruct{} as new(MyStruct). Other
+// fixes, e.g. changing the type of a field or map key from MyStruct to
+// *MyStruct, would change the meaning of the program, because they change the
+// equality and zero value of the values of that type.
+package immutablevet
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"myitcv.io/gogenerate"
+	"myitcv.io/immutable"
+	"myitcv.io/immutable/util"
+)
+
+const (
+	skipFileComment = "//" + immutable.CmdImmutableVet + ":skipFile"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: immutable.CmdImmutableVet,
+	Doc:  "check the correct use of immutable types generated by immutableGen",
+	Run:  run,
+}
+
+// immIntf is the method set of myitcv.io/immutable.Immutable, which we
+// construct rather than load because the package being analysed need not
+// import myitcv.io/immutable
+var immIntf = func() *types.Interface {
+	boolRes := types.NewTuple(types.NewVar(token.NoPos, nil, "", types.Typ[types.Bool]))
+	seen := types.NewMap(types.NewInterfaceType(nil, nil).Complete(), types.Typ[types.Bool])
+
+	mutable := types.NewFunc(token.NoPos, nil, "Mutable",
+		types.NewSignatureType(nil, nil, nil, nil, boolRes, false))
+	isDeeply := types.NewFunc(token.NoPos, nil, "IsDeeplyNonMutable",
+		types.NewSignatureType(nil, nil, nil, types.NewTuple(types.NewVar(token.NoPos, nil, "seen", seen)), boolRes, false))
+
+	return types.NewInterfaceType([]*types.Func{mutable, isDeeply}, nil).Complete()
+}()
+
+type immutableVetter struct {
+	pass *analysis.Pass
+
+	skipFiles map[string]bool
+
+	// immTmpls is the set of immutable template types in the package
+	// being analysed
+	immTmpls map[types.Type]bool
+
+	// helper field used to hold Range() method calls on immutable types
+	rngs map[*ast.Ident]bool
+
+	// valid composite literals
+	vcls map[*ast.CompositeLit]bool
+
+	typesCache map[string]bool
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	iv := &immutableVetter{
+		pass:      pass,
+		skipFiles: make(map[string]bool),
+		immTmpls:  make(map[types.Type]bool),
+		rngs:      make(map[*ast.Ident]bool),
+		vcls:      make(map[*ast.CompositeLit]bool),
+		typesCache: map[string]bool{
+			"time.Time": true,
+		},
+	}
+
+	iv.vetPackage()
+
+	return nil, nil
+}
+
+func (iv *immutableVetter) ensurePointerTyp(n ast.Node, typ ast.Expr) {
+	if ts, ok := n.(*ast.TypeSpec); ok {
+		if ts.Assign.IsValid() {
+			// we are an alias; this is fine in all cases
+			return
+		}
+	}
+	t := iv.pass.TypesInfo.Types[typ].Type
+	if t == nil {
+		return
+	}
+	p := types.NewPointer(t)
+	switch util.IsImmType(p).(type) {
+	case util.ImmTypeMap, util.ImmTypeSet, util.ImmTypeSlice, util.ImmTypeStruct:
+	default:
+		return
+	}
+
+	// adding a * to the type of a variable, field, map key etc changes the
+	// equality and zero value of its values, hence we only suggest a fix for
+	// an empty composite literal
+	var fixes []analysis.SuggestedFix
+	if cl, ok := n.(*ast.CompositeLit); ok && len(cl.Elts) == 0 {
+		fixes = iv.newFix(cl, cl.Type)
+	}
+
+	iv.errorf(fixes, n.Pos(), "type should be %v", p)
+}
+
+// newFix returns a suggested fix that replaces n, a composite literal with no
+// elements or the address of such a literal, with new(typ)
+func (iv *immutableVetter) newFix(n ast.Node, typ ast.Expr) []analysis.SuggestedFix {
+	t := iv.exprString(typ)
+
+	return []analysis.SuggestedFix{{
+		Message: fmt.Sprintf("Use new(%v)", t),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     n.Pos(),
+			End:     n.End(),
+			NewText: []byte("new(" + t + ")"),
+		}},
+	}}
+}
+
+func (iv *immutableVetter) Visit(node ast.Node) ast.Visitor {
+	info := iv.pass.TypesInfo
+
+	switch node := node.(type) {
+	case *ast.File:
+		for _, cg := range node.Comments {
+			for _, c := range cg.List {
+				if c.Text == skipFileComment {
+					iv.skipFiles[iv.pass.Fset.Position(node.Pos()).Filename] = true
+					return nil
+				}
+			}
+		}
+	case *ast.ValueSpec:
+		iv.ensurePointerTyp(node, node.Type)
+	case *ast.ArrayType:
+		iv.ensurePointerTyp(node, node.Elt)
+	case *ast.MapType:
+		iv.ensurePointerTyp(node, node.Key)
+		iv.ensurePointerTyp(node, node.Value)
+	case *ast.Field:
+		iv.ensurePointerTyp(node, node.Type)
+	case *ast.UnaryExpr:
+		if node.Op != token.AND {
+			break
+		}
+
+		cl, ok := node.X.(*ast.CompositeLit)
+		if !ok {
+			break
+		}
+
+		t := info.Types[cl.Type].Type
+		p := types.NewPointer(t)
+		switch util.IsImmType(p).(type) {
+		case util.ImmTypeMap, util.ImmTypeSet, util.ImmTypeSlice, util.ImmTypeStruct:
+			var fixes []analysis.SuggestedFix
+			if len(cl.Elts) == 0 {
+				fixes = iv.newFix(node, cl.Type)
+			}
+			iv.errorf(fixes, node.Pos(), "construct using new() or generated constructors")
+			iv.vcls[cl] = true
+		}
+	case *ast.CompositeLit:
+		if ok := iv.vcls[node]; ok {
+			break
+		}
+
+		iv.ensurePointerTyp(node, node.Type)
+	case *ast.TypeSpec:
+		iv.ensurePointerTyp(node, node.Type)
+	case *ast.SelectorExpr:
+		sel, ok := info.Selections[node]
+		if !ok {
+			// this is fine... !ok implies a selector expression
+			// that is a qualified identifier as opposed to a method
+			// field selector
+			break
+		}
+
+		if !isImmListOrMap(sel.Recv()) {
+			break
+		}
+
+		switch node.Sel.Name {
+		case "Range":
+			if _, ok := iv.rngs[node.Sel]; !ok {
+				iv.rngs[node.Sel] = false
+			}
+		}
+	case *ast.RangeStmt:
+		v := node.X
+		ce, ok := v.(*ast.CallExpr)
+		if !ok {
+			break
+		}
+
+		e := ce.Fun
+		se, ok := e.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+
+		sel, ok := info.Selections[se]
+		if !ok {
+			// then it must be a qualified identifier
+			break
+		}
+
+		if !isImmListOrMap(sel.Recv()) {
+			break
+		}
+
+		if sel.Kind() != types.MethodVal {
+			break
+		}
+
+		ri := se.Sel
+		if ri.Name != "Range" {
+			break
+		}
+		iv.rngs[ri] = true
+	case *ast.CallExpr:
+		switch fun := node.Fun.(type) {
+		case *ast.Ident:
+			if fun.Name != "append" {
+				break
+			}
+
+			if len(node.Args) != 2 {
+				break
+			}
+
+			e := node.Args[1]
+			ce, ok := e.(*ast.CallExpr)
+			if !ok {
+				break
+			}
+
+			se, ok := ce.Fun.(*ast.SelectorExpr)
+			if !ok {
+				break
+			}
+
+			sel, ok := info.Selections[se]
+			if !ok {
+				break
+			}
+
+			if !isImmListOrMap(sel.Recv()) {
+				break
+			}
+
+			ri := se.Sel
+			if ri.Name != "Range" {
+				break
+			}
+
+			if node.Ellipsis == node.Args[1].End() {
+				iv.rngs[ri] = true
+			}
+		case *ast.SelectorExpr:
+			sel, ok := info.Selections[fun]
+			if !ok {
+				// this is fine... !ok implies a selector expression
+				// that is a qualified identifier as opposed to a method
+				// field selector
+				break
+			}
+
+			if !isImmListOrMap(sel.Recv()) {
+				break
+			}
+
+			if sel.Kind() != types.MethodVal {
+				break
+			}
+
+			ri := fun.Sel
+			if ri.Name != "Append" {
+				break
+			}
+
+			if len(node.Args) != 1 {
+				break
+			}
+
+			if node.Ellipsis == token.NoPos {
+				break
+			}
+
+			ace, ok := node.Args[0].(*ast.CallExpr)
+			if !ok {
+				break
+			}
+
+			{
+				se, ok := ace.Fun.(*ast.SelectorExpr)
+				if !ok {
+					break
+				}
+
+				sel, ok := info.Selections[se]
+				if !ok {
+					// this is fine... !ok implies a selector expression
+					// that is a qualified identifier as opposed to a method
+					// field selector
+					break
+				}
+
+				if !isImmListOrMap(sel.Recv()) {
+					break
+				}
+
+				if sel.Kind() != types.MethodVal {
+					break
+				}
+
+				ri := se.Sel
+				if ri.Name == "Range" {
+					iv.rngs[ri] = true
+				}
+			}
+		}
+	}
+	return iv
+}
+
+func isImmListOrMap(t types.Type) bool {
+	switch util.IsImmType(t).(type) {
+	case util.ImmTypeMap, util.ImmTypeSet, util.ImmTypeSlice:
+		return true
+	}
+
+	return false
+}
+
+func (iv *immutableVetter) isImmTmpl(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.Pointer:
+		return iv.isImmTmpl(t.Elem())
+	}
+
+	return iv.immTmpls[t]
+}
+
+func (iv *immutableVetter) vetPackage() {
+	pass := iv.pass
+	info := pass.TypesInfo
+
+	for _, f := range pass.Files {
+		for _, d := range f.Decls {
+			gd, ok := d.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+
+			if gd.Tok != token.TYPE {
+				continue
+			}
+
+			for _, s := range gd.Specs {
+				ts := s.(*ast.TypeSpec)
+
+				_, ok := util.IsImmTmpl(ts)
+				if !ok {
+					continue
+				}
+
+				o := info.ObjectOf(ts.Name)
+				iv.immTmpls[o.Type()] = true
+
+				st, ok := o.Type().(*types.Named).Underlying().(*types.Struct)
+				if !ok {
+					continue
+				}
+
+				for i := 0; i < st.NumFields(); i++ {
+					f := st.Field(i)
+					if !iv.isImmType(f.Type()) {
+						iv.errorf(nil, f.Pos(), "immutable struct field must be immutable type; %v is not", f.Type())
+					}
+				}
+			}
+		}
+	}
+
+	for _, fnode := range pass.Files {
+		ast.Walk(iv, fnode)
+	}
+
+	iv.vetEscapes()
+
+	for exp, t := range info.Types {
+		switch {
+		case t.IsType():
+			typ := t.Type
+
+			if !iv.isImmTmpl(typ) {
+				continue
+			}
+
+			fn := pass.Fset.Position(exp.Pos()).Filename
+
+			if !gogenerate.FileGeneratedBy(fn, immutable.CmdImmutableGen) {
+				iv.errorf(nil, exp.Pos(), "template type %v should never get used", typ)
+			}
+
+		case t.IsValue():
+			p := types.NewPointer(t.Type)
+			switch util.IsImmType(p).(type) {
+			case util.ImmTypeMap:
+			case util.ImmTypeSet:
+			case util.ImmTypeSlice:
+			case util.ImmTypeStruct:
+			default:
+				continue
+			}
+
+			fn := pass.Fset.Position(exp.Pos()).Filename
+
+			if !iv.skipFiles[fn] {
+				iv.errorf(nil, exp.Pos(), "non-pointer value of immutable type %v found", p)
+			}
+		}
+
+	}
+
+	// find selector exprs which access properties of Immutable types
+	for exp, sel := range info.Selections {
+		isField := sel.Kind() == types.FieldVal
+		if !isField {
+			continue
+		}
+
+		if util.IsImmType(sel.Recv()) == nil {
+			continue
+		}
+
+		if iv.skipFiles[pass.Fset.Position(exp.X.Pos()).Filename] {
+			continue
+		}
+
+		oname := sel.Obj().Name()
+		iv.errorf(nil, exp.X.Pos(), "should not be using %v of %v immutable type", oname, sel.Recv())
+	}
+
+	for k, v := range iv.rngs {
+		if v == false {
+			iv.errorf(nil, k.NamePos, "Range() of immutable type must appear in a range statement or used with an ellipsis as the second argument to append")
+		}
+	}
+}
+
+func (iv *immutableVetter) isImmType(t types.Type) bool {
+	if v, ok := iv.typesCache[t.String()]; ok {
+		return v
+	}
+
+	switch t := t.(type) {
+	case *types.Named:
+
+		iv.typesCache[t.String()] = true
+
+		v := iv.isImmType(t.Underlying())
+		iv.typesCache[t.String()] = v
+
+		return v
+	case *types.Basic:
+		return true
+	case *types.Map, *types.Slice:
+		return false
+	case *types.Pointer:
+		return util.IsImmType(t) != nil
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			f := t.Field(i)
+			if !iv.isImmType(f.Type()) {
+				return false
+			}
+		}
+
+		return true
+	case *types.Interface:
+		return types.Implements(t, immIntf)
+	}
+
+	// signatures, channels etc are not immutable; nor do we try to reason
+	// about anything else
+	return false
+}
+
+func (iv *immutableVetter) exprString(e ast.Expr) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, iv.pass.Fset, e)
+	return buf.String()
+}
+
+// errorf reports a diagnostic at pos, with the suggested fixes (if any)
+func (iv *immutableVetter) errorf(fixes []analysis.SuggestedFix, pos token.Pos, format string, args ...interface{}) {
+	iv.pass.Report(analysis.Diagnostic{
+		Pos:            pos,
+		Message:        fmt.Sprintf(format, args...),
+		SuggestedFixes: fixes,
+	})
+}
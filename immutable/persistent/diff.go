@@ -0,0 +1,208 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package persistent
+
+// Equal returns whether m and o contain the same keys with values that are
+// equal according to eq. Subtrees shared by m and o are not compared.
+func (m *Map[K, V]) Equal(o *Map[K, V], eq func(a, b V) bool) bool {
+	if m.Len() != o.Len() {
+		return false
+	}
+	equal := true
+	m.Diff(o, eq, func(K, V, bool, V, bool) bool {
+		equal = false
+		return false
+	})
+	return equal
+}
+
+// Diff calls f for each key that is in only one of m and o, or whose values in
+// m and o are not equal according to eq, until f returns false. a and aok are
+// the value of the key in m and whether m contains the key; likewise b and bok
+// for o. Keys are visited in an unspecified order. Subtrees shared by m and o
+// are not visited.
+func (m *Map[K, V]) Diff(o *Map[K, V], eq func(a, b V) bool, f func(k K, a V, aok bool, b V, bok bool) bool) {
+	var ra, rb *mnode[K, V]
+	if m != nil {
+		ra = m.root
+	}
+	if o != nil {
+		rb = o.root
+	}
+	if ra == nil {
+		ra = &mnode[K, V]{}
+	}
+	if rb == nil {
+		rb = &mnode[K, V]{}
+	}
+	d := &mapDiff[K, V]{eq: eq, f: f}
+	d.nodes(ra, rb, 0)
+}
+
+type mapDiff[K comparable, V any] struct {
+	eq   func(a, b V) bool
+	f    func(k K, a V, aok bool, b V, bok bool) bool
+	done bool
+}
+
+func (d *mapDiff[K, V]) report(k K, a V, aok bool, b V, bok bool) {
+	if d.done {
+		return
+	}
+	if aok && bok && d.eq(a, b) {
+		return
+	}
+	if !d.f(k, a, aok, b, bok) {
+		d.done = true
+	}
+}
+
+// nodes compares the nodes a and b, which are at depth shift
+func (d *mapDiff[K, V]) nodes(a, b *mnode[K, V], shift uint) {
+	if a == b || d.done {
+		return
+	}
+	var zero V
+	if shift >= 64 {
+		// collision nodes
+		for _, e := range a.entries {
+			v, ok := b.collisionGet(e.key)
+			d.report(e.key, e.val, true, v, ok)
+		}
+		for _, e := range b.entries {
+			if _, ok := a.collisionGet(e.key); !ok {
+				d.report(e.key, zero, false, e.val, true)
+			}
+		}
+		return
+	}
+	for i := uint(0); i < width && !d.done; i++ {
+		bit := uint32(1) << i
+		switch {
+		case a.dataMap&bit != 0 && b.dataMap&bit != 0:
+			ea, eb := a.entries[index(a.dataMap, bit)], b.entries[index(b.dataMap, bit)]
+			if ea.key == eb.key {
+				d.report(ea.key, ea.val, true, eb.val, true)
+			} else {
+				d.report(ea.key, ea.val, true, zero, false)
+				d.report(eb.key, zero, false, eb.val, true)
+			}
+		case a.nodeMap&bit != 0 && b.nodeMap&bit != 0:
+			d.nodes(a.nodes[index(a.nodeMap, bit)], b.nodes[index(b.nodeMap, bit)], shift+levelBits)
+		case a.dataMap&bit != 0 && b.nodeMap&bit != 0:
+			d.entryNode(a.entries[index(a.dataMap, bit)], b.nodes[index(b.nodeMap, bit)], shift+levelBits, false)
+		case a.nodeMap&bit != 0 && b.dataMap&bit != 0:
+			d.entryNode(b.entries[index(b.dataMap, bit)], a.nodes[index(a.nodeMap, bit)], shift+levelBits, true)
+		case a.dataMap&bit != 0:
+			e := a.entries[index(a.dataMap, bit)]
+			d.report(e.key, e.val, true, zero, false)
+		case a.nodeMap&bit != 0:
+			a.nodes[index(a.nodeMap, bit)].each(func(k K, v V) bool {
+				d.report(k, v, true, zero, false)
+				return !d.done
+			})
+		case b.dataMap&bit != 0:
+			e := b.entries[index(b.dataMap, bit)]
+			d.report(e.key, zero, false, e.val, true)
+		case b.nodeMap&bit != 0:
+			b.nodes[index(b.nodeMap, bit)].each(func(k K, v V) bool {
+				d.report(k, zero, false, v, true)
+				return !d.done
+			})
+		}
+	}
+}
+
+// entryNode compares the single entry e with the entries of the node n at
+// depth shift. If swapped, e is from the second map of the comparison, else
+// the first.
+func (d *mapDiff[K, V]) entryNode(e entry[K, V], n *mnode[K, V], shift uint, swapped bool) {
+	var zero V
+	found := false
+	n.each(func(k K, v V) bool {
+		switch {
+		case k == e.key && swapped:
+			found = true
+			d.report(k, v, true, e.val, true)
+		case k == e.key:
+			found = true
+			d.report(k, e.val, true, v, true)
+		case swapped:
+			d.report(k, v, true, zero, false)
+		default:
+			d.report(k, zero, false, v, true)
+		}
+		return !d.done
+	})
+	if !found {
+		if swapped {
+			d.report(e.key, zero, false, e.val, true)
+		} else {
+			d.report(e.key, e.val, true, zero, false)
+		}
+	}
+}
+
+// Equal returns whether v and o have the same length and values at each index
+// that are equal according to eq. Leaves shared by v and o are not compared.
+func (v *Vector[T]) Equal(o *Vector[T], eq func(a, b T) bool) bool {
+	if v.Len() != o.Len() {
+		return false
+	}
+	equal := true
+	v.Diff(o, eq, func(int, T, bool, T, bool) bool {
+		equal = false
+		return false
+	})
+	return equal
+}
+
+// Diff calls f, in index order, for each index that is in only one of v and
+// o, or whose values in v and o are not equal according to eq, until f
+// returns false. a and aok are the value at the index in v and whether the
+// index is in range for v; likewise b and bok for o. Leaves shared by v and o
+// are not visited.
+func (v *Vector[T]) Diff(o *Vector[T], eq func(a, b T) bool, f func(i int, a T, aok bool, b T, bok bool) bool) {
+	la, lb := v.Len(), o.Len()
+	var zero T
+	for c := 0; c*width < la || c*width < lb; c++ {
+		ca, cb := v.chunk(c), o.chunk(c)
+		if len(ca) > 0 && len(ca) == len(cb) && &ca[0] == &cb[0] {
+			continue
+		}
+		for j := 0; j < len(ca) || j < len(cb); j++ {
+			a, b := zero, zero
+			aok, bok := j < len(ca), j < len(cb)
+			if aok {
+				a = ca[j]
+			}
+			if bok {
+				b = cb[j]
+			}
+			if aok && bok && eq(a, b) {
+				continue
+			}
+			if !f(c*width+j, a, aok, b, bok) {
+				return
+			}
+		}
+	}
+}
+
+// chunk returns the values of v with indices in [c*width, (c+1)*width), which
+// are held either by a leaf or by the tail
+func (v *Vector[T]) chunk(c int) []T {
+	i := c * width
+	if i >= v.Len() {
+		return nil
+	}
+	if i >= v.tailOffset() {
+		return v.tail
+	}
+	n := v.root
+	for level := v.shift; level > 0; level -= levelBits {
+		n = n.children[(i>>level)&mask]
+	}
+	return n.values
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package persistent
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+type mapChange struct {
+	k   int
+	a   int
+	aok bool
+	b   int
+	bok bool
+}
+
+func TestMapDiff(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	eq := func(a, b int) bool { return a == b }
+
+	var m1 *Map[int, int]
+	for i := 0; i < 2000; i++ {
+		m1 = m1.Set(r.Intn(3000), i)
+	}
+
+	m2 := m1
+	for i := 0; i < 200; i++ {
+		k := r.Intn(3000)
+		switch r.Intn(3) {
+		case 0:
+			m2 = m2.Del(k)
+		case 1:
+			m2 = m2.Set(k, -i)
+		default:
+			// a change that is equal
+			if v, ok := m2.Get(k); ok {
+				m2 = m2.Set(k, v)
+			}
+		}
+	}
+
+	g1, g2 := m1.ToMap(), m2.ToMap()
+
+	var exp []mapChange
+	for k, a := range g1 {
+		b, bok := g2[k]
+		if !bok || a != b {
+			exp = append(exp, mapChange{k, a, true, b, bok})
+		}
+	}
+	for k, b := range g2 {
+		if _, ok := g1[k]; !ok {
+			exp = append(exp, mapChange{k, 0, false, b, true})
+		}
+	}
+
+	var got []mapChange
+	m1.Diff(m2, eq, func(k int, a int, aok bool, b int, bok bool) bool {
+		got = append(got, mapChange{k, a, aok, b, bok})
+		return true
+	})
+
+	for _, cs := range [][]mapChange{exp, got} {
+		sort.Slice(cs, func(i, j int) bool { return cs[i].k < cs[j].k })
+	}
+
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %v; got %v", exp, got)
+	}
+
+	if m1.Equal(m2, eq) != (len(exp) == 0) {
+		t.Fatalf("Equal inconsistent with Diff")
+	}
+
+	// -1 is never a key, so m3 has the same entries as, but shares only part of
+	// its structure with, m2
+	if m3 := m2.Set(-1, 0).Del(-1); !m2.Equal(m3, eq) {
+		t.Fatalf("maps with the same entries should be equal")
+	}
+
+	if m1.Equal(nil, eq) || !(*Map[int, int])(nil).Equal(NewMap[int, int](), eq) {
+		t.Fatalf("unexpected Equal result for nil maps")
+	}
+}
+
+func TestVectorDiff(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+
+	vals := make([]int, 1000)
+	for i := range vals {
+		vals[i] = i
+	}
+	v1 := NewVector(vals...)
+	v2 := v1.Set(500, -1).Set(3, 3).Append(1000, 1001)
+
+	type change struct {
+		i, a int
+		aok  bool
+		b    int
+		bok  bool
+	}
+
+	var got []change
+	v1.Diff(v2, eq, func(i int, a int, aok bool, b int, bok bool) bool {
+		got = append(got, change{i, a, aok, b, bok})
+		return true
+	})
+
+	exp := []change{
+		{500, 500, true, -1, true},
+		{1000, 0, false, 1000, true},
+		{1001, 0, false, 1001, true},
+	}
+
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %v; got %v", exp, got)
+	}
+
+	if v1.Equal(v2, eq) || !v1.Equal(NewVector(vals...), eq) {
+		t.Fatalf("unexpected Equal result")
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package persistent
+
+import "math/bits"
+
+// Map is a persistent map implemented as a compressed hash array mapped prefix
+// tree (CHAMP). Get, Set and Del are O(log n); Set and Del return a new Map
+// that shares structure with the receiver.
+type Map[K comparable, V any] struct {
+	root *mnode[K, V]
+	len  int
+}
+
+type entry[K comparable, V any] struct {
+	hash uint64
+	key  K
+	val  V
+}
+
+// mnode is a node of a Map. Each 5 bit fragment of a key's hash, starting
+// with the least significant, selects a position in a node at successive
+// depths. A position holds either an entry (dataMap) or a child node
+// (nodeMap). Beyond the 64 bits of the hash, a node is a collision node, in
+// which case entries holds the entries with identical hashes, unordered, and
+// both maps are zero.
+type mnode[K comparable, V any] struct {
+	dataMap uint32
+	nodeMap uint32
+	entries []entry[K, V]
+	nodes   []*mnode[K, V]
+}
+
+// NewMap returns an empty Map.
+func NewMap[K comparable, V any]() *Map[K, V] {
+	return &Map[K, V]{}
+}
+
+// Len returns the number of entries in m.
+func (m *Map[K, V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return m.len
+}
+
+// Get returns the value with key k and true if m contains k, else the zero
+// value and false.
+func (m *Map[K, V]) Get(k K) (V, bool) {
+	if m == nil || m.root == nil {
+		var zero V
+		return zero, false
+	}
+	return m.root.get(hashOf(k), k)
+}
+
+// Set returns a Map that contains the entries of m with the value for key k
+// set to v.
+func (m *Map[K, V]) Set(k K, v V) *Map[K, V] {
+	var root *mnode[K, V]
+	l := 0
+	if m != nil {
+		root, l = m.root, m.len
+	}
+	if root == nil {
+		root = &mnode[K, V]{}
+	}
+	e := entry[K, V]{hash: hashOf(k), key: k, val: v}
+	res, added := root.set(e, 0)
+	if added {
+		l++
+	}
+	return &Map[K, V]{root: res, len: l}
+}
+
+// Del returns a Map that contains the entries of m without key k. If m does
+// not contain k, m is returned.
+func (m *Map[K, V]) Del(k K) *Map[K, V] {
+	if m == nil || m.root == nil {
+		return m
+	}
+	res, removed := m.root.del(hashOf(k), k, 0)
+	if !removed {
+		return m
+	}
+	return &Map[K, V]{root: res, len: m.len - 1}
+}
+
+// Each calls f for each entry in m, in an unspecified order, until f returns
+// false.
+func (m *Map[K, V]) Each(f func(k K, v V) bool) {
+	if m == nil || m.root == nil {
+		return
+	}
+	m.root.each(f)
+}
+
+// ToMap returns a newly allocated Go map containing the entries of m.
+func (m *Map[K, V]) ToMap() map[K]V {
+	res := make(map[K]V, m.Len())
+	m.Each(func(k K, v V) bool {
+		res[k] = v
+		return true
+	})
+	return res
+}
+
+func bitpos(h uint64, shift uint) uint32 {
+	return 1 << ((h >> shift) & mask)
+}
+
+func index(bitmap, bit uint32) int {
+	return bits.OnesCount32(bitmap & (bit - 1))
+}
+
+func (n *mnode[K, V]) get(h uint64, k K) (V, bool) {
+	var zero V
+	for shift := uint(0); ; shift += levelBits {
+		if shift >= 64 {
+			return n.collisionGet(k)
+		}
+		bit := bitpos(h, shift)
+		switch {
+		case n.dataMap&bit != 0:
+			e := n.entries[index(n.dataMap, bit)]
+			if e.key == k {
+				return e.val, true
+			}
+			return zero, false
+		case n.nodeMap&bit != 0:
+			n = n.nodes[index(n.nodeMap, bit)]
+		default:
+			return zero, false
+		}
+	}
+}
+
+func (n *mnode[K, V]) collisionGet(k K) (V, bool) {
+	for _, e := range n.entries {
+		if e.key == k {
+			return e.val, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+func (n *mnode[K, V]) set(e entry[K, V], shift uint) (*mnode[K, V], bool) {
+	if shift >= 64 {
+		for i, c := range n.entries {
+			if c.key == e.key {
+				res := n.copy()
+				res.entries[i] = e
+				return res, false
+			}
+		}
+		res := n.copy()
+		res.entries = append(res.entries, e)
+		return res, true
+	}
+
+	bit := bitpos(e.hash, shift)
+	switch {
+	case n.dataMap&bit != 0:
+		i := index(n.dataMap, bit)
+		c := n.entries[i]
+		res := n.copy()
+		if c.key == e.key {
+			res.entries[i] = e
+			return res, false
+		}
+		// push both entries down into a new child
+		child := merge(c, e, shift+levelBits)
+		res.dataMap &^= bit
+		res.entries = append(res.entries[:i], res.entries[i+1:]...)
+		res.nodeMap |= bit
+		res.nodes = insertAt(res.nodes, index(res.nodeMap, bit), child)
+		return res, true
+	case n.nodeMap&bit != 0:
+		i := index(n.nodeMap, bit)
+		child, added := n.nodes[i].set(e, shift+levelBits)
+		res := n.copy()
+		res.nodes[i] = child
+		return res, added
+	default:
+		res := n.copy()
+		res.dataMap |= bit
+		res.entries = insertAt(res.entries, index(res.dataMap, bit), e)
+		return res, true
+	}
+}
+
+// merge returns a node at depth shift containing the distinct entries e1 and
+// e2
+func merge[K comparable, V any](e1, e2 entry[K, V], shift uint) *mnode[K, V] {
+	if shift >= 64 {
+		return &mnode[K, V]{entries: []entry[K, V]{e1, e2}}
+	}
+	b1, b2 := bitpos(e1.hash, shift), bitpos(e2.hash, shift)
+	if b1 == b2 {
+		return &mnode[K, V]{
+			nodeMap: b1,
+			nodes:   []*mnode[K, V]{merge(e1, e2, shift+levelBits)},
+		}
+	}
+	if b2 < b1 {
+		e1, e2 = e2, e1
+	}
+	return &mnode[K, V]{
+		dataMap: b1 | b2,
+		entries: []entry[K, V]{e1, e2},
+	}
+}
+
+func (n *mnode[K, V]) del(h uint64, k K, shift uint) (*mnode[K, V], bool) {
+	if shift >= 64 {
+		for i, c := range n.entries {
+			if c.key == k {
+				res := n.copy()
+				res.entries = append(res.entries[:i], res.entries[i+1:]...)
+				return res, true
+			}
+		}
+		return n, false
+	}
+
+	bit := bitpos(h, shift)
+	switch {
+	case n.dataMap&bit != 0:
+		i := index(n.dataMap, bit)
+		if n.entries[i].key != k {
+			return n, false
+		}
+		res := n.copy()
+		res.dataMap &^= bit
+		res.entries = append(res.entries[:i], res.entries[i+1:]...)
+		return res, true
+	case n.nodeMap&bit != 0:
+		i := index(n.nodeMap, bit)
+		child, removed := n.nodes[i].del(h, k, shift+levelBits)
+		if !removed {
+			return n, false
+		}
+		res := n.copy()
+		if len(child.nodes) == 0 && len(child.entries) == 1 {
+			// keep the tree canonical by pulling a single remaining entry up
+			// into this node
+			res.nodeMap &^= bit
+			res.nodes = append(res.nodes[:i], res.nodes[i+1:]...)
+			res.dataMap |= bit
+			res.entries = insertAt(res.entries, index(res.dataMap, bit), child.entries[0])
+		} else {
+			res.nodes[i] = child
+		}
+		return res, true
+	default:
+		return n, false
+	}
+}
+
+func (n *mnode[K, V]) each(f func(k K, v V) bool) bool {
+	for _, e := range n.entries {
+		if !f(e.key, e.val) {
+			return false
+		}
+	}
+	for _, c := range n.nodes {
+		if !c.each(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// copy returns a shallow copy of n whose entries and nodes can be modified
+// without affecting n
+func (n *mnode[K, V]) copy() *mnode[K, V] {
+	res := &mnode[K, V]{
+		dataMap: n.dataMap,
+		nodeMap: n.nodeMap,
+	}
+	if n.entries != nil {
+		res.entries = make([]entry[K, V], len(n.entries), len(n.entries)+1)
+		copy(res.entries, n.entries)
+	}
+	if n.nodes != nil {
+		res.nodes = make([]*mnode[K, V], len(n.nodes), len(n.nodes)+1)
+		copy(res.nodes, n.nodes)
+	}
+	return res
+}
+
+func insertAt[T any](s []T, i int, v T) []T {
+	var zero T
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+	return s
+}
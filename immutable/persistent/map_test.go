@@ -0,0 +1,139 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package persistent
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestMapRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	var m *Map[int, int]
+	exp := make(map[int]int)
+
+	var versions []*Map[int, int]
+	var expVersions []map[int]int
+
+	for i := 0; i < 20000; i++ {
+		k := r.Intn(5000)
+		if r.Intn(3) == 0 {
+			m = m.Del(k)
+			delete(exp, k)
+		} else {
+			m = m.Set(k, i)
+			exp[k] = i
+		}
+		if i%1000 == 0 {
+			versions = append(versions, m)
+			expVersions = append(expVersions, copyMap(exp))
+		}
+	}
+
+	checkMap(t, m, exp)
+
+	// earlier versions must be unaffected by later updates
+	for i, v := range versions {
+		checkMap(t, v, expVersions[i])
+	}
+
+	for k := range exp {
+		m = m.Del(k)
+	}
+	if m.Len() != 0 {
+		t.Fatalf("expected empty map; got length %v", m.Len())
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	type key struct {
+		s string
+		p *int
+		i interface{}
+		f float64
+	}
+
+	one, two := 1, 2
+
+	var m *Map[key, int]
+	m = m.Set(key{"a", &one, 1, 0}, 1)
+	m = m.Set(key{"a", &two, 1, 0}, 2)
+	m = m.Set(key{"a", &one, "1", 0}, 3)
+
+	checks := []struct {
+		k   key
+		v   int
+		has bool
+	}{
+		{key{"a", &one, 1, 0}, 1, true},
+		{key{"a", &two, 1, 0}, 2, true},
+		{key{"a", &one, "1", 0}, 3, true},
+		{key{"a", &one, 1, negZero()}, 1, true},
+		{key{"b", &one, 1, 0}, 0, false},
+	}
+
+	for _, c := range checks {
+		v, ok := m.Get(c.k)
+		if v != c.v || ok != c.has {
+			t.Errorf("expected Get(%v) to be (%v, %v); got (%v, %v)", c.k, c.v, c.has, v, ok)
+		}
+	}
+}
+
+func TestMapCollisions(t *testing.T) {
+	n := &mnode[string, int]{}
+	keys := []string{"a", "b", "c"}
+
+	for i, k := range keys {
+		n, _ = n.set(entry[string, int]{hash: 42, key: k, val: i}, 0)
+	}
+
+	for i, k := range keys {
+		if v, ok := n.get(42, k); !ok || v != i {
+			t.Fatalf("expected get(%q) to be (%v, true); got (%v, %v)", k, i, v, ok)
+		}
+	}
+	if _, ok := n.get(42, "d"); ok {
+		t.Fatalf("expected get(\"d\") to fail")
+	}
+
+	n, _ = n.del(42, "b", 0)
+	n, _ = n.del(42, "a", 0)
+
+	// the remaining entry should have been pulled up to the root
+	if len(n.nodes) != 0 || len(n.entries) != 1 || n.entries[0].key != "c" {
+		t.Fatalf("expected root with single entry c; got %+v", n)
+	}
+}
+
+func negZero() float64 {
+	z := 0.0
+	return -z
+}
+
+func checkMap(t *testing.T, m *Map[int, int], exp map[int]int) {
+	t.Helper()
+
+	if m.Len() != len(exp) {
+		t.Fatalf("expected length %v; got %v", len(exp), m.Len())
+	}
+	for k, ev := range exp {
+		if v, ok := m.Get(k); !ok || v != ev {
+			t.Fatalf("expected Get(%v) to be (%v, true); got (%v, %v)", k, ev, v, ok)
+		}
+	}
+	if got := m.ToMap(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected ToMap() to be %v; got %v", exp, got)
+	}
+}
+
+func copyMap(m map[int]int) map[int]int {
+	res := make(map[int]int, len(m))
+	for k, v := range m {
+		res[k] = v
+	}
+	return res
+}
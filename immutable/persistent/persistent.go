@@ -0,0 +1,21 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+// Package persistent provides persistent (structurally shared) maps, vectors
+// and ordered trees. Updates return a new value that shares all but O(log n)
+// of its structure with the value from which it was derived; the original
+// value is left unchanged.
+//
+// The package backs the immutable map and slice types generated by
+// myitcv.io/immutable/cmd/immutableGen for templates annotated with
+// //immutableGen:persistent or, in the case of Tree, //immutableGen:ordered.
+// It is not intended for direct use.
+//
+// A nil *Map, *Vector or *Tree is a valid, empty value.
+package persistent
+
+const (
+	levelBits = 5
+	width     = 1 << levelBits
+	mask      = width - 1
+)
@@ -0,0 +1,538 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package persistent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Entry is an entry of a Tree.
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// Tree is a persistent ordered map implemented as an AVL tree. The entries of
+// a Tree are ordered by the less function with which it was created or, if
+// that function is nil, by the order in which their keys were first set. Get,
+// Set and Del are O(log n); Set and Del return a new Tree that shares
+// structure with the receiver.
+//
+// A nil *Tree is ordered by insertion.
+type Tree[K comparable, V any] struct {
+	root *tnode[K, V]
+	len  int
+	less func(a, b K) bool
+
+	// in case less is nil, the tree is ordered by sequence number. seqs maps
+	// each key to its sequence number, and next is the sequence number of
+	// the next key to be added.
+	seqs *Map[K, uint64]
+	next uint64
+}
+
+type tnode[K comparable, V any] struct {
+	key    K
+	val    V
+	seq    uint64
+	height int
+
+	left  *tnode[K, V]
+	right *tnode[K, V]
+}
+
+// NewTree returns an empty Tree ordered by less or, if less is nil, by
+// insertion.
+func NewTree[K comparable, V any](less func(a, b K) bool) *Tree[K, V] {
+	return &Tree[K, V]{less: less}
+}
+
+// Len returns the number of entries in t.
+func (t *Tree[K, V]) Len() int {
+	if t == nil {
+		return 0
+	}
+	return t.len
+}
+
+// Get returns the value with key k and true if t contains k, else the zero
+// value and false.
+func (t *Tree[K, V]) Get(k K) (V, bool) {
+	if n := t.find(k); n != nil {
+		return n.val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Set returns a Tree that contains the entries of t with the value for key k
+// set to v. In case t is ordered by insertion, and does not contain k, k is
+// ordered after all the keys of t.
+func (t *Tree[K, V]) Set(k K, v V) *Tree[K, V] {
+	res := t.clone()
+
+	var seq uint64
+	if res.less == nil {
+		s, ok := res.seqs.Get(k)
+		if !ok {
+			s = res.next
+			res.next++
+			res.seqs = res.seqs.Set(k, s)
+		}
+		seq = s
+	}
+
+	root, added := res.insert(res.root, k, seq, v)
+	res.root = root
+	if added {
+		res.len++
+	}
+	return res
+}
+
+// Del returns a Tree that contains the entries of t without key k. If t does
+// not contain k, t is returned.
+func (t *Tree[K, V]) Del(k K) *Tree[K, V] {
+	n := t.find(k)
+	if n == nil {
+		return t
+	}
+
+	res := t.clone()
+	res.root = res.remove(res.root, k, n.seq)
+	res.len--
+	if res.less == nil {
+		res.seqs = res.seqs.Del(k)
+	}
+	return res
+}
+
+// Each calls f for each entry in t, in order, until f returns false.
+func (t *Tree[K, V]) Each(f func(k K, v V) bool) {
+	if t == nil {
+		return
+	}
+	t.root.each(f)
+}
+
+// Entries returns a newly allocated slice of the entries of t, in order.
+func (t *Tree[K, V]) Entries() []Entry[K, V] {
+	res := make([]Entry[K, V], 0, t.Len())
+	t.Each(func(k K, v V) bool {
+		res = append(res, Entry[K, V]{Key: k, Value: v})
+		return true
+	})
+	return res
+}
+
+// ToMap returns a newly allocated Go map containing the entries of t.
+func (t *Tree[K, V]) ToMap() map[K]V {
+	res := make(map[K]V, t.Len())
+	t.Each(func(k K, v V) bool {
+		res[k] = v
+		return true
+	})
+	return res
+}
+
+// Min returns the first entry of t and true, or zero values and false if t is
+// empty.
+func (t *Tree[K, V]) Min() (K, V, bool) {
+	var n *tnode[K, V]
+	if t != nil {
+		for n = t.root; n != nil && n.left != nil; n = n.left {
+		}
+	}
+	return n.entry()
+}
+
+// Max returns the last entry of t and true, or zero values and false if t is
+// empty.
+func (t *Tree[K, V]) Max() (K, V, bool) {
+	var n *tnode[K, V]
+	if t != nil {
+		for n = t.root; n != nil && n.right != nil; n = n.right {
+		}
+	}
+	return n.entry()
+}
+
+// Floor returns the entry of t with the greatest key less than or equal to k
+// and true, or zero values and false if there is no such entry. Floor panics
+// if t is ordered by insertion.
+func (t *Tree[K, V]) Floor(k K) (K, V, bool) {
+	t.mustBeSorted("Floor")
+
+	var res *tnode[K, V]
+	for n := t.root; n != nil; {
+		switch c := t.cmp(k, 0, n); {
+		case c == 0:
+			return n.entry()
+		case c < 0:
+			n = n.left
+		default:
+			res, n = n, n.right
+		}
+	}
+	return res.entry()
+}
+
+// Ceil returns the entry of t with the least key greater than or equal to k
+// and true, or zero values and false if there is no such entry. Ceil panics
+// if t is ordered by insertion.
+func (t *Tree[K, V]) Ceil(k K) (K, V, bool) {
+	t.mustBeSorted("Ceil")
+
+	var res *tnode[K, V]
+	for n := t.root; n != nil; {
+		switch c := t.cmp(k, 0, n); {
+		case c == 0:
+			return n.entry()
+		case c > 0:
+			n = n.right
+		default:
+			res, n = n, n.left
+		}
+	}
+	return res.entry()
+}
+
+// EachBetween calls f for each entry in t with a key greater than or equal to
+// lo and less than hi, in order, until f returns false. EachBetween panics if
+// t is ordered by insertion.
+func (t *Tree[K, V]) EachBetween(lo, hi K, f func(k K, v V) bool) {
+	t.mustBeSorted("EachBetween")
+	t.between(t.root, lo, hi, f)
+}
+
+// Equal returns whether t and o contain the same keys, with values that are
+// equal according to eq. The order of the entries of t and o is not
+// considered.
+func (t *Tree[K, V]) Equal(o *Tree[K, V], eq func(a, b V) bool) bool {
+	if t.Len() != o.Len() {
+		return false
+	}
+	res := true
+	t.Each(func(k K, a V) bool {
+		b, ok := o.Get(k)
+		res = ok && eq(a, b)
+		return res
+	})
+	return res
+}
+
+// Diff calls f for each key that is in only one of t and o, or that is in
+// both but with values that are not equal according to eq, until f returns
+// false. aok and bok indicate whether t and o, respectively, contain k; a and
+// b are the corresponding values, or zero values in case the key is absent.
+func (t *Tree[K, V]) Diff(o *Tree[K, V], eq func(a, b V) bool, f func(k K, a V, aok bool, b V, bok bool) bool) {
+	cont := true
+	t.Each(func(k K, a V) bool {
+		b, bok := o.Get(k)
+		if !bok || !eq(a, b) {
+			cont = f(k, a, true, b, bok)
+		}
+		return cont
+	})
+	if !cont {
+		return
+	}
+	o.Each(func(k K, b V) bool {
+		if _, ok := t.Get(k); !ok {
+			var a V
+			return f(k, a, false, b, true)
+		}
+		return true
+	})
+}
+
+// MarshalJSON returns the JSON encoding of t as a JSON object, the members of
+// which are in the order of the entries of t. Keys are encoded as they are for
+// a Go map by encoding/json.
+func (t *Tree[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+
+	buf.WriteByte('{')
+	t.Each(func(k K, v V) bool {
+		var b []byte
+		b, err = json.Marshal(map[K]V{k: v})
+		if err != nil {
+			return false
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.Write(b[1 : len(b)-1])
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// SetJSON returns a Tree that contains the entries of t, with the members of
+// the JSON object b set in the order in which they appear. Keys are decoded as
+// they are for a Go map by encoding/json. If b is the JSON null value, t is
+// returned.
+func (t *Tree[K, V]) SetJSON(b []byte) (*Tree[K, V], error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok == nil {
+		return t, nil
+	}
+	if tok != json.Delim('{') {
+		return nil, fmt.Errorf("expected JSON object; got %v", tok)
+	}
+
+	res := t
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var val json.RawMessage
+		if err := dec.Decode(&val); err != nil {
+			return nil, err
+		}
+
+		// decode the member as a single entry Go map, in order that the key
+		// is decoded exactly as encoding/json would
+		key, err := json.Marshal(tok)
+		if err != nil {
+			return nil, err
+		}
+		member := append(append(append(append([]byte{'{'}, key...), ':'), val...), '}')
+
+		var e map[K]V
+		if err := json.Unmarshal(member, &e); err != nil {
+			return nil, err
+		}
+		for k, v := range e {
+			res = res.Set(k, v)
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (t *Tree[K, V]) clone() *Tree[K, V] {
+	if t == nil {
+		return &Tree[K, V]{}
+	}
+	res := *t
+	return &res
+}
+
+func (t *Tree[K, V]) mustBeSorted(m string) {
+	if t == nil || t.less == nil {
+		panic(fmt.Errorf("%v called on a Tree ordered by insertion", m))
+	}
+}
+
+// find returns the node with key k, or nil if t does not contain k
+func (t *Tree[K, V]) find(k K) *tnode[K, V] {
+	if t == nil {
+		return nil
+	}
+
+	var seq uint64
+	if t.less == nil {
+		s, ok := t.seqs.Get(k)
+		if !ok {
+			return nil
+		}
+		seq = s
+	}
+
+	for n := t.root; n != nil; {
+		switch c := t.cmp(k, seq, n); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// cmp compares the key k, with sequence number seq, to the key of n
+func (t *Tree[K, V]) cmp(k K, seq uint64, n *tnode[K, V]) int {
+	switch {
+	case t.less == nil:
+		switch {
+		case seq < n.seq:
+			return -1
+		case seq > n.seq:
+			return 1
+		}
+	case t.less(k, n.key):
+		return -1
+	case t.less(n.key, k):
+		return 1
+	}
+	return 0
+}
+
+// insert returns a copy of the subtree n with the value for key k set to v,
+// and whether k was added
+func (t *Tree[K, V]) insert(n *tnode[K, V], k K, seq uint64, v V) (*tnode[K, V], bool) {
+	if n == nil {
+		return &tnode[K, V]{key: k, val: v, seq: seq, height: 1}, true
+	}
+
+	res := *n
+	added := false
+
+	switch c := t.cmp(k, seq, n); {
+	case c < 0:
+		res.left, added = t.insert(n.left, k, seq, v)
+	case c > 0:
+		res.right, added = t.insert(n.right, k, seq, v)
+	default:
+		res.val = v
+		return &res, false
+	}
+
+	return res.rebalance(), added
+}
+
+// remove returns a copy of the subtree n without key k, which n must contain
+func (t *Tree[K, V]) remove(n *tnode[K, V], k K, seq uint64) *tnode[K, V] {
+	switch c := t.cmp(k, seq, n); {
+	case c < 0:
+		res := *n
+		res.left = t.remove(n.left, k, seq)
+		return res.rebalance()
+	case c > 0:
+		res := *n
+		res.right = t.remove(n.right, k, seq)
+		return res.rebalance()
+	}
+
+	switch {
+	case n.left == nil:
+		return n.right
+	case n.right == nil:
+		return n.left
+	}
+
+	// replace n with the minimum of its right subtree
+	m := n.right
+	for m.left != nil {
+		m = m.left
+	}
+	res := *m
+	res.left = n.left
+	res.right = n.right.removeMin()
+	return res.rebalance()
+}
+
+func (t *Tree[K, V]) between(n *tnode[K, V], lo, hi K, f func(k K, v V) bool) bool {
+	if n == nil {
+		return true
+	}
+	if t.less(n.key, lo) {
+		return t.between(n.right, lo, hi, f)
+	}
+	if !t.less(n.key, hi) {
+		return t.between(n.left, lo, hi, f)
+	}
+	return t.between(n.left, lo, hi, f) && f(n.key, n.val) && t.between(n.right, lo, hi, f)
+}
+
+func (n *tnode[K, V]) entry() (K, V, bool) {
+	if n == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return n.key, n.val, true
+}
+
+func (n *tnode[K, V]) each(f func(k K, v V) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.left.each(f) && f(n.key, n.val) && n.right.each(f)
+}
+
+// removeMin returns a copy of the subtree n without its minimum
+func (n *tnode[K, V]) removeMin() *tnode[K, V] {
+	if n.left == nil {
+		return n.right
+	}
+	res := *n
+	res.left = n.left.removeMin()
+	return res.rebalance()
+}
+
+func (n *tnode[K, V]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *tnode[K, V]) fix() {
+	n.height = 1 + max(n.left.getHeight(), n.right.getHeight())
+}
+
+// rebalance restores the AVL invariant at n, a node that is not shared, and
+// returns the root of the resulting subtree
+func (n *tnode[K, V]) rebalance() *tnode[K, V] {
+	n.fix()
+
+	switch bf := n.left.getHeight() - n.right.getHeight(); {
+	case bf > 1:
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			l := *n.left
+			n.left = l.rotateLeft()
+		}
+		return n.rotateRight()
+	case bf < -1:
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			r := *n.right
+			n.right = r.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+
+	return n
+}
+
+// rotateRight rotates n, a node that is not shared, to the right
+func (n *tnode[K, V]) rotateRight() *tnode[K, V] {
+	l := *n.left
+	n.left = l.right
+	n.fix()
+	l.right = n
+	l.fix()
+	return &l
+}
+
+// rotateLeft rotates n, a node that is not shared, to the left
+func (n *tnode[K, V]) rotateLeft() *tnode[K, V] {
+	r := *n.right
+	n.right = r.left
+	n.fix()
+	r.left = n
+	r.fix()
+	return &r
+}
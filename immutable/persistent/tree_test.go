@@ -0,0 +1,205 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package persistent
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func intLess(a, b int) bool {
+	return a < b
+}
+
+func TestTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	m := NewTree[int, int](intLess)
+	exp := make(map[int]int)
+
+	var versions []*Tree[int, int]
+	var expVersions []map[int]int
+
+	for i := 0; i < 20000; i++ {
+		k := r.Intn(5000)
+		if r.Intn(3) == 0 {
+			m = m.Del(k)
+			delete(exp, k)
+		} else {
+			m = m.Set(k, i)
+			exp[k] = i
+		}
+		if i%1000 == 0 {
+			versions = append(versions, m)
+			expVersions = append(expVersions, copyMap(exp))
+		}
+	}
+
+	checkTree(t, m, exp)
+
+	// earlier versions must be unaffected by later updates
+	for i, v := range versions {
+		checkTree(t, v, expVersions[i])
+	}
+
+	for k := range exp {
+		m = m.Del(k)
+	}
+	if m.Len() != 0 {
+		t.Fatalf("expected empty tree; got length %v", m.Len())
+	}
+}
+
+func TestTreeInsertionOrder(t *testing.T) {
+	var m *Tree[string, int]
+
+	m = m.Set("c", 1).Set("a", 2).Set("b", 3)
+	m2 := m.Set("c", 4).Del("a").Set("a", 5)
+
+	check := func(m *Tree[string, int], exp ...Entry[string, int]) {
+		t.Helper()
+		if got := m.Entries(); !reflect.DeepEqual(got, exp) {
+			t.Fatalf("expected %v; got %v", exp, got)
+		}
+	}
+
+	check(m, Entry[string, int]{"c", 1}, Entry[string, int]{"a", 2}, Entry[string, int]{"b", 3})
+	check(m2, Entry[string, int]{"c", 4}, Entry[string, int]{"b", 3}, Entry[string, int]{"a", 5})
+
+	if k, v, ok := m2.Min(); !ok || k != "c" || v != 4 {
+		t.Fatalf("expected Min() to be (c, 4, true); got (%v, %v, %v)", k, v, ok)
+	}
+	if k, v, ok := m2.Max(); !ok || k != "a" || v != 5 {
+		t.Fatalf("expected Max() to be (a, 5, true); got (%v, %v, %v)", k, v, ok)
+	}
+
+	if !m.Equal(m.Del("a").Set("a", 2), func(a, b int) bool { return a == b }) {
+		t.Fatalf("expected trees with the same entries in a different order to be equal")
+	}
+}
+
+func TestTreeQueries(t *testing.T) {
+	m := NewTree[int, string](intLess)
+
+	if _, _, ok := m.Min(); ok {
+		t.Fatalf("expected Min() of empty tree to fail")
+	}
+
+	for _, k := range []int{50, 10, 40, 20, 30} {
+		m = m.Set(k, "")
+	}
+
+	floor := map[int]int{5: -1, 10: 10, 15: 10, 50: 50, 55: 50}
+	for k, e := range floor {
+		got, _, ok := m.Floor(k)
+		if !ok {
+			got = -1
+		}
+		if got != e {
+			t.Errorf("expected Floor(%v) to be %v; got %v", k, e, got)
+		}
+	}
+
+	ceil := map[int]int{5: 10, 10: 10, 15: 20, 50: 50, 55: -1}
+	for k, e := range ceil {
+		got, _, ok := m.Ceil(k)
+		if !ok {
+			got = -1
+		}
+		if got != e {
+			t.Errorf("expected Ceil(%v) to be %v; got %v", k, e, got)
+		}
+	}
+
+	var keys []int
+	m.EachBetween(15, 50, func(k int, _ string) bool {
+		keys = append(keys, k)
+		return true
+	})
+	if exp := []int{20, 30, 40}; !reflect.DeepEqual(keys, exp) {
+		t.Fatalf("expected EachBetween(15, 50) to visit %v; got %v", exp, keys)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected Floor on a tree ordered by insertion to panic")
+		}
+	}()
+	NewTree[int, string](nil).Floor(1)
+}
+
+func TestTreeJSON(t *testing.T) {
+	m := NewTree[int, string](nil).Set(3, "c").Set(1, "a").Set(2, "b")
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	if exp := `{"3":"c","1":"a","2":"b"}`; string(b) != exp {
+		t.Fatalf("expected %v; got %s", exp, b)
+	}
+
+	m2, err := NewTree[int, string](nil).SetJSON(b)
+	if err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(m2.Entries(), m.Entries()) {
+		t.Fatalf("expected %v; got %v", m.Entries(), m2.Entries())
+	}
+
+	if m3, err := m.SetJSON([]byte("null")); err != nil || m3 != m {
+		t.Fatalf("expected null to leave the tree unchanged; got %v, %v", m3, err)
+	}
+}
+
+func checkTree(t *testing.T, m *Tree[int, int], exp map[int]int) {
+	t.Helper()
+
+	if m.Len() != len(exp) {
+		t.Fatalf("expected length %v; got %v", len(exp), m.Len())
+	}
+	for k, ev := range exp {
+		if v, ok := m.Get(k); !ok || v != ev {
+			t.Fatalf("expected Get(%v) to be (%v, true); got (%v, %v)", k, ev, v, ok)
+		}
+	}
+
+	var keys []int
+	for k := range exp {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	var got []int
+	m.Each(func(k, _ int) bool {
+		got = append(got, k)
+		return true
+	})
+	if len(keys) > 0 && !reflect.DeepEqual(got, keys) {
+		t.Fatalf("expected keys %v; got %v", keys, got)
+	}
+
+	checkBalanced(t, m.root)
+}
+
+// checkBalanced checks the AVL invariant of the subtree n, returning its
+// height
+func checkBalanced(t *testing.T, n *tnode[int, int]) int {
+	t.Helper()
+
+	if n == nil {
+		return 0
+	}
+	l, r := checkBalanced(t, n.left), checkBalanced(t, n.right)
+	if l-r > 1 || r-l > 1 {
+		t.Fatalf("unbalanced node %v: heights %v and %v", n.key, l, r)
+	}
+	if h := 1 + max(l, r); n.height != h {
+		t.Fatalf("node %v has height %v; expected %v", n.key, n.height, h)
+	}
+	return n.height
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package persistent
+
+import "fmt"
+
+// Vector is a persistent vector implemented as a radix balanced tree of
+// 32-way nodes with a tail buffer. Get and Set are O(log n), Append is
+// amortised O(1); Set and Append return a new Vector that shares structure
+// with the receiver.
+type Vector[T any] struct {
+	len   int
+	shift uint
+	root  *vnode[T]
+
+	// tail holds the last 1-32 values of a non-empty vector. A tail is never
+	// appended to in place, because it may be shared with other Vectors
+	tail []T
+}
+
+// vnode is a node of a Vector. Leaf nodes hold values, all other nodes hold
+// children.
+type vnode[T any] struct {
+	children []*vnode[T]
+	values   []T
+}
+
+// NewVector returns a Vector containing vs.
+func NewVector[T any](vs ...T) *Vector[T] {
+	return (*Vector[T])(nil).Append(vs...)
+}
+
+// Len returns the number of values in v.
+func (v *Vector[T]) Len() int {
+	if v == nil {
+		return 0
+	}
+	return v.len
+}
+
+// Get returns the value at index i. Get panics if i is out of range.
+func (v *Vector[T]) Get(i int) T {
+	v.checkIndex(i)
+	if off := v.tailOffset(); i >= off {
+		return v.tail[i-off]
+	}
+	n := v.root
+	for level := v.shift; level > 0; level -= levelBits {
+		n = n.children[(i>>level)&mask]
+	}
+	return n.values[i&mask]
+}
+
+// Set returns a Vector that contains the values of v with the value at index
+// i set to val. Set panics if i is out of range.
+func (v *Vector[T]) Set(i int, val T) *Vector[T] {
+	v.checkIndex(i)
+	res := *v
+	if off := v.tailOffset(); i >= off {
+		res.tail = make([]T, len(v.tail), width)
+		copy(res.tail, v.tail)
+		res.tail[i-off] = val
+		return &res
+	}
+	res.root = v.root.set(v.shift, i, val)
+	return &res
+}
+
+// Append returns a Vector that contains the values of v followed by vs.
+func (v *Vector[T]) Append(vs ...T) *Vector[T] {
+	var res Vector[T]
+	if v != nil {
+		res = *v
+	}
+	if len(vs) == 0 && v != nil {
+		return v
+	}
+	if res.root == nil {
+		res.root = &vnode[T]{}
+		res.shift = levelBits
+	}
+	tail := make([]T, len(res.tail), width)
+	copy(tail, res.tail)
+	for _, val := range vs {
+		if len(tail) == width {
+			res.pushTail(tail)
+			tail = make([]T, 0, width)
+		}
+		tail = append(tail, val)
+		res.len++
+	}
+	res.tail = tail
+	return &res
+}
+
+// Each calls f for each value in v, in index order, until f returns false.
+func (v *Vector[T]) Each(f func(i int, val T) bool) {
+	if v == nil {
+		return
+	}
+	i := 0
+	if !v.root.each(v.shift, &i, f) {
+		return
+	}
+	for _, val := range v.tail {
+		if !f(i, val) {
+			return
+		}
+		i++
+	}
+}
+
+// ToSlice returns a newly allocated slice containing the values of v.
+func (v *Vector[T]) ToSlice() []T {
+	res := make([]T, 0, v.Len())
+	v.Each(func(_ int, val T) bool {
+		res = append(res, val)
+		return true
+	})
+	return res
+}
+
+func (v *Vector[T]) checkIndex(i int) {
+	if i < 0 || i >= v.Len() {
+		panic(fmt.Errorf("index out of range [%v] with length %v", i, v.Len()))
+	}
+}
+
+// tailOffset returns the index of the first value in the tail
+func (v *Vector[T]) tailOffset() int {
+	return v.len - len(v.tail)
+}
+
+// pushTail moves the full tail into the tree of v, which must not be shared
+// with any other Vector.
+func (v *Vector[T]) pushTail(tail []T) {
+	leaf := &vnode[T]{values: tail}
+	// the index of the first value of the tail
+	i := v.len - width
+	if i>>levelBits >= 1<<v.shift {
+		// the tree is full; grow a new root
+		v.root = &vnode[T]{children: []*vnode[T]{v.root, newPath(v.shift, leaf)}}
+		v.shift += levelBits
+		return
+	}
+	v.root = v.root.pushTail(v.shift, i, leaf)
+}
+
+func (n *vnode[T]) pushTail(level uint, i int, leaf *vnode[T]) *vnode[T] {
+	res := &vnode[T]{children: make([]*vnode[T], len(n.children), width)}
+	copy(res.children, n.children)
+	sub := (i >> level) & mask
+	var child *vnode[T]
+	switch {
+	case level == levelBits:
+		child = leaf
+	case sub < len(n.children):
+		child = n.children[sub].pushTail(level-levelBits, i, leaf)
+	default:
+		child = newPath(level-levelBits, leaf)
+	}
+	if sub < len(res.children) {
+		res.children[sub] = child
+	} else {
+		res.children = append(res.children, child)
+	}
+	return res
+}
+
+// newPath returns a path of nodes down to leaf, such that the returned node
+// is at level
+func newPath[T any](level uint, leaf *vnode[T]) *vnode[T] {
+	if level == 0 {
+		return leaf
+	}
+	return &vnode[T]{children: []*vnode[T]{newPath(level-levelBits, leaf)}}
+}
+
+func (n *vnode[T]) set(level uint, i int, val T) *vnode[T] {
+	if level == 0 {
+		res := &vnode[T]{values: make([]T, len(n.values))}
+		copy(res.values, n.values)
+		res.values[i&mask] = val
+		return res
+	}
+	res := &vnode[T]{children: make([]*vnode[T], len(n.children), cap(n.children))}
+	copy(res.children, n.children)
+	sub := (i >> level) & mask
+	res.children[sub] = n.children[sub].set(level-levelBits, i, val)
+	return res
+}
+
+func (n *vnode[T]) each(level uint, i *int, f func(i int, val T) bool) bool {
+	if level == 0 {
+		for _, val := range n.values {
+			if !f(*i, val) {
+				return false
+			}
+			*i++
+		}
+		return true
+	}
+	for _, c := range n.children {
+		if !c.each(level-levelBits, i, f) {
+			return false
+		}
+	}
+	return true
+}
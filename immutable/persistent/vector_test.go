@@ -0,0 +1,98 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package persistent
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestVectorAppend(t *testing.T) {
+	var v *Vector[int]
+	var exp []int
+
+	var versions []*Vector[int]
+	var expVersions [][]int
+
+	// enough values for a tree of depth three
+	for i := 0; i < 40000; i++ {
+		v = v.Append(i)
+		exp = append(exp, i)
+		if i%997 == 0 {
+			versions = append(versions, v)
+			expVersions = append(expVersions, append([]int(nil), exp...))
+		}
+	}
+
+	checkVector(t, v, exp)
+
+	for i, vv := range versions {
+		checkVector(t, vv, expVersions[i])
+	}
+}
+
+func TestVectorAppendMany(t *testing.T) {
+	for _, n := range []int{0, 1, 31, 32, 33, 1024, 1056, 1057, 5000} {
+		exp := make([]int, n)
+		for i := range exp {
+			exp[i] = i
+		}
+		checkVector(t, NewVector(exp...), exp)
+
+		// appending to a shared prefix must not affect the prefix
+		pre := NewVector(exp...)
+		a := pre.Append(1, 2, 3)
+		b := pre.Append(4)
+		checkVector(t, pre, exp)
+		checkVector(t, a, append(append([]int(nil), exp...), 1, 2, 3))
+		checkVector(t, b, append(append([]int(nil), exp...), 4))
+	}
+}
+
+func TestVectorSet(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	exp := make([]int, 3000)
+	v := NewVector(exp...)
+	orig := v
+
+	for i := 0; i < 10000; i++ {
+		j := r.Intn(len(exp))
+		v = v.Set(j, i)
+		exp[j] = i
+	}
+
+	checkVector(t, v, exp)
+	checkVector(t, orig, make([]int, 3000))
+}
+
+func TestVectorGetOutOfRange(t *testing.T) {
+	for _, i := range []int{-1, 3} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected Get(%v) to panic", i)
+				}
+			}()
+			NewVector(1, 2, 3).Get(i)
+		}()
+	}
+}
+
+func checkVector(t *testing.T, v *Vector[int], exp []int) {
+	t.Helper()
+
+	if v.Len() != len(exp) {
+		t.Fatalf("expected length %v; got %v", len(exp), v.Len())
+	}
+	for i, ev := range exp {
+		if got := v.Get(i); got != ev {
+			t.Fatalf("expected Get(%v) to be %v; got %v", i, ev, got)
+		}
+	}
+	if got := v.ToSlice(); !reflect.DeepEqual(got, exp) && len(exp) > 0 {
+		t.Fatalf("expected ToSlice() to be %v; got %v", exp, got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"golang.org/x/tools/go/types/typeutil"
+	"myitcv.io/immutable"
 )
 
 type ImmType interface {
@@ -38,6 +39,13 @@ type (
 		Elem types.Type
 	}
 
+	// ImmTypeSet is used to indicate a type that is immutable by virtue of
+	// being a pointer to a struct type that was itself generated from an _Imm_
+	// set template.
+	ImmTypeSet struct {
+		Elem types.Type
+	}
+
 	// ImmTypeImplsIntf is used to indicate a type that is not an ImmTypeStruct,
 	// ImmTypeMap or ImmTypeSlice, but still satisfies the immutable "interface".
 	// See the docs for myitcv.io/immutable.Immutable.
@@ -52,6 +60,7 @@ func (i ImmTypeBasic) isImmType()     {}
 func (i ImmTypeStruct) isImmType()    {}
 func (i ImmTypeMap) isImmType()       {}
 func (i ImmTypeSlice) isImmType()     {}
+func (i ImmTypeSet) isImmType()       {}
 func (i ImmTypeImplsIntf) isImmType() {}
 func (i ImmTypeSimple) isImmType()    {}
 
@@ -272,15 +281,32 @@ func (i *immCache) lookup(tt types.Type) (v ImmType) {
 		case "__tmpl":
 			hasTmpl = true
 		case "theMap":
-			m := f.Type().(*types.Map)
-			v = ImmTypeMap{
-				Key:  m.Key(),
-				Elem: m.Elem(),
+			if m, ok := f.Type().(*types.Map); ok {
+				v = ImmTypeMap{
+					Key:  m.Key(),
+					Elem: m.Elem(),
+				}
+			} else if args := runtimeTypeArgs(f.Type(), "Map", "Tree"); len(args) == 2 {
+				v = ImmTypeMap{
+					Key:  args[0],
+					Elem: args[1],
+				}
+			}
+		case "theSet":
+			if m, ok := f.Type().(*types.Map); ok {
+				v = ImmTypeSet{
+					Elem: m.Key(),
+				}
 			}
 		case "theSlice":
-			s := f.Type().(*types.Slice)
-			v = ImmTypeSlice{
-				Elem: s.Elem(),
+			if s, ok := f.Type().(*types.Slice); ok {
+				v = ImmTypeSlice{
+					Elem: s.Elem(),
+				}
+			} else if args := runtimeTypeArgs(f.Type(), "Vector", "Slice"); len(args) == 1 {
+				v = ImmTypeSlice{
+					Elem: args[0],
+				}
 			}
 		}
 	}
@@ -294,6 +320,43 @@ func (i *immCache) lookup(tt types.Type) (v ImmType) {
 	return
 }
 
+// runtimeTypeArgs returns the type arguments of t in case t is a pointer to
+// one of the named generic types in myitcv.io/immutable/persistent or
+// myitcv.io/immutable/container, the backing types of immutable maps and
+// slices generated from persistent or ordered templates, or in container mode.
+func runtimeTypeArgs(t types.Type, names ...string) []types.Type {
+	pt, ok := t.(*types.Pointer)
+	if !ok {
+		return nil
+	}
+	nt, ok := pt.Elem().(*types.Named)
+	if !ok {
+		return nil
+	}
+	obj := nt.Obj()
+	if obj.Pkg() == nil {
+		return nil
+	}
+	switch obj.Pkg().Path() {
+	case immutable.PersistentPkgImportPath, immutable.ContainerPkgImportPath:
+	default:
+		return nil
+	}
+	found := false
+	for _, n := range names {
+		found = found || obj.Name() == n
+	}
+	if !found {
+		return nil
+	}
+	targs := nt.TypeArgs()
+	res := make([]types.Type, targs.Len())
+	for i := range res {
+		res[i] = targs.At(i)
+	}
+	return res
+}
+
 // IsImmType determines whether the supplied type is an immutable type. In case
 // a type is immutable, a value of type ImmTypeStruct, ImmTypeSlice or
 // ImmTypeMap is returned. In case the type is immutable but neither of the
@@ -44,7 +44,7 @@ func (f *Formatter) Fmt(files []string, importPaths []string) {
 }
 
 func (f *Formatter) println(a ...interface{}) {
-	fmt.Fprintf(f.Output, strings.Repeat("\t", f.indent))
+	fmt.Fprint(f.Output, strings.Repeat("\t", f.indent))
 	fmt.Fprintln(f.Output, a...)
 }
 
@@ -12,6 +12,11 @@ type coreGen struct {
 	buf  *bytes.Buffer
 	tbuf *bytes.Buffer
 	jbuf *bytes.Buffer
+
+	// dbuf and nbuf hold the element constructors when building with (js) and
+	// without (!js) a DOM respectively
+	dbuf *bytes.Buffer
+	nbuf *bytes.Buffer
 }
 
 func newCoreGen() *coreGen {
@@ -19,6 +24,8 @@ func newCoreGen() *coreGen {
 		buf:  bytes.NewBuffer(nil),
 		tbuf: bytes.NewBuffer(nil),
 		jbuf: bytes.NewBuffer(nil),
+		dbuf: bytes.NewBuffer(nil),
+		nbuf: bytes.NewBuffer(nil),
 	}
 }
 
@@ -34,6 +41,14 @@ func (c *coreGen) jpf(format string, vals ...interface{}) {
 	fmt.Fprintf(c.jbuf, format, vals...)
 }
 
+func (c *coreGen) dpf(format string, vals ...interface{}) {
+	fmt.Fprintf(c.dbuf, format, vals...)
+}
+
+func (c *coreGen) npf(format string, vals ...interface{}) {
+	fmt.Fprintf(c.nbuf, format, vals...)
+}
+
 func (c *coreGen) pln(vals ...interface{}) {
 	fmt.Fprintln(c.buf, vals...)
 }
@@ -46,6 +61,14 @@ func (c *coreGen) jpln(vals ...interface{}) {
 	fmt.Fprintln(c.jbuf, vals...)
 }
 
+func (c *coreGen) dpln(vals ...interface{}) {
+	fmt.Fprintln(c.dbuf, vals...)
+}
+
+func (c *coreGen) npln(vals ...interface{}) {
+	fmt.Fprintln(c.nbuf, vals...)
+}
+
 func (c *coreGen) pt(tmpl string, val interface{}) {
 	tmplExec(c.buf, tmpl, val)
 }
@@ -58,6 +81,14 @@ func (c *coreGen) jpt(tmpl string, val interface{}) {
 	tmplExec(c.jbuf, tmpl, val)
 }
 
+func (c *coreGen) dpt(tmpl string, val interface{}) {
+	tmplExec(c.dbuf, tmpl, val)
+}
+
+func (c *coreGen) npt(tmpl string, val interface{}) {
+	tmplExec(c.nbuf, tmpl, val)
+}
+
 func tmplExec(w io.Writer, tmpl string, val interface{}) {
 	tmpl = strings.TrimPrefix(tmpl, "\n")
 
@@ -1,6 +1,9 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type Elem struct {
 	// The myitcv.io/react Name of the element - not set directly, taken from
@@ -90,6 +93,18 @@ func (e *Elem) ChildArg() string {
 	return ""
 }
 
+// ChildSlice is the []Element of the children of the element, for use after
+// ChildConvert
+func (e *Elem) ChildSlice() string {
+	if e.Child != "" {
+		return "[]Element{child}"
+	} else if e.Children != "" {
+		return strings.TrimSuffix(e.ChildArg(), "...")
+	}
+
+	return "nil"
+}
+
 func (e *Elem) ChildrenReactType() string {
 	if e.Children[0] == '*' {
 		return "*react." + e.Children[1:]
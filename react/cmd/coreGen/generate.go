@@ -168,8 +168,24 @@ func dogen(dir, pkgName, license string) {
 	cg.pf("// Code generated by %v. DO NOT EDIT.\n", coreGenCmd)
 	cg.pln()
 	cg.pf("package %v\n", pkgName)
-	cg.pln()
-	cg.pln(`import "github.com/gopherjs/gopherjs/js"`)
+
+	// DOM header
+	cg.dpln("// +build js")
+	cg.dpln()
+	cg.dpf("// Code generated by %v. DO NOT EDIT.\n", coreGenCmd)
+	cg.dpln()
+	cg.dpf("package %v\n", pkgName)
+	cg.dpln()
+	cg.dpln(`import "github.com/gopherjs/gopherjs/js"`)
+
+	// no DOM header
+	cg.npln("// +build !js")
+	cg.npln()
+	cg.npf("// Code generated by %v. DO NOT EDIT.\n", coreGenCmd)
+	cg.npln()
+	cg.npf("package %v\n", pkgName)
+	cg.npln()
+	cg.npln(`import "myitcv.io/react/internal/core"`)
 
 	// test header
 	cg.tpln("// +build js")
@@ -245,7 +261,9 @@ type {{.Name}}Props struct {
 	{{.Name}} {{.Type -}}
 	{{end}}
 }
+		`, e)
 
+		cg.dpt(`
 // {{.Name}} creates a new instance of a <{{.React}}> element with the provided props and
 // children
 func {{.Name}}(props *{{.Name}}Props, {{.ChildParam}}) *{{.Name}}Elem {
@@ -310,6 +328,50 @@ func {{.Name}}(props *{{.Name}}Props, {{.ChildParam}}) *{{.Name}}Elem {
 }
 		`, e)
 
+		cg.npt(`
+// {{.Name}} creates a new instance of a <{{.React}}> element with the provided props and
+// children
+func {{.Name}}(props *{{.Name}}Props, {{.ChildParam}}) *{{.Name}}Elem {
+	{{.ChildConvert}}
+
+	res := &core.HTMLElement{
+		Tag:      "{{.HTML}}",
+		Children: {{.ChildSlice}},
+	}
+
+	if props != nil {
+		{{- range .Attributes}}
+			{{- if or .IsEvent (eq .Name "Key") (eq .Name "Ref") }}
+			{{- else if eq .Name "DataSet" }}
+			res.Attrs = append(res.Attrs, mapAttrs("data-", props.DataSet)...)
+			{{- else if eq .Name "AriaSet" }}
+			res.Attrs = append(res.Attrs, mapAttrs("aria-", props.AriaSet)...)
+			{{- else if eq .Name "DangerouslySetInnerHTML" }}
+			if props.DangerouslySetInnerHTML != nil {
+				res.InnerHTML = &props.DangerouslySetInnerHTML.html
+			}
+			{{- else if eq .Name "Style" }}
+			if s := props.Style.css(); s != "" {
+				res.Attrs = append(res.Attrs, core.Attr{Name: "{{.HTML}}", Value: s})
+			}
+			{{- else if eq .Type "bool" }}
+			if props.{{.Name}} {
+				res.Attrs = append(res.Attrs, core.Attr{Name: "{{.HTML}}", Value: "true"})
+			}
+			{{- else}}
+			if props.{{.Name}} != "" {
+				res.Attrs = append(res.Attrs, core.Attr{Name: "{{.HTML}}", Value: props.{{.Name}}})
+			}
+			{{- end}}
+		{{- end}}
+	}
+
+	return &{{.Name}}Elem{
+		Element: res,
+	}
+}
+		`, e)
+
 		if !e.SkipTests {
 			cg.tpt(`
 func Test{{.Name}}Elem(t *testing.T) {
@@ -385,6 +447,8 @@ func parse{{.Name}}(n *html.Node) *react.{{.Name}}Elem {
 	}
 
 	write(cg.buf, gogenerate.NameFile(pkgName, coreGenCmd))
+	write(cg.dbuf, gogenerate.NameFile(pkgName+"_js", coreGenCmd))
+	write(cg.nbuf, gogenerate.NameFile(pkgName+"_nojs", coreGenCmd))
 	write(cg.tbuf, gogenerate.NameTestFile(pkgName, coreGenCmd))
 	write(cg.jbuf, filepath.Join("jsx", gogenerate.NameFile("jsx", coreGenCmd)))
 }
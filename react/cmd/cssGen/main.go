@@ -120,7 +120,11 @@ var tmpl = `
 
 package react
 
-import "github.com/gopherjs/gopherjs/js"
+import (
+	"strings"
+
+	"github.com/gopherjs/gopherjs/js"
+)
 
 // CSS defines CSS attributes for HTML components. Largely based on
 // https://developer.mozilla.org/en-US/docs/Web/CSS/Reference
@@ -149,6 +153,24 @@ func (c *CSS) hack() *CSS {
 
 	return &CSS{o: o}
 }
+
+// css returns the value of the HTML style attribute corresponding to c, used
+// when rendering without a DOM
+func (c *CSS) css() string {
+	if c == nil {
+		return ""
+	}
+
+	var decls []string
+
+	{{range . }}
+	if c.{{.Name}} != "" {
+		decls = append(decls, "{{.HTML}}:"+c.{{.Name}})
+	}
+	{{- end}}
+
+	return strings.Join(decls, ";")
+}
 `
 
 var jsxTmpl = `
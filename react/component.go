@@ -0,0 +1,111 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+// +build !js
+
+package react
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/gopherjs/gopherjs/js"
+	"honnef.co/go/js/dom"
+
+	"myitcv.io/react/internal/core"
+)
+
+// Without a DOM, elements are pure Go values that can be rendered to HTML by
+// myitcv.io/react/ssr. Components are not mounted, hence SetState and
+// ForceUpdate do not cause a component to render.
+
+// object is nil without a DOM; it is declared for the benefit of CSS.hack,
+// which is not called
+var object *js.Object
+
+// symbolFragment identifies a Fragment to createElement
+var symbolFragment = new(struct{})
+
+// ComponentDef is embedded in a type definition to indicate the type is a component
+type ComponentDef struct {
+	elem *core.ComponentElement
+}
+
+func (c ComponentDef) Props() Props {
+	p, _ := c.elem.Props.(Props)
+	return p
+}
+
+func (c ComponentDef) Children() []Element {
+	return c.elem.Children
+}
+
+func (c ComponentDef) SetState(i State) {
+	if cur := c.State(); cur != nil && i.EqualsIntf(cur) {
+		return
+	}
+
+	c.elem.State = i
+}
+
+func (c ComponentDef) State() State {
+	s, _ := c.elem.State.(State)
+	return s
+}
+
+func (c ComponentDef) ForceUpdate() {}
+
+func CreateElement(buildCmp ComponentBuilder, newprops Props, children ...Element) Element {
+	res := &core.ComponentElement{
+		Type:     reflect.TypeOf(buildCmp(ComponentDef{})),
+		Children: children,
+	}
+
+	if newprops != nil {
+		res.Props = newprops
+	}
+
+	res.Build = func() interface{} {
+		return buildCmp(ComponentDef{elem: res})
+	}
+
+	return res
+}
+
+func createElement(cmp interface{}, props interface{}, children ...Element) Element {
+	if cmp != symbolFragment {
+		panic("createElement without a DOM supports only Fragment")
+	}
+
+	return &core.FragmentElement{
+		Children: children,
+	}
+}
+
+// Render is not supported without a DOM; use myitcv.io/react/ssr to render an
+// Element as HTML instead
+func Render(el Element, container dom.Element) Element {
+	panic("react: Render requires a DOM; use myitcv.io/react/ssr instead")
+}
+
+// NewDangerousInnerHTML creates a new DangerousInnerHTML instance, using the
+// supplied string as the raw HTML
+func NewDangerousInnerHTML(s string) *DangerousInnerHTML {
+	return &DangerousInnerHTML{html: s}
+}
+
+// mapAttrs returns the HTML attributes corresponding to the entries of m, a
+// DataSet or AriaSet, in key order
+func mapAttrs(prefix string, m map[string]string) []core.Attr {
+	var res []core.Attr
+
+	for k, v := range m {
+		res = append(res, core.Attr{Name: prefix + k, Value: v})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res
+}
@@ -5,6 +5,9 @@ package imm
 //immutableVet:skipFile
 
 import (
+	"encoding/json"
+	"reflect"
+
 	"myitcv.io/immutable"
 )
 
@@ -163,6 +166,97 @@ func (s *strEntrySelect) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	return true
 }
 
+// Equal returns whether m and other contain the same keys with equal values
+func (m *strEntrySelect) Equal(other *strEntrySelect) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	if len(m.theMap) != len(other.theMap) {
+		return false
+	}
+
+	for k, a := range m.theMap {
+		b, ok := other.theMap[k]
+		if !ok || !(reflect.DeepEqual(a, b)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, ordered by path. The path
+// of a change is the key of the entry that was added, removed or
+// modified, or the key followed by the path of a change within a
+// modified value.
+func (m *strEntrySelect) Diff(other *strEntrySelect) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a Label, aok bool, b Label, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	for k, a := range m.theMap {
+		b, bok := other.theMap[k]
+		if bok && (reflect.DeepEqual(a, b)) {
+			continue
+		}
+		change(immutable.KeyPath(k), a, true, b, bok)
+	}
+
+	for k, b := range other.theMap {
+		if _, ok := m.theMap[k]; !ok {
+			var a Label
+			change(immutable.KeyPath(k), a, false, b, true)
+		}
+	}
+
+	return immutable.SortChanges(res)
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a map[string]Label
+func (m *strEntrySelect) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a
+// map[string]Label into m. m is left immutable.
+func (m *strEntrySelect) UnmarshalJSON(b []byte) error {
+	var v map[string]Label
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *newStrEntrySelectCap(len(v)).WithMutable(func(mi *strEntrySelect) {
+		for k, e := range v {
+			mi.Set(k, e)
+		}
+	})
+
+	return nil
+}
+
 //
 // LabelEntries is an immutable type and has the following template:
 //
@@ -311,6 +405,93 @@ func (s *LabelEntries) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	return true
 }
 
+// Equal returns whether m and other have the same length and equal values
+// at each index
+func (m *LabelEntries) Equal(other *LabelEntries) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	if len(m.theSlice) != len(other.theSlice) {
+		return false
+	}
+
+	for i, a := range m.theSlice {
+		b := other.theSlice[i]
+		if !(reflect.DeepEqual(a, b)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, in index order. The path
+// of a change is the index that was added, removed or modified, or the
+// index followed by the path of a change within a modified value.
+func (m *LabelEntries) Diff(other *LabelEntries) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a Label, aok bool, b Label, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	for i := 0; i < len(m.theSlice) || i < len(other.theSlice); i++ {
+		var a, b Label
+		aok, bok := i < len(m.theSlice), i < len(other.theSlice)
+		if aok {
+			a = m.theSlice[i]
+		}
+		if bok {
+			b = other.theSlice[i]
+		}
+		if aok && bok && (reflect.DeepEqual(a, b)) {
+			continue
+		}
+		change(immutable.IndexPath(i), a, aok, b, bok)
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a []Label
+func (m *LabelEntries) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []Label
+// into m. m is left immutable.
+func (m *LabelEntries) UnmarshalJSON(b []byte) error {
+	var v []Label
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *NewLabelEntries(v...)
+
+	return nil
+}
+
 //
 // entriesKeysSelect is an immutable type and has the following template:
 //
@@ -458,3 +639,90 @@ func (s *entriesKeysSelect) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	}
 	return true
 }
+
+// Equal returns whether m and other have the same length and equal values
+// at each index
+func (m *entriesKeysSelect) Equal(other *entriesKeysSelect) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	if len(m.theSlice) != len(other.theSlice) {
+		return false
+	}
+
+	for i, a := range m.theSlice {
+		b := other.theSlice[i]
+		if !(a == b) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, in index order. The path
+// of a change is the index that was added, removed or modified, or the
+// index followed by the path of a change within a modified value.
+func (m *entriesKeysSelect) Diff(other *entriesKeysSelect) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a entryKey, aok bool, b entryKey, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	for i := 0; i < len(m.theSlice) || i < len(other.theSlice); i++ {
+		var a, b entryKey
+		aok, bok := i < len(m.theSlice), i < len(other.theSlice)
+		if aok {
+			a = m.theSlice[i]
+		}
+		if bok {
+			b = other.theSlice[i]
+		}
+		if aok && bok && (a == b) {
+			continue
+		}
+		change(immutable.IndexPath(i), a, aok, b, bok)
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a []entryKey
+func (m *entriesKeysSelect) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []entryKey
+// into m. m is left immutable.
+func (m *entriesKeysSelect) UnmarshalJSON(b []byte) error {
+	var v []entryKey
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *newEntriesKeysSelect(v...)
+
+	return nil
+}
@@ -11,17 +11,9 @@ import "github.com/gopherjs/gopherjs/js"
 // for more details
 type DangerousInnerHTML struct {
 	o *js.Object
-}
-
-// NewDangerousInnerHTML creates a new DangerousInnerHTML instance, using the
-// supplied string as the raw HTML
-func NewDangerousInnerHTML(s string) *DangerousInnerHTML {
-	o := object.New()
-	o.Set("__html", s)
-
-	res := &DangerousInnerHTML{o: o}
 
-	return res
+	// html is the raw HTML, used in place of o when rendering without a DOM
+	html string
 }
 
 func (d *DangerousInnerHTML) reactElement() {}
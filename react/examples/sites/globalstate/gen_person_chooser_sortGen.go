@@ -2,50 +2,520 @@
 
 package main
 
-import "sort"
-import "myitcv.io/sorter"
+import (
+	"math/bits"
 
-import "myitcv.io/react/examples/sites/globalstate/model"
+	"myitcv.io/react/examples/sites/globalstate/model"
+	"myitcv.io/sorter"
+)
 
 func sortPeopleKeysByName(vs *model.People) *model.People {
 	theVs := vs.AsMutable()
-
-	sort.Sort(&sorter.Wrapper{
-		LenFunc: func() int {
-			return theVs.Len()
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(orderPeopleKeysByName(theVs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			jPrev := theVs.Get(j)
-			iPrev := theVs.Get(i)
-
-			theVs.Set(j, iPrev)
-			theVs.Set(i, jPrev)
-		},
-	})
+	pdqsort_sortPeopleKeysByName(theVs, 0, theVs.Len(), bits.Len(uint(theVs.Len())))
 
 	return theVs.AsImmutable(vs)
 }
+
 func stableSortPeopleKeysByName(vs *model.People) *model.People {
 	theVs := vs.AsMutable()
-
-	sort.Stable(&sorter.Wrapper{
-		LenFunc: func() int {
-			return theVs.Len()
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(orderPeopleKeysByName(theVs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			jPrev := theVs.Get(j)
-			iPrev := theVs.Get(i)
-
-			theVs.Set(j, iPrev)
-			theVs.Set(i, jPrev)
-		},
-	})
+	stable_stableSortPeopleKeysByName(theVs, theVs.Len())
 
 	return theVs.AsImmutable(vs)
 }
+
+// swap_sortPeopleKeysByName swaps the elements i and j of data, which must be mutable.
+func swap_sortPeopleKeysByName(data *model.People, i, j int) {
+	vi, vj := data.Get(i), data.Get(j)
+	data.Set(i, vj)
+	data.Set(j, vi)
+}
+
+// insertionSort_sortPeopleKeysByName sorts data[a:b] using insertion sort.
+func insertionSort_sortPeopleKeysByName(data *model.People, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && bool(orderPeopleKeysByName(data, j, j-1)); j-- {
+			swap_sortPeopleKeysByName(data, j, j-1)
+		}
+	}
+}
+
+// siftDown_sortPeopleKeysByName implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_sortPeopleKeysByName(data *model.People, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && bool(orderPeopleKeysByName(data, first+child, first+child+1)) {
+			child++
+		}
+		if !bool(orderPeopleKeysByName(data, first+root, first+child)) {
+			return
+		}
+		swap_sortPeopleKeysByName(data, first+root, first+child)
+		root = child
+	}
+}
+
+func heapSort_sortPeopleKeysByName(data *model.People, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_sortPeopleKeysByName(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		swap_sortPeopleKeysByName(data, first, first+i)
+		siftDown_sortPeopleKeysByName(data, lo, i, first)
+	}
+}
+
+// pdqsort_sortPeopleKeysByName sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_sortPeopleKeysByName(data *model.People, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_sortPeopleKeysByName(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_sortPeopleKeysByName(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_sortPeopleKeysByName(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_sortPeopleKeysByName(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_sortPeopleKeysByName(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_sortPeopleKeysByName(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !bool(orderPeopleKeysByName(data, a-1, pivot)) {
+			mid := partitionEqual_sortPeopleKeysByName(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_sortPeopleKeysByName(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_sortPeopleKeysByName(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_sortPeopleKeysByName(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_sortPeopleKeysByName does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_sortPeopleKeysByName(data *model.People, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	swap_sortPeopleKeysByName(data, a, pivot)
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && bool(orderPeopleKeysByName(data, i, a)) {
+		i++
+	}
+	for i <= j && !bool(orderPeopleKeysByName(data, j, a)) {
+		j--
+	}
+	if i > j {
+		swap_sortPeopleKeysByName(data, j, a)
+		return j, true
+	}
+	swap_sortPeopleKeysByName(data, i, j)
+	i++
+	j--
+
+	for {
+		for i <= j && bool(orderPeopleKeysByName(data, i, a)) {
+			i++
+		}
+		for i <= j && !bool(orderPeopleKeysByName(data, j, a)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		swap_sortPeopleKeysByName(data, i, j)
+		i++
+		j--
+	}
+	swap_sortPeopleKeysByName(data, j, a)
+	return j, false
+}
+
+// partitionEqual_sortPeopleKeysByName partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_sortPeopleKeysByName(data *model.People, a, b, pivot int) (newpivot int) {
+	swap_sortPeopleKeysByName(data, a, pivot)
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !bool(orderPeopleKeysByName(data, a, i)) {
+			i++
+		}
+		for i <= j && bool(orderPeopleKeysByName(data, a, j)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		swap_sortPeopleKeysByName(data, i, j)
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_sortPeopleKeysByName partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_sortPeopleKeysByName(data *model.People, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !bool(orderPeopleKeysByName(data, i, i-1)) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		swap_sortPeopleKeysByName(data, i, i-1)
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !bool(orderPeopleKeysByName(data, j, j-1)) {
+					break
+				}
+				swap_sortPeopleKeysByName(data, j, j-1)
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !bool(orderPeopleKeysByName(data, j, j-1)) {
+					break
+				}
+				swap_sortPeopleKeysByName(data, j, j-1)
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_sortPeopleKeysByName scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_sortPeopleKeysByName(data *model.People, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			swap_sortPeopleKeysByName(data, idx, a+other)
+		}
+	}
+}
+
+// choosePivot_sortPeopleKeysByName chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_sortPeopleKeysByName(data *model.People, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_sortPeopleKeysByName(data, i, &swaps)
+			j = medianAdjacent_sortPeopleKeysByName(data, j, &swaps)
+			k = medianAdjacent_sortPeopleKeysByName(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_sortPeopleKeysByName(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_sortPeopleKeysByName returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_sortPeopleKeysByName(data *model.People, a, b int, swaps *int) (int, int) {
+	if bool(orderPeopleKeysByName(data, b, a)) {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_sortPeopleKeysByName returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_sortPeopleKeysByName(data *model.People, a, b, c int, swaps *int) int {
+	a, b = order2_sortPeopleKeysByName(data, a, b, swaps)
+	b, c = order2_sortPeopleKeysByName(data, b, c, swaps)
+	a, b = order2_sortPeopleKeysByName(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_sortPeopleKeysByName finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_sortPeopleKeysByName(data *model.People, a int, swaps *int) int {
+	return median_sortPeopleKeysByName(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_sortPeopleKeysByName(data *model.People, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		swap_sortPeopleKeysByName(data, i, j)
+		i++
+		j--
+	}
+}
+
+// swap_stableSortPeopleKeysByName swaps the elements i and j of data, which must be mutable.
+func swap_stableSortPeopleKeysByName(data *model.People, i, j int) {
+	vi, vj := data.Get(i), data.Get(j)
+	data.Set(i, vj)
+	data.Set(j, vi)
+}
+
+// insertionSort_stableSortPeopleKeysByName sorts data[a:b] using insertion sort.
+func insertionSort_stableSortPeopleKeysByName(data *model.People, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && bool(orderPeopleKeysByName(data, j, j-1)); j-- {
+			swap_stableSortPeopleKeysByName(data, j, j-1)
+		}
+	}
+}
+
+// stable_stableSortPeopleKeysByName sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_stableSortPeopleKeysByName.
+func stable_stableSortPeopleKeysByName(data *model.People, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_stableSortPeopleKeysByName(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_stableSortPeopleKeysByName(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_stableSortPeopleKeysByName(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_stableSortPeopleKeysByName(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_stableSortPeopleKeysByName merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_stableSortPeopleKeysByName(data *model.People, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if bool(orderPeopleKeysByName(data, h, a)) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			swap_stableSortPeopleKeysByName(data, k, k+1)
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !bool(orderPeopleKeysByName(data, m, h)) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			swap_stableSortPeopleKeysByName(data, k, k-1)
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !bool(orderPeopleKeysByName(data, p-c, c)) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_stableSortPeopleKeysByName(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_stableSortPeopleKeysByName(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_stableSortPeopleKeysByName(data, mid, end, b)
+	}
+}
+
+// rotate_stableSortPeopleKeysByName rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_stableSortPeopleKeysByName(data *model.People, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_stableSortPeopleKeysByName(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_stableSortPeopleKeysByName(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_stableSortPeopleKeysByName(data, m-i, m, i)
+}
+
+func swapRange_stableSortPeopleKeysByName(data *model.People, a, b, n int) {
+	for i := 0; i < n; i++ {
+		swap_stableSortPeopleKeysByName(data, a+i, b+i)
+	}
+}
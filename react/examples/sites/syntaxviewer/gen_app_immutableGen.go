@@ -5,6 +5,8 @@ package main
 //immutableVet:skipFile
 
 import (
+	"encoding/json"
+
 	"myitcv.io/immutable"
 )
 
@@ -138,3 +140,100 @@ func (s *langState) SetError(n bool) *langState {
 	res.field_Error = n
 	return &res
 }
+
+// Equal returns whether the fields of s and other are equal
+func (s *langState) Equal(other *langState) bool {
+	if s == other {
+		return true
+	}
+
+	if s == nil || other == nil {
+		return false
+	}
+
+	if !(s.field_Code == other.field_Code) {
+		return false
+	}
+
+	if !(s.field_Ast == other.field_Ast) {
+		return false
+	}
+
+	if !(s.field_Error == other.field_Error) {
+		return false
+	}
+
+	return true
+}
+
+// Diff returns the changes between s and other, in field order. The path of
+// a change is the name of the field that was modified, or the name
+// followed by the path of a change within the modified field.
+func (s *langState) Diff(other *langState) []immutable.Change {
+	if s == other {
+		return nil
+	}
+
+	if s == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: s, New: other}}
+	}
+
+	var res []immutable.Change
+
+	if !(s.field_Code == other.field_Code) {
+		res = append(res, immutable.Change{Path: ".Code", Kind: immutable.ChangeModified, Old: s.field_Code, New: other.field_Code})
+	}
+
+	if !(s.field_Ast == other.field_Ast) {
+		res = append(res, immutable.Change{Path: ".Ast", Kind: immutable.ChangeModified, Old: s.field_Ast, New: other.field_Ast})
+	}
+
+	if !(s.field_Error == other.field_Error) {
+		res = append(res, immutable.Change{Path: ".Error", Kind: immutable.ChangeModified, Old: s.field_Error, New: other.field_Error})
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling s as if it were a
+// value of its template type
+func (s *langState) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	v := struct {
+		Code  string
+		Ast   string
+		Error bool
+	}{
+		Code:  s.field_Code,
+		Ast:   s.field_Ast,
+		Error: s.field_Error,
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling into a zero
+// value of the template type of s, from which s is then set. s is left
+// immutable.
+func (s *langState) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Code  string
+		Ast   string
+		Error bool
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*s = langState{
+		field_Code:  v.Code,
+		field_Ast:   v.Ast,
+		field_Error: v.Error,
+	}
+
+	return nil
+}
@@ -2,8 +2,6 @@
 
 package react
 
-import "github.com/gopherjs/gopherjs/js"
-
 // AElem is the React element definition corresponding to the HTML <a> element
 type AElem struct {
 	Element
@@ -31,80 +29,6 @@ type AProps struct {
 	Title                   string
 }
 
-// A creates a new instance of a <a> element with the provided props and
-// children
-func A(props *AProps, children ...Element) *AElem {
-	type _AProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		Href                    string              `js:"href"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-		Target                  string              `js:"target"`
-		Title                   string              `js:"title"`
-	}
-
-	rprops := &_AProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		rprops.Href = props.Href
-
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-		rprops.Target = props.Target
-
-		rprops.Title = props.Title
-	}
-
-	return &AElem{
-		Element: createElement("a", rprops, children...),
-	}
-}
-
 // AbbrElem is the React element definition corresponding to the HTML <abbr> element
 type AbbrElem struct {
 	Element
@@ -129,72 +53,6 @@ type AbbrProps struct {
 	Style                   *CSS
 }
 
-// Abbr creates a new instance of a <abbr> element with the provided props and
-// children
-func Abbr(props *AbbrProps, children ...Element) *AbbrElem {
-	type _AbbrProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_AbbrProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &AbbrElem{
-		Element: createElement("abbr", rprops, children...),
-	}
-}
-
 // ArticleElem is the React element definition corresponding to the HTML <article> element
 type ArticleElem struct {
 	Element
@@ -219,72 +77,6 @@ type ArticleProps struct {
 	Style                   *CSS
 }
 
-// Article creates a new instance of a <article> element with the provided props and
-// children
-func Article(props *ArticleProps, children ...Element) *ArticleElem {
-	type _ArticleProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_ArticleProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &ArticleElem{
-		Element: createElement("article", rprops, children...),
-	}
-}
-
 // AsideElem is the React element definition corresponding to the HTML <aside> element
 type AsideElem struct {
 	Element
@@ -309,72 +101,6 @@ type AsideProps struct {
 	Style                   *CSS
 }
 
-// Aside creates a new instance of a <aside> element with the provided props and
-// children
-func Aside(props *AsideProps, children ...Element) *AsideElem {
-	type _AsideProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_AsideProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &AsideElem{
-		Element: createElement("aside", rprops, children...),
-	}
-}
-
 // BElem is the React element definition corresponding to the HTML <b> element
 type BElem struct {
 	Element
@@ -399,72 +125,6 @@ type BProps struct {
 	Style                   *CSS
 }
 
-// B creates a new instance of a <b> element with the provided props and
-// children
-func B(props *BProps, children ...Element) *BElem {
-	type _BProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_BProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &BElem{
-		Element: createElement("b", rprops, children...),
-	}
-}
-
 // BrElem is the React element definition corresponding to the HTML <br> element
 type BrElem struct {
 	Element
@@ -489,72 +149,6 @@ type BrProps struct {
 	Style                   *CSS
 }
 
-// Br creates a new instance of a <br> element with the provided props and
-// children
-func Br(props *BrProps, children ...Element) *BrElem {
-	type _BrProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_BrProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &BrElem{
-		Element: createElement("br", rprops, children...),
-	}
-}
-
 // ButtonElem is the React element definition corresponding to the HTML <button> element
 type ButtonElem struct {
 	Element
@@ -581,78 +175,6 @@ type ButtonProps struct {
 	Type                    string
 }
 
-// Button creates a new instance of a <button> element with the provided props and
-// children
-func Button(props *ButtonProps, children ...Element) *ButtonElem {
-	type _ButtonProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		Disabled                string              `js:"disabled" react:"omitempty"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-		Type                    string              `js:"type"`
-	}
-
-	rprops := &_ButtonProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.Disabled != "" {
-			rprops.Disabled = props.Disabled
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-		rprops.Type = props.Type
-	}
-
-	return &ButtonElem{
-		Element: createElement("button", rprops, children...),
-	}
-}
-
 // CaptionElem is the React element definition corresponding to the HTML <caption> element
 type CaptionElem struct {
 	Element
@@ -677,72 +199,6 @@ type CaptionProps struct {
 	Style                   *CSS
 }
 
-// Caption creates a new instance of a <caption> element with the provided props and
-// children
-func Caption(props *CaptionProps, children ...Element) *CaptionElem {
-	type _CaptionProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_CaptionProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &CaptionElem{
-		Element: createElement("caption", rprops, children...),
-	}
-}
-
 // CodeElem is the React element definition corresponding to the HTML <code> element
 type CodeElem struct {
 	Element
@@ -767,72 +223,6 @@ type CodeProps struct {
 	Style                   *CSS
 }
 
-// Code creates a new instance of a <code> element with the provided props and
-// children
-func Code(props *CodeProps, children ...Element) *CodeElem {
-	type _CodeProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_CodeProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &CodeElem{
-		Element: createElement("code", rprops, children...),
-	}
-}
-
 // DivElem is the React element definition corresponding to the HTML <div> element
 type DivElem struct {
 	Element
@@ -857,72 +247,6 @@ type DivProps struct {
 	Style                   *CSS
 }
 
-// Div creates a new instance of a <div> element with the provided props and
-// children
-func Div(props *DivProps, children ...Element) *DivElem {
-	type _DivProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_DivProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &DivElem{
-		Element: createElement("div", rprops, children...),
-	}
-}
-
 // EmElem is the React element definition corresponding to the HTML <em> element
 type EmElem struct {
 	Element
@@ -947,72 +271,6 @@ type EmProps struct {
 	Style                   *CSS
 }
 
-// Em creates a new instance of a <em> element with the provided props and
-// children
-func Em(props *EmProps, children ...Element) *EmElem {
-	type _EmProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_EmProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &EmElem{
-		Element: createElement("em", rprops, children...),
-	}
-}
-
 // FooterElem is the React element definition corresponding to the HTML <footer> element
 type FooterElem struct {
 	Element
@@ -1037,72 +295,6 @@ type FooterProps struct {
 	Style                   *CSS
 }
 
-// Footer creates a new instance of a <footer> element with the provided props and
-// children
-func Footer(props *FooterProps, children ...Element) *FooterElem {
-	type _FooterProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_FooterProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &FooterElem{
-		Element: createElement("footer", rprops, children...),
-	}
-}
-
 // FormElem is the React element definition corresponding to the HTML <form> element
 type FormElem struct {
 	Element
@@ -1128,76 +320,6 @@ type FormProps struct {
 	Style                   *CSS
 }
 
-// Form creates a new instance of a <form> element with the provided props and
-// children
-func Form(props *FormProps, children ...Element) *FormElem {
-	type _FormProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		OnSubmit                OnSubmit            `js:"onSubmit"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_FormProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.OnSubmit != nil {
-			rprops.o.Set("onSubmit", props.OnSubmit.OnSubmit)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &FormElem{
-		Element: createElement("form", rprops, children...),
-	}
-}
-
 // H1Elem is the React element definition corresponding to the HTML <h1> element
 type H1Elem struct {
 	Element
@@ -1222,72 +344,6 @@ type H1Props struct {
 	Style                   *CSS
 }
 
-// H1 creates a new instance of a <h1> element with the provided props and
-// children
-func H1(props *H1Props, children ...Element) *H1Elem {
-	type _H1Props struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_H1Props{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &H1Elem{
-		Element: createElement("h1", rprops, children...),
-	}
-}
-
 // H2Elem is the React element definition corresponding to the HTML <h2> element
 type H2Elem struct {
 	Element
@@ -1312,72 +368,6 @@ type H2Props struct {
 	Style                   *CSS
 }
 
-// H2 creates a new instance of a <h2> element with the provided props and
-// children
-func H2(props *H2Props, children ...Element) *H2Elem {
-	type _H2Props struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_H2Props{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &H2Elem{
-		Element: createElement("h2", rprops, children...),
-	}
-}
-
 // H3Elem is the React element definition corresponding to the HTML <h3> element
 type H3Elem struct {
 	Element
@@ -1402,72 +392,6 @@ type H3Props struct {
 	Style                   *CSS
 }
 
-// H3 creates a new instance of a <h3> element with the provided props and
-// children
-func H3(props *H3Props, children ...Element) *H3Elem {
-	type _H3Props struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_H3Props{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &H3Elem{
-		Element: createElement("h3", rprops, children...),
-	}
-}
-
 // H4Elem is the React element definition corresponding to the HTML <h4> element
 type H4Elem struct {
 	Element
@@ -1492,72 +416,6 @@ type H4Props struct {
 	Style                   *CSS
 }
 
-// H4 creates a new instance of a <h4> element with the provided props and
-// children
-func H4(props *H4Props, children ...Element) *H4Elem {
-	type _H4Props struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_H4Props{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &H4Elem{
-		Element: createElement("h4", rprops, children...),
-	}
-}
-
 // H5Elem is the React element definition corresponding to the HTML <h5> element
 type H5Elem struct {
 	Element
@@ -1582,72 +440,6 @@ type H5Props struct {
 	Style                   *CSS
 }
 
-// H5 creates a new instance of a <h5> element with the provided props and
-// children
-func H5(props *H5Props, children ...Element) *H5Elem {
-	type _H5Props struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_H5Props{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &H5Elem{
-		Element: createElement("h5", rprops, children...),
-	}
-}
-
 // H6Elem is the React element definition corresponding to the HTML <h6> element
 type H6Elem struct {
 	Element
@@ -1672,72 +464,6 @@ type H6Props struct {
 	Style                   *CSS
 }
 
-// H6 creates a new instance of a <h6> element with the provided props and
-// children
-func H6(props *H6Props, children ...Element) *H6Elem {
-	type _H6Props struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_H6Props{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &H6Elem{
-		Element: createElement("h6", rprops, children...),
-	}
-}
-
 // HeaderElem is the React element definition corresponding to the HTML <header> element
 type HeaderElem struct {
 	Element
@@ -1762,72 +488,6 @@ type HeaderProps struct {
 	Style                   *CSS
 }
 
-// Header creates a new instance of a <header> element with the provided props and
-// children
-func Header(props *HeaderProps, children ...Element) *HeaderElem {
-	type _HeaderProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_HeaderProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &HeaderElem{
-		Element: createElement("header", rprops, children...),
-	}
-}
-
 // HrElem is the React element definition corresponding to the HTML <hr> element
 type HrElem struct {
 	Element
@@ -1852,72 +512,6 @@ type HrProps struct {
 	Style                   *CSS
 }
 
-// Hr creates a new instance of a <hr> element with the provided props and
-// children
-func Hr(props *HrProps) *HrElem {
-	type _HrProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_HrProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &HrElem{
-		Element: createElement("hr", rprops),
-	}
-}
-
 // IElem is the React element definition corresponding to the HTML <i> element
 type IElem struct {
 	Element
@@ -1942,72 +536,6 @@ type IProps struct {
 	Style                   *CSS
 }
 
-// I creates a new instance of a <i> element with the provided props and
-// children
-func I(props *IProps, children ...Element) *IElem {
-	type _IProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_IProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &IElem{
-		Element: createElement("i", rprops, children...),
-	}
-}
-
 // IFrameElem is the React element definition corresponding to the HTML <iframe> element
 type IFrameElem struct {
 	Element
@@ -2033,75 +561,6 @@ type IFrameProps struct {
 	Style                   *CSS
 }
 
-// IFrame creates a new instance of a <iframe> element with the provided props and
-// children
-func IFrame(props *IFrameProps, children ...Element) *IFrameElem {
-	type _IFrameProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		SrcDoc                  string              `js:"srcDoc"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_IFrameProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		rprops.SrcDoc = props.SrcDoc
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &IFrameElem{
-		Element: createElement("iframe", rprops, children...),
-	}
-}
-
 // ImgElem is the React element definition corresponding to the HTML <img> element
 type ImgElem struct {
 	Element
@@ -2128,78 +587,6 @@ type ImgProps struct {
 	Style                   *CSS
 }
 
-// Img creates a new instance of a <img> element with the provided props and
-// children
-func Img(props *ImgProps, children ...Element) *ImgElem {
-	type _ImgProps struct {
-		o                       *js.Object
-		Alt                     string              `js:"alt"`
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Src                     string              `js:"src"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_ImgProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.Alt = props.Alt
-
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		rprops.Src = props.Src
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &ImgElem{
-		Element: createElement("img", rprops, children...),
-	}
-}
-
 // InputElem is the React element definition corresponding to the HTML <input> element
 type InputElem struct {
 	Element
@@ -2227,80 +614,6 @@ type InputProps struct {
 	Value                   string
 }
 
-// Input creates a new instance of a <input> element with the provided props and
-// children
-func Input(props *InputProps, children ...Element) *InputElem {
-	type _InputProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Placeholder             string              `js:"placeholder"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-		Type                    string              `js:"type"`
-		Value                   string              `js:"value"`
-	}
-
-	rprops := &_InputProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		rprops.Placeholder = props.Placeholder
-
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-		rprops.Type = props.Type
-
-		rprops.Value = props.Value
-	}
-
-	return &InputElem{
-		Element: createElement("input", rprops, children...),
-	}
-}
-
 // LabelElem is the React element definition corresponding to the HTML <label> element
 type LabelElem struct {
 	Element
@@ -2326,75 +639,6 @@ type LabelProps struct {
 	Style                   *CSS
 }
 
-// Label creates a new instance of a <label> element with the provided props and
-// children
-func Label(props *LabelProps, children ...Element) *LabelElem {
-	type _LabelProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		For                     string              `js:"htmlFor"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_LabelProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		rprops.For = props.For
-
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &LabelElem{
-		Element: createElement("label", rprops, children...),
-	}
-}
-
 // LiElem is the React element definition corresponding to the HTML <li> element
 type LiElem struct {
 	Element
@@ -2421,72 +665,6 @@ type LiProps struct {
 	Style                   *CSS
 }
 
-// Li creates a new instance of a <li> element with the provided props and
-// children
-func Li(props *LiProps, children ...Element) *LiElem {
-	type _LiProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_LiProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &LiElem{
-		Element: createElement("li", rprops, children...),
-	}
-}
-
 // MainElem is the React element definition corresponding to the HTML <main> element
 type MainElem struct {
 	Element
@@ -2511,72 +689,6 @@ type MainProps struct {
 	Style                   *CSS
 }
 
-// Main creates a new instance of a <main> element with the provided props and
-// children
-func Main(props *MainProps, children ...Element) *MainElem {
-	type _MainProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_MainProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &MainElem{
-		Element: createElement("main", rprops, children...),
-	}
-}
-
 // NavElem is the React element definition corresponding to the HTML <nav> element
 type NavElem struct {
 	Element
@@ -2601,72 +713,6 @@ type NavProps struct {
 	Style                   *CSS
 }
 
-// Nav creates a new instance of a <nav> element with the provided props and
-// children
-func Nav(props *NavProps, children ...Element) *NavElem {
-	type _NavProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_NavProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &NavElem{
-		Element: createElement("nav", rprops, children...),
-	}
-}
-
 // OptionElem is the React element definition corresponding to the HTML <option> element
 type OptionElem struct {
 	Element
@@ -2692,74 +738,6 @@ type OptionProps struct {
 	Value                   string
 }
 
-// Option creates a new instance of a <option> element with the provided props and
-// children
-func Option(props *OptionProps, children ...Element) *OptionElem {
-	type _OptionProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-		Value                   string              `js:"value"`
-	}
-
-	rprops := &_OptionProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-		rprops.Value = props.Value
-	}
-
-	return &OptionElem{
-		Element: createElement("option", rprops, children...),
-	}
-}
-
 // PElem is the React element definition corresponding to the HTML <p> element
 type PElem struct {
 	Element
@@ -2784,72 +762,6 @@ type PProps struct {
 	Style                   *CSS
 }
 
-// P creates a new instance of a <p> element with the provided props and
-// children
-func P(props *PProps, children ...Element) *PElem {
-	type _PProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_PProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &PElem{
-		Element: createElement("p", rprops, children...),
-	}
-}
-
 // PreElem is the React element definition corresponding to the HTML <pre> element
 type PreElem struct {
 	Element
@@ -2874,72 +786,6 @@ type PreProps struct {
 	Style                   *CSS
 }
 
-// Pre creates a new instance of a <pre> element with the provided props and
-// children
-func Pre(props *PreProps, children ...Element) *PreElem {
-	type _PreProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_PreProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &PreElem{
-		Element: createElement("pre", rprops, children...),
-	}
-}
-
 // SelectElem is the React element definition corresponding to the HTML <select> element
 type SelectElem struct {
 	Element
@@ -2965,79 +811,6 @@ type SelectProps struct {
 	Value                   string
 }
 
-// Select creates a new instance of a <select> element with the provided props and
-// children
-func Select(props *SelectProps, children ...*OptionElem) *SelectElem {
-	type _SelectProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-		Value                   string              `js:"value"`
-	}
-
-	var elems []Element
-	for _, v := range children {
-		elems = append(elems, v)
-	}
-
-	rprops := &_SelectProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-		rprops.Value = props.Value
-	}
-
-	return &SelectElem{
-		Element: createElement("select", rprops, elems...),
-	}
-}
-
 // SpanElem is the React element definition corresponding to the HTML <span> element
 type SpanElem struct {
 	Element
@@ -3062,72 +835,6 @@ type SpanProps struct {
 	Style                   *CSS
 }
 
-// Span creates a new instance of a <span> element with the provided props and
-// children
-func Span(props *SpanProps, children ...Element) *SpanElem {
-	type _SpanProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_SpanProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &SpanElem{
-		Element: createElement("span", rprops, children...),
-	}
-}
-
 // StrikeElem is the React element definition corresponding to the HTML <s> element
 type StrikeElem struct {
 	Element
@@ -3152,72 +859,6 @@ type StrikeProps struct {
 	Style                   *CSS
 }
 
-// Strike creates a new instance of a <s> element with the provided props and
-// children
-func Strike(props *StrikeProps, children ...Element) *StrikeElem {
-	type _StrikeProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_StrikeProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &StrikeElem{
-		Element: createElement("s", rprops, children...),
-	}
-}
-
 // SupElem is the React element definition corresponding to the HTML <sup> element
 type SupElem struct {
 	Element
@@ -3242,72 +883,6 @@ type SupProps struct {
 	Style                   *CSS
 }
 
-// Sup creates a new instance of a <sup> element with the provided props and
-// children
-func Sup(props *SupProps, children ...Element) *SupElem {
-	type _SupProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_SupProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &SupElem{
-		Element: createElement("sup", rprops, children...),
-	}
-}
-
 // TableElem is the React element definition corresponding to the HTML <table> element
 type TableElem struct {
 	Element
@@ -3332,72 +907,6 @@ type TableProps struct {
 	Style                   *CSS
 }
 
-// Table creates a new instance of a <table> element with the provided props and
-// children
-func Table(props *TableProps, children ...Element) *TableElem {
-	type _TableProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_TableProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &TableElem{
-		Element: createElement("table", rprops, children...),
-	}
-}
-
 // TbodyElem is the React element definition corresponding to the HTML <tbody> element
 type TbodyElem struct {
 	Element
@@ -3422,72 +931,6 @@ type TbodyProps struct {
 	Style                   *CSS
 }
 
-// Tbody creates a new instance of a <tbody> element with the provided props and
-// children
-func Tbody(props *TbodyProps, children ...Element) *TbodyElem {
-	type _TbodyProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_TbodyProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &TbodyElem{
-		Element: createElement("tbody", rprops, children...),
-	}
-}
-
 // TdElem is the React element definition corresponding to the HTML <td> element
 type TdElem struct {
 	Element
@@ -3512,72 +955,6 @@ type TdProps struct {
 	Style                   *CSS
 }
 
-// Td creates a new instance of a <td> element with the provided props and
-// children
-func Td(props *TdProps, children ...Element) *TdElem {
-	type _TdProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_TdProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &TdElem{
-		Element: createElement("td", rprops, children...),
-	}
-}
-
 // TextAreaElem is the React element definition corresponding to the HTML <textarea> element
 type TextAreaElem struct {
 	Element
@@ -3604,77 +981,6 @@ type TextAreaProps struct {
 	Value                   string
 }
 
-// TextArea creates a new instance of a <textarea> element with the provided props and
-// children
-func TextArea(props *TextAreaProps, children ...Element) *TextAreaElem {
-	type _TextAreaProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Placeholder             string              `js:"placeholder"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-		Value                   string              `js:"value"`
-	}
-
-	rprops := &_TextAreaProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		rprops.Placeholder = props.Placeholder
-
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-		rprops.Value = props.Value
-	}
-
-	return &TextAreaElem{
-		Element: createElement("textarea", rprops, children...),
-	}
-}
-
 // ThElem is the React element definition corresponding to the HTML <th> element
 type ThElem struct {
 	Element
@@ -3699,72 +1005,6 @@ type ThProps struct {
 	Style                   *CSS
 }
 
-// Th creates a new instance of a <th> element with the provided props and
-// children
-func Th(props *ThProps, children ...Element) *ThElem {
-	type _ThProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_ThProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &ThElem{
-		Element: createElement("th", rprops, children...),
-	}
-}
-
 // TheadElem is the React element definition corresponding to the HTML <thead> element
 type TheadElem struct {
 	Element
@@ -3789,72 +1029,6 @@ type TheadProps struct {
 	Style                   *CSS
 }
 
-// Thead creates a new instance of a <thead> element with the provided props and
-// children
-func Thead(props *TheadProps, children ...Element) *TheadElem {
-	type _TheadProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_TheadProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &TheadElem{
-		Element: createElement("thead", rprops, children...),
-	}
-}
-
 // TrElem is the React element definition corresponding to the HTML <tr> element
 type TrElem struct {
 	Element
@@ -3879,72 +1053,6 @@ type TrProps struct {
 	Style                   *CSS
 }
 
-// Tr creates a new instance of a <tr> element with the provided props and
-// children
-func Tr(props *TrProps, children ...Element) *TrElem {
-	type _TrProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	rprops := &_TrProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &TrElem{
-		Element: createElement("tr", rprops, children...),
-	}
-}
-
 // UlElem is the React element definition corresponding to the HTML <ul> element
 type UlElem struct {
 	Element
@@ -3968,74 +1076,3 @@ type UlProps struct {
 	Role                    string
 	Style                   *CSS
 }
-
-// Ul creates a new instance of a <ul> element with the provided props and
-// children
-func Ul(props *UlProps, children ...RendersLi) *UlElem {
-	type _UlProps struct {
-		o                       *js.Object
-		AriaExpanded            bool                `js:"aria-expanded"`
-		AriaHasPopup            bool                `js:"aria-haspopup"`
-		AriaLabelledBy          string              `js:"aria-labelledby"`
-		ClassName               string              `js:"className"`
-		DangerouslySetInnerHTML *DangerousInnerHTML `js:"dangerouslySetInnerHTML"`
-		ID                      string              `js:"id" react:"omitempty"`
-		Key                     string              `js:"key" react:"omitempty"`
-		OnChange                OnChange            `js:"onChange"`
-		OnClick                 OnClick             `js:"onClick"`
-		Ref                     Ref                 `js:"ref"`
-		Role                    string              `js:"role"`
-		Style                   *CSS                `js:"style"`
-	}
-
-	var elems []Element
-	for _, v := range children {
-		elems = append(elems, v)
-	}
-
-	rprops := &_UlProps{
-		o: object.New(),
-	}
-
-	if props != nil {
-		rprops.AriaExpanded = props.AriaExpanded
-
-		rprops.AriaHasPopup = props.AriaHasPopup
-
-		rprops.AriaLabelledBy = props.AriaLabelledBy
-
-		rprops.ClassName = props.ClassName
-
-		rprops.DangerouslySetInnerHTML = props.DangerouslySetInnerHTML
-
-		if props.DataSet != nil {
-			for dk, dv := range props.DataSet {
-				rprops.o.Set("data-"+dk, dv)
-			}
-		}
-		if props.ID != "" {
-			rprops.ID = props.ID
-		}
-		if props.Key != "" {
-			rprops.Key = props.Key
-		}
-		if props.OnChange != nil {
-			rprops.o.Set("onChange", props.OnChange.OnChange)
-		}
-		if props.OnClick != nil {
-			rprops.o.Set("onClick", props.OnClick.OnClick)
-		}
-		if props.Ref != nil {
-			rprops.o.Set("ref", props.Ref.Ref)
-		}
-		rprops.Role = props.Role
-
-		// TODO: until we have a resolution on
-		// https://github.com/gopherjs/gopherjs/issues/236
-		rprops.Style = props.Style.hack()
-	}
-
-	return &UlElem{
-		Element: createElement("ul", rprops, elems...),
-	}
-}
@@ -2,11 +2,14 @@
 
 package react
 
-import "github.com/gopherjs/gopherjs/js"
+import (
+	"strings"
+
+	"github.com/gopherjs/gopherjs/js"
+)
 
 // CSS defines CSS attributes for HTML components. Largely based on
 // https://developer.mozilla.org/en-US/docs/Web/CSS/Reference
-//
 type CSS struct {
 	o *js.Object
 
@@ -57,3 +60,64 @@ func (c *CSS) hack() *CSS {
 
 	return &CSS{o: o}
 }
+
+// css returns the value of the HTML style attribute corresponding to c, used
+// when rendering without a DOM
+func (c *CSS) css() string {
+	if c == nil {
+		return ""
+	}
+
+	var decls []string
+
+	if c.Float != "" {
+		decls = append(decls, "float:"+c.Float)
+	}
+	if c.FontSize != "" {
+		decls = append(decls, "font-size:"+c.FontSize)
+	}
+	if c.FontStyle != "" {
+		decls = append(decls, "font-style:"+c.FontStyle)
+	}
+	if c.FontWeight != "" {
+		decls = append(decls, "font-weight:"+c.FontWeight)
+	}
+	if c.Height != "" {
+		decls = append(decls, "height:"+c.Height)
+	}
+	if c.Left != "" {
+		decls = append(decls, "left:"+c.Left)
+	}
+	if c.MarginTop != "" {
+		decls = append(decls, "margin-top:"+c.MarginTop)
+	}
+	if c.MaxHeight != "" {
+		decls = append(decls, "max-height:"+c.MaxHeight)
+	}
+	if c.MinHeight != "" {
+		decls = append(decls, "min-height:"+c.MinHeight)
+	}
+	if c.Overflow != "" {
+		decls = append(decls, "overflow:"+c.Overflow)
+	}
+	if c.OverflowY != "" {
+		decls = append(decls, "overflow-y:"+c.OverflowY)
+	}
+	if c.Position != "" {
+		decls = append(decls, "position:"+c.Position)
+	}
+	if c.Resize != "" {
+		decls = append(decls, "resize:"+c.Resize)
+	}
+	if c.Top != "" {
+		decls = append(decls, "top:"+c.Top)
+	}
+	if c.Width != "" {
+		decls = append(decls, "width:"+c.Width)
+	}
+	if c.ZIndex != "" {
+		decls = append(decls, "z-index:"+c.ZIndex)
+	}
+
+	return strings.Join(decls, ";")
+}
@@ -1,6 +1,10 @@
 package core
 
-import "github.com/gopherjs/gopherjs/js"
+import (
+	"reflect"
+
+	"github.com/gopherjs/gopherjs/js"
+)
 
 type S string
 
@@ -15,3 +19,54 @@ func (r *ElementHolder) reactElement() {}
 type Element interface {
 	reactElement()
 }
+
+// The following are the elements created when building without a DOM, i.e.
+// !js, in place of an ElementHolder. They are walked by myitcv.io/react/ssr.
+
+// HTMLElement is an element corresponding to an HTML element
+type HTMLElement struct {
+	// Tag is the name of the HTML element, e.g. div
+	Tag string
+
+	// Attrs are the HTML attributes of the element, derived from its props
+	Attrs []Attr
+
+	// InnerHTML is the raw HTML content of the element, from its
+	// DangerouslySetInnerHTML prop, if set
+	InnerHTML *string
+
+	Children []Element
+}
+
+func (h *HTMLElement) reactElement() {}
+
+// Attr is an HTML attribute
+type Attr struct {
+	Name  string
+	Value string
+}
+
+// FragmentElement is a React Fragment
+type FragmentElement struct {
+	Children []Element
+}
+
+func (f *FragmentElement) reactElement() {}
+
+// ComponentElement is an instance of a component
+type ComponentElement struct {
+	// Type is the type of the component
+	Type reflect.Type
+
+	// Props, Children and State are the props (a myitcv.io/react.Props),
+	// children and current state (a myitcv.io/react.State) of the component
+	Props    interface{}
+	Children []Element
+	State    interface{}
+
+	// Build returns the component (a myitcv.io/react.Component) defined for
+	// this element
+	Build func() interface{}
+}
+
+func (c *ComponentElement) reactElement() {}
@@ -0,0 +1,208 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+// +build !js
+
+/*
+
+Package ssr renders myitcv.io/react Element trees as HTML without a browser,
+for example on the server for first paint, or in golden-file tests.
+
+Package ssr is only available when building without GopherJS (i.e. !js); in
+that case the element constructors of myitcv.io/react build pure Go values
+that are walked by Render. Components are rendered by calling their
+GetInitialState, ComponentWillMount and Render methods in turn; the DOM-only
+lifecycle methods, e.g. ComponentDidMount, are not called. Event handlers,
+Key and Ref are not rendered.
+
+*/
+package ssr
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"reflect"
+	"strings"
+
+	"myitcv.io/react"
+	"myitcv.io/react/internal/core"
+)
+
+// voidElements are the HTML elements that cannot have children, and hence
+// have no end tag
+var voidElements = map[string]bool{
+	"br":    true,
+	"hr":    true,
+	"img":   true,
+	"input": true,
+}
+
+var elementType = reflect.TypeOf((*react.Element)(nil)).Elem()
+
+type componentWithGetInitialState interface {
+	GetInitialStateIntf() react.State
+}
+
+type componentWithWillMount interface {
+	ComponentWillMount()
+}
+
+// Render writes the HTML corresponding to el to w
+func Render(w io.Writer, el react.Element) error {
+	s, err := RenderToString(el)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, s)
+	return err
+}
+
+// RenderToString returns the HTML corresponding to el
+func RenderToString(el react.Element) (string, error) {
+	r := new(renderer)
+
+	if err := r.render(el); err != nil {
+		return "", err
+	}
+
+	return r.buf.String(), nil
+}
+
+type renderer struct {
+	buf strings.Builder
+
+	// selectValue is the value of the <select> element, if any, within which
+	// we are rendering
+	selectValue *string
+}
+
+func (r *renderer) render(el react.Element) error {
+	switch el := el.(type) {
+	case nil:
+		return nil
+	case core.S:
+		r.buf.WriteString(html.EscapeString(string(el)))
+		return nil
+	case *core.HTMLElement:
+		return r.renderHTML(el)
+	case *core.FragmentElement:
+		return r.renderChildren(el.Children)
+	case *core.ComponentElement:
+		return r.renderComponent(el)
+	}
+
+	// el is otherwise a wrapper, e.g. *react.DivElem or a component's Elem
+	// type, that embeds the Element it wraps
+	v := reflect.ValueOf(el)
+	if v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Struct {
+		if v.IsNil() {
+			return nil
+		}
+
+		f := v.Elem().FieldByName("Element")
+		if f.IsValid() && f.Type() == elementType {
+			el, _ := f.Interface().(react.Element)
+			return r.render(el)
+		}
+	}
+
+	return fmt.Errorf("ssr: cannot render element of type %T", el)
+}
+
+func (r *renderer) renderChildren(children []react.Element) error {
+	for _, c := range children {
+		if err := r.render(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *renderer) renderHTML(el *core.HTMLElement) error {
+	attrs := el.Attrs
+
+	// the value of a <textarea> is its content, and that of a <select>
+	// determines which of its options is selected
+	var value *string
+
+	switch el.Tag {
+	case "textarea", "select":
+		attrs = nil
+		for _, a := range el.Attrs {
+			if a.Name == "value" {
+				v := a.Value
+				value = &v
+				continue
+			}
+			attrs = append(attrs, a)
+		}
+	case "option":
+		if r.selectValue != nil {
+			for _, a := range el.Attrs {
+				if a.Name == "value" && a.Value == *r.selectValue {
+					attrs = append(attrs[:len(attrs):len(attrs)], core.Attr{Name: "selected", Value: ""})
+					break
+				}
+			}
+		}
+	}
+
+	r.buf.WriteString("<" + el.Tag)
+	for _, a := range attrs {
+		fmt.Fprintf(&r.buf, " %v=\"%v\"", a.Name, html.EscapeString(a.Value))
+	}
+
+	if voidElements[el.Tag] {
+		r.buf.WriteString("/>")
+		return nil
+	}
+
+	r.buf.WriteString(">")
+
+	switch {
+	case el.InnerHTML != nil:
+		r.buf.WriteString(*el.InnerHTML)
+	case el.Tag == "textarea" && value != nil:
+		r.buf.WriteString(html.EscapeString(*value))
+	case el.Tag == "select":
+		prev := r.selectValue
+		r.selectValue = value
+
+		err := r.renderChildren(el.Children)
+
+		r.selectValue = prev
+
+		if err != nil {
+			return err
+		}
+	default:
+		if err := r.renderChildren(el.Children); err != nil {
+			return err
+		}
+	}
+
+	r.buf.WriteString("</" + el.Tag + ">")
+
+	return nil
+}
+
+func (r *renderer) renderComponent(el *core.ComponentElement) error {
+	cmp, ok := el.Build().(react.Component)
+	if !ok {
+		return fmt.Errorf("ssr: %v is not a component", el.Type)
+	}
+
+	el.State = nil
+	if cmp, ok := cmp.(componentWithGetInitialState); ok {
+		el.State = cmp.GetInitialStateIntf()
+	}
+
+	if cmp, ok := cmp.(componentWithWillMount); ok {
+		cmp.ComponentWillMount()
+	}
+
+	return r.render(cmp.RendersElement())
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+// +build !js
+
+package ssr_test
+
+import (
+	"bytes"
+	"testing"
+
+	"myitcv.io/react"
+	"myitcv.io/react/examples/hellomessage"
+	"myitcv.io/react/examples/todoapp"
+	"myitcv.io/react/ssr"
+)
+
+// CounterDef is a component with initial state that is changed by
+// ComponentWillMount. The definitions that would otherwise be generated by
+// reactGen are written by hand.
+type CounterDef struct {
+	react.ComponentDef
+}
+
+type CounterProps struct {
+	Start int
+}
+
+type CounterState struct {
+	count int
+}
+
+type CounterElem struct {
+	react.Element
+}
+
+func Counter(p CounterProps) *CounterElem {
+	return &CounterElem{
+		Element: react.CreateElement(buildCounter, p),
+	}
+}
+
+func buildCounter(cd react.ComponentDef) react.Component {
+	return CounterDef{ComponentDef: cd}
+}
+
+func (c CounterDef) GetInitialStateIntf() react.State {
+	return CounterState{count: c.ComponentDef.Props().(CounterProps).Start}
+}
+
+func (c CounterDef) ComponentWillMount() {
+	s := c.ComponentDef.State().(CounterState)
+	s.count++
+	c.SetState(s)
+}
+
+func (c CounterDef) RendersElement() react.Element {
+	return react.Span(nil, react.Sprintf("count %v", c.ComponentDef.State().(CounterState).count))
+}
+
+func (c CounterProps) IsProps() {}
+
+func (c CounterProps) EqualsIntf(v react.Props) bool {
+	return c == v.(CounterProps)
+}
+
+func (c CounterState) IsState() {}
+
+func (c CounterState) EqualsIntf(v react.State) bool {
+	return c == v.(CounterState)
+}
+
+func TestRender(t *testing.T) {
+	cases := []struct {
+		name string
+		el   react.Element
+		exp  string
+	}{
+		{
+			name: "string",
+			el:   react.S("a < b & c"),
+			exp:  "a &lt; b &amp; c",
+		},
+		{
+			name: "attributes",
+			el: react.A(&react.AProps{
+				Href:         "/x?a=1&b=2",
+				ClassName:    "link",
+				AriaExpanded: true,
+				DataSet:      react.DataSet{"toggle": "dropdown", "id": "1"},
+				Style:        &react.CSS{FontSize: "12px", Width: "10%"},
+				Key:          "key",
+				OnClick:      nil,
+			}, react.S("go")),
+			exp: `<a aria-expanded="true" class="link" data-id="1" data-toggle="dropdown" href="/x?a=1&amp;b=2" style="font-size:12px;width:10%">go</a>`,
+		},
+		{
+			name: "void",
+			el:   react.P(nil, react.S("a"), react.Br(nil), react.Img(&react.ImgProps{Src: "x.png"})),
+			exp:  `<p>a<br/><img src="x.png"/></p>`,
+		},
+		{
+			name: "fragment",
+			el:   react.Fragment(react.H1(nil, react.S("a")), react.Hr(nil)),
+			exp:  `<h1>a</h1><hr/>`,
+		},
+		{
+			name: "inner HTML",
+			el:   react.Div(&react.DivProps{DangerouslySetInnerHTML: react.NewDangerousInnerHTML("<b>x</b>")}),
+			exp:  `<div><b>x</b></div>`,
+		},
+		{
+			name: "textarea",
+			el:   react.TextArea(&react.TextAreaProps{Value: "<x>"}),
+			exp:  `<textarea>&lt;x&gt;</textarea>`,
+		},
+		{
+			name: "select",
+			el: react.Select(&react.SelectProps{Value: "b"},
+				react.Option(&react.OptionProps{Value: "a"}, react.S("A")),
+				react.Option(&react.OptionProps{Value: "b"}, react.S("B")),
+			),
+			exp: `<select><option value="a">A</option><option value="b" selected="">B</option></select>`,
+		},
+		{
+			name: "component",
+			el:   hellomessage.HelloMessage(hellomessage.HelloMessageProps{Name: "Paul"}, react.S("!")),
+			exp:  `<div>Hello Paul!</div>`,
+		},
+		{
+			name: "component with state",
+			el:   react.Div(nil, Counter(CounterProps{Start: 41})),
+			exp:  `<div><span>count 42</span></div>`,
+		},
+		{
+			name: "todo app",
+			el:   todoapp.TodoApp(),
+			exp: `<h3>TODO</h3><ul></ul><form class="form-inline"><div class="form-group">` +
+				`<label class="sr-only" for="todoText">Todo Item</label>` +
+				`<input class="form-control" id="todoText" placeholder="Todo Item" type="text"/>` +
+				`<button class="btn btn-default" type="submit">Add #1</button></div></form>`,
+		},
+	}
+
+	for _, c := range cases {
+		got, err := ssr.RenderToString(c.el)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", c.name, err)
+			continue
+		}
+
+		if got != c.exp {
+			t.Errorf("%v: expected:\n%v\ngot:\n%v", c.name, c.exp, got)
+		}
+	}
+}
+
+func TestRenderIdempotent(t *testing.T) {
+	el := Counter(CounterProps{Start: 1})
+
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+
+		if err := ssr.Render(&buf, el); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if exp, got := "<span>count 2</span>", buf.String(); got != exp {
+			t.Fatalf("expected render %v to be %q; got %q", i, exp, got)
+		}
+	}
+}
+
+func TestRenderUnknown(t *testing.T) {
+	// only pointers to wrapper types are unwrapped
+	el := struct{ react.Element }{react.S("x")}
+
+	if _, err := ssr.RenderToString(el); err == nil {
+		t.Fatalf("expected an error rendering %T", el)
+	}
+}
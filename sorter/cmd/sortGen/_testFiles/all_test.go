@@ -2,7 +2,10 @@ package main
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
+
+	"myitcv.io/sorter/cmd/sortGen/_testFiles/internal/other"
 )
 
 func TestSort(t *testing.T) {
@@ -38,3 +41,136 @@ func TestSort(t *testing.T) {
 	sortOtherMySlice(nil)
 	stableSortOtherMySlice(nil)
 }
+
+func TestImmutable(t *testing.T) {
+	m1 := NewMySlice("banana", "apple", "cherry")
+	p1 := NewMyPersistentSlice("banana", "apple", "cherry")
+	c1 := NewMyContainerSlice("banana", "apple", "cherry")
+
+	for _, c := range []struct {
+		name string
+		sort func() (orig, sorted []string, mutable bool)
+	}{
+		{"sortMySlice", func() ([]string, []string, bool) {
+			res := sortMySlice(m1)
+			return m1.Range(), res.Range(), res.Mutable()
+		}},
+		{"stableSortMyPersistentSlice", func() ([]string, []string, bool) {
+			res := stableSortMyPersistentSlice(p1)
+			return p1.Range(), res.Range(), res.Mutable()
+		}},
+		{"sortMyContainerSlice", func() ([]string, []string, bool) {
+			res := sortMyContainerSlice(c1)
+			return c1.Range(), res.Range(), res.Mutable()
+		}},
+	} {
+		orig, sorted, mutable := c.sort()
+
+		if exp := []string{"banana", "apple", "cherry"}; !reflect.DeepEqual(orig, exp) {
+			t.Errorf("expected %v to leave its argument unchanged; got %v", c.name, orig)
+		}
+
+		if exp := []string{"apple", "banana", "cherry"}; !reflect.DeepEqual(sorted, exp) {
+			t.Errorf("expected %v to return %v; got %v", c.name, exp, sorted)
+		}
+
+		if mutable {
+			t.Errorf("expected %v to return an immutable value", c.name)
+		}
+	}
+
+	if res := sortMyPersistentSlice(nil); res != nil {
+		t.Errorf("expected sortMyPersistentSlice(nil) to be nil; got %v", res)
+	}
+}
+
+func TestArray(t *testing.T) {
+	people := [3]person{
+		{"Sarah", 60},
+		{"Jill", 34},
+		{"Paul", 25},
+	}
+
+	sortArrayByName(&people)
+
+	if exp := [3]person{{"Jill", 34}, {"Paul", 25}, {"Sarah", 60}}; people != exp {
+		t.Fatalf("expected %v; got %v", exp, people)
+	}
+
+	stableSortArrayByAge(&people)
+
+	if exp := [3]person{{"Paul", 25}, {"Jill", 34}, {"Sarah", 60}}; people != exp {
+		t.Fatalf("expected %v; got %v", exp, people)
+	}
+
+	sortArrayPointerByName(&people)
+
+	if exp := [3]person{{"Jill", 34}, {"Paul", 25}, {"Sarah", 60}}; people != exp {
+		t.Fatalf("expected %v; got %v", exp, people)
+	}
+}
+
+func TestHelpers(t *testing.T) {
+	m1 := NewMySlice("banana", "apple", "cherry", "apple")
+	m2 := sortMySlice(m1)
+
+	if isSortedMySlice(m1) || !isSortedMySlice(m2) {
+		t.Fatalf("expected only %v to be sorted", m2)
+	}
+
+	if i, j := searchMySlice(m2, "apple"), searchUpperMySlice(m2, "apple"); i != 0 || j != 2 {
+		t.Fatalf("expected apple to be found at [0, 2); got [%v, %v)", i, j)
+	}
+
+	m3 := dedupeSortedMySlice(m2)
+	if m3.Mutable() || m3.Len() != 3 || m3.Get(0) != "apple" {
+		t.Fatalf("expected an immutable slice of 3 elements, starting with apple; got %v", m3)
+	}
+
+	m4 := mergeSortedMySlice(m3, NewMySlice("blueberry"))
+	if m4.Len() != 4 || m4.Get(1) != "banana" || m4.Get(2) != "blueberry" {
+		t.Fatalf("expected blueberry to be merged after banana; got %v", m4)
+	}
+
+	m5 := topKMySlice(m1, 2)
+	if m5.Len() != 2 || m5.Get(0) != "apple" || m5.Get(1) != "apple" {
+		t.Fatalf("expected the top 2 to be apple, apple; got %v", m5)
+	}
+
+	if m1.Get(0) != "banana" {
+		t.Fatalf("expected topKMySlice to leave its argument unchanged; got %v", m1)
+	}
+
+	searchOtherMySlice(nil, "")
+	topKByName(nil, 1)
+}
+
+func TestComposed(t *testing.T) {
+	people := []person{
+		{"Sarah", 60},
+		{"Jill", 34},
+		{"Paul", 34},
+	}
+
+	SortByAgeThenName(people)
+
+	if exp := []person{{"Jill", 34}, {"Paul", 34}, {"Sarah", 60}}; !reflect.DeepEqual(people, exp) {
+		t.Fatalf("expected %v; got %v", exp, people)
+	}
+
+	StableSortByAgeThenNameDesc(people)
+
+	if exp := []person{{"Sarah", 60}, {"Paul", 34}, {"Jill", 34}}; !reflect.DeepEqual(people, exp) {
+		t.Fatalf("expected %v; got %v", exp, people)
+	}
+
+	m := other.NewMySlice("apple", "cherry", "banana")
+
+	if got := sortReversedOtherMySlice(m); got.Get(0) != "cherry" || got.Get(2) != "apple" {
+		t.Fatalf("expected %v to be sorted in reverse", got)
+	}
+
+	if got := sortReversedOtherMySliceDesc(m); got.Get(0) != "apple" || got.Get(2) != "cherry" {
+		t.Fatalf("expected %v to be sorted", got)
+	}
+}
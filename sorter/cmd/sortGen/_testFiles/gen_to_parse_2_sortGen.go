@@ -2,32 +2,1851 @@
 
 package main
 
-import "sort"
-import "myitcv.io/sorter"
+import (
+	"math/bits"
+
+	"myitcv.io/sorter"
+)
 
 func SortByAge(vs []person) {
-	sort.Sort(&sorter.Wrapper{
-		LenFunc: func() int {
-			return len(vs)
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(OrderByAge(vs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			vs[i], vs[j] = vs[j], vs[i]
-		},
-	})
+	pdqsort_SortByAge(vs, 0, len(vs), bits.Len(uint(len(vs))))
 }
+
 func StableSortByAge(vs []person) {
-	sort.Stable(&sorter.Wrapper{
-		LenFunc: func() int {
-			return len(vs)
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(OrderByAge(vs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			vs[i], vs[j] = vs[j], vs[i]
-		},
-	})
+	stable_StableSortByAge(vs, len(vs))
+}
+
+// insertionSort_SortByAge sorts data[a:b] using insertion sort.
+func insertionSort_SortByAge(data []person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data[j].age < data[j-1].age); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// siftDown_SortByAge implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_SortByAge(data []person, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && (data[first+child].age < data[first+child+1].age) {
+			child++
+		}
+		if !(data[first+root].age < data[first+child].age) {
+			return
+		}
+		data[first+root], data[first+child] = data[first+child], data[first+root]
+		root = child
+	}
+}
+
+func heapSort_SortByAge(data []person, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_SortByAge(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i] = data[first+i], data[first]
+		siftDown_SortByAge(data, lo, i, first)
+	}
+}
+
+// pdqsort_SortByAge sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_SortByAge(data []person, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_SortByAge(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_SortByAge(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_SortByAge(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_SortByAge(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_SortByAge(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_SortByAge(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !(data[a-1].age < data[pivot].age) {
+			mid := partitionEqual_SortByAge(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_SortByAge(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_SortByAge(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_SortByAge(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_SortByAge does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_SortByAge(data []person, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && (data[i].age < data[a].age) {
+		i++
+	}
+	for i <= j && !(data[j].age < data[a].age) {
+		j--
+	}
+	if i > j {
+		data[j], data[a] = data[a], data[j]
+		return j, true
+	}
+	data[i], data[j] = data[j], data[i]
+	i++
+	j--
+
+	for {
+		for i <= j && (data[i].age < data[a].age) {
+			i++
+		}
+		for i <= j && !(data[j].age < data[a].age) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	data[j], data[a] = data[a], data[j]
+	return j, false
+}
+
+// partitionEqual_SortByAge partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_SortByAge(data []person, a, b, pivot int) (newpivot int) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !(data[a].age < data[i].age) {
+			i++
+		}
+		for i <= j && (data[a].age < data[j].age) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_SortByAge partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_SortByAge(data []person, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !(data[i].age < data[i-1].age) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		data[i], data[i-1] = data[i-1], data[i]
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !(data[j].age < data[j-1].age) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !(data[j].age < data[j-1].age) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_SortByAge scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_SortByAge(data []person, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			data[idx], data[a+other] = data[a+other], data[idx]
+		}
+	}
+}
+
+// choosePivot_SortByAge chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_SortByAge(data []person, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_SortByAge(data, i, &swaps)
+			j = medianAdjacent_SortByAge(data, j, &swaps)
+			k = medianAdjacent_SortByAge(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_SortByAge(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_SortByAge returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_SortByAge(data []person, a, b int, swaps *int) (int, int) {
+	if data[b].age < data[a].age {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_SortByAge returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_SortByAge(data []person, a, b, c int, swaps *int) int {
+	a, b = order2_SortByAge(data, a, b, swaps)
+	b, c = order2_SortByAge(data, b, c, swaps)
+	a, b = order2_SortByAge(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_SortByAge finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_SortByAge(data []person, a int, swaps *int) int {
+	return median_SortByAge(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_SortByAge(data []person, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+}
+
+// insertionSort_StableSortByAge sorts data[a:b] using insertion sort.
+func insertionSort_StableSortByAge(data []person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data[j].age < data[j-1].age); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// stable_StableSortByAge sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_StableSortByAge.
+func stable_StableSortByAge(data []person, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_StableSortByAge(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_StableSortByAge(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_StableSortByAge(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_StableSortByAge(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_StableSortByAge merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_StableSortByAge(data []person, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if data[h].age < data[a].age {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1] = data[k+1], data[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(data[m].age < data[h].age) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1] = data[k-1], data[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(data[p-c].age < data[c].age) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_StableSortByAge(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_StableSortByAge(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_StableSortByAge(data, mid, end, b)
+	}
+}
+
+// rotate_StableSortByAge rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_StableSortByAge(data []person, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_StableSortByAge(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_StableSortByAge(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_StableSortByAge(data, m-i, m, i)
+}
+
+func swapRange_StableSortByAge(data []person, a, b, n int) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i] = data[b+i], data[a+i]
+	}
+}
+
+// SearchByAge returns the smallest index i at which vs[i] is not less than x,
+// or the length of vs if there is no such index. vs must be sorted.
+func SearchByAge(vs []person, x person) int {
+	data := make([]person, 2)
+	data[1] = x
+
+	i, j := 0, len(vs)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data[0] = vs[h]
+		if data[0].age < data[1].age {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// SearchUpperByAge returns the smallest index i at which x is less than vs[i],
+// or the length of vs if there is no such index. vs must be sorted.
+func SearchUpperByAge(vs []person, x person) int {
+	data := make([]person, 2)
+	data[1] = x
+
+	i, j := 0, len(vs)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data[0] = vs[h]
+		if !(data[1].age < data[0].age) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// IsSortedByAge returns whether data is sorted.
+func IsSortedByAge(data []person) bool {
+	for i := len(data) - 1; i > 0; i-- {
+		if data[i].age < data[i-1].age {
+			return false
+		}
+	}
+	return true
+}
+
+// MergeSortedByAge returns the result of merging a and b, which must be sorted.
+// The merge is stable: of equal elements, those of a precede those of b.
+func MergeSortedByAge(a, b []person) []person {
+	data := make([]person, 2)
+	res := make([]person, 0, len(a)+len(b))
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		data[0] = b[j]
+		data[1] = a[i]
+		if data[0].age < data[1].age {
+			res = append(res, b[j])
+			j++
+		} else {
+			res = append(res, a[i])
+			i++
+		}
+	}
+	for ; i < len(a); i++ {
+		res = append(res, a[i])
+	}
+	for ; j < len(b); j++ {
+		res = append(res, b[j])
+	}
+
+	return res
+}
+
+// DedupeSortedByAge returns data with all but the first of each run of equal
+// elements removed. data must be sorted. The elements are moved within data, the
+// prefix of which is returned.
+func DedupeSortedByAge(data []person) []person {
+	if len(data) == 0 {
+		return data
+	}
+
+	k := 1
+	for i := 1; i < len(data); i++ {
+		if data[k-1].age < data[i].age {
+			data[k] = data[i]
+			k++
+		}
+	}
+
+	return data[:k]
+}
+
+// TopKByAge returns the k least elements of vs, in order, using a heap of
+// size k. The elements are moved within vs, the prefix of which is
+// returned.
+func TopKByAge(vs []person, k int) []person {
+	data := vs
+	n := len(data)
+
+	if k > n {
+		k = n
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	// a max heap of the least k elements seen so far
+	for i := (k - 1) / 2; i >= 0; i-- {
+		siftDown_SortByAge(data, i, k, 0)
+	}
+	for i := k; i < n; i++ {
+		if data[i].age < data[0].age {
+			data[0], data[i] = data[i], data[0]
+			siftDown_SortByAge(data, 0, k, 0)
+		}
+	}
+	heapSort_SortByAge(data, 0, k)
+
+	return data[:k]
+}
+func SortByAgeThenName(vs []person) {
+	pdqsort_SortByAgeThenName(vs, 0, len(vs), bits.Len(uint(len(vs))))
+}
+
+func StableSortByAgeThenName(vs []person) {
+	stable_StableSortByAgeThenName(vs, len(vs))
+}
+
+// insertionSort_SortByAgeThenName sorts data[a:b] using insertion sort.
+func insertionSort_SortByAgeThenName(data []person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && ((data[j].age < data[j-1].age) || !(data[j-1].age < data[j].age) && (data[j].name < data[j-1].name)); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// siftDown_SortByAgeThenName implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_SortByAgeThenName(data []person, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && ((data[first+child].age < data[first+child+1].age) || !(data[first+child+1].age < data[first+child].age) && (data[first+child].name < data[first+child+1].name)) {
+			child++
+		}
+		if !((data[first+root].age < data[first+child].age) || !(data[first+child].age < data[first+root].age) && (data[first+root].name < data[first+child].name)) {
+			return
+		}
+		data[first+root], data[first+child] = data[first+child], data[first+root]
+		root = child
+	}
+}
+
+func heapSort_SortByAgeThenName(data []person, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_SortByAgeThenName(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i] = data[first+i], data[first]
+		siftDown_SortByAgeThenName(data, lo, i, first)
+	}
+}
+
+// pdqsort_SortByAgeThenName sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_SortByAgeThenName(data []person, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_SortByAgeThenName(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_SortByAgeThenName(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_SortByAgeThenName(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_SortByAgeThenName(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_SortByAgeThenName(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_SortByAgeThenName(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !((data[a-1].age < data[pivot].age) || !(data[pivot].age < data[a-1].age) && (data[a-1].name < data[pivot].name)) {
+			mid := partitionEqual_SortByAgeThenName(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_SortByAgeThenName(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_SortByAgeThenName(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_SortByAgeThenName(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_SortByAgeThenName does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_SortByAgeThenName(data []person, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && ((data[i].age < data[a].age) || !(data[a].age < data[i].age) && (data[i].name < data[a].name)) {
+		i++
+	}
+	for i <= j && !((data[j].age < data[a].age) || !(data[a].age < data[j].age) && (data[j].name < data[a].name)) {
+		j--
+	}
+	if i > j {
+		data[j], data[a] = data[a], data[j]
+		return j, true
+	}
+	data[i], data[j] = data[j], data[i]
+	i++
+	j--
+
+	for {
+		for i <= j && ((data[i].age < data[a].age) || !(data[a].age < data[i].age) && (data[i].name < data[a].name)) {
+			i++
+		}
+		for i <= j && !((data[j].age < data[a].age) || !(data[a].age < data[j].age) && (data[j].name < data[a].name)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	data[j], data[a] = data[a], data[j]
+	return j, false
+}
+
+// partitionEqual_SortByAgeThenName partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_SortByAgeThenName(data []person, a, b, pivot int) (newpivot int) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !((data[a].age < data[i].age) || !(data[i].age < data[a].age) && (data[a].name < data[i].name)) {
+			i++
+		}
+		for i <= j && ((data[a].age < data[j].age) || !(data[j].age < data[a].age) && (data[a].name < data[j].name)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_SortByAgeThenName partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_SortByAgeThenName(data []person, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !((data[i].age < data[i-1].age) || !(data[i-1].age < data[i].age) && (data[i].name < data[i-1].name)) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		data[i], data[i-1] = data[i-1], data[i]
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !((data[j].age < data[j-1].age) || !(data[j-1].age < data[j].age) && (data[j].name < data[j-1].name)) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !((data[j].age < data[j-1].age) || !(data[j-1].age < data[j].age) && (data[j].name < data[j-1].name)) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_SortByAgeThenName scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_SortByAgeThenName(data []person, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			data[idx], data[a+other] = data[a+other], data[idx]
+		}
+	}
+}
+
+// choosePivot_SortByAgeThenName chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_SortByAgeThenName(data []person, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_SortByAgeThenName(data, i, &swaps)
+			j = medianAdjacent_SortByAgeThenName(data, j, &swaps)
+			k = medianAdjacent_SortByAgeThenName(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_SortByAgeThenName(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_SortByAgeThenName returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_SortByAgeThenName(data []person, a, b int, swaps *int) (int, int) {
+	if (data[b].age < data[a].age) || !(data[a].age < data[b].age) && (data[b].name < data[a].name) {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_SortByAgeThenName returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_SortByAgeThenName(data []person, a, b, c int, swaps *int) int {
+	a, b = order2_SortByAgeThenName(data, a, b, swaps)
+	b, c = order2_SortByAgeThenName(data, b, c, swaps)
+	a, b = order2_SortByAgeThenName(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_SortByAgeThenName finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_SortByAgeThenName(data []person, a int, swaps *int) int {
+	return median_SortByAgeThenName(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_SortByAgeThenName(data []person, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+}
+
+// insertionSort_StableSortByAgeThenName sorts data[a:b] using insertion sort.
+func insertionSort_StableSortByAgeThenName(data []person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && ((data[j].age < data[j-1].age) || !(data[j-1].age < data[j].age) && (data[j].name < data[j-1].name)); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// stable_StableSortByAgeThenName sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_StableSortByAgeThenName.
+func stable_StableSortByAgeThenName(data []person, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_StableSortByAgeThenName(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_StableSortByAgeThenName(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_StableSortByAgeThenName(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_StableSortByAgeThenName(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_StableSortByAgeThenName merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_StableSortByAgeThenName(data []person, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if (data[h].age < data[a].age) || !(data[a].age < data[h].age) && (data[h].name < data[a].name) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1] = data[k+1], data[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !((data[m].age < data[h].age) || !(data[h].age < data[m].age) && (data[m].name < data[h].name)) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1] = data[k-1], data[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !((data[p-c].age < data[c].age) || !(data[c].age < data[p-c].age) && (data[p-c].name < data[c].name)) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_StableSortByAgeThenName(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_StableSortByAgeThenName(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_StableSortByAgeThenName(data, mid, end, b)
+	}
+}
+
+// rotate_StableSortByAgeThenName rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_StableSortByAgeThenName(data []person, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_StableSortByAgeThenName(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_StableSortByAgeThenName(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_StableSortByAgeThenName(data, m-i, m, i)
+}
+
+func swapRange_StableSortByAgeThenName(data []person, a, b, n int) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i] = data[b+i], data[a+i]
+	}
+}
+
+// SearchByAgeThenName returns the smallest index i at which vs[i] is not less than x,
+// or the length of vs if there is no such index. vs must be sorted.
+func SearchByAgeThenName(vs []person, x person) int {
+	data := make([]person, 2)
+	data[1] = x
+
+	i, j := 0, len(vs)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data[0] = vs[h]
+		if (data[0].age < data[1].age) || !(data[1].age < data[0].age) && (data[0].name < data[1].name) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// SearchUpperByAgeThenName returns the smallest index i at which x is less than vs[i],
+// or the length of vs if there is no such index. vs must be sorted.
+func SearchUpperByAgeThenName(vs []person, x person) int {
+	data := make([]person, 2)
+	data[1] = x
+
+	i, j := 0, len(vs)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data[0] = vs[h]
+		if !((data[1].age < data[0].age) || !(data[0].age < data[1].age) && (data[1].name < data[0].name)) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// IsSortedByAgeThenName returns whether data is sorted.
+func IsSortedByAgeThenName(data []person) bool {
+	for i := len(data) - 1; i > 0; i-- {
+		if (data[i].age < data[i-1].age) || !(data[i-1].age < data[i].age) && (data[i].name < data[i-1].name) {
+			return false
+		}
+	}
+	return true
+}
+
+// MergeSortedByAgeThenName returns the result of merging a and b, which must be sorted.
+// The merge is stable: of equal elements, those of a precede those of b.
+func MergeSortedByAgeThenName(a, b []person) []person {
+	data := make([]person, 2)
+	res := make([]person, 0, len(a)+len(b))
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		data[0] = b[j]
+		data[1] = a[i]
+		if (data[0].age < data[1].age) || !(data[1].age < data[0].age) && (data[0].name < data[1].name) {
+			res = append(res, b[j])
+			j++
+		} else {
+			res = append(res, a[i])
+			i++
+		}
+	}
+	for ; i < len(a); i++ {
+		res = append(res, a[i])
+	}
+	for ; j < len(b); j++ {
+		res = append(res, b[j])
+	}
+
+	return res
+}
+
+// DedupeSortedByAgeThenName returns data with all but the first of each run of equal
+// elements removed. data must be sorted. The elements are moved within data, the
+// prefix of which is returned.
+func DedupeSortedByAgeThenName(data []person) []person {
+	if len(data) == 0 {
+		return data
+	}
+
+	k := 1
+	for i := 1; i < len(data); i++ {
+		if (data[k-1].age < data[i].age) || !(data[i].age < data[k-1].age) && (data[k-1].name < data[i].name) {
+			data[k] = data[i]
+			k++
+		}
+	}
+
+	return data[:k]
+}
+
+// TopKByAgeThenName returns the k least elements of vs, in order, using a heap of
+// size k. The elements are moved within vs, the prefix of which is
+// returned.
+func TopKByAgeThenName(vs []person, k int) []person {
+	data := vs
+	n := len(data)
+
+	if k > n {
+		k = n
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	// a max heap of the least k elements seen so far
+	for i := (k - 1) / 2; i >= 0; i-- {
+		siftDown_SortByAgeThenName(data, i, k, 0)
+	}
+	for i := k; i < n; i++ {
+		if (data[i].age < data[0].age) || !(data[0].age < data[i].age) && (data[i].name < data[0].name) {
+			data[0], data[i] = data[i], data[0]
+			siftDown_SortByAgeThenName(data, 0, k, 0)
+		}
+	}
+	heapSort_SortByAgeThenName(data, 0, k)
+
+	return data[:k]
+}
+func SortByAgeThenNameDesc(vs []person) {
+	pdqsort_SortByAgeThenNameDesc(vs, 0, len(vs), bits.Len(uint(len(vs))))
+}
+
+func StableSortByAgeThenNameDesc(vs []person) {
+	stable_StableSortByAgeThenNameDesc(vs, len(vs))
+}
+
+// insertionSort_SortByAgeThenNameDesc sorts data[a:b] using insertion sort.
+func insertionSort_SortByAgeThenNameDesc(data []person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && ((data[j-1].age < data[j].age) || !(data[j].age < data[j-1].age) && (data[j-1].name < data[j].name)); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// siftDown_SortByAgeThenNameDesc implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_SortByAgeThenNameDesc(data []person, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && ((data[first+child+1].age < data[first+child].age) || !(data[first+child].age < data[first+child+1].age) && (data[first+child+1].name < data[first+child].name)) {
+			child++
+		}
+		if !((data[first+child].age < data[first+root].age) || !(data[first+root].age < data[first+child].age) && (data[first+child].name < data[first+root].name)) {
+			return
+		}
+		data[first+root], data[first+child] = data[first+child], data[first+root]
+		root = child
+	}
+}
+
+func heapSort_SortByAgeThenNameDesc(data []person, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_SortByAgeThenNameDesc(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i] = data[first+i], data[first]
+		siftDown_SortByAgeThenNameDesc(data, lo, i, first)
+	}
+}
+
+// pdqsort_SortByAgeThenNameDesc sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_SortByAgeThenNameDesc(data []person, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_SortByAgeThenNameDesc(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_SortByAgeThenNameDesc(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_SortByAgeThenNameDesc(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_SortByAgeThenNameDesc(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_SortByAgeThenNameDesc(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_SortByAgeThenNameDesc(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !((data[pivot].age < data[a-1].age) || !(data[a-1].age < data[pivot].age) && (data[pivot].name < data[a-1].name)) {
+			mid := partitionEqual_SortByAgeThenNameDesc(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_SortByAgeThenNameDesc(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_SortByAgeThenNameDesc(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_SortByAgeThenNameDesc(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_SortByAgeThenNameDesc does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_SortByAgeThenNameDesc(data []person, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && ((data[a].age < data[i].age) || !(data[i].age < data[a].age) && (data[a].name < data[i].name)) {
+		i++
+	}
+	for i <= j && !((data[a].age < data[j].age) || !(data[j].age < data[a].age) && (data[a].name < data[j].name)) {
+		j--
+	}
+	if i > j {
+		data[j], data[a] = data[a], data[j]
+		return j, true
+	}
+	data[i], data[j] = data[j], data[i]
+	i++
+	j--
+
+	for {
+		for i <= j && ((data[a].age < data[i].age) || !(data[i].age < data[a].age) && (data[a].name < data[i].name)) {
+			i++
+		}
+		for i <= j && !((data[a].age < data[j].age) || !(data[j].age < data[a].age) && (data[a].name < data[j].name)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	data[j], data[a] = data[a], data[j]
+	return j, false
+}
+
+// partitionEqual_SortByAgeThenNameDesc partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_SortByAgeThenNameDesc(data []person, a, b, pivot int) (newpivot int) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !((data[i].age < data[a].age) || !(data[a].age < data[i].age) && (data[i].name < data[a].name)) {
+			i++
+		}
+		for i <= j && ((data[j].age < data[a].age) || !(data[a].age < data[j].age) && (data[j].name < data[a].name)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_SortByAgeThenNameDesc partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_SortByAgeThenNameDesc(data []person, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !((data[i-1].age < data[i].age) || !(data[i].age < data[i-1].age) && (data[i-1].name < data[i].name)) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		data[i], data[i-1] = data[i-1], data[i]
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !((data[j-1].age < data[j].age) || !(data[j].age < data[j-1].age) && (data[j-1].name < data[j].name)) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !((data[j-1].age < data[j].age) || !(data[j].age < data[j-1].age) && (data[j-1].name < data[j].name)) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_SortByAgeThenNameDesc scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_SortByAgeThenNameDesc(data []person, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			data[idx], data[a+other] = data[a+other], data[idx]
+		}
+	}
+}
+
+// choosePivot_SortByAgeThenNameDesc chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_SortByAgeThenNameDesc(data []person, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_SortByAgeThenNameDesc(data, i, &swaps)
+			j = medianAdjacent_SortByAgeThenNameDesc(data, j, &swaps)
+			k = medianAdjacent_SortByAgeThenNameDesc(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_SortByAgeThenNameDesc(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_SortByAgeThenNameDesc returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_SortByAgeThenNameDesc(data []person, a, b int, swaps *int) (int, int) {
+	if (data[a].age < data[b].age) || !(data[b].age < data[a].age) && (data[a].name < data[b].name) {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_SortByAgeThenNameDesc returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_SortByAgeThenNameDesc(data []person, a, b, c int, swaps *int) int {
+	a, b = order2_SortByAgeThenNameDesc(data, a, b, swaps)
+	b, c = order2_SortByAgeThenNameDesc(data, b, c, swaps)
+	a, b = order2_SortByAgeThenNameDesc(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_SortByAgeThenNameDesc finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_SortByAgeThenNameDesc(data []person, a int, swaps *int) int {
+	return median_SortByAgeThenNameDesc(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_SortByAgeThenNameDesc(data []person, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+}
+
+// insertionSort_StableSortByAgeThenNameDesc sorts data[a:b] using insertion sort.
+func insertionSort_StableSortByAgeThenNameDesc(data []person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && ((data[j-1].age < data[j].age) || !(data[j].age < data[j-1].age) && (data[j-1].name < data[j].name)); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// stable_StableSortByAgeThenNameDesc sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_StableSortByAgeThenNameDesc.
+func stable_StableSortByAgeThenNameDesc(data []person, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_StableSortByAgeThenNameDesc(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_StableSortByAgeThenNameDesc(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_StableSortByAgeThenNameDesc(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_StableSortByAgeThenNameDesc(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_StableSortByAgeThenNameDesc merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_StableSortByAgeThenNameDesc(data []person, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if (data[a].age < data[h].age) || !(data[h].age < data[a].age) && (data[a].name < data[h].name) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1] = data[k+1], data[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !((data[h].age < data[m].age) || !(data[m].age < data[h].age) && (data[h].name < data[m].name)) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1] = data[k-1], data[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !((data[c].age < data[p-c].age) || !(data[p-c].age < data[c].age) && (data[c].name < data[p-c].name)) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_StableSortByAgeThenNameDesc(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_StableSortByAgeThenNameDesc(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_StableSortByAgeThenNameDesc(data, mid, end, b)
+	}
+}
+
+// rotate_StableSortByAgeThenNameDesc rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_StableSortByAgeThenNameDesc(data []person, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_StableSortByAgeThenNameDesc(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_StableSortByAgeThenNameDesc(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_StableSortByAgeThenNameDesc(data, m-i, m, i)
+}
+
+func swapRange_StableSortByAgeThenNameDesc(data []person, a, b, n int) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i] = data[b+i], data[a+i]
+	}
+}
+
+// SearchByAgeThenNameDesc returns the smallest index i at which vs[i] is not less than x,
+// or the length of vs if there is no such index. vs must be sorted.
+func SearchByAgeThenNameDesc(vs []person, x person) int {
+	data := make([]person, 2)
+	data[1] = x
+
+	i, j := 0, len(vs)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data[0] = vs[h]
+		if (data[1].age < data[0].age) || !(data[0].age < data[1].age) && (data[1].name < data[0].name) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// SearchUpperByAgeThenNameDesc returns the smallest index i at which x is less than vs[i],
+// or the length of vs if there is no such index. vs must be sorted.
+func SearchUpperByAgeThenNameDesc(vs []person, x person) int {
+	data := make([]person, 2)
+	data[1] = x
+
+	i, j := 0, len(vs)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data[0] = vs[h]
+		if !((data[0].age < data[1].age) || !(data[1].age < data[0].age) && (data[0].name < data[1].name)) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// IsSortedByAgeThenNameDesc returns whether data is sorted.
+func IsSortedByAgeThenNameDesc(data []person) bool {
+	for i := len(data) - 1; i > 0; i-- {
+		if (data[i-1].age < data[i].age) || !(data[i].age < data[i-1].age) && (data[i-1].name < data[i].name) {
+			return false
+		}
+	}
+	return true
+}
+
+// MergeSortedByAgeThenNameDesc returns the result of merging a and b, which must be sorted.
+// The merge is stable: of equal elements, those of a precede those of b.
+func MergeSortedByAgeThenNameDesc(a, b []person) []person {
+	data := make([]person, 2)
+	res := make([]person, 0, len(a)+len(b))
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		data[0] = b[j]
+		data[1] = a[i]
+		if (data[1].age < data[0].age) || !(data[0].age < data[1].age) && (data[1].name < data[0].name) {
+			res = append(res, b[j])
+			j++
+		} else {
+			res = append(res, a[i])
+			i++
+		}
+	}
+	for ; i < len(a); i++ {
+		res = append(res, a[i])
+	}
+	for ; j < len(b); j++ {
+		res = append(res, b[j])
+	}
+
+	return res
+}
+
+// DedupeSortedByAgeThenNameDesc returns data with all but the first of each run of equal
+// elements removed. data must be sorted. The elements are moved within data, the
+// prefix of which is returned.
+func DedupeSortedByAgeThenNameDesc(data []person) []person {
+	if len(data) == 0 {
+		return data
+	}
+
+	k := 1
+	for i := 1; i < len(data); i++ {
+		if (data[i].age < data[k-1].age) || !(data[k-1].age < data[i].age) && (data[i].name < data[k-1].name) {
+			data[k] = data[i]
+			k++
+		}
+	}
+
+	return data[:k]
+}
+
+// TopKByAgeThenNameDesc returns the k least elements of vs, in order, using a heap of
+// size k. The elements are moved within vs, the prefix of which is
+// returned.
+func TopKByAgeThenNameDesc(vs []person, k int) []person {
+	data := vs
+	n := len(data)
+
+	if k > n {
+		k = n
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	// a max heap of the least k elements seen so far
+	for i := (k - 1) / 2; i >= 0; i-- {
+		siftDown_SortByAgeThenNameDesc(data, i, k, 0)
+	}
+	for i := k; i < n; i++ {
+		if (data[0].age < data[i].age) || !(data[i].age < data[0].age) && (data[0].name < data[i].name) {
+			data[0], data[i] = data[i], data[0]
+			siftDown_SortByAgeThenNameDesc(data, 0, k, 0)
+		}
+	}
+	heapSort_SortByAgeThenNameDesc(data, 0, k)
+
+	return data[:k]
 }
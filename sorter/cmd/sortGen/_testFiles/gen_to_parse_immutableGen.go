@@ -5,7 +5,11 @@ package main
 //immutableVet:skipFile
 
 import (
+	"encoding/json"
+
 	"myitcv.io/immutable"
+	"myitcv.io/immutable/container"
+	"myitcv.io/immutable/persistent"
 )
 
 //
@@ -155,3 +159,469 @@ func (s *MySlice) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
 	}
 	return true
 }
+
+// Equal returns whether m and other have the same length and equal values
+// at each index
+func (m *MySlice) Equal(other *MySlice) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	if len(m.theSlice) != len(other.theSlice) {
+		return false
+	}
+
+	for i, a := range m.theSlice {
+		b := other.theSlice[i]
+		if !(a == b) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the changes between m and other, in index order. The path
+// of a change is the index that was added, removed or modified, or the
+// index followed by the path of a change within a modified value.
+func (m *MySlice) Diff(other *MySlice) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a string, aok bool, b string, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	for i := 0; i < len(m.theSlice) || i < len(other.theSlice); i++ {
+		var a, b string
+		aok, bok := i < len(m.theSlice), i < len(other.theSlice)
+		if aok {
+			a = m.theSlice[i]
+		}
+		if bok {
+			b = other.theSlice[i]
+		}
+		if aok && bok && (a == b) {
+			continue
+		}
+		change(immutable.IndexPath(i), a, aok, b, bok)
+	}
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a []string
+func (m *MySlice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []string
+// into m. m is left immutable.
+func (m *MySlice) UnmarshalJSON(b []byte) error {
+	var v []string
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *NewMySlice(v...)
+
+	return nil
+}
+
+//immutableGen:persistent
+//
+// MyPersistentSlice is an immutable type and has the following template:
+//
+// 	[]string
+//
+type MyPersistentSlice struct {
+	theSlice *persistent.Vector[string]
+	mutable  bool
+	__tmpl   *_Imm_MyPersistentSlice
+}
+
+var _ immutable.Immutable = new(MyPersistentSlice)
+var _ = new(MyPersistentSlice).__tmpl
+
+func NewMyPersistentSlice(s ...string) *MyPersistentSlice {
+	return &MyPersistentSlice{
+		theSlice: persistent.NewVector(s...),
+	}
+}
+
+func NewMyPersistentSliceLen(l int) *MyPersistentSlice {
+	return &MyPersistentSlice{
+		theSlice: persistent.NewVector(make([]string, l)...),
+	}
+}
+
+func (m *MyPersistentSlice) Mutable() bool {
+	return m.mutable
+}
+
+func (m *MyPersistentSlice) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theSlice.Len()
+}
+
+func (m *MyPersistentSlice) Get(i int) string {
+	return m.theSlice.Get(i)
+}
+
+func (m *MyPersistentSlice) AsMutable() *MyPersistentSlice {
+	if m == nil {
+		return nil
+	}
+
+	if m.Mutable() {
+		return m
+	}
+
+	res := m.dup()
+	res.mutable = true
+
+	return res
+}
+
+func (m *MyPersistentSlice) dup() *MyPersistentSlice {
+	res := &MyPersistentSlice{
+		theSlice: m.theSlice,
+	}
+
+	return res
+}
+
+func (m *MyPersistentSlice) AsImmutable(v *MyPersistentSlice) *MyPersistentSlice {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.mutable = false
+	return m
+}
+
+func (m *MyPersistentSlice) Range() []string {
+	if m == nil {
+		return nil
+	}
+
+	return m.theSlice.ToSlice()
+}
+
+func (m *MyPersistentSlice) WithMutable(f func(mi *MyPersistentSlice)) *MyPersistentSlice {
+	res := m.AsMutable()
+	f(res)
+	res = res.AsImmutable(m)
+
+	return res
+}
+
+func (m *MyPersistentSlice) WithImmutable(f func(mi *MyPersistentSlice)) *MyPersistentSlice {
+	prev := m.mutable
+	m.mutable = false
+	f(m)
+	m.mutable = prev
+
+	return m
+}
+
+func (m *MyPersistentSlice) Set(i int, v string) *MyPersistentSlice {
+	if m.mutable {
+		m.theSlice = m.theSlice.Set(i, v)
+		return m
+	}
+
+	res := m.dup()
+	res.theSlice = res.theSlice.Set(i, v)
+
+	return res
+}
+
+func (m *MyPersistentSlice) Append(v ...string) *MyPersistentSlice {
+	if m.mutable {
+		m.theSlice = m.theSlice.Append(v...)
+		return m
+	}
+
+	res := m.dup()
+	res.theSlice = res.theSlice.Append(v...)
+
+	return res
+}
+func (s *MyPersistentSlice) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	return true
+}
+
+// Equal returns whether m and other have the same length and equal values
+// at each index
+func (m *MyPersistentSlice) Equal(other *MyPersistentSlice) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	return m.theSlice.Equal(other.theSlice, func(a, b string) bool {
+		return a == b
+	})
+}
+
+// Diff returns the changes between m and other, in index order. The path
+// of a change is the index that was added, removed or modified, or the
+// index followed by the path of a change within a modified value.
+func (m *MyPersistentSlice) Diff(other *MyPersistentSlice) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	var res []immutable.Change
+
+	change := func(p string, a string, aok bool, b string, bok bool) {
+		switch {
+		case !aok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeAdded, New: b})
+		case !bok:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeRemoved, Old: a})
+		default:
+			res = append(res, immutable.Change{Path: p, Kind: immutable.ChangeModified, Old: a, New: b})
+		}
+	}
+
+	m.theSlice.Diff(other.theSlice, func(a, b string) bool {
+		return a == b
+	}, func(i int, a string, aok bool, b string, bok bool) bool {
+		change(immutable.IndexPath(i), a, aok, b, bok)
+		return true
+	})
+
+	return res
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a []string
+func (m *MyPersistentSlice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Range())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []string
+// into m. m is left immutable.
+func (m *MyPersistentSlice) UnmarshalJSON(b []byte) error {
+	var v []string
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*m = *NewMyPersistentSlice(v...)
+
+	return nil
+}
+
+//immutableGen:container
+//
+// MyContainerSlice is an immutable type and has the following template:
+//
+// 	[]string
+//
+type MyContainerSlice struct {
+	theSlice *container.Slice[string]
+	__tmpl   *_Imm_MyContainerSlice
+}
+
+var _ immutable.Immutable = new(MyContainerSlice)
+var _ = new(MyContainerSlice).__tmpl
+
+func NewMyContainerSlice(s ...string) *MyContainerSlice {
+	return &MyContainerSlice{
+		theSlice: container.NewSlice(s...),
+	}
+}
+
+func NewMyContainerSliceLen(l int) *MyContainerSlice {
+	return &MyContainerSlice{
+		theSlice: container.NewSliceLen[string](l),
+	}
+}
+
+// wrap returns m if c is the container of m, else a new MyContainerSlice around c
+func (m *MyContainerSlice) wrap(c *container.Slice[string]) *MyContainerSlice {
+	if c == m.theSlice {
+		return m
+	}
+
+	return &MyContainerSlice{
+		theSlice: c,
+	}
+}
+
+func (m *MyContainerSlice) Mutable() bool {
+	return m.theSlice.Mutable()
+}
+
+func (m *MyContainerSlice) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.theSlice.Len()
+}
+
+func (m *MyContainerSlice) Get(i int) string {
+	return m.theSlice.Get(i)
+}
+
+func (m *MyContainerSlice) AsMutable() *MyContainerSlice {
+	if m == nil {
+		return nil
+	}
+
+	return m.wrap(m.theSlice.AsMutable())
+}
+
+func (m *MyContainerSlice) AsImmutable(v *MyContainerSlice) *MyContainerSlice {
+	if m == nil {
+		return nil
+	}
+
+	if v == m {
+		return m
+	}
+
+	m.theSlice.AsImmutable()
+	return m
+}
+
+func (m *MyContainerSlice) Range() []string {
+	if m == nil {
+		return nil
+	}
+
+	return m.theSlice.Range()
+}
+
+func (m *MyContainerSlice) WithMutable(f func(mi *MyContainerSlice)) *MyContainerSlice {
+	res := m.AsMutable()
+	f(res)
+	res = res.AsImmutable(m)
+
+	return res
+}
+
+func (m *MyContainerSlice) WithImmutable(f func(mi *MyContainerSlice)) *MyContainerSlice {
+	m.theSlice.WithImmutable(func() {
+		f(m)
+	})
+
+	return m
+}
+
+func (m *MyContainerSlice) Set(i int, v string) *MyContainerSlice {
+	return m.wrap(m.theSlice.Set(i, v))
+}
+
+func (m *MyContainerSlice) Append(v ...string) *MyContainerSlice {
+	return m.wrap(m.theSlice.Append(v...))
+}
+func (s *MyContainerSlice) IsDeeplyNonMutable(seen map[interface{}]bool) bool {
+	if s == nil {
+		return true
+	}
+
+	if s.Mutable() {
+		return false
+	}
+	return true
+}
+
+// Equal returns whether m and other have the same length and equal values
+// at each index
+func (m *MyContainerSlice) Equal(other *MyContainerSlice) bool {
+	if m == other {
+		return true
+	}
+
+	if m == nil || other == nil {
+		return false
+	}
+
+	return m.theSlice.Equal(other.theSlice, func(a, b string) bool {
+		return a == b
+	})
+}
+
+// Diff returns the changes between m and other, in index order. The path
+// of a change is the index that was added, removed or modified, or the
+// index followed by the path of a change within a modified value.
+func (m *MyContainerSlice) Diff(other *MyContainerSlice) []immutable.Change {
+	if m == other {
+		return nil
+	}
+
+	if m == nil || other == nil {
+		return []immutable.Change{{Kind: immutable.ChangeModified, Old: m, New: other}}
+	}
+
+	return m.theSlice.Changes(other.theSlice, func(a, b string) bool {
+		return a == b
+	}, nil)
+}
+
+// MarshalJSON implements json.Marshaler, marshalling m as a []string
+func (m *MyContainerSlice) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+
+	return m.theSlice.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshalling a []string
+// into m. m is left immutable.
+func (m *MyContainerSlice) UnmarshalJSON(b []byte) error {
+	c := new(container.Slice[string])
+	if err := c.UnmarshalJSON(b); err != nil {
+		return err
+	}
+
+	*m = MyContainerSlice{theSlice: c}
+
+	return nil
+}
@@ -2,223 +2,8385 @@
 
 package main
 
-import "sort"
-import "myitcv.io/sorter"
+import (
+	"bytes"
+	"math/bits"
 
-import "bytes"
-import "myitcv.io/sorter/cmd/sortGen/_testFiles/internal/other"
+	"myitcv.io/sorter"
+	"myitcv.io/sorter/cmd/sortGen/_testFiles/internal/other"
+)
 
 func sortByName(vs []person) {
-	sort.Sort(&sorter.Wrapper{
-		LenFunc: func() int {
-			return len(vs)
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(orderByName(vs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			vs[i], vs[j] = vs[j], vs[i]
-		},
-	})
+	pdqsort_sortByName(vs, 0, len(vs), bits.Len(uint(len(vs))))
 }
+
 func stableSortByName(vs []person) {
-	sort.Stable(&sorter.Wrapper{
-		LenFunc: func() int {
-			return len(vs)
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(orderByName(vs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			vs[i], vs[j] = vs[j], vs[i]
-		},
-	})
+	stable_stableSortByName(vs, len(vs))
+}
+
+// insertionSort_sortByName sorts data[a:b] using insertion sort.
+func insertionSort_sortByName(data []person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data[j].name < data[j-1].name); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// siftDown_sortByName implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_sortByName(data []person, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && (data[first+child].name < data[first+child+1].name) {
+			child++
+		}
+		if !(data[first+root].name < data[first+child].name) {
+			return
+		}
+		data[first+root], data[first+child] = data[first+child], data[first+root]
+		root = child
+	}
+}
+
+func heapSort_sortByName(data []person, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_sortByName(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i] = data[first+i], data[first]
+		siftDown_sortByName(data, lo, i, first)
+	}
+}
+
+// pdqsort_sortByName sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_sortByName(data []person, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_sortByName(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_sortByName(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_sortByName(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_sortByName(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_sortByName(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_sortByName(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !(data[a-1].name < data[pivot].name) {
+			mid := partitionEqual_sortByName(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_sortByName(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_sortByName(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_sortByName(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_sortByName does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_sortByName(data []person, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && (data[i].name < data[a].name) {
+		i++
+	}
+	for i <= j && !(data[j].name < data[a].name) {
+		j--
+	}
+	if i > j {
+		data[j], data[a] = data[a], data[j]
+		return j, true
+	}
+	data[i], data[j] = data[j], data[i]
+	i++
+	j--
+
+	for {
+		for i <= j && (data[i].name < data[a].name) {
+			i++
+		}
+		for i <= j && !(data[j].name < data[a].name) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	data[j], data[a] = data[a], data[j]
+	return j, false
+}
+
+// partitionEqual_sortByName partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_sortByName(data []person, a, b, pivot int) (newpivot int) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !(data[a].name < data[i].name) {
+			i++
+		}
+		for i <= j && (data[a].name < data[j].name) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_sortByName partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_sortByName(data []person, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !(data[i].name < data[i-1].name) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		data[i], data[i-1] = data[i-1], data[i]
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !(data[j].name < data[j-1].name) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !(data[j].name < data[j-1].name) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_sortByName scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_sortByName(data []person, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			data[idx], data[a+other] = data[a+other], data[idx]
+		}
+	}
+}
+
+// choosePivot_sortByName chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_sortByName(data []person, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_sortByName(data, i, &swaps)
+			j = medianAdjacent_sortByName(data, j, &swaps)
+			k = medianAdjacent_sortByName(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_sortByName(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_sortByName returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_sortByName(data []person, a, b int, swaps *int) (int, int) {
+	if data[b].name < data[a].name {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_sortByName returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_sortByName(data []person, a, b, c int, swaps *int) int {
+	a, b = order2_sortByName(data, a, b, swaps)
+	b, c = order2_sortByName(data, b, c, swaps)
+	a, b = order2_sortByName(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_sortByName finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_sortByName(data []person, a int, swaps *int) int {
+	return median_sortByName(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_sortByName(data []person, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+}
+
+// insertionSort_stableSortByName sorts data[a:b] using insertion sort.
+func insertionSort_stableSortByName(data []person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data[j].name < data[j-1].name); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// stable_stableSortByName sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_stableSortByName.
+func stable_stableSortByName(data []person, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_stableSortByName(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_stableSortByName(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_stableSortByName(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_stableSortByName(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_stableSortByName merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_stableSortByName(data []person, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if data[h].name < data[a].name {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1] = data[k+1], data[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(data[m].name < data[h].name) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1] = data[k-1], data[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(data[p-c].name < data[c].name) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_stableSortByName(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_stableSortByName(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_stableSortByName(data, mid, end, b)
+	}
+}
+
+// rotate_stableSortByName rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_stableSortByName(data []person, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_stableSortByName(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_stableSortByName(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_stableSortByName(data, m-i, m, i)
+}
+
+func swapRange_stableSortByName(data []person, a, b, n int) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i] = data[b+i], data[a+i]
+	}
+}
+
+// searchByName returns the smallest index i at which vs[i] is not less than x,
+// or the length of vs if there is no such index. vs must be sorted.
+func searchByName(vs []person, x person) int {
+	data := make([]person, 2)
+	data[1] = x
+
+	i, j := 0, len(vs)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data[0] = vs[h]
+		if data[0].name < data[1].name {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// searchUpperByName returns the smallest index i at which x is less than vs[i],
+// or the length of vs if there is no such index. vs must be sorted.
+func searchUpperByName(vs []person, x person) int {
+	data := make([]person, 2)
+	data[1] = x
+
+	i, j := 0, len(vs)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data[0] = vs[h]
+		if !(data[1].name < data[0].name) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// isSortedByName returns whether data is sorted.
+func isSortedByName(data []person) bool {
+	for i := len(data) - 1; i > 0; i-- {
+		if data[i].name < data[i-1].name {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeSortedByName returns the result of merging a and b, which must be sorted.
+// The merge is stable: of equal elements, those of a precede those of b.
+func mergeSortedByName(a, b []person) []person {
+	data := make([]person, 2)
+	res := make([]person, 0, len(a)+len(b))
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		data[0] = b[j]
+		data[1] = a[i]
+		if data[0].name < data[1].name {
+			res = append(res, b[j])
+			j++
+		} else {
+			res = append(res, a[i])
+			i++
+		}
+	}
+	for ; i < len(a); i++ {
+		res = append(res, a[i])
+	}
+	for ; j < len(b); j++ {
+		res = append(res, b[j])
+	}
+
+	return res
+}
+
+// dedupeSortedByName returns data with all but the first of each run of equal
+// elements removed. data must be sorted. The elements are moved within data, the
+// prefix of which is returned.
+func dedupeSortedByName(data []person) []person {
+	if len(data) == 0 {
+		return data
+	}
+
+	k := 1
+	for i := 1; i < len(data); i++ {
+		if data[k-1].name < data[i].name {
+			data[k] = data[i]
+			k++
+		}
+	}
+
+	return data[:k]
+}
+
+// topKByName returns the k least elements of vs, in order, using a heap of
+// size k. The elements are moved within vs, the prefix of which is
+// returned.
+func topKByName(vs []person, k int) []person {
+	data := vs
+	n := len(data)
+
+	if k > n {
+		k = n
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	// a max heap of the least k elements seen so far
+	for i := (k - 1) / 2; i >= 0; i-- {
+		siftDown_sortByName(data, i, k, 0)
+	}
+	for i := k; i < n; i++ {
+		if data[i].name < data[0].name {
+			data[0], data[i] = data[i], data[0]
+			siftDown_sortByName(data, 0, k, 0)
+		}
+	}
+	heapSort_sortByName(data, 0, k)
+
+	return data[:k]
 }
 func sortMySlice(vs *MySlice) *MySlice {
 	theVs := vs.AsMutable()
-
-	sort.Sort(&sorter.Wrapper{
-		LenFunc: func() int {
-			return theVs.Len()
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(orderMySlice(theVs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			jPrev := theVs.Get(j)
-			iPrev := theVs.Get(i)
-
-			theVs.Set(j, iPrev)
-			theVs.Set(i, jPrev)
-		},
-	})
+	pdqsort_sortMySlice(theVs, 0, theVs.Len(), bits.Len(uint(theVs.Len())))
 
 	return theVs.AsImmutable(vs)
 }
+
 func stableSortMySlice(vs *MySlice) *MySlice {
 	theVs := vs.AsMutable()
-
-	sort.Stable(&sorter.Wrapper{
-		LenFunc: func() int {
-			return theVs.Len()
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(orderMySlice(theVs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			jPrev := theVs.Get(j)
-			iPrev := theVs.Get(i)
-
-			theVs.Set(j, iPrev)
-			theVs.Set(i, jPrev)
-		},
-	})
+	stable_stableSortMySlice(theVs, theVs.Len())
 
 	return theVs.AsImmutable(vs)
 }
-func sortOtherMySlice(vs *other.MySlice) *other.MySlice {
-	theVs := vs.AsMutable()
 
-	sort.Sort(&sorter.Wrapper{
-		LenFunc: func() int {
-			return theVs.Len()
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(orderOtherMySlice(theVs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			jPrev := theVs.Get(j)
-			iPrev := theVs.Get(i)
-
-			theVs.Set(j, iPrev)
-			theVs.Set(i, jPrev)
-		},
-	})
+// swap_sortMySlice swaps the elements i and j of data, which must be mutable.
+func swap_sortMySlice(data *MySlice, i, j int) {
+	vi, vj := data.Get(i), data.Get(j)
+	data.Set(i, vj)
+	data.Set(j, vi)
+}
 
-	return theVs.AsImmutable(vs)
+// insertionSort_sortMySlice sorts data[a:b] using insertion sort.
+func insertionSort_sortMySlice(data *MySlice, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data.Get(j) < data.Get(j-1)); j-- {
+			swap_sortMySlice(data, j, j-1)
+		}
+	}
 }
-func stableSortOtherMySlice(vs *other.MySlice) *other.MySlice {
+
+// siftDown_sortMySlice implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_sortMySlice(data *MySlice, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && (data.Get(first+child) < data.Get(first+child+1)) {
+			child++
+		}
+		if !(data.Get(first+root) < data.Get(first+child)) {
+			return
+		}
+		swap_sortMySlice(data, first+root, first+child)
+		root = child
+	}
+}
+
+func heapSort_sortMySlice(data *MySlice, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_sortMySlice(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		swap_sortMySlice(data, first, first+i)
+		siftDown_sortMySlice(data, lo, i, first)
+	}
+}
+
+// pdqsort_sortMySlice sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_sortMySlice(data *MySlice, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_sortMySlice(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_sortMySlice(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_sortMySlice(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_sortMySlice(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_sortMySlice(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_sortMySlice(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !(data.Get(a-1) < data.Get(pivot)) {
+			mid := partitionEqual_sortMySlice(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_sortMySlice(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_sortMySlice(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_sortMySlice(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_sortMySlice does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_sortMySlice(data *MySlice, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	swap_sortMySlice(data, a, pivot)
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && (data.Get(i) < data.Get(a)) {
+		i++
+	}
+	for i <= j && !(data.Get(j) < data.Get(a)) {
+		j--
+	}
+	if i > j {
+		swap_sortMySlice(data, j, a)
+		return j, true
+	}
+	swap_sortMySlice(data, i, j)
+	i++
+	j--
+
+	for {
+		for i <= j && (data.Get(i) < data.Get(a)) {
+			i++
+		}
+		for i <= j && !(data.Get(j) < data.Get(a)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		swap_sortMySlice(data, i, j)
+		i++
+		j--
+	}
+	swap_sortMySlice(data, j, a)
+	return j, false
+}
+
+// partitionEqual_sortMySlice partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_sortMySlice(data *MySlice, a, b, pivot int) (newpivot int) {
+	swap_sortMySlice(data, a, pivot)
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !(data.Get(a) < data.Get(i)) {
+			i++
+		}
+		for i <= j && (data.Get(a) < data.Get(j)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		swap_sortMySlice(data, i, j)
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_sortMySlice partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_sortMySlice(data *MySlice, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !(data.Get(i) < data.Get(i-1)) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		swap_sortMySlice(data, i, i-1)
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !(data.Get(j) < data.Get(j-1)) {
+					break
+				}
+				swap_sortMySlice(data, j, j-1)
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !(data.Get(j) < data.Get(j-1)) {
+					break
+				}
+				swap_sortMySlice(data, j, j-1)
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_sortMySlice scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_sortMySlice(data *MySlice, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			swap_sortMySlice(data, idx, a+other)
+		}
+	}
+}
+
+// choosePivot_sortMySlice chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_sortMySlice(data *MySlice, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_sortMySlice(data, i, &swaps)
+			j = medianAdjacent_sortMySlice(data, j, &swaps)
+			k = medianAdjacent_sortMySlice(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_sortMySlice(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_sortMySlice returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_sortMySlice(data *MySlice, a, b int, swaps *int) (int, int) {
+	if data.Get(b) < data.Get(a) {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_sortMySlice returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_sortMySlice(data *MySlice, a, b, c int, swaps *int) int {
+	a, b = order2_sortMySlice(data, a, b, swaps)
+	b, c = order2_sortMySlice(data, b, c, swaps)
+	a, b = order2_sortMySlice(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_sortMySlice finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_sortMySlice(data *MySlice, a int, swaps *int) int {
+	return median_sortMySlice(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_sortMySlice(data *MySlice, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		swap_sortMySlice(data, i, j)
+		i++
+		j--
+	}
+}
+
+// swap_stableSortMySlice swaps the elements i and j of data, which must be mutable.
+func swap_stableSortMySlice(data *MySlice, i, j int) {
+	vi, vj := data.Get(i), data.Get(j)
+	data.Set(i, vj)
+	data.Set(j, vi)
+}
+
+// insertionSort_stableSortMySlice sorts data[a:b] using insertion sort.
+func insertionSort_stableSortMySlice(data *MySlice, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data.Get(j) < data.Get(j-1)); j-- {
+			swap_stableSortMySlice(data, j, j-1)
+		}
+	}
+}
+
+// stable_stableSortMySlice sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_stableSortMySlice.
+func stable_stableSortMySlice(data *MySlice, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_stableSortMySlice(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_stableSortMySlice(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_stableSortMySlice(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_stableSortMySlice(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_stableSortMySlice merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_stableSortMySlice(data *MySlice, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if data.Get(h) < data.Get(a) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			swap_stableSortMySlice(data, k, k+1)
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(data.Get(m) < data.Get(h)) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			swap_stableSortMySlice(data, k, k-1)
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(data.Get(p-c) < data.Get(c)) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_stableSortMySlice(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_stableSortMySlice(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_stableSortMySlice(data, mid, end, b)
+	}
+}
+
+// rotate_stableSortMySlice rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_stableSortMySlice(data *MySlice, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_stableSortMySlice(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_stableSortMySlice(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_stableSortMySlice(data, m-i, m, i)
+}
+
+func swapRange_stableSortMySlice(data *MySlice, a, b, n int) {
+	for i := 0; i < n; i++ {
+		swap_stableSortMySlice(data, a+i, b+i)
+	}
+}
+
+// searchMySlice returns the smallest index i at which vs[i] is not less than x,
+// or the length of vs if there is no such index. vs must be sorted.
+func searchMySlice(vs *MySlice, x string) int {
+	data := NewMySliceLen(2).AsMutable()
+	data.Set(1, x)
+
+	i, j := 0, vs.Len()
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data.Set(0, vs.Get(h))
+		if data.Get(0) < data.Get(1) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// searchUpperMySlice returns the smallest index i at which x is less than vs[i],
+// or the length of vs if there is no such index. vs must be sorted.
+func searchUpperMySlice(vs *MySlice, x string) int {
+	data := NewMySliceLen(2).AsMutable()
+	data.Set(1, x)
+
+	i, j := 0, vs.Len()
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data.Set(0, vs.Get(h))
+		if !(data.Get(1) < data.Get(0)) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// isSortedMySlice returns whether data is sorted.
+func isSortedMySlice(data *MySlice) bool {
+	for i := data.Len() - 1; i > 0; i-- {
+		if data.Get(i) < data.Get(i-1) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeSortedMySlice returns the result of merging a and b, which must be sorted.
+// The merge is stable: of equal elements, those of a precede those of b.
+func mergeSortedMySlice(a, b *MySlice) *MySlice {
+	data := NewMySliceLen(2).AsMutable()
+	res := NewMySliceLen(0).AsMutable()
+
+	i, j := 0, 0
+	for i < a.Len() && j < b.Len() {
+		data.Set(0, b.Get(j))
+		data.Set(1, a.Get(i))
+		if data.Get(0) < data.Get(1) {
+			res = res.Append(b.Get(j))
+			j++
+		} else {
+			res = res.Append(a.Get(i))
+			i++
+		}
+	}
+	for ; i < a.Len(); i++ {
+		res = res.Append(a.Get(i))
+	}
+	for ; j < b.Len(); j++ {
+		res = res.Append(b.Get(j))
+	}
+
+	return res.AsImmutable(nil)
+}
+
+// dedupeSortedMySlice returns data with all but the first of each run of equal
+// elements removed. data must be sorted.
+func dedupeSortedMySlice(data *MySlice) *MySlice {
+	if data.Len() == 0 {
+		return data
+	}
+
+	res := NewMySliceLen(0).AsMutable().Append(data.Get(0))
+
+	last := 0
+	for i := 1; i < data.Len(); i++ {
+		if data.Get(last) < data.Get(i) {
+			res.Append(data.Get(i))
+			last = i
+		}
+	}
+
+	return res.AsImmutable(nil)
+}
+
+// topKMySlice returns the k least elements of vs, in order, using a heap of
+// size k.
+func topKMySlice(vs *MySlice, k int) *MySlice {
+	data := vs.AsMutable()
+	n := data.Len()
+
+	if k > n {
+		k = n
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	// a max heap of the least k elements seen so far
+	for i := (k - 1) / 2; i >= 0; i-- {
+		siftDown_sortMySlice(data, i, k, 0)
+	}
+	for i := k; i < n; i++ {
+		if data.Get(i) < data.Get(0) {
+			swap_sortMySlice(data, 0, i)
+			siftDown_sortMySlice(data, 0, k, 0)
+		}
+	}
+	heapSort_sortMySlice(data, 0, k)
+
+	res := NewMySliceLen(0).AsMutable()
+	for i := 0; i < k; i++ {
+		res.Append(data.Get(i))
+	}
+
+	return res.AsImmutable(nil)
+}
+func sortMyPersistentSlice(vs *MyPersistentSlice) *MyPersistentSlice {
 	theVs := vs.AsMutable()
+	pdqsort_sortMyPersistentSlice(theVs, 0, theVs.Len(), bits.Len(uint(theVs.Len())))
+
+	return theVs.AsImmutable(vs)
+}
 
-	sort.Stable(&sorter.Wrapper{
-		LenFunc: func() int {
-			return theVs.Len()
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(orderOtherMySlice(theVs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			jPrev := theVs.Get(j)
-			iPrev := theVs.Get(i)
-
-			theVs.Set(j, iPrev)
-			theVs.Set(i, jPrev)
-		},
-	})
+func stableSortMyPersistentSlice(vs *MyPersistentSlice) *MyPersistentSlice {
+	theVs := vs.AsMutable()
+	stable_stableSortMyPersistentSlice(theVs, theVs.Len())
 
 	return theVs.AsImmutable(vs)
 }
-func sortPointerByName(vs []*person) {
-	sort.Sort(&sorter.Wrapper{
-		LenFunc: func() int {
-			return len(vs)
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(orderPointerByName(vs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			vs[i], vs[j] = vs[j], vs[i]
-		},
-	})
+
+// swap_sortMyPersistentSlice swaps the elements i and j of data, which must be mutable.
+func swap_sortMyPersistentSlice(data *MyPersistentSlice, i, j int) {
+	vi, vj := data.Get(i), data.Get(j)
+	data.Set(i, vj)
+	data.Set(j, vi)
 }
-func stableSortPointerByName(vs []*person) {
-	sort.Stable(&sorter.Wrapper{
-		LenFunc: func() int {
-			return len(vs)
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(orderPointerByName(vs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			vs[i], vs[j] = vs[j], vs[i]
-		},
-	})
+
+// insertionSort_sortMyPersistentSlice sorts data[a:b] using insertion sort.
+func insertionSort_sortMyPersistentSlice(data *MyPersistentSlice, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data.Get(j) < data.Get(j-1)); j-- {
+			swap_sortMyPersistentSlice(data, j, j-1)
+		}
+	}
 }
-func sortBufferByContents(vs []bytes.Buffer) {
-	sort.Sort(&sorter.Wrapper{
-		LenFunc: func() int {
-			return len(vs)
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(orderBufferByContents(vs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			vs[i], vs[j] = vs[j], vs[i]
-		},
-	})
+
+// siftDown_sortMyPersistentSlice implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_sortMyPersistentSlice(data *MyPersistentSlice, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && (data.Get(first+child) < data.Get(first+child+1)) {
+			child++
+		}
+		if !(data.Get(first+root) < data.Get(first+child)) {
+			return
+		}
+		swap_sortMyPersistentSlice(data, first+root, first+child)
+		root = child
+	}
 }
-func stableSortBufferByContents(vs []bytes.Buffer) {
-	sort.Stable(&sorter.Wrapper{
-		LenFunc: func() int {
-			return len(vs)
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(orderBufferByContents(vs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			vs[i], vs[j] = vs[j], vs[i]
-		},
-	})
+
+func heapSort_sortMyPersistentSlice(data *MyPersistentSlice, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_sortMyPersistentSlice(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		swap_sortMyPersistentSlice(data, first, first+i)
+		siftDown_sortMyPersistentSlice(data, lo, i, first)
+	}
 }
-func sortMap(vs []map[string]bool) {
-	sort.Sort(&sorter.Wrapper{
-		LenFunc: func() int {
-			return len(vs)
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(orderMap(vs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			vs[i], vs[j] = vs[j], vs[i]
-		},
-	})
+
+// pdqsort_sortMyPersistentSlice sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_sortMyPersistentSlice(data *MyPersistentSlice, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_sortMyPersistentSlice(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_sortMyPersistentSlice(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_sortMyPersistentSlice(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_sortMyPersistentSlice(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_sortMyPersistentSlice(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_sortMyPersistentSlice(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !(data.Get(a-1) < data.Get(pivot)) {
+			mid := partitionEqual_sortMyPersistentSlice(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_sortMyPersistentSlice(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_sortMyPersistentSlice(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_sortMyPersistentSlice(data, mid+1, b, limit)
+			b = mid
+		}
+	}
 }
-func stableSortMap(vs []map[string]bool) {
-	sort.Stable(&sorter.Wrapper{
-		LenFunc: func() int {
-			return len(vs)
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(orderMap(vs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			vs[i], vs[j] = vs[j], vs[i]
-		},
-	})
+
+// partition_sortMyPersistentSlice does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_sortMyPersistentSlice(data *MyPersistentSlice, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	swap_sortMyPersistentSlice(data, a, pivot)
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && (data.Get(i) < data.Get(a)) {
+		i++
+	}
+	for i <= j && !(data.Get(j) < data.Get(a)) {
+		j--
+	}
+	if i > j {
+		swap_sortMyPersistentSlice(data, j, a)
+		return j, true
+	}
+	swap_sortMyPersistentSlice(data, i, j)
+	i++
+	j--
+
+	for {
+		for i <= j && (data.Get(i) < data.Get(a)) {
+			i++
+		}
+		for i <= j && !(data.Get(j) < data.Get(a)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		swap_sortMyPersistentSlice(data, i, j)
+		i++
+		j--
+	}
+	swap_sortMyPersistentSlice(data, j, a)
+	return j, false
 }
-func (e *example) sortBanana(vs []string) {
-	sort.Sort(&sorter.Wrapper{
-		LenFunc: func() int {
-			return len(vs)
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(e.orderBanana(vs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			vs[i], vs[j] = vs[j], vs[i]
-		},
-	})
+
+// partitionEqual_sortMyPersistentSlice partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_sortMyPersistentSlice(data *MyPersistentSlice, a, b, pivot int) (newpivot int) {
+	swap_sortMyPersistentSlice(data, a, pivot)
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !(data.Get(a) < data.Get(i)) {
+			i++
+		}
+		for i <= j && (data.Get(a) < data.Get(j)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		swap_sortMyPersistentSlice(data, i, j)
+		i++
+		j--
+	}
+	return i
 }
-func (e *example) stableSortBanana(vs []string) {
-	sort.Stable(&sorter.Wrapper{
-		LenFunc: func() int {
-			return len(vs)
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(e.orderBanana(vs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			vs[i], vs[j] = vs[j], vs[i]
-		},
-	})
+
+// partialInsertionSort_sortMyPersistentSlice partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_sortMyPersistentSlice(data *MyPersistentSlice, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !(data.Get(i) < data.Get(i-1)) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		swap_sortMyPersistentSlice(data, i, i-1)
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !(data.Get(j) < data.Get(j-1)) {
+					break
+				}
+				swap_sortMyPersistentSlice(data, j, j-1)
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !(data.Get(j) < data.Get(j-1)) {
+					break
+				}
+				swap_sortMyPersistentSlice(data, j, j-1)
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_sortMyPersistentSlice scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_sortMyPersistentSlice(data *MyPersistentSlice, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			swap_sortMyPersistentSlice(data, idx, a+other)
+		}
+	}
+}
+
+// choosePivot_sortMyPersistentSlice chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_sortMyPersistentSlice(data *MyPersistentSlice, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_sortMyPersistentSlice(data, i, &swaps)
+			j = medianAdjacent_sortMyPersistentSlice(data, j, &swaps)
+			k = medianAdjacent_sortMyPersistentSlice(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_sortMyPersistentSlice(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_sortMyPersistentSlice returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_sortMyPersistentSlice(data *MyPersistentSlice, a, b int, swaps *int) (int, int) {
+	if data.Get(b) < data.Get(a) {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_sortMyPersistentSlice returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_sortMyPersistentSlice(data *MyPersistentSlice, a, b, c int, swaps *int) int {
+	a, b = order2_sortMyPersistentSlice(data, a, b, swaps)
+	b, c = order2_sortMyPersistentSlice(data, b, c, swaps)
+	a, b = order2_sortMyPersistentSlice(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_sortMyPersistentSlice finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_sortMyPersistentSlice(data *MyPersistentSlice, a int, swaps *int) int {
+	return median_sortMyPersistentSlice(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_sortMyPersistentSlice(data *MyPersistentSlice, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		swap_sortMyPersistentSlice(data, i, j)
+		i++
+		j--
+	}
+}
+
+// swap_stableSortMyPersistentSlice swaps the elements i and j of data, which must be mutable.
+func swap_stableSortMyPersistentSlice(data *MyPersistentSlice, i, j int) {
+	vi, vj := data.Get(i), data.Get(j)
+	data.Set(i, vj)
+	data.Set(j, vi)
+}
+
+// insertionSort_stableSortMyPersistentSlice sorts data[a:b] using insertion sort.
+func insertionSort_stableSortMyPersistentSlice(data *MyPersistentSlice, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data.Get(j) < data.Get(j-1)); j-- {
+			swap_stableSortMyPersistentSlice(data, j, j-1)
+		}
+	}
+}
+
+// stable_stableSortMyPersistentSlice sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_stableSortMyPersistentSlice.
+func stable_stableSortMyPersistentSlice(data *MyPersistentSlice, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_stableSortMyPersistentSlice(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_stableSortMyPersistentSlice(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_stableSortMyPersistentSlice(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_stableSortMyPersistentSlice(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_stableSortMyPersistentSlice merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_stableSortMyPersistentSlice(data *MyPersistentSlice, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if data.Get(h) < data.Get(a) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			swap_stableSortMyPersistentSlice(data, k, k+1)
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(data.Get(m) < data.Get(h)) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			swap_stableSortMyPersistentSlice(data, k, k-1)
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(data.Get(p-c) < data.Get(c)) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_stableSortMyPersistentSlice(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_stableSortMyPersistentSlice(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_stableSortMyPersistentSlice(data, mid, end, b)
+	}
+}
+
+// rotate_stableSortMyPersistentSlice rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_stableSortMyPersistentSlice(data *MyPersistentSlice, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_stableSortMyPersistentSlice(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_stableSortMyPersistentSlice(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_stableSortMyPersistentSlice(data, m-i, m, i)
+}
+
+func swapRange_stableSortMyPersistentSlice(data *MyPersistentSlice, a, b, n int) {
+	for i := 0; i < n; i++ {
+		swap_stableSortMyPersistentSlice(data, a+i, b+i)
+	}
+}
+
+// searchMyPersistentSlice returns the smallest index i at which vs[i] is not less than x,
+// or the length of vs if there is no such index. vs must be sorted.
+func searchMyPersistentSlice(vs *MyPersistentSlice, x string) int {
+	data := NewMyPersistentSliceLen(2).AsMutable()
+	data.Set(1, x)
+
+	i, j := 0, vs.Len()
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data.Set(0, vs.Get(h))
+		if data.Get(0) < data.Get(1) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// searchUpperMyPersistentSlice returns the smallest index i at which x is less than vs[i],
+// or the length of vs if there is no such index. vs must be sorted.
+func searchUpperMyPersistentSlice(vs *MyPersistentSlice, x string) int {
+	data := NewMyPersistentSliceLen(2).AsMutable()
+	data.Set(1, x)
+
+	i, j := 0, vs.Len()
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data.Set(0, vs.Get(h))
+		if !(data.Get(1) < data.Get(0)) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// isSortedMyPersistentSlice returns whether data is sorted.
+func isSortedMyPersistentSlice(data *MyPersistentSlice) bool {
+	for i := data.Len() - 1; i > 0; i-- {
+		if data.Get(i) < data.Get(i-1) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeSortedMyPersistentSlice returns the result of merging a and b, which must be sorted.
+// The merge is stable: of equal elements, those of a precede those of b.
+func mergeSortedMyPersistentSlice(a, b *MyPersistentSlice) *MyPersistentSlice {
+	data := NewMyPersistentSliceLen(2).AsMutable()
+	res := NewMyPersistentSliceLen(0).AsMutable()
+
+	i, j := 0, 0
+	for i < a.Len() && j < b.Len() {
+		data.Set(0, b.Get(j))
+		data.Set(1, a.Get(i))
+		if data.Get(0) < data.Get(1) {
+			res = res.Append(b.Get(j))
+			j++
+		} else {
+			res = res.Append(a.Get(i))
+			i++
+		}
+	}
+	for ; i < a.Len(); i++ {
+		res = res.Append(a.Get(i))
+	}
+	for ; j < b.Len(); j++ {
+		res = res.Append(b.Get(j))
+	}
+
+	return res.AsImmutable(nil)
+}
+
+// dedupeSortedMyPersistentSlice returns data with all but the first of each run of equal
+// elements removed. data must be sorted.
+func dedupeSortedMyPersistentSlice(data *MyPersistentSlice) *MyPersistentSlice {
+	if data.Len() == 0 {
+		return data
+	}
+
+	res := NewMyPersistentSliceLen(0).AsMutable().Append(data.Get(0))
+
+	last := 0
+	for i := 1; i < data.Len(); i++ {
+		if data.Get(last) < data.Get(i) {
+			res.Append(data.Get(i))
+			last = i
+		}
+	}
+
+	return res.AsImmutable(nil)
+}
+
+// topKMyPersistentSlice returns the k least elements of vs, in order, using a heap of
+// size k.
+func topKMyPersistentSlice(vs *MyPersistentSlice, k int) *MyPersistentSlice {
+	data := vs.AsMutable()
+	n := data.Len()
+
+	if k > n {
+		k = n
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	// a max heap of the least k elements seen so far
+	for i := (k - 1) / 2; i >= 0; i-- {
+		siftDown_sortMyPersistentSlice(data, i, k, 0)
+	}
+	for i := k; i < n; i++ {
+		if data.Get(i) < data.Get(0) {
+			swap_sortMyPersistentSlice(data, 0, i)
+			siftDown_sortMyPersistentSlice(data, 0, k, 0)
+		}
+	}
+	heapSort_sortMyPersistentSlice(data, 0, k)
+
+	res := NewMyPersistentSliceLen(0).AsMutable()
+	for i := 0; i < k; i++ {
+		res.Append(data.Get(i))
+	}
+
+	return res.AsImmutable(nil)
+}
+func sortMyContainerSlice(vs *MyContainerSlice) *MyContainerSlice {
+	theVs := vs.AsMutable()
+	pdqsort_sortMyContainerSlice(theVs, 0, theVs.Len(), bits.Len(uint(theVs.Len())))
+
+	return theVs.AsImmutable(vs)
+}
+
+func stableSortMyContainerSlice(vs *MyContainerSlice) *MyContainerSlice {
+	theVs := vs.AsMutable()
+	stable_stableSortMyContainerSlice(theVs, theVs.Len())
+
+	return theVs.AsImmutable(vs)
+}
+
+// swap_sortMyContainerSlice swaps the elements i and j of data, which must be mutable.
+func swap_sortMyContainerSlice(data *MyContainerSlice, i, j int) {
+	vi, vj := data.Get(i), data.Get(j)
+	data.Set(i, vj)
+	data.Set(j, vi)
+}
+
+// insertionSort_sortMyContainerSlice sorts data[a:b] using insertion sort.
+func insertionSort_sortMyContainerSlice(data *MyContainerSlice, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data.Get(j) < data.Get(j-1)); j-- {
+			swap_sortMyContainerSlice(data, j, j-1)
+		}
+	}
+}
+
+// siftDown_sortMyContainerSlice implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_sortMyContainerSlice(data *MyContainerSlice, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && (data.Get(first+child) < data.Get(first+child+1)) {
+			child++
+		}
+		if !(data.Get(first+root) < data.Get(first+child)) {
+			return
+		}
+		swap_sortMyContainerSlice(data, first+root, first+child)
+		root = child
+	}
+}
+
+func heapSort_sortMyContainerSlice(data *MyContainerSlice, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_sortMyContainerSlice(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		swap_sortMyContainerSlice(data, first, first+i)
+		siftDown_sortMyContainerSlice(data, lo, i, first)
+	}
+}
+
+// pdqsort_sortMyContainerSlice sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_sortMyContainerSlice(data *MyContainerSlice, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_sortMyContainerSlice(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_sortMyContainerSlice(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_sortMyContainerSlice(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_sortMyContainerSlice(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_sortMyContainerSlice(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_sortMyContainerSlice(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !(data.Get(a-1) < data.Get(pivot)) {
+			mid := partitionEqual_sortMyContainerSlice(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_sortMyContainerSlice(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_sortMyContainerSlice(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_sortMyContainerSlice(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_sortMyContainerSlice does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_sortMyContainerSlice(data *MyContainerSlice, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	swap_sortMyContainerSlice(data, a, pivot)
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && (data.Get(i) < data.Get(a)) {
+		i++
+	}
+	for i <= j && !(data.Get(j) < data.Get(a)) {
+		j--
+	}
+	if i > j {
+		swap_sortMyContainerSlice(data, j, a)
+		return j, true
+	}
+	swap_sortMyContainerSlice(data, i, j)
+	i++
+	j--
+
+	for {
+		for i <= j && (data.Get(i) < data.Get(a)) {
+			i++
+		}
+		for i <= j && !(data.Get(j) < data.Get(a)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		swap_sortMyContainerSlice(data, i, j)
+		i++
+		j--
+	}
+	swap_sortMyContainerSlice(data, j, a)
+	return j, false
+}
+
+// partitionEqual_sortMyContainerSlice partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_sortMyContainerSlice(data *MyContainerSlice, a, b, pivot int) (newpivot int) {
+	swap_sortMyContainerSlice(data, a, pivot)
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !(data.Get(a) < data.Get(i)) {
+			i++
+		}
+		for i <= j && (data.Get(a) < data.Get(j)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		swap_sortMyContainerSlice(data, i, j)
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_sortMyContainerSlice partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_sortMyContainerSlice(data *MyContainerSlice, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !(data.Get(i) < data.Get(i-1)) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		swap_sortMyContainerSlice(data, i, i-1)
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !(data.Get(j) < data.Get(j-1)) {
+					break
+				}
+				swap_sortMyContainerSlice(data, j, j-1)
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !(data.Get(j) < data.Get(j-1)) {
+					break
+				}
+				swap_sortMyContainerSlice(data, j, j-1)
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_sortMyContainerSlice scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_sortMyContainerSlice(data *MyContainerSlice, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			swap_sortMyContainerSlice(data, idx, a+other)
+		}
+	}
+}
+
+// choosePivot_sortMyContainerSlice chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_sortMyContainerSlice(data *MyContainerSlice, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_sortMyContainerSlice(data, i, &swaps)
+			j = medianAdjacent_sortMyContainerSlice(data, j, &swaps)
+			k = medianAdjacent_sortMyContainerSlice(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_sortMyContainerSlice(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_sortMyContainerSlice returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_sortMyContainerSlice(data *MyContainerSlice, a, b int, swaps *int) (int, int) {
+	if data.Get(b) < data.Get(a) {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_sortMyContainerSlice returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_sortMyContainerSlice(data *MyContainerSlice, a, b, c int, swaps *int) int {
+	a, b = order2_sortMyContainerSlice(data, a, b, swaps)
+	b, c = order2_sortMyContainerSlice(data, b, c, swaps)
+	a, b = order2_sortMyContainerSlice(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_sortMyContainerSlice finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_sortMyContainerSlice(data *MyContainerSlice, a int, swaps *int) int {
+	return median_sortMyContainerSlice(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_sortMyContainerSlice(data *MyContainerSlice, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		swap_sortMyContainerSlice(data, i, j)
+		i++
+		j--
+	}
+}
+
+// swap_stableSortMyContainerSlice swaps the elements i and j of data, which must be mutable.
+func swap_stableSortMyContainerSlice(data *MyContainerSlice, i, j int) {
+	vi, vj := data.Get(i), data.Get(j)
+	data.Set(i, vj)
+	data.Set(j, vi)
+}
+
+// insertionSort_stableSortMyContainerSlice sorts data[a:b] using insertion sort.
+func insertionSort_stableSortMyContainerSlice(data *MyContainerSlice, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data.Get(j) < data.Get(j-1)); j-- {
+			swap_stableSortMyContainerSlice(data, j, j-1)
+		}
+	}
+}
+
+// stable_stableSortMyContainerSlice sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_stableSortMyContainerSlice.
+func stable_stableSortMyContainerSlice(data *MyContainerSlice, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_stableSortMyContainerSlice(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_stableSortMyContainerSlice(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_stableSortMyContainerSlice(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_stableSortMyContainerSlice(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_stableSortMyContainerSlice merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_stableSortMyContainerSlice(data *MyContainerSlice, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if data.Get(h) < data.Get(a) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			swap_stableSortMyContainerSlice(data, k, k+1)
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(data.Get(m) < data.Get(h)) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			swap_stableSortMyContainerSlice(data, k, k-1)
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(data.Get(p-c) < data.Get(c)) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_stableSortMyContainerSlice(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_stableSortMyContainerSlice(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_stableSortMyContainerSlice(data, mid, end, b)
+	}
+}
+
+// rotate_stableSortMyContainerSlice rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_stableSortMyContainerSlice(data *MyContainerSlice, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_stableSortMyContainerSlice(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_stableSortMyContainerSlice(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_stableSortMyContainerSlice(data, m-i, m, i)
+}
+
+func swapRange_stableSortMyContainerSlice(data *MyContainerSlice, a, b, n int) {
+	for i := 0; i < n; i++ {
+		swap_stableSortMyContainerSlice(data, a+i, b+i)
+	}
+}
+
+// searchMyContainerSlice returns the smallest index i at which vs[i] is not less than x,
+// or the length of vs if there is no such index. vs must be sorted.
+func searchMyContainerSlice(vs *MyContainerSlice, x string) int {
+	data := NewMyContainerSliceLen(2).AsMutable()
+	data.Set(1, x)
+
+	i, j := 0, vs.Len()
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data.Set(0, vs.Get(h))
+		if data.Get(0) < data.Get(1) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// searchUpperMyContainerSlice returns the smallest index i at which x is less than vs[i],
+// or the length of vs if there is no such index. vs must be sorted.
+func searchUpperMyContainerSlice(vs *MyContainerSlice, x string) int {
+	data := NewMyContainerSliceLen(2).AsMutable()
+	data.Set(1, x)
+
+	i, j := 0, vs.Len()
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data.Set(0, vs.Get(h))
+		if !(data.Get(1) < data.Get(0)) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// isSortedMyContainerSlice returns whether data is sorted.
+func isSortedMyContainerSlice(data *MyContainerSlice) bool {
+	for i := data.Len() - 1; i > 0; i-- {
+		if data.Get(i) < data.Get(i-1) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeSortedMyContainerSlice returns the result of merging a and b, which must be sorted.
+// The merge is stable: of equal elements, those of a precede those of b.
+func mergeSortedMyContainerSlice(a, b *MyContainerSlice) *MyContainerSlice {
+	data := NewMyContainerSliceLen(2).AsMutable()
+	res := NewMyContainerSliceLen(0).AsMutable()
+
+	i, j := 0, 0
+	for i < a.Len() && j < b.Len() {
+		data.Set(0, b.Get(j))
+		data.Set(1, a.Get(i))
+		if data.Get(0) < data.Get(1) {
+			res = res.Append(b.Get(j))
+			j++
+		} else {
+			res = res.Append(a.Get(i))
+			i++
+		}
+	}
+	for ; i < a.Len(); i++ {
+		res = res.Append(a.Get(i))
+	}
+	for ; j < b.Len(); j++ {
+		res = res.Append(b.Get(j))
+	}
+
+	return res.AsImmutable(nil)
+}
+
+// dedupeSortedMyContainerSlice returns data with all but the first of each run of equal
+// elements removed. data must be sorted.
+func dedupeSortedMyContainerSlice(data *MyContainerSlice) *MyContainerSlice {
+	if data.Len() == 0 {
+		return data
+	}
+
+	res := NewMyContainerSliceLen(0).AsMutable().Append(data.Get(0))
+
+	last := 0
+	for i := 1; i < data.Len(); i++ {
+		if data.Get(last) < data.Get(i) {
+			res.Append(data.Get(i))
+			last = i
+		}
+	}
+
+	return res.AsImmutable(nil)
+}
+
+// topKMyContainerSlice returns the k least elements of vs, in order, using a heap of
+// size k.
+func topKMyContainerSlice(vs *MyContainerSlice, k int) *MyContainerSlice {
+	data := vs.AsMutable()
+	n := data.Len()
+
+	if k > n {
+		k = n
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	// a max heap of the least k elements seen so far
+	for i := (k - 1) / 2; i >= 0; i-- {
+		siftDown_sortMyContainerSlice(data, i, k, 0)
+	}
+	for i := k; i < n; i++ {
+		if data.Get(i) < data.Get(0) {
+			swap_sortMyContainerSlice(data, 0, i)
+			siftDown_sortMyContainerSlice(data, 0, k, 0)
+		}
+	}
+	heapSort_sortMyContainerSlice(data, 0, k)
+
+	res := NewMyContainerSliceLen(0).AsMutable()
+	for i := 0; i < k; i++ {
+		res.Append(data.Get(i))
+	}
+
+	return res.AsImmutable(nil)
+}
+func sortArrayByName(vs *[3]person) {
+	pdqsort_sortArrayByName(vs, 0, len(vs), bits.Len(uint(len(vs))))
+}
+
+func stableSortArrayByName(vs *[3]person) {
+	stable_stableSortArrayByName(vs, len(vs))
+}
+
+// insertionSort_sortArrayByName sorts data[a:b] using insertion sort.
+func insertionSort_sortArrayByName(data *[3]person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && ((*data)[j].name < (*data)[j-1].name); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// siftDown_sortArrayByName implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_sortArrayByName(data *[3]person, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && ((*data)[first+child].name < (*data)[first+child+1].name) {
+			child++
+		}
+		if !((*data)[first+root].name < (*data)[first+child].name) {
+			return
+		}
+		data[first+root], data[first+child] = data[first+child], data[first+root]
+		root = child
+	}
+}
+
+func heapSort_sortArrayByName(data *[3]person, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_sortArrayByName(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i] = data[first+i], data[first]
+		siftDown_sortArrayByName(data, lo, i, first)
+	}
+}
+
+// pdqsort_sortArrayByName sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_sortArrayByName(data *[3]person, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_sortArrayByName(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_sortArrayByName(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_sortArrayByName(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_sortArrayByName(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_sortArrayByName(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_sortArrayByName(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !((*data)[a-1].name < (*data)[pivot].name) {
+			mid := partitionEqual_sortArrayByName(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_sortArrayByName(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_sortArrayByName(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_sortArrayByName(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_sortArrayByName does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_sortArrayByName(data *[3]person, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && ((*data)[i].name < (*data)[a].name) {
+		i++
+	}
+	for i <= j && !((*data)[j].name < (*data)[a].name) {
+		j--
+	}
+	if i > j {
+		data[j], data[a] = data[a], data[j]
+		return j, true
+	}
+	data[i], data[j] = data[j], data[i]
+	i++
+	j--
+
+	for {
+		for i <= j && ((*data)[i].name < (*data)[a].name) {
+			i++
+		}
+		for i <= j && !((*data)[j].name < (*data)[a].name) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	data[j], data[a] = data[a], data[j]
+	return j, false
+}
+
+// partitionEqual_sortArrayByName partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_sortArrayByName(data *[3]person, a, b, pivot int) (newpivot int) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !((*data)[a].name < (*data)[i].name) {
+			i++
+		}
+		for i <= j && ((*data)[a].name < (*data)[j].name) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_sortArrayByName partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_sortArrayByName(data *[3]person, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !((*data)[i].name < (*data)[i-1].name) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		data[i], data[i-1] = data[i-1], data[i]
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !((*data)[j].name < (*data)[j-1].name) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !((*data)[j].name < (*data)[j-1].name) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_sortArrayByName scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_sortArrayByName(data *[3]person, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			data[idx], data[a+other] = data[a+other], data[idx]
+		}
+	}
+}
+
+// choosePivot_sortArrayByName chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_sortArrayByName(data *[3]person, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_sortArrayByName(data, i, &swaps)
+			j = medianAdjacent_sortArrayByName(data, j, &swaps)
+			k = medianAdjacent_sortArrayByName(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_sortArrayByName(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_sortArrayByName returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_sortArrayByName(data *[3]person, a, b int, swaps *int) (int, int) {
+	if (*data)[b].name < (*data)[a].name {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_sortArrayByName returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_sortArrayByName(data *[3]person, a, b, c int, swaps *int) int {
+	a, b = order2_sortArrayByName(data, a, b, swaps)
+	b, c = order2_sortArrayByName(data, b, c, swaps)
+	a, b = order2_sortArrayByName(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_sortArrayByName finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_sortArrayByName(data *[3]person, a int, swaps *int) int {
+	return median_sortArrayByName(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_sortArrayByName(data *[3]person, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+}
+
+// insertionSort_stableSortArrayByName sorts data[a:b] using insertion sort.
+func insertionSort_stableSortArrayByName(data *[3]person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && ((*data)[j].name < (*data)[j-1].name); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// stable_stableSortArrayByName sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_stableSortArrayByName.
+func stable_stableSortArrayByName(data *[3]person, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_stableSortArrayByName(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_stableSortArrayByName(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_stableSortArrayByName(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_stableSortArrayByName(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_stableSortArrayByName merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_stableSortArrayByName(data *[3]person, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if (*data)[h].name < (*data)[a].name {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1] = data[k+1], data[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !((*data)[m].name < (*data)[h].name) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1] = data[k-1], data[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !((*data)[p-c].name < (*data)[c].name) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_stableSortArrayByName(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_stableSortArrayByName(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_stableSortArrayByName(data, mid, end, b)
+	}
+}
+
+// rotate_stableSortArrayByName rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_stableSortArrayByName(data *[3]person, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_stableSortArrayByName(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_stableSortArrayByName(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_stableSortArrayByName(data, m-i, m, i)
+}
+
+func swapRange_stableSortArrayByName(data *[3]person, a, b, n int) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i] = data[b+i], data[a+i]
+	}
+}
+func sortArrayByAge(vs *[3]person) {
+	pdqsort_sortArrayByAge(vs, 0, len(vs), bits.Len(uint(len(vs))))
+}
+
+func stableSortArrayByAge(vs *[3]person) {
+	stable_stableSortArrayByAge(vs, len(vs))
+}
+
+// insertionSort_sortArrayByAge sorts data[a:b] using insertion sort.
+func insertionSort_sortArrayByAge(data *[3]person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && bool(orderArrayByAge(*data, j, j-1)); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// siftDown_sortArrayByAge implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_sortArrayByAge(data *[3]person, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && bool(orderArrayByAge(*data, first+child, first+child+1)) {
+			child++
+		}
+		if !bool(orderArrayByAge(*data, first+root, first+child)) {
+			return
+		}
+		data[first+root], data[first+child] = data[first+child], data[first+root]
+		root = child
+	}
+}
+
+func heapSort_sortArrayByAge(data *[3]person, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_sortArrayByAge(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i] = data[first+i], data[first]
+		siftDown_sortArrayByAge(data, lo, i, first)
+	}
+}
+
+// pdqsort_sortArrayByAge sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_sortArrayByAge(data *[3]person, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_sortArrayByAge(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_sortArrayByAge(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_sortArrayByAge(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_sortArrayByAge(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_sortArrayByAge(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_sortArrayByAge(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !bool(orderArrayByAge(*data, a-1, pivot)) {
+			mid := partitionEqual_sortArrayByAge(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_sortArrayByAge(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_sortArrayByAge(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_sortArrayByAge(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_sortArrayByAge does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_sortArrayByAge(data *[3]person, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && bool(orderArrayByAge(*data, i, a)) {
+		i++
+	}
+	for i <= j && !bool(orderArrayByAge(*data, j, a)) {
+		j--
+	}
+	if i > j {
+		data[j], data[a] = data[a], data[j]
+		return j, true
+	}
+	data[i], data[j] = data[j], data[i]
+	i++
+	j--
+
+	for {
+		for i <= j && bool(orderArrayByAge(*data, i, a)) {
+			i++
+		}
+		for i <= j && !bool(orderArrayByAge(*data, j, a)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	data[j], data[a] = data[a], data[j]
+	return j, false
+}
+
+// partitionEqual_sortArrayByAge partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_sortArrayByAge(data *[3]person, a, b, pivot int) (newpivot int) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !bool(orderArrayByAge(*data, a, i)) {
+			i++
+		}
+		for i <= j && bool(orderArrayByAge(*data, a, j)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_sortArrayByAge partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_sortArrayByAge(data *[3]person, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !bool(orderArrayByAge(*data, i, i-1)) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		data[i], data[i-1] = data[i-1], data[i]
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !bool(orderArrayByAge(*data, j, j-1)) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !bool(orderArrayByAge(*data, j, j-1)) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_sortArrayByAge scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_sortArrayByAge(data *[3]person, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			data[idx], data[a+other] = data[a+other], data[idx]
+		}
+	}
+}
+
+// choosePivot_sortArrayByAge chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_sortArrayByAge(data *[3]person, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_sortArrayByAge(data, i, &swaps)
+			j = medianAdjacent_sortArrayByAge(data, j, &swaps)
+			k = medianAdjacent_sortArrayByAge(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_sortArrayByAge(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_sortArrayByAge returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_sortArrayByAge(data *[3]person, a, b int, swaps *int) (int, int) {
+	if bool(orderArrayByAge(*data, b, a)) {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_sortArrayByAge returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_sortArrayByAge(data *[3]person, a, b, c int, swaps *int) int {
+	a, b = order2_sortArrayByAge(data, a, b, swaps)
+	b, c = order2_sortArrayByAge(data, b, c, swaps)
+	a, b = order2_sortArrayByAge(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_sortArrayByAge finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_sortArrayByAge(data *[3]person, a int, swaps *int) int {
+	return median_sortArrayByAge(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_sortArrayByAge(data *[3]person, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+}
+
+// insertionSort_stableSortArrayByAge sorts data[a:b] using insertion sort.
+func insertionSort_stableSortArrayByAge(data *[3]person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && bool(orderArrayByAge(*data, j, j-1)); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// stable_stableSortArrayByAge sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_stableSortArrayByAge.
+func stable_stableSortArrayByAge(data *[3]person, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_stableSortArrayByAge(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_stableSortArrayByAge(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_stableSortArrayByAge(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_stableSortArrayByAge(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_stableSortArrayByAge merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_stableSortArrayByAge(data *[3]person, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if bool(orderArrayByAge(*data, h, a)) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1] = data[k+1], data[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !bool(orderArrayByAge(*data, m, h)) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1] = data[k-1], data[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !bool(orderArrayByAge(*data, p-c, c)) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_stableSortArrayByAge(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_stableSortArrayByAge(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_stableSortArrayByAge(data, mid, end, b)
+	}
+}
+
+// rotate_stableSortArrayByAge rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_stableSortArrayByAge(data *[3]person, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_stableSortArrayByAge(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_stableSortArrayByAge(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_stableSortArrayByAge(data, m-i, m, i)
+}
+
+func swapRange_stableSortArrayByAge(data *[3]person, a, b, n int) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i] = data[b+i], data[a+i]
+	}
+}
+func sortArrayPointerByName(vs *[3]person) {
+	pdqsort_sortArrayPointerByName(vs, 0, len(vs), bits.Len(uint(len(vs))))
+}
+
+func stableSortArrayPointerByName(vs *[3]person) {
+	stable_stableSortArrayPointerByName(vs, len(vs))
+}
+
+// insertionSort_sortArrayPointerByName sorts data[a:b] using insertion sort.
+func insertionSort_sortArrayPointerByName(data *[3]person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data[j].name < data[j-1].name); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// siftDown_sortArrayPointerByName implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_sortArrayPointerByName(data *[3]person, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && (data[first+child].name < data[first+child+1].name) {
+			child++
+		}
+		if !(data[first+root].name < data[first+child].name) {
+			return
+		}
+		data[first+root], data[first+child] = data[first+child], data[first+root]
+		root = child
+	}
+}
+
+func heapSort_sortArrayPointerByName(data *[3]person, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_sortArrayPointerByName(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i] = data[first+i], data[first]
+		siftDown_sortArrayPointerByName(data, lo, i, first)
+	}
+}
+
+// pdqsort_sortArrayPointerByName sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_sortArrayPointerByName(data *[3]person, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_sortArrayPointerByName(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_sortArrayPointerByName(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_sortArrayPointerByName(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_sortArrayPointerByName(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_sortArrayPointerByName(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_sortArrayPointerByName(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !(data[a-1].name < data[pivot].name) {
+			mid := partitionEqual_sortArrayPointerByName(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_sortArrayPointerByName(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_sortArrayPointerByName(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_sortArrayPointerByName(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_sortArrayPointerByName does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_sortArrayPointerByName(data *[3]person, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && (data[i].name < data[a].name) {
+		i++
+	}
+	for i <= j && !(data[j].name < data[a].name) {
+		j--
+	}
+	if i > j {
+		data[j], data[a] = data[a], data[j]
+		return j, true
+	}
+	data[i], data[j] = data[j], data[i]
+	i++
+	j--
+
+	for {
+		for i <= j && (data[i].name < data[a].name) {
+			i++
+		}
+		for i <= j && !(data[j].name < data[a].name) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	data[j], data[a] = data[a], data[j]
+	return j, false
+}
+
+// partitionEqual_sortArrayPointerByName partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_sortArrayPointerByName(data *[3]person, a, b, pivot int) (newpivot int) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !(data[a].name < data[i].name) {
+			i++
+		}
+		for i <= j && (data[a].name < data[j].name) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_sortArrayPointerByName partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_sortArrayPointerByName(data *[3]person, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !(data[i].name < data[i-1].name) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		data[i], data[i-1] = data[i-1], data[i]
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !(data[j].name < data[j-1].name) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !(data[j].name < data[j-1].name) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_sortArrayPointerByName scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_sortArrayPointerByName(data *[3]person, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			data[idx], data[a+other] = data[a+other], data[idx]
+		}
+	}
+}
+
+// choosePivot_sortArrayPointerByName chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_sortArrayPointerByName(data *[3]person, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_sortArrayPointerByName(data, i, &swaps)
+			j = medianAdjacent_sortArrayPointerByName(data, j, &swaps)
+			k = medianAdjacent_sortArrayPointerByName(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_sortArrayPointerByName(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_sortArrayPointerByName returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_sortArrayPointerByName(data *[3]person, a, b int, swaps *int) (int, int) {
+	if data[b].name < data[a].name {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_sortArrayPointerByName returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_sortArrayPointerByName(data *[3]person, a, b, c int, swaps *int) int {
+	a, b = order2_sortArrayPointerByName(data, a, b, swaps)
+	b, c = order2_sortArrayPointerByName(data, b, c, swaps)
+	a, b = order2_sortArrayPointerByName(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_sortArrayPointerByName finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_sortArrayPointerByName(data *[3]person, a int, swaps *int) int {
+	return median_sortArrayPointerByName(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_sortArrayPointerByName(data *[3]person, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+}
+
+// insertionSort_stableSortArrayPointerByName sorts data[a:b] using insertion sort.
+func insertionSort_stableSortArrayPointerByName(data *[3]person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data[j].name < data[j-1].name); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// stable_stableSortArrayPointerByName sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_stableSortArrayPointerByName.
+func stable_stableSortArrayPointerByName(data *[3]person, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_stableSortArrayPointerByName(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_stableSortArrayPointerByName(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_stableSortArrayPointerByName(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_stableSortArrayPointerByName(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_stableSortArrayPointerByName merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_stableSortArrayPointerByName(data *[3]person, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if data[h].name < data[a].name {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1] = data[k+1], data[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(data[m].name < data[h].name) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1] = data[k-1], data[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(data[p-c].name < data[c].name) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_stableSortArrayPointerByName(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_stableSortArrayPointerByName(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_stableSortArrayPointerByName(data, mid, end, b)
+	}
+}
+
+// rotate_stableSortArrayPointerByName rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_stableSortArrayPointerByName(data *[3]person, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_stableSortArrayPointerByName(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_stableSortArrayPointerByName(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_stableSortArrayPointerByName(data, m-i, m, i)
+}
+
+func swapRange_stableSortArrayPointerByName(data *[3]person, a, b, n int) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i] = data[b+i], data[a+i]
+	}
+}
+func sortOtherMySlice(vs *other.MySlice) *other.MySlice {
+	theVs := vs.AsMutable()
+	pdqsort_sortOtherMySlice(theVs, 0, theVs.Len(), bits.Len(uint(theVs.Len())))
+
+	return theVs.AsImmutable(vs)
+}
+
+func stableSortOtherMySlice(vs *other.MySlice) *other.MySlice {
+	theVs := vs.AsMutable()
+	stable_stableSortOtherMySlice(theVs, theVs.Len())
+
+	return theVs.AsImmutable(vs)
+}
+
+// swap_sortOtherMySlice swaps the elements i and j of data, which must be mutable.
+func swap_sortOtherMySlice(data *other.MySlice, i, j int) {
+	vi, vj := data.Get(i), data.Get(j)
+	data.Set(i, vj)
+	data.Set(j, vi)
+}
+
+// insertionSort_sortOtherMySlice sorts data[a:b] using insertion sort.
+func insertionSort_sortOtherMySlice(data *other.MySlice, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data.Get(j) < data.Get(j-1)); j-- {
+			swap_sortOtherMySlice(data, j, j-1)
+		}
+	}
+}
+
+// siftDown_sortOtherMySlice implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_sortOtherMySlice(data *other.MySlice, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && (data.Get(first+child) < data.Get(first+child+1)) {
+			child++
+		}
+		if !(data.Get(first+root) < data.Get(first+child)) {
+			return
+		}
+		swap_sortOtherMySlice(data, first+root, first+child)
+		root = child
+	}
+}
+
+func heapSort_sortOtherMySlice(data *other.MySlice, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_sortOtherMySlice(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		swap_sortOtherMySlice(data, first, first+i)
+		siftDown_sortOtherMySlice(data, lo, i, first)
+	}
+}
+
+// pdqsort_sortOtherMySlice sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_sortOtherMySlice(data *other.MySlice, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_sortOtherMySlice(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_sortOtherMySlice(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_sortOtherMySlice(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_sortOtherMySlice(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_sortOtherMySlice(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_sortOtherMySlice(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !(data.Get(a-1) < data.Get(pivot)) {
+			mid := partitionEqual_sortOtherMySlice(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_sortOtherMySlice(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_sortOtherMySlice(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_sortOtherMySlice(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_sortOtherMySlice does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_sortOtherMySlice(data *other.MySlice, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	swap_sortOtherMySlice(data, a, pivot)
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && (data.Get(i) < data.Get(a)) {
+		i++
+	}
+	for i <= j && !(data.Get(j) < data.Get(a)) {
+		j--
+	}
+	if i > j {
+		swap_sortOtherMySlice(data, j, a)
+		return j, true
+	}
+	swap_sortOtherMySlice(data, i, j)
+	i++
+	j--
+
+	for {
+		for i <= j && (data.Get(i) < data.Get(a)) {
+			i++
+		}
+		for i <= j && !(data.Get(j) < data.Get(a)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		swap_sortOtherMySlice(data, i, j)
+		i++
+		j--
+	}
+	swap_sortOtherMySlice(data, j, a)
+	return j, false
+}
+
+// partitionEqual_sortOtherMySlice partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_sortOtherMySlice(data *other.MySlice, a, b, pivot int) (newpivot int) {
+	swap_sortOtherMySlice(data, a, pivot)
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !(data.Get(a) < data.Get(i)) {
+			i++
+		}
+		for i <= j && (data.Get(a) < data.Get(j)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		swap_sortOtherMySlice(data, i, j)
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_sortOtherMySlice partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_sortOtherMySlice(data *other.MySlice, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !(data.Get(i) < data.Get(i-1)) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		swap_sortOtherMySlice(data, i, i-1)
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !(data.Get(j) < data.Get(j-1)) {
+					break
+				}
+				swap_sortOtherMySlice(data, j, j-1)
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !(data.Get(j) < data.Get(j-1)) {
+					break
+				}
+				swap_sortOtherMySlice(data, j, j-1)
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_sortOtherMySlice scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_sortOtherMySlice(data *other.MySlice, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			swap_sortOtherMySlice(data, idx, a+other)
+		}
+	}
+}
+
+// choosePivot_sortOtherMySlice chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_sortOtherMySlice(data *other.MySlice, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_sortOtherMySlice(data, i, &swaps)
+			j = medianAdjacent_sortOtherMySlice(data, j, &swaps)
+			k = medianAdjacent_sortOtherMySlice(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_sortOtherMySlice(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_sortOtherMySlice returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_sortOtherMySlice(data *other.MySlice, a, b int, swaps *int) (int, int) {
+	if data.Get(b) < data.Get(a) {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_sortOtherMySlice returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_sortOtherMySlice(data *other.MySlice, a, b, c int, swaps *int) int {
+	a, b = order2_sortOtherMySlice(data, a, b, swaps)
+	b, c = order2_sortOtherMySlice(data, b, c, swaps)
+	a, b = order2_sortOtherMySlice(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_sortOtherMySlice finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_sortOtherMySlice(data *other.MySlice, a int, swaps *int) int {
+	return median_sortOtherMySlice(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_sortOtherMySlice(data *other.MySlice, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		swap_sortOtherMySlice(data, i, j)
+		i++
+		j--
+	}
+}
+
+// swap_stableSortOtherMySlice swaps the elements i and j of data, which must be mutable.
+func swap_stableSortOtherMySlice(data *other.MySlice, i, j int) {
+	vi, vj := data.Get(i), data.Get(j)
+	data.Set(i, vj)
+	data.Set(j, vi)
+}
+
+// insertionSort_stableSortOtherMySlice sorts data[a:b] using insertion sort.
+func insertionSort_stableSortOtherMySlice(data *other.MySlice, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data.Get(j) < data.Get(j-1)); j-- {
+			swap_stableSortOtherMySlice(data, j, j-1)
+		}
+	}
+}
+
+// stable_stableSortOtherMySlice sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_stableSortOtherMySlice.
+func stable_stableSortOtherMySlice(data *other.MySlice, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_stableSortOtherMySlice(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_stableSortOtherMySlice(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_stableSortOtherMySlice(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_stableSortOtherMySlice(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_stableSortOtherMySlice merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_stableSortOtherMySlice(data *other.MySlice, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if data.Get(h) < data.Get(a) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			swap_stableSortOtherMySlice(data, k, k+1)
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(data.Get(m) < data.Get(h)) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			swap_stableSortOtherMySlice(data, k, k-1)
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(data.Get(p-c) < data.Get(c)) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_stableSortOtherMySlice(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_stableSortOtherMySlice(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_stableSortOtherMySlice(data, mid, end, b)
+	}
+}
+
+// rotate_stableSortOtherMySlice rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_stableSortOtherMySlice(data *other.MySlice, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_stableSortOtherMySlice(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_stableSortOtherMySlice(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_stableSortOtherMySlice(data, m-i, m, i)
+}
+
+func swapRange_stableSortOtherMySlice(data *other.MySlice, a, b, n int) {
+	for i := 0; i < n; i++ {
+		swap_stableSortOtherMySlice(data, a+i, b+i)
+	}
+}
+
+// searchOtherMySlice returns the smallest index i at which vs[i] is not less than x,
+// or the length of vs if there is no such index. vs must be sorted.
+func searchOtherMySlice(vs *other.MySlice, x string) int {
+	data := other.NewMySliceLen(2).AsMutable()
+	data.Set(1, x)
+
+	i, j := 0, vs.Len()
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data.Set(0, vs.Get(h))
+		if data.Get(0) < data.Get(1) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// searchUpperOtherMySlice returns the smallest index i at which x is less than vs[i],
+// or the length of vs if there is no such index. vs must be sorted.
+func searchUpperOtherMySlice(vs *other.MySlice, x string) int {
+	data := other.NewMySliceLen(2).AsMutable()
+	data.Set(1, x)
+
+	i, j := 0, vs.Len()
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data.Set(0, vs.Get(h))
+		if !(data.Get(1) < data.Get(0)) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// isSortedOtherMySlice returns whether data is sorted.
+func isSortedOtherMySlice(data *other.MySlice) bool {
+	for i := data.Len() - 1; i > 0; i-- {
+		if data.Get(i) < data.Get(i-1) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeSortedOtherMySlice returns the result of merging a and b, which must be sorted.
+// The merge is stable: of equal elements, those of a precede those of b.
+func mergeSortedOtherMySlice(a, b *other.MySlice) *other.MySlice {
+	data := other.NewMySliceLen(2).AsMutable()
+	res := other.NewMySliceLen(0).AsMutable()
+
+	i, j := 0, 0
+	for i < a.Len() && j < b.Len() {
+		data.Set(0, b.Get(j))
+		data.Set(1, a.Get(i))
+		if data.Get(0) < data.Get(1) {
+			res = res.Append(b.Get(j))
+			j++
+		} else {
+			res = res.Append(a.Get(i))
+			i++
+		}
+	}
+	for ; i < a.Len(); i++ {
+		res = res.Append(a.Get(i))
+	}
+	for ; j < b.Len(); j++ {
+		res = res.Append(b.Get(j))
+	}
+
+	return res.AsImmutable(nil)
+}
+
+// dedupeSortedOtherMySlice returns data with all but the first of each run of equal
+// elements removed. data must be sorted.
+func dedupeSortedOtherMySlice(data *other.MySlice) *other.MySlice {
+	if data.Len() == 0 {
+		return data
+	}
+
+	res := other.NewMySliceLen(0).AsMutable().Append(data.Get(0))
+
+	last := 0
+	for i := 1; i < data.Len(); i++ {
+		if data.Get(last) < data.Get(i) {
+			res.Append(data.Get(i))
+			last = i
+		}
+	}
+
+	return res.AsImmutable(nil)
+}
+
+// topKOtherMySlice returns the k least elements of vs, in order, using a heap of
+// size k.
+func topKOtherMySlice(vs *other.MySlice, k int) *other.MySlice {
+	data := vs.AsMutable()
+	n := data.Len()
+
+	if k > n {
+		k = n
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	// a max heap of the least k elements seen so far
+	for i := (k - 1) / 2; i >= 0; i-- {
+		siftDown_sortOtherMySlice(data, i, k, 0)
+	}
+	for i := k; i < n; i++ {
+		if data.Get(i) < data.Get(0) {
+			swap_sortOtherMySlice(data, 0, i)
+			siftDown_sortOtherMySlice(data, 0, k, 0)
+		}
+	}
+	heapSort_sortOtherMySlice(data, 0, k)
+
+	res := other.NewMySliceLen(0).AsMutable()
+	for i := 0; i < k; i++ {
+		res.Append(data.Get(i))
+	}
+
+	return res.AsImmutable(nil)
+}
+func sortPointerByName(vs []*person) {
+	pdqsort_sortPointerByName(vs, 0, len(vs), bits.Len(uint(len(vs))))
+}
+
+func stableSortPointerByName(vs []*person) {
+	stable_stableSortPointerByName(vs, len(vs))
+}
+
+// insertionSort_sortPointerByName sorts data[a:b] using insertion sort.
+func insertionSort_sortPointerByName(data []*person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data[j].name < data[j-1].name); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// siftDown_sortPointerByName implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_sortPointerByName(data []*person, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && (data[first+child].name < data[first+child+1].name) {
+			child++
+		}
+		if !(data[first+root].name < data[first+child].name) {
+			return
+		}
+		data[first+root], data[first+child] = data[first+child], data[first+root]
+		root = child
+	}
+}
+
+func heapSort_sortPointerByName(data []*person, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_sortPointerByName(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i] = data[first+i], data[first]
+		siftDown_sortPointerByName(data, lo, i, first)
+	}
+}
+
+// pdqsort_sortPointerByName sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_sortPointerByName(data []*person, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_sortPointerByName(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_sortPointerByName(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_sortPointerByName(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_sortPointerByName(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_sortPointerByName(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_sortPointerByName(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !(data[a-1].name < data[pivot].name) {
+			mid := partitionEqual_sortPointerByName(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_sortPointerByName(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_sortPointerByName(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_sortPointerByName(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_sortPointerByName does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_sortPointerByName(data []*person, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && (data[i].name < data[a].name) {
+		i++
+	}
+	for i <= j && !(data[j].name < data[a].name) {
+		j--
+	}
+	if i > j {
+		data[j], data[a] = data[a], data[j]
+		return j, true
+	}
+	data[i], data[j] = data[j], data[i]
+	i++
+	j--
+
+	for {
+		for i <= j && (data[i].name < data[a].name) {
+			i++
+		}
+		for i <= j && !(data[j].name < data[a].name) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	data[j], data[a] = data[a], data[j]
+	return j, false
+}
+
+// partitionEqual_sortPointerByName partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_sortPointerByName(data []*person, a, b, pivot int) (newpivot int) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !(data[a].name < data[i].name) {
+			i++
+		}
+		for i <= j && (data[a].name < data[j].name) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_sortPointerByName partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_sortPointerByName(data []*person, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !(data[i].name < data[i-1].name) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		data[i], data[i-1] = data[i-1], data[i]
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !(data[j].name < data[j-1].name) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !(data[j].name < data[j-1].name) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_sortPointerByName scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_sortPointerByName(data []*person, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			data[idx], data[a+other] = data[a+other], data[idx]
+		}
+	}
+}
+
+// choosePivot_sortPointerByName chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_sortPointerByName(data []*person, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_sortPointerByName(data, i, &swaps)
+			j = medianAdjacent_sortPointerByName(data, j, &swaps)
+			k = medianAdjacent_sortPointerByName(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_sortPointerByName(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_sortPointerByName returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_sortPointerByName(data []*person, a, b int, swaps *int) (int, int) {
+	if data[b].name < data[a].name {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_sortPointerByName returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_sortPointerByName(data []*person, a, b, c int, swaps *int) int {
+	a, b = order2_sortPointerByName(data, a, b, swaps)
+	b, c = order2_sortPointerByName(data, b, c, swaps)
+	a, b = order2_sortPointerByName(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_sortPointerByName finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_sortPointerByName(data []*person, a int, swaps *int) int {
+	return median_sortPointerByName(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_sortPointerByName(data []*person, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+}
+
+// insertionSort_stableSortPointerByName sorts data[a:b] using insertion sort.
+func insertionSort_stableSortPointerByName(data []*person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data[j].name < data[j-1].name); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// stable_stableSortPointerByName sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_stableSortPointerByName.
+func stable_stableSortPointerByName(data []*person, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_stableSortPointerByName(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_stableSortPointerByName(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_stableSortPointerByName(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_stableSortPointerByName(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_stableSortPointerByName merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_stableSortPointerByName(data []*person, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if data[h].name < data[a].name {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1] = data[k+1], data[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(data[m].name < data[h].name) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1] = data[k-1], data[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(data[p-c].name < data[c].name) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_stableSortPointerByName(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_stableSortPointerByName(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_stableSortPointerByName(data, mid, end, b)
+	}
+}
+
+// rotate_stableSortPointerByName rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_stableSortPointerByName(data []*person, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_stableSortPointerByName(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_stableSortPointerByName(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_stableSortPointerByName(data, m-i, m, i)
+}
+
+func swapRange_stableSortPointerByName(data []*person, a, b, n int) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i] = data[b+i], data[a+i]
+	}
+}
+
+// searchPointerByName returns the smallest index i at which vs[i] is not less than x,
+// or the length of vs if there is no such index. vs must be sorted.
+func searchPointerByName(vs []*person, x *person) int {
+	data := make([]*person, 2)
+	data[1] = x
+
+	i, j := 0, len(vs)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data[0] = vs[h]
+		if data[0].name < data[1].name {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// searchUpperPointerByName returns the smallest index i at which x is less than vs[i],
+// or the length of vs if there is no such index. vs must be sorted.
+func searchUpperPointerByName(vs []*person, x *person) int {
+	data := make([]*person, 2)
+	data[1] = x
+
+	i, j := 0, len(vs)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data[0] = vs[h]
+		if !(data[1].name < data[0].name) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// isSortedPointerByName returns whether data is sorted.
+func isSortedPointerByName(data []*person) bool {
+	for i := len(data) - 1; i > 0; i-- {
+		if data[i].name < data[i-1].name {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeSortedPointerByName returns the result of merging a and b, which must be sorted.
+// The merge is stable: of equal elements, those of a precede those of b.
+func mergeSortedPointerByName(a, b []*person) []*person {
+	data := make([]*person, 2)
+	res := make([]*person, 0, len(a)+len(b))
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		data[0] = b[j]
+		data[1] = a[i]
+		if data[0].name < data[1].name {
+			res = append(res, b[j])
+			j++
+		} else {
+			res = append(res, a[i])
+			i++
+		}
+	}
+	for ; i < len(a); i++ {
+		res = append(res, a[i])
+	}
+	for ; j < len(b); j++ {
+		res = append(res, b[j])
+	}
+
+	return res
+}
+
+// dedupeSortedPointerByName returns data with all but the first of each run of equal
+// elements removed. data must be sorted. The elements are moved within data, the
+// prefix of which is returned.
+func dedupeSortedPointerByName(data []*person) []*person {
+	if len(data) == 0 {
+		return data
+	}
+
+	k := 1
+	for i := 1; i < len(data); i++ {
+		if data[k-1].name < data[i].name {
+			data[k] = data[i]
+			k++
+		}
+	}
+
+	return data[:k]
+}
+
+// topKPointerByName returns the k least elements of vs, in order, using a heap of
+// size k. The elements are moved within vs, the prefix of which is
+// returned.
+func topKPointerByName(vs []*person, k int) []*person {
+	data := vs
+	n := len(data)
+
+	if k > n {
+		k = n
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	// a max heap of the least k elements seen so far
+	for i := (k - 1) / 2; i >= 0; i-- {
+		siftDown_sortPointerByName(data, i, k, 0)
+	}
+	for i := k; i < n; i++ {
+		if data[i].name < data[0].name {
+			data[0], data[i] = data[i], data[0]
+			siftDown_sortPointerByName(data, 0, k, 0)
+		}
+	}
+	heapSort_sortPointerByName(data, 0, k)
+
+	return data[:k]
+}
+func sortBufferByContents(vs []bytes.Buffer) {
+	pdqsort_sortBufferByContents(vs, 0, len(vs), bits.Len(uint(len(vs))))
+}
+
+func stableSortBufferByContents(vs []bytes.Buffer) {
+	stable_stableSortBufferByContents(vs, len(vs))
+}
+
+// insertionSort_sortBufferByContents sorts data[a:b] using insertion sort.
+func insertionSort_sortBufferByContents(data []bytes.Buffer, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data[j].String() < data[j-1].String()); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// siftDown_sortBufferByContents implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_sortBufferByContents(data []bytes.Buffer, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && (data[first+child].String() < data[first+child+1].String()) {
+			child++
+		}
+		if !(data[first+root].String() < data[first+child].String()) {
+			return
+		}
+		data[first+root], data[first+child] = data[first+child], data[first+root]
+		root = child
+	}
+}
+
+func heapSort_sortBufferByContents(data []bytes.Buffer, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_sortBufferByContents(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i] = data[first+i], data[first]
+		siftDown_sortBufferByContents(data, lo, i, first)
+	}
+}
+
+// pdqsort_sortBufferByContents sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_sortBufferByContents(data []bytes.Buffer, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_sortBufferByContents(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_sortBufferByContents(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_sortBufferByContents(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_sortBufferByContents(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_sortBufferByContents(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_sortBufferByContents(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !(data[a-1].String() < data[pivot].String()) {
+			mid := partitionEqual_sortBufferByContents(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_sortBufferByContents(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_sortBufferByContents(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_sortBufferByContents(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_sortBufferByContents does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_sortBufferByContents(data []bytes.Buffer, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && (data[i].String() < data[a].String()) {
+		i++
+	}
+	for i <= j && !(data[j].String() < data[a].String()) {
+		j--
+	}
+	if i > j {
+		data[j], data[a] = data[a], data[j]
+		return j, true
+	}
+	data[i], data[j] = data[j], data[i]
+	i++
+	j--
+
+	for {
+		for i <= j && (data[i].String() < data[a].String()) {
+			i++
+		}
+		for i <= j && !(data[j].String() < data[a].String()) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	data[j], data[a] = data[a], data[j]
+	return j, false
+}
+
+// partitionEqual_sortBufferByContents partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_sortBufferByContents(data []bytes.Buffer, a, b, pivot int) (newpivot int) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !(data[a].String() < data[i].String()) {
+			i++
+		}
+		for i <= j && (data[a].String() < data[j].String()) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_sortBufferByContents partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_sortBufferByContents(data []bytes.Buffer, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !(data[i].String() < data[i-1].String()) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		data[i], data[i-1] = data[i-1], data[i]
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !(data[j].String() < data[j-1].String()) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !(data[j].String() < data[j-1].String()) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_sortBufferByContents scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_sortBufferByContents(data []bytes.Buffer, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			data[idx], data[a+other] = data[a+other], data[idx]
+		}
+	}
+}
+
+// choosePivot_sortBufferByContents chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_sortBufferByContents(data []bytes.Buffer, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_sortBufferByContents(data, i, &swaps)
+			j = medianAdjacent_sortBufferByContents(data, j, &swaps)
+			k = medianAdjacent_sortBufferByContents(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_sortBufferByContents(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_sortBufferByContents returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_sortBufferByContents(data []bytes.Buffer, a, b int, swaps *int) (int, int) {
+	if data[b].String() < data[a].String() {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_sortBufferByContents returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_sortBufferByContents(data []bytes.Buffer, a, b, c int, swaps *int) int {
+	a, b = order2_sortBufferByContents(data, a, b, swaps)
+	b, c = order2_sortBufferByContents(data, b, c, swaps)
+	a, b = order2_sortBufferByContents(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_sortBufferByContents finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_sortBufferByContents(data []bytes.Buffer, a int, swaps *int) int {
+	return median_sortBufferByContents(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_sortBufferByContents(data []bytes.Buffer, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+}
+
+// insertionSort_stableSortBufferByContents sorts data[a:b] using insertion sort.
+func insertionSort_stableSortBufferByContents(data []bytes.Buffer, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data[j].String() < data[j-1].String()); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// stable_stableSortBufferByContents sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_stableSortBufferByContents.
+func stable_stableSortBufferByContents(data []bytes.Buffer, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_stableSortBufferByContents(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_stableSortBufferByContents(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_stableSortBufferByContents(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_stableSortBufferByContents(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_stableSortBufferByContents merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_stableSortBufferByContents(data []bytes.Buffer, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if data[h].String() < data[a].String() {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1] = data[k+1], data[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(data[m].String() < data[h].String()) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1] = data[k-1], data[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(data[p-c].String() < data[c].String()) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_stableSortBufferByContents(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_stableSortBufferByContents(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_stableSortBufferByContents(data, mid, end, b)
+	}
+}
+
+// rotate_stableSortBufferByContents rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_stableSortBufferByContents(data []bytes.Buffer, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_stableSortBufferByContents(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_stableSortBufferByContents(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_stableSortBufferByContents(data, m-i, m, i)
+}
+
+func swapRange_stableSortBufferByContents(data []bytes.Buffer, a, b, n int) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i] = data[b+i], data[a+i]
+	}
+}
+
+// searchBufferByContents returns the smallest index i at which vs[i] is not less than x,
+// or the length of vs if there is no such index. vs must be sorted.
+func searchBufferByContents(vs []bytes.Buffer, x bytes.Buffer) int {
+	data := make([]bytes.Buffer, 2)
+	data[1] = x
+
+	i, j := 0, len(vs)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data[0] = vs[h]
+		if data[0].String() < data[1].String() {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// searchUpperBufferByContents returns the smallest index i at which x is less than vs[i],
+// or the length of vs if there is no such index. vs must be sorted.
+func searchUpperBufferByContents(vs []bytes.Buffer, x bytes.Buffer) int {
+	data := make([]bytes.Buffer, 2)
+	data[1] = x
+
+	i, j := 0, len(vs)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data[0] = vs[h]
+		if !(data[1].String() < data[0].String()) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// isSortedBufferByContents returns whether data is sorted.
+func isSortedBufferByContents(data []bytes.Buffer) bool {
+	for i := len(data) - 1; i > 0; i-- {
+		if data[i].String() < data[i-1].String() {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeSortedBufferByContents returns the result of merging a and b, which must be sorted.
+// The merge is stable: of equal elements, those of a precede those of b.
+func mergeSortedBufferByContents(a, b []bytes.Buffer) []bytes.Buffer {
+	data := make([]bytes.Buffer, 2)
+	res := make([]bytes.Buffer, 0, len(a)+len(b))
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		data[0] = b[j]
+		data[1] = a[i]
+		if data[0].String() < data[1].String() {
+			res = append(res, b[j])
+			j++
+		} else {
+			res = append(res, a[i])
+			i++
+		}
+	}
+	for ; i < len(a); i++ {
+		res = append(res, a[i])
+	}
+	for ; j < len(b); j++ {
+		res = append(res, b[j])
+	}
+
+	return res
+}
+
+// dedupeSortedBufferByContents returns data with all but the first of each run of equal
+// elements removed. data must be sorted. The elements are moved within data, the
+// prefix of which is returned.
+func dedupeSortedBufferByContents(data []bytes.Buffer) []bytes.Buffer {
+	if len(data) == 0 {
+		return data
+	}
+
+	k := 1
+	for i := 1; i < len(data); i++ {
+		if data[k-1].String() < data[i].String() {
+			data[k] = data[i]
+			k++
+		}
+	}
+
+	return data[:k]
+}
+
+// topKBufferByContents returns the k least elements of vs, in order, using a heap of
+// size k. The elements are moved within vs, the prefix of which is
+// returned.
+func topKBufferByContents(vs []bytes.Buffer, k int) []bytes.Buffer {
+	data := vs
+	n := len(data)
+
+	if k > n {
+		k = n
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	// a max heap of the least k elements seen so far
+	for i := (k - 1) / 2; i >= 0; i-- {
+		siftDown_sortBufferByContents(data, i, k, 0)
+	}
+	for i := k; i < n; i++ {
+		if data[i].String() < data[0].String() {
+			data[0], data[i] = data[i], data[0]
+			siftDown_sortBufferByContents(data, 0, k, 0)
+		}
+	}
+	heapSort_sortBufferByContents(data, 0, k)
+
+	return data[:k]
+}
+func sortMap(vs []map[string]bool) {
+	pdqsort_sortMap(vs, 0, len(vs), bits.Len(uint(len(vs))))
+}
+
+func stableSortMap(vs []map[string]bool) {
+	stable_stableSortMap(vs, len(vs))
+}
+
+// insertionSort_sortMap sorts data[a:b] using insertion sort.
+func insertionSort_sortMap(data []map[string]bool, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && bool(orderMap(data, j, j-1)); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// siftDown_sortMap implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_sortMap(data []map[string]bool, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && bool(orderMap(data, first+child, first+child+1)) {
+			child++
+		}
+		if !bool(orderMap(data, first+root, first+child)) {
+			return
+		}
+		data[first+root], data[first+child] = data[first+child], data[first+root]
+		root = child
+	}
+}
+
+func heapSort_sortMap(data []map[string]bool, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_sortMap(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i] = data[first+i], data[first]
+		siftDown_sortMap(data, lo, i, first)
+	}
+}
+
+// pdqsort_sortMap sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_sortMap(data []map[string]bool, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_sortMap(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_sortMap(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_sortMap(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_sortMap(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_sortMap(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_sortMap(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !bool(orderMap(data, a-1, pivot)) {
+			mid := partitionEqual_sortMap(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_sortMap(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_sortMap(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_sortMap(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_sortMap does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_sortMap(data []map[string]bool, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && bool(orderMap(data, i, a)) {
+		i++
+	}
+	for i <= j && !bool(orderMap(data, j, a)) {
+		j--
+	}
+	if i > j {
+		data[j], data[a] = data[a], data[j]
+		return j, true
+	}
+	data[i], data[j] = data[j], data[i]
+	i++
+	j--
+
+	for {
+		for i <= j && bool(orderMap(data, i, a)) {
+			i++
+		}
+		for i <= j && !bool(orderMap(data, j, a)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	data[j], data[a] = data[a], data[j]
+	return j, false
+}
+
+// partitionEqual_sortMap partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_sortMap(data []map[string]bool, a, b, pivot int) (newpivot int) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !bool(orderMap(data, a, i)) {
+			i++
+		}
+		for i <= j && bool(orderMap(data, a, j)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_sortMap partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_sortMap(data []map[string]bool, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !bool(orderMap(data, i, i-1)) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		data[i], data[i-1] = data[i-1], data[i]
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !bool(orderMap(data, j, j-1)) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !bool(orderMap(data, j, j-1)) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_sortMap scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_sortMap(data []map[string]bool, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			data[idx], data[a+other] = data[a+other], data[idx]
+		}
+	}
+}
+
+// choosePivot_sortMap chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_sortMap(data []map[string]bool, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_sortMap(data, i, &swaps)
+			j = medianAdjacent_sortMap(data, j, &swaps)
+			k = medianAdjacent_sortMap(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_sortMap(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_sortMap returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_sortMap(data []map[string]bool, a, b int, swaps *int) (int, int) {
+	if bool(orderMap(data, b, a)) {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_sortMap returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_sortMap(data []map[string]bool, a, b, c int, swaps *int) int {
+	a, b = order2_sortMap(data, a, b, swaps)
+	b, c = order2_sortMap(data, b, c, swaps)
+	a, b = order2_sortMap(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_sortMap finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_sortMap(data []map[string]bool, a int, swaps *int) int {
+	return median_sortMap(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_sortMap(data []map[string]bool, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+}
+
+// insertionSort_stableSortMap sorts data[a:b] using insertion sort.
+func insertionSort_stableSortMap(data []map[string]bool, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && bool(orderMap(data, j, j-1)); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// stable_stableSortMap sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_stableSortMap.
+func stable_stableSortMap(data []map[string]bool, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_stableSortMap(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_stableSortMap(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_stableSortMap(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_stableSortMap(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_stableSortMap merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_stableSortMap(data []map[string]bool, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if bool(orderMap(data, h, a)) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1] = data[k+1], data[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !bool(orderMap(data, m, h)) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1] = data[k-1], data[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !bool(orderMap(data, p-c, c)) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_stableSortMap(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_stableSortMap(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_stableSortMap(data, mid, end, b)
+	}
+}
+
+// rotate_stableSortMap rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_stableSortMap(data []map[string]bool, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_stableSortMap(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_stableSortMap(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_stableSortMap(data, m-i, m, i)
+}
+
+func swapRange_stableSortMap(data []map[string]bool, a, b, n int) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i] = data[b+i], data[a+i]
+	}
+}
+
+// searchMap returns the smallest index i at which vs[i] is not less than x,
+// or the length of vs if there is no such index. vs must be sorted.
+func searchMap(vs []map[string]bool, x map[string]bool) int {
+	data := make([]map[string]bool, 2)
+	data[1] = x
+
+	i, j := 0, len(vs)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data[0] = vs[h]
+		if bool(orderMap(data, 0, 1)) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// searchUpperMap returns the smallest index i at which x is less than vs[i],
+// or the length of vs if there is no such index. vs must be sorted.
+func searchUpperMap(vs []map[string]bool, x map[string]bool) int {
+	data := make([]map[string]bool, 2)
+	data[1] = x
+
+	i, j := 0, len(vs)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data[0] = vs[h]
+		if !bool(orderMap(data, 1, 0)) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// isSortedMap returns whether data is sorted.
+func isSortedMap(data []map[string]bool) bool {
+	for i := len(data) - 1; i > 0; i-- {
+		if bool(orderMap(data, i, i-1)) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeSortedMap returns the result of merging a and b, which must be sorted.
+// The merge is stable: of equal elements, those of a precede those of b.
+func mergeSortedMap(a, b []map[string]bool) []map[string]bool {
+	data := make([]map[string]bool, 2)
+	res := make([]map[string]bool, 0, len(a)+len(b))
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		data[0] = b[j]
+		data[1] = a[i]
+		if bool(orderMap(data, 0, 1)) {
+			res = append(res, b[j])
+			j++
+		} else {
+			res = append(res, a[i])
+			i++
+		}
+	}
+	for ; i < len(a); i++ {
+		res = append(res, a[i])
+	}
+	for ; j < len(b); j++ {
+		res = append(res, b[j])
+	}
+
+	return res
+}
+
+// dedupeSortedMap returns data with all but the first of each run of equal
+// elements removed. data must be sorted. The elements are moved within data, the
+// prefix of which is returned.
+func dedupeSortedMap(data []map[string]bool) []map[string]bool {
+	if len(data) == 0 {
+		return data
+	}
+
+	k := 1
+	for i := 1; i < len(data); i++ {
+		if bool(orderMap(data, k-1, i)) {
+			data[k] = data[i]
+			k++
+		}
+	}
+
+	return data[:k]
+}
+
+// topKMap returns the k least elements of vs, in order, using a heap of
+// size k. The elements are moved within vs, the prefix of which is
+// returned.
+func topKMap(vs []map[string]bool, k int) []map[string]bool {
+	data := vs
+	n := len(data)
+
+	if k > n {
+		k = n
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	// a max heap of the least k elements seen so far
+	for i := (k - 1) / 2; i >= 0; i-- {
+		siftDown_sortMap(data, i, k, 0)
+	}
+	for i := k; i < n; i++ {
+		if bool(orderMap(data, i, 0)) {
+			data[0], data[i] = data[i], data[0]
+			siftDown_sortMap(data, 0, k, 0)
+		}
+	}
+	heapSort_sortMap(data, 0, k)
+
+	return data[:k]
+}
+func (e *example) sortBanana(vs []string) {
+	e.pdqsort_sortBanana(vs, 0, len(vs), bits.Len(uint(len(vs))))
+}
+
+func (e *example) stableSortBanana(vs []string) {
+	e.stable_stableSortBanana(vs, len(vs))
+}
+
+// insertionSort_sortBanana sorts data[a:b] using insertion sort.
+func (recv *example) insertionSort_sortBanana(data []string, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data[j] < data[j-1]); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// siftDown_sortBanana implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func (recv *example) siftDown_sortBanana(data []string, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && (data[first+child] < data[first+child+1]) {
+			child++
+		}
+		if !(data[first+root] < data[first+child]) {
+			return
+		}
+		data[first+root], data[first+child] = data[first+child], data[first+root]
+		root = child
+	}
+}
+
+func (recv *example) heapSort_sortBanana(data []string, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		recv.siftDown_sortBanana(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i] = data[first+i], data[first]
+		recv.siftDown_sortBanana(data, lo, i, first)
+	}
+}
+
+// pdqsort_sortBanana sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func (recv *example) pdqsort_sortBanana(data []string, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			recv.insertionSort_sortBanana(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			recv.heapSort_sortBanana(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			recv.breakPatterns_sortBanana(data, a, b)
+			limit--
+		}
+
+		pivot, hint := recv.choosePivot_sortBanana(data, a, b)
+		if hint == sorter.DecreasingHint {
+			recv.reverseRange_sortBanana(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if recv.partialInsertionSort_sortBanana(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !(data[a-1] < data[pivot]) {
+			mid := recv.partitionEqual_sortBanana(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := recv.partition_sortBanana(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			recv.pdqsort_sortBanana(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			recv.pdqsort_sortBanana(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_sortBanana does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func (recv *example) partition_sortBanana(data []string, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && (data[i] < data[a]) {
+		i++
+	}
+	for i <= j && !(data[j] < data[a]) {
+		j--
+	}
+	if i > j {
+		data[j], data[a] = data[a], data[j]
+		return j, true
+	}
+	data[i], data[j] = data[j], data[i]
+	i++
+	j--
+
+	for {
+		for i <= j && (data[i] < data[a]) {
+			i++
+		}
+		for i <= j && !(data[j] < data[a]) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	data[j], data[a] = data[a], data[j]
+	return j, false
+}
+
+// partitionEqual_sortBanana partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func (recv *example) partitionEqual_sortBanana(data []string, a, b, pivot int) (newpivot int) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !(data[a] < data[i]) {
+			i++
+		}
+		for i <= j && (data[a] < data[j]) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_sortBanana partially sorts a slice, returns true if the slice is sorted at the end.
+func (recv *example) partialInsertionSort_sortBanana(data []string, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !(data[i] < data[i-1]) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		data[i], data[i-1] = data[i-1], data[i]
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !(data[j] < data[j-1]) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !(data[j] < data[j-1]) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_sortBanana scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func (recv *example) breakPatterns_sortBanana(data []string, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			data[idx], data[a+other] = data[a+other], data[idx]
+		}
+	}
+}
+
+// choosePivot_sortBanana chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func (recv *example) choosePivot_sortBanana(data []string, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = recv.medianAdjacent_sortBanana(data, i, &swaps)
+			j = recv.medianAdjacent_sortBanana(data, j, &swaps)
+			k = recv.medianAdjacent_sortBanana(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = recv.median_sortBanana(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_sortBanana returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func (recv *example) order2_sortBanana(data []string, a, b int, swaps *int) (int, int) {
+	if data[b] < data[a] {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_sortBanana returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func (recv *example) median_sortBanana(data []string, a, b, c int, swaps *int) int {
+	a, b = recv.order2_sortBanana(data, a, b, swaps)
+	b, c = recv.order2_sortBanana(data, b, c, swaps)
+	a, b = recv.order2_sortBanana(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_sortBanana finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func (recv *example) medianAdjacent_sortBanana(data []string, a int, swaps *int) int {
+	return recv.median_sortBanana(data, a-1, a, a+1, swaps)
+}
+
+func (recv *example) reverseRange_sortBanana(data []string, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+}
+
+// insertionSort_stableSortBanana sorts data[a:b] using insertion sort.
+func (recv *example) insertionSort_stableSortBanana(data []string, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data[j] < data[j-1]); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// stable_stableSortBanana sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_stableSortBanana.
+func (recv *example) stable_stableSortBanana(data []string, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		recv.insertionSort_stableSortBanana(data, a, b)
+		a = b
+		b += blockSize
+	}
+	recv.insertionSort_stableSortBanana(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			recv.symMerge_stableSortBanana(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			recv.symMerge_stableSortBanana(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_stableSortBanana merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func (recv *example) symMerge_stableSortBanana(data []string, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if data[h] < data[a] {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1] = data[k+1], data[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(data[m] < data[h]) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1] = data[k-1], data[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(data[p-c] < data[c]) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		recv.rotate_stableSortBanana(data, start, m, end)
+	}
+	if a < start && start < mid {
+		recv.symMerge_stableSortBanana(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		recv.symMerge_stableSortBanana(data, mid, end, b)
+	}
+}
+
+// rotate_stableSortBanana rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func (recv *example) rotate_stableSortBanana(data []string, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			recv.swapRange_stableSortBanana(data, m-i, m, j)
+			i -= j
+		} else {
+			recv.swapRange_stableSortBanana(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	recv.swapRange_stableSortBanana(data, m-i, m, i)
+}
+
+func (recv *example) swapRange_stableSortBanana(data []string, a, b, n int) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i] = data[b+i], data[a+i]
+	}
+}
+
+// searchBanana returns the smallest index i at which vs[i] is not less than x,
+// or the length of vs if there is no such index. vs must be sorted.
+func (recv *example) searchBanana(vs []string, x string) int {
+	data := make([]string, 2)
+	data[1] = x
+
+	i, j := 0, len(vs)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data[0] = vs[h]
+		if data[0] < data[1] {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// searchUpperBanana returns the smallest index i at which x is less than vs[i],
+// or the length of vs if there is no such index. vs must be sorted.
+func (recv *example) searchUpperBanana(vs []string, x string) int {
+	data := make([]string, 2)
+	data[1] = x
+
+	i, j := 0, len(vs)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data[0] = vs[h]
+		if !(data[1] < data[0]) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// isSortedBanana returns whether data is sorted.
+func (recv *example) isSortedBanana(data []string) bool {
+	for i := len(data) - 1; i > 0; i-- {
+		if data[i] < data[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeSortedBanana returns the result of merging a and b, which must be sorted.
+// The merge is stable: of equal elements, those of a precede those of b.
+func (recv *example) mergeSortedBanana(a, b []string) []string {
+	data := make([]string, 2)
+	res := make([]string, 0, len(a)+len(b))
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		data[0] = b[j]
+		data[1] = a[i]
+		if data[0] < data[1] {
+			res = append(res, b[j])
+			j++
+		} else {
+			res = append(res, a[i])
+			i++
+		}
+	}
+	for ; i < len(a); i++ {
+		res = append(res, a[i])
+	}
+	for ; j < len(b); j++ {
+		res = append(res, b[j])
+	}
+
+	return res
+}
+
+// dedupeSortedBanana returns data with all but the first of each run of equal
+// elements removed. data must be sorted. The elements are moved within data, the
+// prefix of which is returned.
+func (recv *example) dedupeSortedBanana(data []string) []string {
+	if len(data) == 0 {
+		return data
+	}
+
+	k := 1
+	for i := 1; i < len(data); i++ {
+		if data[k-1] < data[i] {
+			data[k] = data[i]
+			k++
+		}
+	}
+
+	return data[:k]
+}
+
+// topKBanana returns the k least elements of vs, in order, using a heap of
+// size k. The elements are moved within vs, the prefix of which is
+// returned.
+func (recv *example) topKBanana(vs []string, k int) []string {
+	data := vs
+	n := len(data)
+
+	if k > n {
+		k = n
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	// a max heap of the least k elements seen so far
+	for i := (k - 1) / 2; i >= 0; i-- {
+		recv.siftDown_sortBanana(data, i, k, 0)
+	}
+	for i := k; i < n; i++ {
+		if data[i] < data[0] {
+			data[0], data[i] = data[i], data[0]
+			recv.siftDown_sortBanana(data, 0, k, 0)
+		}
+	}
+	recv.heapSort_sortBanana(data, 0, k)
+
+	return data[:k]
+}
+func sortReversedOtherMySlice(vs *other.MySlice) *other.MySlice {
+	theVs := vs.AsMutable()
+	pdqsort_sortReversedOtherMySlice(theVs, 0, theVs.Len(), bits.Len(uint(theVs.Len())))
+
+	return theVs.AsImmutable(vs)
+}
+
+func stableSortReversedOtherMySlice(vs *other.MySlice) *other.MySlice {
+	theVs := vs.AsMutable()
+	stable_stableSortReversedOtherMySlice(theVs, theVs.Len())
+
+	return theVs.AsImmutable(vs)
+}
+
+// swap_sortReversedOtherMySlice swaps the elements i and j of data, which must be mutable.
+func swap_sortReversedOtherMySlice(data *other.MySlice, i, j int) {
+	vi, vj := data.Get(i), data.Get(j)
+	data.Set(i, vj)
+	data.Set(j, vi)
+}
+
+// insertionSort_sortReversedOtherMySlice sorts data[a:b] using insertion sort.
+func insertionSort_sortReversedOtherMySlice(data *other.MySlice, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data.Get(j-1) < data.Get(j)); j-- {
+			swap_sortReversedOtherMySlice(data, j, j-1)
+		}
+	}
+}
+
+// siftDown_sortReversedOtherMySlice implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_sortReversedOtherMySlice(data *other.MySlice, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && (data.Get(first+child+1) < data.Get(first+child)) {
+			child++
+		}
+		if !(data.Get(first+child) < data.Get(first+root)) {
+			return
+		}
+		swap_sortReversedOtherMySlice(data, first+root, first+child)
+		root = child
+	}
+}
+
+func heapSort_sortReversedOtherMySlice(data *other.MySlice, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_sortReversedOtherMySlice(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		swap_sortReversedOtherMySlice(data, first, first+i)
+		siftDown_sortReversedOtherMySlice(data, lo, i, first)
+	}
+}
+
+// pdqsort_sortReversedOtherMySlice sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_sortReversedOtherMySlice(data *other.MySlice, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_sortReversedOtherMySlice(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_sortReversedOtherMySlice(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_sortReversedOtherMySlice(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_sortReversedOtherMySlice(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_sortReversedOtherMySlice(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_sortReversedOtherMySlice(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !(data.Get(pivot) < data.Get(a-1)) {
+			mid := partitionEqual_sortReversedOtherMySlice(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_sortReversedOtherMySlice(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_sortReversedOtherMySlice(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_sortReversedOtherMySlice(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_sortReversedOtherMySlice does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_sortReversedOtherMySlice(data *other.MySlice, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	swap_sortReversedOtherMySlice(data, a, pivot)
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && (data.Get(a) < data.Get(i)) {
+		i++
+	}
+	for i <= j && !(data.Get(a) < data.Get(j)) {
+		j--
+	}
+	if i > j {
+		swap_sortReversedOtherMySlice(data, j, a)
+		return j, true
+	}
+	swap_sortReversedOtherMySlice(data, i, j)
+	i++
+	j--
+
+	for {
+		for i <= j && (data.Get(a) < data.Get(i)) {
+			i++
+		}
+		for i <= j && !(data.Get(a) < data.Get(j)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		swap_sortReversedOtherMySlice(data, i, j)
+		i++
+		j--
+	}
+	swap_sortReversedOtherMySlice(data, j, a)
+	return j, false
+}
+
+// partitionEqual_sortReversedOtherMySlice partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_sortReversedOtherMySlice(data *other.MySlice, a, b, pivot int) (newpivot int) {
+	swap_sortReversedOtherMySlice(data, a, pivot)
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !(data.Get(i) < data.Get(a)) {
+			i++
+		}
+		for i <= j && (data.Get(j) < data.Get(a)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		swap_sortReversedOtherMySlice(data, i, j)
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_sortReversedOtherMySlice partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_sortReversedOtherMySlice(data *other.MySlice, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !(data.Get(i-1) < data.Get(i)) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		swap_sortReversedOtherMySlice(data, i, i-1)
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !(data.Get(j-1) < data.Get(j)) {
+					break
+				}
+				swap_sortReversedOtherMySlice(data, j, j-1)
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !(data.Get(j-1) < data.Get(j)) {
+					break
+				}
+				swap_sortReversedOtherMySlice(data, j, j-1)
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_sortReversedOtherMySlice scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_sortReversedOtherMySlice(data *other.MySlice, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			swap_sortReversedOtherMySlice(data, idx, a+other)
+		}
+	}
+}
+
+// choosePivot_sortReversedOtherMySlice chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_sortReversedOtherMySlice(data *other.MySlice, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_sortReversedOtherMySlice(data, i, &swaps)
+			j = medianAdjacent_sortReversedOtherMySlice(data, j, &swaps)
+			k = medianAdjacent_sortReversedOtherMySlice(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_sortReversedOtherMySlice(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_sortReversedOtherMySlice returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_sortReversedOtherMySlice(data *other.MySlice, a, b int, swaps *int) (int, int) {
+	if data.Get(a) < data.Get(b) {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_sortReversedOtherMySlice returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_sortReversedOtherMySlice(data *other.MySlice, a, b, c int, swaps *int) int {
+	a, b = order2_sortReversedOtherMySlice(data, a, b, swaps)
+	b, c = order2_sortReversedOtherMySlice(data, b, c, swaps)
+	a, b = order2_sortReversedOtherMySlice(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_sortReversedOtherMySlice finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_sortReversedOtherMySlice(data *other.MySlice, a int, swaps *int) int {
+	return median_sortReversedOtherMySlice(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_sortReversedOtherMySlice(data *other.MySlice, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		swap_sortReversedOtherMySlice(data, i, j)
+		i++
+		j--
+	}
+}
+
+// swap_stableSortReversedOtherMySlice swaps the elements i and j of data, which must be mutable.
+func swap_stableSortReversedOtherMySlice(data *other.MySlice, i, j int) {
+	vi, vj := data.Get(i), data.Get(j)
+	data.Set(i, vj)
+	data.Set(j, vi)
+}
+
+// insertionSort_stableSortReversedOtherMySlice sorts data[a:b] using insertion sort.
+func insertionSort_stableSortReversedOtherMySlice(data *other.MySlice, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data.Get(j-1) < data.Get(j)); j-- {
+			swap_stableSortReversedOtherMySlice(data, j, j-1)
+		}
+	}
+}
+
+// stable_stableSortReversedOtherMySlice sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_stableSortReversedOtherMySlice.
+func stable_stableSortReversedOtherMySlice(data *other.MySlice, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_stableSortReversedOtherMySlice(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_stableSortReversedOtherMySlice(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_stableSortReversedOtherMySlice(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_stableSortReversedOtherMySlice(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_stableSortReversedOtherMySlice merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_stableSortReversedOtherMySlice(data *other.MySlice, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if data.Get(a) < data.Get(h) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			swap_stableSortReversedOtherMySlice(data, k, k+1)
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(data.Get(h) < data.Get(m)) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			swap_stableSortReversedOtherMySlice(data, k, k-1)
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(data.Get(c) < data.Get(p-c)) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_stableSortReversedOtherMySlice(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_stableSortReversedOtherMySlice(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_stableSortReversedOtherMySlice(data, mid, end, b)
+	}
+}
+
+// rotate_stableSortReversedOtherMySlice rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_stableSortReversedOtherMySlice(data *other.MySlice, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_stableSortReversedOtherMySlice(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_stableSortReversedOtherMySlice(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_stableSortReversedOtherMySlice(data, m-i, m, i)
+}
+
+func swapRange_stableSortReversedOtherMySlice(data *other.MySlice, a, b, n int) {
+	for i := 0; i < n; i++ {
+		swap_stableSortReversedOtherMySlice(data, a+i, b+i)
+	}
+}
+
+// searchReversedOtherMySlice returns the smallest index i at which vs[i] is not less than x,
+// or the length of vs if there is no such index. vs must be sorted.
+func searchReversedOtherMySlice(vs *other.MySlice, x string) int {
+	data := other.NewMySliceLen(2).AsMutable()
+	data.Set(1, x)
+
+	i, j := 0, vs.Len()
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data.Set(0, vs.Get(h))
+		if data.Get(1) < data.Get(0) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// searchUpperReversedOtherMySlice returns the smallest index i at which x is less than vs[i],
+// or the length of vs if there is no such index. vs must be sorted.
+func searchUpperReversedOtherMySlice(vs *other.MySlice, x string) int {
+	data := other.NewMySliceLen(2).AsMutable()
+	data.Set(1, x)
+
+	i, j := 0, vs.Len()
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data.Set(0, vs.Get(h))
+		if !(data.Get(0) < data.Get(1)) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// isSortedReversedOtherMySlice returns whether data is sorted.
+func isSortedReversedOtherMySlice(data *other.MySlice) bool {
+	for i := data.Len() - 1; i > 0; i-- {
+		if data.Get(i-1) < data.Get(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeSortedReversedOtherMySlice returns the result of merging a and b, which must be sorted.
+// The merge is stable: of equal elements, those of a precede those of b.
+func mergeSortedReversedOtherMySlice(a, b *other.MySlice) *other.MySlice {
+	data := other.NewMySliceLen(2).AsMutable()
+	res := other.NewMySliceLen(0).AsMutable()
+
+	i, j := 0, 0
+	for i < a.Len() && j < b.Len() {
+		data.Set(0, b.Get(j))
+		data.Set(1, a.Get(i))
+		if data.Get(1) < data.Get(0) {
+			res = res.Append(b.Get(j))
+			j++
+		} else {
+			res = res.Append(a.Get(i))
+			i++
+		}
+	}
+	for ; i < a.Len(); i++ {
+		res = res.Append(a.Get(i))
+	}
+	for ; j < b.Len(); j++ {
+		res = res.Append(b.Get(j))
+	}
+
+	return res.AsImmutable(nil)
+}
+
+// dedupeSortedReversedOtherMySlice returns data with all but the first of each run of equal
+// elements removed. data must be sorted.
+func dedupeSortedReversedOtherMySlice(data *other.MySlice) *other.MySlice {
+	if data.Len() == 0 {
+		return data
+	}
+
+	res := other.NewMySliceLen(0).AsMutable().Append(data.Get(0))
+
+	last := 0
+	for i := 1; i < data.Len(); i++ {
+		if data.Get(i) < data.Get(last) {
+			res.Append(data.Get(i))
+			last = i
+		}
+	}
+
+	return res.AsImmutable(nil)
+}
+
+// topKReversedOtherMySlice returns the k least elements of vs, in order, using a heap of
+// size k.
+func topKReversedOtherMySlice(vs *other.MySlice, k int) *other.MySlice {
+	data := vs.AsMutable()
+	n := data.Len()
+
+	if k > n {
+		k = n
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	// a max heap of the least k elements seen so far
+	for i := (k - 1) / 2; i >= 0; i-- {
+		siftDown_sortReversedOtherMySlice(data, i, k, 0)
+	}
+	for i := k; i < n; i++ {
+		if data.Get(0) < data.Get(i) {
+			swap_sortReversedOtherMySlice(data, 0, i)
+			siftDown_sortReversedOtherMySlice(data, 0, k, 0)
+		}
+	}
+	heapSort_sortReversedOtherMySlice(data, 0, k)
+
+	res := other.NewMySliceLen(0).AsMutable()
+	for i := 0; i < k; i++ {
+		res.Append(data.Get(i))
+	}
+
+	return res.AsImmutable(nil)
+}
+func sortReversedOtherMySliceDesc(vs *other.MySlice) *other.MySlice {
+	theVs := vs.AsMutable()
+	pdqsort_sortReversedOtherMySliceDesc(theVs, 0, theVs.Len(), bits.Len(uint(theVs.Len())))
+
+	return theVs.AsImmutable(vs)
+}
+
+func stableSortReversedOtherMySliceDesc(vs *other.MySlice) *other.MySlice {
+	theVs := vs.AsMutable()
+	stable_stableSortReversedOtherMySliceDesc(theVs, theVs.Len())
+
+	return theVs.AsImmutable(vs)
+}
+
+// swap_sortReversedOtherMySliceDesc swaps the elements i and j of data, which must be mutable.
+func swap_sortReversedOtherMySliceDesc(data *other.MySlice, i, j int) {
+	vi, vj := data.Get(i), data.Get(j)
+	data.Set(i, vj)
+	data.Set(j, vi)
+}
+
+// insertionSort_sortReversedOtherMySliceDesc sorts data[a:b] using insertion sort.
+func insertionSort_sortReversedOtherMySliceDesc(data *other.MySlice, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data.Get(j) < data.Get(j-1)); j-- {
+			swap_sortReversedOtherMySliceDesc(data, j, j-1)
+		}
+	}
+}
+
+// siftDown_sortReversedOtherMySliceDesc implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_sortReversedOtherMySliceDesc(data *other.MySlice, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && (data.Get(first+child) < data.Get(first+child+1)) {
+			child++
+		}
+		if !(data.Get(first+root) < data.Get(first+child)) {
+			return
+		}
+		swap_sortReversedOtherMySliceDesc(data, first+root, first+child)
+		root = child
+	}
+}
+
+func heapSort_sortReversedOtherMySliceDesc(data *other.MySlice, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_sortReversedOtherMySliceDesc(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		swap_sortReversedOtherMySliceDesc(data, first, first+i)
+		siftDown_sortReversedOtherMySliceDesc(data, lo, i, first)
+	}
+}
+
+// pdqsort_sortReversedOtherMySliceDesc sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_sortReversedOtherMySliceDesc(data *other.MySlice, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_sortReversedOtherMySliceDesc(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_sortReversedOtherMySliceDesc(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_sortReversedOtherMySliceDesc(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_sortReversedOtherMySliceDesc(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_sortReversedOtherMySliceDesc(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_sortReversedOtherMySliceDesc(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !(data.Get(a-1) < data.Get(pivot)) {
+			mid := partitionEqual_sortReversedOtherMySliceDesc(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_sortReversedOtherMySliceDesc(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_sortReversedOtherMySliceDesc(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_sortReversedOtherMySliceDesc(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_sortReversedOtherMySliceDesc does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_sortReversedOtherMySliceDesc(data *other.MySlice, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	swap_sortReversedOtherMySliceDesc(data, a, pivot)
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && (data.Get(i) < data.Get(a)) {
+		i++
+	}
+	for i <= j && !(data.Get(j) < data.Get(a)) {
+		j--
+	}
+	if i > j {
+		swap_sortReversedOtherMySliceDesc(data, j, a)
+		return j, true
+	}
+	swap_sortReversedOtherMySliceDesc(data, i, j)
+	i++
+	j--
+
+	for {
+		for i <= j && (data.Get(i) < data.Get(a)) {
+			i++
+		}
+		for i <= j && !(data.Get(j) < data.Get(a)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		swap_sortReversedOtherMySliceDesc(data, i, j)
+		i++
+		j--
+	}
+	swap_sortReversedOtherMySliceDesc(data, j, a)
+	return j, false
+}
+
+// partitionEqual_sortReversedOtherMySliceDesc partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_sortReversedOtherMySliceDesc(data *other.MySlice, a, b, pivot int) (newpivot int) {
+	swap_sortReversedOtherMySliceDesc(data, a, pivot)
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !(data.Get(a) < data.Get(i)) {
+			i++
+		}
+		for i <= j && (data.Get(a) < data.Get(j)) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		swap_sortReversedOtherMySliceDesc(data, i, j)
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_sortReversedOtherMySliceDesc partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_sortReversedOtherMySliceDesc(data *other.MySlice, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !(data.Get(i) < data.Get(i-1)) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		swap_sortReversedOtherMySliceDesc(data, i, i-1)
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !(data.Get(j) < data.Get(j-1)) {
+					break
+				}
+				swap_sortReversedOtherMySliceDesc(data, j, j-1)
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !(data.Get(j) < data.Get(j-1)) {
+					break
+				}
+				swap_sortReversedOtherMySliceDesc(data, j, j-1)
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_sortReversedOtherMySliceDesc scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_sortReversedOtherMySliceDesc(data *other.MySlice, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			swap_sortReversedOtherMySliceDesc(data, idx, a+other)
+		}
+	}
+}
+
+// choosePivot_sortReversedOtherMySliceDesc chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_sortReversedOtherMySliceDesc(data *other.MySlice, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_sortReversedOtherMySliceDesc(data, i, &swaps)
+			j = medianAdjacent_sortReversedOtherMySliceDesc(data, j, &swaps)
+			k = medianAdjacent_sortReversedOtherMySliceDesc(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_sortReversedOtherMySliceDesc(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_sortReversedOtherMySliceDesc returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_sortReversedOtherMySliceDesc(data *other.MySlice, a, b int, swaps *int) (int, int) {
+	if data.Get(b) < data.Get(a) {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_sortReversedOtherMySliceDesc returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_sortReversedOtherMySliceDesc(data *other.MySlice, a, b, c int, swaps *int) int {
+	a, b = order2_sortReversedOtherMySliceDesc(data, a, b, swaps)
+	b, c = order2_sortReversedOtherMySliceDesc(data, b, c, swaps)
+	a, b = order2_sortReversedOtherMySliceDesc(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_sortReversedOtherMySliceDesc finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_sortReversedOtherMySliceDesc(data *other.MySlice, a int, swaps *int) int {
+	return median_sortReversedOtherMySliceDesc(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_sortReversedOtherMySliceDesc(data *other.MySlice, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		swap_sortReversedOtherMySliceDesc(data, i, j)
+		i++
+		j--
+	}
+}
+
+// swap_stableSortReversedOtherMySliceDesc swaps the elements i and j of data, which must be mutable.
+func swap_stableSortReversedOtherMySliceDesc(data *other.MySlice, i, j int) {
+	vi, vj := data.Get(i), data.Get(j)
+	data.Set(i, vj)
+	data.Set(j, vi)
+}
+
+// insertionSort_stableSortReversedOtherMySliceDesc sorts data[a:b] using insertion sort.
+func insertionSort_stableSortReversedOtherMySliceDesc(data *other.MySlice, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data.Get(j) < data.Get(j-1)); j-- {
+			swap_stableSortReversedOtherMySliceDesc(data, j, j-1)
+		}
+	}
+}
+
+// stable_stableSortReversedOtherMySliceDesc sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_stableSortReversedOtherMySliceDesc.
+func stable_stableSortReversedOtherMySliceDesc(data *other.MySlice, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_stableSortReversedOtherMySliceDesc(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_stableSortReversedOtherMySliceDesc(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_stableSortReversedOtherMySliceDesc(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_stableSortReversedOtherMySliceDesc(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_stableSortReversedOtherMySliceDesc merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_stableSortReversedOtherMySliceDesc(data *other.MySlice, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if data.Get(h) < data.Get(a) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			swap_stableSortReversedOtherMySliceDesc(data, k, k+1)
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(data.Get(m) < data.Get(h)) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			swap_stableSortReversedOtherMySliceDesc(data, k, k-1)
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(data.Get(p-c) < data.Get(c)) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_stableSortReversedOtherMySliceDesc(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_stableSortReversedOtherMySliceDesc(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_stableSortReversedOtherMySliceDesc(data, mid, end, b)
+	}
+}
+
+// rotate_stableSortReversedOtherMySliceDesc rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_stableSortReversedOtherMySliceDesc(data *other.MySlice, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_stableSortReversedOtherMySliceDesc(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_stableSortReversedOtherMySliceDesc(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_stableSortReversedOtherMySliceDesc(data, m-i, m, i)
+}
+
+func swapRange_stableSortReversedOtherMySliceDesc(data *other.MySlice, a, b, n int) {
+	for i := 0; i < n; i++ {
+		swap_stableSortReversedOtherMySliceDesc(data, a+i, b+i)
+	}
+}
+
+// searchReversedOtherMySliceDesc returns the smallest index i at which vs[i] is not less than x,
+// or the length of vs if there is no such index. vs must be sorted.
+func searchReversedOtherMySliceDesc(vs *other.MySlice, x string) int {
+	data := other.NewMySliceLen(2).AsMutable()
+	data.Set(1, x)
+
+	i, j := 0, vs.Len()
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data.Set(0, vs.Get(h))
+		if data.Get(0) < data.Get(1) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// searchUpperReversedOtherMySliceDesc returns the smallest index i at which x is less than vs[i],
+// or the length of vs if there is no such index. vs must be sorted.
+func searchUpperReversedOtherMySliceDesc(vs *other.MySlice, x string) int {
+	data := other.NewMySliceLen(2).AsMutable()
+	data.Set(1, x)
+
+	i, j := 0, vs.Len()
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		data.Set(0, vs.Get(h))
+		if !(data.Get(1) < data.Get(0)) {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// isSortedReversedOtherMySliceDesc returns whether data is sorted.
+func isSortedReversedOtherMySliceDesc(data *other.MySlice) bool {
+	for i := data.Len() - 1; i > 0; i-- {
+		if data.Get(i) < data.Get(i-1) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeSortedReversedOtherMySliceDesc returns the result of merging a and b, which must be sorted.
+// The merge is stable: of equal elements, those of a precede those of b.
+func mergeSortedReversedOtherMySliceDesc(a, b *other.MySlice) *other.MySlice {
+	data := other.NewMySliceLen(2).AsMutable()
+	res := other.NewMySliceLen(0).AsMutable()
+
+	i, j := 0, 0
+	for i < a.Len() && j < b.Len() {
+		data.Set(0, b.Get(j))
+		data.Set(1, a.Get(i))
+		if data.Get(0) < data.Get(1) {
+			res = res.Append(b.Get(j))
+			j++
+		} else {
+			res = res.Append(a.Get(i))
+			i++
+		}
+	}
+	for ; i < a.Len(); i++ {
+		res = res.Append(a.Get(i))
+	}
+	for ; j < b.Len(); j++ {
+		res = res.Append(b.Get(j))
+	}
+
+	return res.AsImmutable(nil)
+}
+
+// dedupeSortedReversedOtherMySliceDesc returns data with all but the first of each run of equal
+// elements removed. data must be sorted.
+func dedupeSortedReversedOtherMySliceDesc(data *other.MySlice) *other.MySlice {
+	if data.Len() == 0 {
+		return data
+	}
+
+	res := other.NewMySliceLen(0).AsMutable().Append(data.Get(0))
+
+	last := 0
+	for i := 1; i < data.Len(); i++ {
+		if data.Get(last) < data.Get(i) {
+			res.Append(data.Get(i))
+			last = i
+		}
+	}
+
+	return res.AsImmutable(nil)
+}
+
+// topKReversedOtherMySliceDesc returns the k least elements of vs, in order, using a heap of
+// size k.
+func topKReversedOtherMySliceDesc(vs *other.MySlice, k int) *other.MySlice {
+	data := vs.AsMutable()
+	n := data.Len()
+
+	if k > n {
+		k = n
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	// a max heap of the least k elements seen so far
+	for i := (k - 1) / 2; i >= 0; i-- {
+		siftDown_sortReversedOtherMySliceDesc(data, i, k, 0)
+	}
+	for i := k; i < n; i++ {
+		if data.Get(i) < data.Get(0) {
+			swap_sortReversedOtherMySliceDesc(data, 0, i)
+			siftDown_sortReversedOtherMySliceDesc(data, 0, k, 0)
+		}
+	}
+	heapSort_sortReversedOtherMySliceDesc(data, 0, k)
+
+	res := other.NewMySliceLen(0).AsMutable()
+	for i := 0; i < k; i++ {
+		res.Append(data.Get(i))
+	}
+
+	return res.AsImmutable(nil)
 }
@@ -1,6 +1,6 @@
 package main
 
-//go:generate gobin -m -run myitcv.io/sorter/cmd/sortGen
+//go:generate gobin -m -run myitcv.io/sorter/cmd/sortGen -helpers
 //go:generate gobin -m -run myitcv.io/immutable/cmd/immutableGen
 
 import (
@@ -13,6 +13,12 @@ import (
 
 type _Imm_MySlice []string
 
+//immutableGen:persistent
+type _Imm_MyPersistentSlice []string
+
+//immutableGen:container
+type _Imm_MyContainerSlice []string
+
 type person struct {
 	name string
 	age  int
@@ -46,11 +52,40 @@ func orderMySlice(things *MySlice, i, j int) sorter.Ordered {
 	return things.Get(i) < things.Get(j)
 }
 
+// MATCH - persistent
+func orderMyPersistentSlice(things *MyPersistentSlice, i, j int) sorter.Ordered {
+	return things.Get(i) < things.Get(j)
+}
+
+// MATCH - container
+func orderMyContainerSlice(things *MyContainerSlice, i, j int) sorter.Ordered {
+	return things.Get(i) < things.Get(j)
+}
+
+// MATCH - array
+func orderArrayByName(persons [3]person, i, j int) sorter.Ordered {
+	return persons[i].name < persons[j].name
+}
+
+// MATCH - array, not inlined
+func orderArrayByAge(persons [3]person, i, j int) sorter.Ordered {
+	a, b := persons[i], persons[j]
+	return a.age < b.age
+}
+
+// MATCH - pointer to array
+func orderArrayPointerByName(persons *[3]person, i, j int) sorter.Ordered {
+	return persons[i].name < persons[j].name
+}
+
 // MATCH - other package
 func orderOtherMySlice(things *other.MySlice, i, j int) sorter.Ordered {
 	return things.Get(i) < things.Get(j)
 }
 
+// MATCH - composed
+var orderReversedOtherMySlice = sorter.Desc(orderOtherMySlice)
+
 // fail
 func order(persons []person, i, j int) sorter.Ordered {
 	return persons[i].name < persons[j].name
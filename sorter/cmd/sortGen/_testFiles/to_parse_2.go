@@ -8,3 +8,6 @@ import mysorter "myitcv.io/sorter"
 func OrderByAge(persons []person, i, j int) mysorter.Ordered {
 	return persons[i].age < persons[j].age
 }
+
+// MATCH - composed of order functions in different files
+var OrderByAgeThenName = mysorter.Then(OrderByAge, orderByName)
@@ -0,0 +1,225 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"myitcv.io/sorter"
+)
+
+// descSuffix is the suffix of the name of the descending variant of a
+// composed order
+const descSuffix = "Desc"
+
+// a composedOrder is a package-level variable the name of which is that of an
+// order function, and the value of which is a call to sorter.Then or
+// sorter.Desc, e.g.
+//
+//	var orderByAgeThenName = sorter.Then(orderByAge, orderByName)
+type composedOrder struct {
+	name *ast.Ident
+	call *ast.CallExpr
+
+	// the name by which the sorter package is imported in file, the file in
+	// which the variable is declared
+	theImport string
+	file      *ast.File
+}
+
+func (g *generator) getComposed() []composedOrder {
+	theImport := g.sorterImport()
+
+	if theImport == "" {
+		return nil
+	}
+
+	var res []composedOrder
+
+	for _, d := range g.file.Decls {
+		gd, ok := d.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+
+		for _, s := range gd.Specs {
+			vs := s.(*ast.ValueSpec)
+
+			if len(vs.Names) != len(vs.Values) {
+				continue
+			}
+
+			for i, n := range vs.Names {
+				if !orderFnRegex.MatchString(n.Name) {
+					continue
+				}
+
+				call, ok := vs.Values[i].(*ast.CallExpr)
+				if !ok || !isComposition(call, theImport) {
+					continue
+				}
+
+				g.ctx.Infof("found a composed order at %v", g.fset.Position(n.Pos()))
+
+				res = append(res, composedOrder{
+					name:      n,
+					call:      call,
+					theImport: theImport,
+					file:      g.file,
+				})
+			}
+		}
+	}
+
+	return res
+}
+
+// isComposition returns whether call is a call to sorter.Then or sorter.Desc,
+// where theImport is the name by which the sorter package is imported
+func isComposition(call *ast.CallExpr, theImport string) bool {
+	se, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	id, ok := se.X.(*ast.Ident)
+	if !ok || id.Name != theImport {
+		return false
+	}
+
+	return se.Sel.Name == sorter.ThenName || se.Sel.Name == sorter.DescName
+}
+
+func (g *generator) createComposedToGen(cos []composedOrder, importMap map[string]bool) []toGen {
+	var funs []toGen
+
+	for _, co := range cos {
+		seen := map[string]bool{co.name.Name: true}
+
+		less, base, imps := g.composedLess(co.call, co, seen)
+
+		addImports(importMap, findImports(base.orderTyp, base.file.Imports))
+		addImports(importMap, imps)
+
+		var elem, newFn string
+		if *fHelpers && !base.isArray {
+			elem, newFn = g.elemType(base, importMap)
+		}
+
+		asc := toGen{
+			orderFn: co.name.Name,
+			typ:     g.dataType(base),
+			less:    less,
+			elem:    elem,
+			newFn:   newFn,
+			imm:     base.isImmSlice,
+			array:   base.isArray,
+		}
+
+		desc := asc
+		desc.orderFn += descSuffix
+		desc.less = func(i, j string) string {
+			return less(j, i)
+		}
+
+		funs = append(funs, asc, desc)
+	}
+
+	return funs
+}
+
+// composedLess returns the Less template function for e, an argument of (or
+// call to) sorter.Then or sorter.Desc in the composed order co. It also
+// returns the match of an order function of which e is composed, that
+// determines the slice type, and the imports required by any order functions
+// that have been inlined. seen is the set of composed orders currently being
+// resolved, in order to detect cycles.
+func (g *generator) composedLess(e ast.Expr, co composedOrder, seen map[string]bool) (func(i, j string) string, match, map[*ast.ImportSpec]bool) {
+	pos := g.fset.Position(e.Pos())
+
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		if m, ok := g.orders[e.Name]; ok {
+			less, inlined := g.lessFunc(m)
+
+			imps := make(map[*ast.ImportSpec]bool)
+			if inlined != nil {
+				imps = findImports(inlined, m.file.Imports)
+			}
+
+			return less, m, imps
+		}
+
+		if o, ok := g.composed[e.Name]; ok {
+			if seen[e.Name] {
+				fatalf("composed order %v refers to itself at %v", e.Name, pos)
+			}
+
+			seen[e.Name] = true
+			defer delete(seen, e.Name)
+
+			return g.composedLess(o.call, o, seen)
+		}
+
+		fatalf("%v at %v is neither an order function nor a composed order", e.Name, pos)
+
+	case *ast.CallExpr:
+		if !isComposition(e, co.theImport) {
+			break
+		}
+
+		fn := e.Fun.(*ast.SelectorExpr).Sel.Name
+
+		if len(e.Args) == 0 || fn == sorter.DescName && len(e.Args) != 1 {
+			fatalf("wrong number of arguments to %v.%v at %v", co.theImport, fn, pos)
+		}
+
+		var lesses []func(i, j string) string
+		var base match
+
+		imps := make(map[*ast.ImportSpec]bool)
+
+		for _, a := range e.Args {
+			less, m, is := g.composedLess(a, co, seen)
+
+			if base.fun == nil {
+				base = m
+			} else if info := g.ctx.Pkg.TypesInfo; !types.Identical(info.TypeOf(base.orderTyp), info.TypeOf(m.orderTyp)) {
+				fatalf("order functions %v and %v composed at %v order different types", base.fun.Name, m.fun.Name, pos)
+			}
+
+			for i := range is {
+				imps[i] = true
+			}
+
+			lesses = append(lesses, less)
+		}
+
+		if fn == sorter.DescName {
+			return func(i, j string) string {
+				return lesses[0](j, i)
+			}, base, imps
+		}
+
+		// an element is less than another if it is less according to the
+		// first order function, or equal according to the first (i.e.
+		// neither is less than the other) and less according to the rest
+		return func(i, j string) string {
+			n := len(lesses) - 1
+
+			res := lesses[n](i, j)
+			for k := n - 1; k >= 0; k-- {
+				res = fmt.Sprintf("(%v || !%v && %v)", lesses[k](i, j), lesses[k](j, i), res)
+			}
+
+			return res
+		}, base, imps
+	}
+
+	fatalf("composed order at %v must be composed of order functions, composed orders and calls to %v.%v or %v.%v", pos, co.theImport, sorter.ThenName, co.theImport, sorter.DescName)
+	return nil, match{}, nil
+}
@@ -0,0 +1,251 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"regexp"
+	"strings"
+)
+
+const (
+	// dataVar is the name of the slice parameter of the functions declared by
+	// pdqsortTmpl, stableTmpl and helpersTmpl
+	dataVar = "data"
+
+	// helperRecv is the name of the receiver of those functions, where the order
+	// function is a method
+	helperRecv = "recv"
+)
+
+// reserved is the set of identifiers declared or used by the generated sort
+// functions. An order function the return expression of which refers to any
+// of these, other than via its parameters, is not inlined.
+var reserved = map[string]bool{
+	dataVar:    true,
+	helperRecv: true,
+	"vs":       true,
+	"theVs":    true,
+	"bits":     true,
+	"sorter":   true,
+}
+
+func init() {
+	comment := regexp.MustCompile(`(?m)//.*$`)
+	action := regexp.MustCompile(`{{[^}]*}}`)
+	ident := regexp.MustCompile(`[[:alpha:]_][[:word:]]*`)
+
+	for _, t := range []string{sortFnsTmpl, pdqsortTmpl, stableTmpl, helpersTmpl} {
+		t = action.ReplaceAllString(comment.ReplaceAllString(t, ""), "")
+		for _, id := range ident.FindAllString(t, -1) {
+			// keywords and predeclared identifiers can't be redeclared by
+			// the generated code
+			if token.Lookup(id).IsKeyword() || types.Universe.Lookup(id) != nil {
+				continue
+			}
+			reserved[id] = true
+		}
+	}
+}
+
+// lessFunc returns the function used as the Less template function for the
+// order function of m: it returns a bool expression for whether element i of
+// dataVar is less than element j. Where the body of the order function is a
+// single return statement, the expression is the result of that statement
+// with the parameters (and receiver) of the function substituted, i.e. the
+// order function is inlined. In that case the inlined expression is also
+// returned, in order that the imports it requires can be determined;
+// otherwise the expression is a call to the order function.
+func (g *generator) lessFunc(m match) (func(i, j string) string, ast.Expr) {
+	fun := m.fun
+
+	// data is the expression for the slice parameter of fun in terms of
+	// dataVar, which is a pointer where fun takes an array by value
+	data := dataVar
+	if m.byValue() {
+		data = "*" + dataVar
+	}
+
+	call := func(i, j string) string {
+		fn := fun.Name.Name
+		if fun.Recv != nil {
+			fn = helperRecv + "." + fn
+		}
+		return fmt.Sprintf("bool(%v(%v, %v, %v))", fn, data, i, j)
+	}
+
+	if fun.Body == nil || len(fun.Body.List) != 1 {
+		return call, nil
+	}
+
+	rs, ok := fun.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(rs.Results) != 1 {
+		return call, nil
+	}
+
+	expr := rs.Results[0]
+
+	// params maps the names of the parameters of fun, and its receiver, to
+	// the role they play: dataVar, i, j or helperRecv
+	params := make(map[string]string)
+
+	roles := []string{dataVar, "i", "j"}
+	for _, f := range fun.Type.Params.List {
+		for _, n := range f.Names {
+			if n.Name == "_" {
+				return call, nil
+			}
+			params[n.Name] = roles[0]
+			roles = roles[1:]
+		}
+	}
+
+	if fun.Recv != nil {
+		for _, n := range fun.Recv.List[0].Names {
+			params[n.Name] = helperRecv
+		}
+	}
+
+	// a subst is the substitution of a parameter in the source of expr
+	type subst struct {
+		pos, end int
+		role     string
+
+		// bare indicates the substituted expression does not need to be
+		// parenthesised
+		bare bool
+	}
+
+	var substs []subst
+	var stack []ast.Node
+
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+
+		var parent ast.Node
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1]
+		}
+		stack = append(stack, n)
+
+		switch n := n.(type) {
+		case *ast.FuncLit, *ast.KeyValueExpr:
+			// the scoping of the identifiers within either is beyond the
+			// simple substitution we perform
+			ok = false
+		case *ast.Ident:
+			if se, isSel := parent.(*ast.SelectorExpr); isSel && se.Sel == n {
+				break
+			}
+
+			role, isParam := params[n.Name]
+			if !isParam {
+				if reserved[n.Name] {
+					ok = false
+				}
+				break
+			}
+
+			var bare bool
+			switch p := parent.(type) {
+			case *ast.IndexExpr:
+				bare = p.Index == n
+			case *ast.CallExpr:
+				bare = p.Fun != n
+			case *ast.SliceExpr:
+				bare = p.X != n
+			case *ast.ParenExpr:
+				bare = true
+			}
+
+			substs = append(substs, subst{
+				pos:  g.fset.Position(n.Pos()).Offset,
+				end:  g.fset.Position(n.End()).Offset,
+				role: role,
+				bare: bare,
+			})
+		}
+
+		return true
+	})
+
+	// the generated code declares variables only for use as the indices
+	// passed to Less, hence an expression that does not refer to both i and
+	// j is not inlined
+	used := make(map[string]bool)
+	for _, s := range substs {
+		used[s.role] = true
+	}
+
+	if !ok || !used["i"] || !used["j"] {
+		return call, nil
+	}
+
+	src := g.source(expr.Pos())
+	start, end := g.fset.Position(expr.Pos()).Offset, g.fset.Position(expr.End()).Offset
+
+	// we don't attempt to preserve comments within expr
+	if txt := string(src[start:end]); strings.Contains(txt, "//") || strings.Contains(txt, "/*") {
+		return call, nil
+	}
+
+	// a comparison is an untyped bool, and so need only be parenthesised;
+	// anything else could be a sorter.Ordered, and so is converted
+	format := "bool(%v)"
+	if be, ok := ast.Unparen(expr).(*ast.BinaryExpr); ok {
+		switch be.Op {
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			format = "(%v)"
+		}
+	}
+
+	less := func(i, j string) string {
+		args := map[string]string{dataVar: data, "i": i, "j": j, helperRecv: helperRecv}
+
+		var sb strings.Builder
+
+		last := start
+		for _, s := range substs {
+			sb.Write(src[last:s.pos])
+
+			a := args[s.role]
+			if !s.bare && !token.IsIdentifier(a) {
+				a = "(" + a + ")"
+			}
+			sb.WriteString(a)
+
+			last = s.end
+		}
+		sb.Write(src[last:end])
+
+		return fmt.Sprintf(format, sb.String())
+	}
+
+	return less, expr
+}
+
+// source returns the contents of the file containing pos
+func (g *generator) source(pos token.Pos) []byte {
+	fn := g.fset.Position(pos).Filename
+
+	if src, ok := g.srcCache[fn]; ok {
+		return src
+	}
+
+	src, err := ioutil.ReadFile(fn)
+	if err != nil {
+		fatalf("could not read %v: %v", fn, err)
+	}
+
+	g.srcCache[fn] = src
+
+	return src
+}
@@ -12,31 +12,30 @@ import (
 	"go/parser"
 	"go/printer"
 	"go/token"
-	"html/template"
-	"io/ioutil"
-	"log"
+	"go/types"
 	"os"
 	"path"
 	"regexp"
-	"runtime"
 	"sort"
 	"strings"
+	"text/template"
 	"unicode"
 	"unicode/utf8"
 
-	"golang.org/x/tools/imports"
-
 	"myitcv.io/gogenerate"
 	"myitcv.io/immutable/util"
 	"myitcv.io/sorter"
 )
 
 const (
-	sortGenCmd           = "sortGen"
 	sortGenCmdImportPath = "myitcv.io/sorter/cmd/sortGen"
 	orderPrefix          = "order"
 )
 
+var (
+	fHelpers = flag.Bool("helpers", false, "generate search, isSorted, mergeSorted, dedupeSorted and topK functions for each order function")
+)
+
 // matching related vars
 var (
 	orderFnRegex    *regexp.Regexp
@@ -45,12 +44,6 @@ var (
 	invalidFileChar *regexp.Regexp
 )
 
-// flags
-var (
-	fLicenseFile = gogenerate.LicenseFileFlag(flag.CommandLine)
-	fGoGenLog    = gogenerate.LogFlag(flag.CommandLine)
-)
-
 func init() {
 	r, n := utf8.DecodeRuneInString(orderPrefix)
 	if r == utf8.RuneError {
@@ -72,118 +65,84 @@ func init() {
 }
 
 func main() {
-	log.SetFlags(0)
-	log.SetPrefix(sortGenCmd + ": ")
-
-	flag.Parse()
-
-	gogenerate.DefaultLogLevel(fGoGenLog, gogenerate.LogFatal)
-
-	envFile, ok := os.LookupEnv(gogenerate.GOFILE)
-	if !ok {
-		fatalf("env not correct; missing %v", gogenerate.GOFILE)
+	g := &gogenerate.Generator{
+		ImportPath: sortGenCmdImportPath,
+		PerPackage: true,
+		Format:     gogenerate.FormatGoimports,
+		Generate:   gen,
 	}
 
-	wd, err := os.Getwd()
-	if err != nil {
-		fatalf("unable to get working directory: %v", err)
-	}
-
-	tags := make(map[string]bool)
-
-	goos := os.Getenv("GOOS")
-	if goos == "" {
-		goos = runtime.GOOS
-	}
-	tags[goos] = true
-
-	goarch := os.Getenv("GOARCH")
-	if goarch == "" {
-		goarch = runtime.GOARCH
-	}
-	tags[goarch] = true
-
-	pathDirFiles, err := gogenerate.FilesContainingCmd(wd, sortGenCmdImportPath, tags)
-	if err != nil {
-		fatalf("could not determine if we are the first file: %v", err)
-	}
-
-	if pathDirFiles == nil {
-		fatalf("cannot find any files containing the %v or %v directive", sortGenCmdImportPath, sortGenCmd)
-	}
-
-	if pathDirFiles[envFile] > 1 {
-		fatalf("expected a single occurrence of %v directive in %v. Got: %v", sortGenCmdImportPath, envFile, pathDirFiles)
-	}
-
-	license, err := gogenerate.CommentLicenseHeader(fLicenseFile)
-	if err != nil {
-		fatalf("could not comment license file: %v", err)
-	}
-
-	// if we get here, we know we are the first file...
-
-	gen(wd, license)
+	os.Exit(g.Main())
 }
 
-func gen(dir, license string) {
-	bpkg, err := build.ImportDir(dir, 0)
+func gen(c *gogenerate.Context) error {
+	bpkg, err := build.ImportDir(c.Dir, 0)
 	if err != nil {
-		fatalf("could not load package in dir %v: %v", dir, err)
+		fatalf("could not load package in dir %v: %v", c.Dir, err)
 	}
 
 	g := &generator{
+		ctx:      c,
 		pkg:      bpkg,
 		pkgCache: map[string]*build.Package{bpkg.ImportPath: bpkg},
 		typCache: make(map[string]map[string]bool),
-		license:  license,
+		srcCache: make(map[string][]byte),
+		orders:   make(map[string]match),
+		composed: make(map[string]composedOrder),
+		fset:     c.Pkg.Fset,
 	}
 
-	g.fset = token.NewFileSet()
-
 	// do this early
 	g.findImmSlices(g.pkg)
 
-	notGenByUs := func(fi os.FileInfo) bool {
-		return !gogenerate.FileGeneratedBy(fi.Name(), sortGenCmd)
-	}
+	// a composed order can refer to order functions and composed orders
+	// declared in any file in the package, hence we find all of them first
+	files := c.Files()
+	matches := make([][]match, len(files))
+	composed := make([][]composedOrder, len(files))
 
-	pkgs, err := parser.ParseDir(g.fset, g.pkg.Dir, notGenByUs, 0)
-	if err != nil {
-		fatalf("could not parse dir %v: %v", g.pkg.Dir, err)
-	}
+	for i, f := range files {
+		g.file = f
 
-	// we are only interested in the package itself, not the exported test package (xtest)
-	pkg, ok := pkgs[g.pkg.Name]
-	if !ok {
-		fatalf("unable to resolve parsed pkg %v", g.pkg.Name)
+		matches[i] = g.getMatches()
+		composed[i] = g.getComposed()
+
+		for _, m := range matches[i] {
+			if m.fun.Recv == nil {
+				g.orders[m.fun.Name.Name] = m
+			}
+		}
+
+		for _, co := range composed[i] {
+			g.composed[co.name.Name] = co
+		}
 	}
 
-	for _, f := range pkg.Files {
-		g.buf = bytes.NewBuffer(nil)
+	for i, f := range files {
 		g.file = f
 
-		matches := g.getMatches()
-
-		if len(matches) == 0 {
+		if len(matches[i]) == 0 && len(composed[i]) == 0 {
 			continue
 		}
 
-		toGen, importMap := g.createToGen(matches)
+		toGen, importMap := g.createToGen(matches[i])
+		toGen = append(toGen, g.createComposedToGen(composed[i], importMap)...)
 
 		if len(toGen) > 0 {
 			g.genMatches(toGen, importMap)
 		}
 	}
+
+	return nil
 }
 
 // a generator is the generator for a given package
 type generator struct {
+	ctx *gogenerate.Context
+
 	// the package in which we are generating
 	pkg *build.Package
 
-	license string
-
 	fset *token.FileSet
 
 	// a cache of build packages that maps a package import path to
@@ -196,6 +155,15 @@ type generator struct {
 	// name)
 	typCache map[string]map[string]bool
 
+	// a cache of the contents of files from which order functions are
+	// inlined, keyed by file name
+	srcCache map[string][]byte
+
+	// the order functions (but not methods) and composed orders declared in
+	// the package, keyed by name
+	orders   map[string]match
+	composed map[string]composedOrder
+
 	// the current file being analysed
 	file *ast.File
 
@@ -210,7 +178,24 @@ type toGen struct {
 	recvVar string
 	recvTyp string
 
+	// recvBase is the type of the receiver, where the order function is a
+	// method
+	recvBase string
+
+	// less is the Less template function for the order function
+	less func(i, j string) string
+
+	// elem is the element type of the slice, and newFn the function that
+	// creates an immutable slice of a given length; both are only set where
+	// helpers are generated
+	elem  string
+	newFn string
+
 	imm bool
+
+	// array indicates a fixed-size array (or pointer to one), for which
+	// helpers are not generated
+	array bool
 }
 
 func (g *generator) findImmSlices(bp *build.Package) map[string]bool {
@@ -291,9 +276,9 @@ func (g *generator) findImmSlices(bp *build.Package) map[string]bool {
 
 // getMatches returns a map[string]fileMatches where the string is the file path where
 // the matches were found
-func (g *generator) getMatches() []match {
-
-	// see whether it imports the sorter package
+// sorterImport returns the name by which the current file imports the sorter
+// package, or "" if it does not
+func (g *generator) sorterImport() string {
 	theImport := ""
 
 	for _, s := range g.file.Imports {
@@ -308,6 +293,14 @@ func (g *generator) getMatches() []match {
 		}
 	}
 
+	return theImport
+}
+
+func (g *generator) getMatches() []match {
+
+	// see whether it imports the sorter package
+	theImport := g.sorterImport()
+
 	if theImport == "" {
 		// if it's not import we can't have any templates in here...
 		return nil
@@ -367,7 +360,8 @@ Decls:
 		}
 
 		m := match{
-			fun: fun,
+			fun:  fun,
+			file: g.file,
 		}
 
 		if g.isSliceExpr(paramList[0]) {
@@ -380,6 +374,11 @@ Decls:
 			m.isImmSlice = true
 		}
 
+		if g.isArrayExpr(paramList[0]) {
+			m.orderTyp = paramList[0]
+			m.isArray = true
+		}
+
 		if m.orderTyp == nil {
 			continue
 		}
@@ -390,7 +389,7 @@ Decls:
 			}
 		}
 
-		infof("found a match at %v", g.fset.Position(fun.Pos()))
+		g.ctx.Infof("found a match at %v", g.fset.Position(fun.Pos()))
 
 		matches = append(matches, m)
 	}
@@ -405,16 +404,11 @@ func (g *generator) createToGen(matches []match) ([]toGen, map[string]bool) {
 
 	// we need to union the list of functions
 	for _, match := range matches {
-		var buf bytes.Buffer
-
-		if err := printer.Fprint(&buf, g.fset, match.orderTyp); err != nil {
-			fatalf("could not ast print type: %v", err)
-		}
-
-		sliceIdent := buf.String()
+		sliceIdent := g.dataType(match)
 
 		recv := ""
 		recvVar := ""
+		recvBase := ""
 
 		if match.fun.Recv != nil {
 			var buf bytes.Buffer
@@ -422,43 +416,136 @@ func (g *generator) createToGen(matches []match) ([]toGen, map[string]bool) {
 			// we know at this point we have a valid method...
 			recvVar = match.fun.Recv.List[0].Names[0].Name
 
-			buf.WriteString("(")
-			buf.WriteString(recvVar)
-
 			if err := printer.Fprint(&buf, g.fset, match.fun.Recv.List[0].Type); err != nil {
 				fatalf("could not ast print recv: %v", err)
 			}
 
-			buf.WriteString(")")
-
-			recv = buf.String()
+			recvBase = buf.String()
+			recv = "(" + recvVar + " " + recvBase + ")"
 		}
 
-		// we need to calculate the required imports
+		less, inlined := g.lessFunc(match)
+
+		// we need to calculate the required imports, including those of an
+		// inlined order function
 		importMatches := findImports(match.orderTyp, g.file.Imports)
 
-		for i := range importMatches {
-			importName := i.Path.Value
-			if i.Name != nil {
-				importName = i.Name.Name + " " + importName
+		if inlined != nil {
+			for i := range findImports(inlined, g.file.Imports) {
+				importMatches[i] = true
 			}
+		}
 
-			importMap[importName] = true
+		addImports(importMap, importMatches)
+
+		var elem, newFn string
+		if *fHelpers && !match.isArray {
+			elem, newFn = g.elemType(match, importMap)
 		}
 
 		funs = append(funs, toGen{
-			orderFn: match.fun.Name.Name,
-			typ:     sliceIdent,
-			recvTyp: recv,
-			recvVar: recvVar,
+			orderFn:  match.fun.Name.Name,
+			typ:      sliceIdent,
+			recvTyp:  recv,
+			recvVar:  recvVar,
+			recvBase: recvBase,
+
+			less: less,
 
-			imm: match.isImmSlice,
+			elem:  elem,
+			newFn: newFn,
+
+			imm:   match.isImmSlice,
+			array: match.isArray,
 		})
 	}
 
 	return funs, importMap
 }
 
+// elemType returns the element type of the slice type of match, and for an
+// immutable slice the name of the function that creates an immutable slice of
+// a given length, adding any imports either requires to importMap
+func (g *generator) elemType(match match, importMap map[string]bool) (string, string) {
+	if !match.isImmSlice {
+		var buf bytes.Buffer
+
+		if err := printer.Fprint(&buf, g.fset, match.orderTyp.(*ast.ArrayType).Elt); err != nil {
+			fatalf("could not ast print type: %v", err)
+		}
+
+		return buf.String(), ""
+	}
+
+	pos := g.fset.Position(match.orderTyp.Pos())
+
+	typ := g.ctx.Pkg.TypesInfo.TypeOf(match.orderTyp)
+	if typ == nil {
+		fatalf("could not determine the type of the immutable slice at %v", pos)
+	}
+
+	// the element type of an immutable slice is the result type of its Get
+	// method
+	obj, _, _ := types.LookupFieldOrMethod(typ, true, g.ctx.Pkg.Types, "Get")
+	get, ok := obj.(*types.Func)
+	if !ok {
+		fatalf("could not find the Get method of the immutable slice at %v", pos)
+	}
+
+	// qualify resolves packages to the names by which they are imported in
+	// the current file
+	qualify := func(p *types.Package) string {
+		if p == g.ctx.Pkg.Types {
+			return ""
+		}
+
+		for _, is := range match.file.Imports {
+			if strings.Trim(is.Path.Value, "\"") != p.Path() {
+				continue
+			}
+
+			importName := is.Path.Value
+			name := p.Name()
+			if is.Name != nil {
+				importName = is.Name.Name + " " + importName
+				name = is.Name.Name
+			}
+
+			importMap[importName] = true
+
+			return name
+		}
+
+		fatalf("package %v is not imported by %v, as required by the immutable slice at %v", p.Path(), pos.Filename, pos)
+		return ""
+	}
+
+	elem := types.TypeString(get.Type().(*types.Signature).Results().At(0).Type(), qualify)
+
+	named, ok := typ.(*types.Pointer).Elem().(*types.Named)
+	if !ok {
+		fatalf("could not determine the name of the immutable slice at %v", pos)
+	}
+
+	name := named.Obj().Name()
+
+	newFn := "New" + name + "Len"
+	if !named.Obj().Exported() {
+		newFn = "new" + capitalise(name) + "Len"
+	}
+
+	if q := qualify(named.Obj().Pkg()); q != "" {
+		newFn = q + "." + newFn
+	}
+
+	return elem, newFn
+}
+
+func capitalise(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[n:]
+}
+
 // a match is a container for a matching func/meth in a file
 type match struct {
 	// the actual function/method that has matched
@@ -466,12 +553,43 @@ type match struct {
 
 	// the "type" of the slice parameter (the first one);
 	// for a slice this is the ArrayType; for an imm slice
-	// this will be a pointer to an imm slice
+	// this will be a pointer to an imm slice; for an array
+	// it is the ArrayType or a pointer to it
 	orderTyp ast.Expr
 
 	// whether the type is an immutable slice or not (i.e. a regular
 	// slice)
 	isImmSlice bool
+
+	// whether the type is a fixed-size array, or a pointer to one
+	isArray bool
+
+	// the file in which fun is declared
+	file *ast.File
+}
+
+// byValue returns whether the order function of m takes a fixed-size array
+// by value. The generated functions instead take a pointer to the array, in
+// order that they can sort it in place.
+func (m match) byValue() bool {
+	_, ok := m.orderTyp.(*ast.ArrayType)
+	return m.isArray && ok
+}
+
+// dataType returns the type of the slice parameter of the functions generated
+// for m
+func (g *generator) dataType(m match) string {
+	var buf bytes.Buffer
+
+	if m.byValue() {
+		buf.WriteString("*")
+	}
+
+	if err := printer.Fprint(&buf, g.fset, m.orderTyp); err != nil {
+		fatalf("could not ast print type: %v", err)
+	}
+
+	return buf.String()
 }
 
 func (g *generator) isSliceExpr(e ast.Expr) bool {
@@ -485,6 +603,20 @@ func (g *generator) isSliceExpr(e ast.Expr) bool {
 	return true
 }
 
+func (g *generator) isArrayExpr(e ast.Expr) bool {
+	// either a fixed-size array or a pointer to one
+	if se, ok := e.(*ast.StarExpr); ok {
+		e = se.X
+	}
+
+	at, ok := e.(*ast.ArrayType)
+	if !ok || at.Len == nil {
+		return false
+	}
+
+	return true
+}
+
 func (g *generator) isImmSliceExpr(e ast.Expr) bool {
 	// must be a pointer to an immutable slice
 	// could be defined in this package or another package
@@ -556,20 +688,11 @@ func (g *generator) genMatches(funs []toGen, imps map[string]bool) {
 
 	fn := g.fset.Position(g.file.Pos()).Filename
 
-	ofName, ok := gogenerate.NameFileFromFile(fn, sortGenCmd)
-	if !ok {
-		fatalf("could not name generated file from %v", fn)
-	}
-
-	g.pf(`// Code generated by sortGen. DO NOT EDIT.
-
-		`)
-
-	g.pf(g.license)
+	g.buf = g.ctx.Output(fn)
 
 	g.pf(`package %v
 
-			import "sort"
+			import "math/bits"
 			import "%v"
 
 		`, g.pkg.Name, sorter.PkgName)
@@ -585,82 +708,120 @@ func (g *generator) genMatches(funs []toGen, imps map[string]bool) {
 	}
 
 	for _, toGen := range funs {
+		sortFns := sortFunctions(toGen.orderFn)
+
 		tmpl := struct {
-			Recv  string
-			Sort  string
-			Name  string
-			Typ   string
-			Order string
+			// Recv and Self are the receiver of the sort functions and
+			// the selector prefix for calls from them
+			Recv string
+			Self string
+
+			// HRecv and HSelf are the equivalent for the functions declared
+			// by pdqsortTmpl and stableTmpl
+			HRecv string
+			HSelf string
+
+			Sort   string
+			Stable string
+
+			// Name is the name of the sort function for which pdqsortTmpl
+			// or stableTmpl are executed
+			Name string
+
+			// the names of the functions declared by helpersTmpl
+			Search       string
+			SearchUpper  string
+			IsSorted     string
+			MergeSorted  string
+			DedupeSorted string
+			TopK         string
+
+			Typ  string
+			Elem string
+			New  string
+			Imm  bool
 		}{
-			Recv:  toGen.recvTyp,
-			Typ:   toGen.typ,
-			Order: toGen.orderFn,
+			Recv:   toGen.recvTyp,
+			Sort:   sortFns[0],
+			Stable: sortFns[1],
+			Typ:    toGen.typ,
+			Elem:   toGen.elem,
+			New:    toGen.newFn,
+			Imm:    toGen.imm,
 		}
 
-		if toGen.recvTyp != "" {
-			tmpl.Order = toGen.recvVar + "." + toGen.orderFn
-		}
+		helpers := *fHelpers && !toGen.array
 
-		sortFns := sortFunctions(toGen.orderFn)
+		if helpers {
+			hs := funcNames(toGen.orderFn, "search", "searchUpper", "isSorted", "mergeSorted", "dedupeSorted", "topK")
 
-		for i, sn := range []string{"Sort", "Stable"} {
-			tmpl.Sort = sn
-			tmpl.Name = sortFns[i]
-
-			if toGen.imm {
-				g.pt(`
-					func {{.Recv}} {{.Name}}(vs {{.Typ}}) {{.Typ}}{
-						theVs := vs.AsMutable()
-
-						sort.{{.Sort}}(&sorter.Wrapper{
-							LenFunc: func() int {
-								return theVs.Len()
-							},
-							LessFunc: func(i, j int) bool {
-								return bool({{.Order}}(theVs, i, j))
-							},
-							SwapFunc: func(i, j int) {
-								jPrev := theVs.Get(j)
-								iPrev := theVs.Get(i)
-
-								theVs.Set(j, iPrev)
-								theVs.Set(i, jPrev)
-							},
-						})
-
-						return theVs.AsImmutable(vs)
-					}
-					`, tmpl)
-			} else {
-				g.pt(`
-					func {{.Recv}} {{.Name}}(vs {{.Typ}}) {
-						sort.{{.Sort}}(&sorter.Wrapper{
-							LenFunc: func() int {
-								return len(vs)
-							},
-							LessFunc: func(i, j int) bool {
-								return bool({{.Order}}(vs, i, j))
-							},
-							SwapFunc: func(i, j int) {
-								vs[i], vs[j] = vs[j], vs[i]
-							},
-						})
-					}
-					`, tmpl)
-			}
+			tmpl.Search = hs[0]
+			tmpl.SearchUpper = hs[1]
+			tmpl.IsSorted = hs[2]
+			tmpl.MergeSorted = hs[3]
+			tmpl.DedupeSorted = hs[4]
+			tmpl.TopK = hs[5]
+		}
 
+		if toGen.recvTyp != "" {
+			tmpl.Self = toGen.recvVar + "."
+			tmpl.HRecv = "(" + helperRecv + " " + toGen.recvBase + ")"
+			tmpl.HSelf = helperRecv + "."
+		}
+
+		funcs := template.FuncMap{
+			"Less": toGen.less,
+			"Swap": func(i, j string) string {
+				if toGen.imm {
+					return fmt.Sprintf("%vswap_%v(%v, %v, %v)", tmpl.HSelf, tmpl.Name, dataVar, i, j)
+				}
+				return fmt.Sprintf("%[1]v[%[2]v], %[1]v[%[3]v] = %[1]v[%[3]v], %[1]v[%[2]v]", dataVar, i, j)
+			},
+			"Get": func(s, i string) string {
+				if toGen.imm {
+					return fmt.Sprintf("%v.Get(%v)", s, i)
+				}
+				return fmt.Sprintf("%v[%v]", s, i)
+			},
+			"Set": func(s, i, v string) string {
+				if toGen.imm {
+					return fmt.Sprintf("%v.Set(%v, %v)", s, i, v)
+				}
+				return fmt.Sprintf("%v[%v] = %v", s, i, v)
+			},
+			"Len": func(s string) string {
+				if toGen.imm {
+					return s + ".Len()"
+				}
+				return "len(" + s + ")"
+			},
+			"Append": func(s, v string) string {
+				if toGen.imm {
+					return fmt.Sprintf("%v.Append(%v)", s, v)
+				}
+				return fmt.Sprintf("append(%v, %v)", s, v)
+			},
+			"Scratch": func() string {
+				if toGen.imm {
+					return toGen.newFn + "(2).AsMutable()"
+				}
+				return fmt.Sprintf("make(%v, 2)", toGen.typ)
+			},
 		}
-	}
 
-	toWrite := g.buf.Bytes()
+		g.pt(sortFnsTmpl, funcs, tmpl)
 
-	res, err := imports.Process(ofName, toWrite, nil)
-	if err == nil {
-		toWrite = res
-	}
+		tmpl.Name = tmpl.Sort
+		g.pt(pdqsortTmpl, funcs, tmpl)
+
+		tmpl.Name = tmpl.Stable
+		g.pt(stableTmpl, funcs, tmpl)
 
-	if err := ioutil.WriteFile(ofName, toWrite, 0644); err != nil {
-		fatalf("could not write %v: %v", ofName, err)
+		if helpers {
+			// the helpers use the functions declared by pdqsortTmpl
+			tmpl.Name = tmpl.Sort
+			g.pt(helpersTmpl, funcs, tmpl)
+		}
 	}
 }
 
@@ -668,14 +829,14 @@ func (g *generator) pf(format string, args ...interface{}) {
 	fmt.Fprintf(g.buf, format, args...)
 }
 
-func (g *generator) pt(tmpl string, val interface{}) {
+func (g *generator) pt(tmpl string, funcs template.FuncMap, val interface{}) {
 	// on the basis most templates are for convenience define inline
 	// as raw string literals which start the ` on one line but then start
 	// the template on the next (for readability) we strip the first leading
 	// \n if one exists
 	tmpl = strings.TrimPrefix(tmpl, "\n")
 
-	t := template.New("tmp")
+	t := template.New("tmp").Funcs(funcs)
 
 	_, err := t.Parse(tmpl)
 	if err != nil {
@@ -689,6 +850,13 @@ func (g *generator) pt(tmpl string, val interface{}) {
 }
 
 func sortFunctions(orderFn string) []string {
+	return funcNames(orderFn, "sort", "stableSort")
+}
+
+// funcNames returns the names of the functions generated for the order
+// function orderFn with each of the (lower case) prefixes, following the
+// capitalisation of orderFn
+func funcNames(orderFn string, prefixes ...string) []string {
 	// TODO this can be improved
 
 	lower := false
@@ -703,11 +871,16 @@ func sortFunctions(orderFn string) []string {
 
 	parts := strings.SplitAfterN(orderFn, split, 2)
 
-	if lower {
-		return []string{"sort" + parts[1], "stableSort" + parts[1]}
+	var res []string
+
+	for _, p := range prefixes {
+		if !lower {
+			p = capitalise(p)
+		}
+		res = append(res, p+parts[1])
 	}
 
-	return []string{"Sort" + parts[1], "StableSort" + parts[1]}
+	return res
 }
 
 type importFinder struct {
@@ -739,6 +912,19 @@ func (i *importFinder) Visit(node ast.Node) ast.Visitor {
 	return i
 }
 
+// addImports adds the imports imps to importMap, in the form in which they are
+// written in the generated file
+func addImports(importMap map[string]bool, imps map[*ast.ImportSpec]bool) {
+	for i := range imps {
+		importName := i.Path.Value
+		if i.Name != nil {
+			importName = i.Name.Name + " " + importName
+		}
+
+		importMap[importName] = true
+	}
+}
+
 func findImports(exp ast.Expr, imports []*ast.ImportSpec) map[*ast.ImportSpec]bool {
 	finder := &importFinder{
 		imports: imports,
@@ -753,9 +939,3 @@ func findImports(exp ast.Expr, imports []*ast.ImportSpec) map[*ast.ImportSpec]bo
 func fatalf(format string, args ...interface{}) {
 	panic(fmt.Errorf(format, args...))
 }
-
-func infof(format string, args ...interface{}) {
-	if *fGoGenLog == string(gogenerate.LogInfo) {
-		log.Printf(format, args...)
-	}
-}
@@ -3,10 +3,329 @@
 
 package main
 
-import "testing"
+//go:generate gobin -m -run myitcv.io/sorter/cmd/sortGen -helpers
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"myitcv.io/sorter"
+)
 
 func TestBasic(t *testing.T) {
 	// we simply check that the generation worked by referring to the
 	// methods/functions we expect to have been generated
 	// hence look into _testFiles/to_parse_test.go
 }
+
+type row struct {
+	id  int
+	seq int
+}
+
+func orderRowsByID(rows []row, i, j int) sorter.Ordered {
+	return rows[i].id < rows[j].id
+}
+
+func orderRowsBySeq(rows []row, i, j int) sorter.Ordered {
+	return rows[i].seq < rows[j].seq
+}
+
+func (r row) isOdd() bool {
+	return r.seq%2 == 1
+}
+
+// orderRowsByOdd is not inlined
+func orderRowsByOdd(rows []row, i, j int) sorter.Ordered {
+	if rows[i].isOdd() == rows[j].isOdd() {
+		return false
+	}
+	return sorter.Ordered(rows[j].isOdd())
+}
+
+func orderRowArrayByID(rows [100]row, i, j int) sorter.Ordered {
+	return rows[i].id < rows[j].id
+}
+
+// orderRowArrayPointerByID is not inlined
+func orderRowArrayPointerByID(rows *[100]row, i, j int) sorter.Ordered {
+	a, b := rows[i], rows[j]
+	return a.id < b.id
+}
+
+var orderRowsByIDThenSeq = sorter.Then(orderRowsByID, orderRowsBySeq)
+
+var orderRowsByOddThenIDThenSeqDesc = sorter.Then(orderRowsByOdd, sorter.Desc(orderRowsByIDThenSeq))
+
+// wrapperSortRowsByID and wrapperStableSortRowsByID are the sort functions
+// that sortGen previously generated for orderRowsByID, against which the
+// generated functions are benchmarked
+
+func wrapperSortRowsByID(vs []row) {
+	sort.Sort(&sorter.Wrapper{
+		LenFunc: func() int {
+			return len(vs)
+		},
+		LessFunc: func(i, j int) bool {
+			return bool(orderRowsByID(vs, i, j))
+		},
+		SwapFunc: func(i, j int) {
+			vs[i], vs[j] = vs[j], vs[i]
+		},
+	})
+}
+
+func wrapperStableSortRowsByID(vs []row) {
+	sort.Stable(&sorter.Wrapper{
+		LenFunc: func() int {
+			return len(vs)
+		},
+		LessFunc: func(i, j int) bool {
+			return bool(orderRowsByID(vs, i, j))
+		},
+		SwapFunc: func(i, j int) {
+			vs[i], vs[j] = vs[j], vs[i]
+		},
+	})
+}
+
+// rows returns n rows in the given order, with ids that repeat such that
+// stability can be verified
+func rows(n int, order string) []row {
+	r := rand.New(rand.NewSource(int64(n)))
+
+	res := make([]row, n)
+	for i := range res {
+		res[i] = row{id: r.Intn(n/4 + 1), seq: i}
+	}
+
+	switch order {
+	case "sorted":
+		sort.SliceStable(res, func(i, j int) bool { return res[i].id < res[j].id })
+	case "reversed":
+		sort.SliceStable(res, func(i, j int) bool { return res[i].id > res[j].id })
+	}
+
+	return res
+}
+
+var sizes = []int{0, 1, 5, 12, 13, 50, 100, 1000, 10000}
+var orders = []string{"random", "sorted", "reversed"}
+
+func TestSortRowsByID(t *testing.T) {
+	for _, n := range sizes {
+		for _, o := range orders {
+			vs := rows(n, o)
+			sortRowsByID(vs)
+
+			if !sort.SliceIsSorted(vs, func(i, j int) bool { return vs[i].id < vs[j].id }) {
+				t.Errorf("sortRowsByID did not sort %v %v rows", n, o)
+			}
+		}
+	}
+}
+
+func TestStableSortRowsByID(t *testing.T) {
+	for _, n := range sizes {
+		for _, o := range orders {
+			vs := rows(n, o)
+			exp := make([]row, n)
+			copy(exp, vs)
+
+			stableSortRowsByID(vs)
+			sort.SliceStable(exp, func(i, j int) bool { return exp[i].id < exp[j].id })
+
+			if !reflect.DeepEqual(vs, exp) {
+				t.Errorf("stableSortRowsByID did not stably sort %v %v rows", n, o)
+			}
+		}
+	}
+}
+
+func TestRowArray(t *testing.T) {
+	for _, o := range orders {
+		for _, c := range []struct {
+			name string
+			sort func(*[100]row)
+		}{
+			{"stableSortRowArrayByID", stableSortRowArrayByID},
+			{"stableSortRowArrayPointerByID", stableSortRowArrayPointerByID},
+		} {
+			var vs, exp [100]row
+			copy(vs[:], rows(len(vs), o))
+			exp = vs
+
+			c.sort(&vs)
+			sort.SliceStable(exp[:], func(i, j int) bool { return exp[i].id < exp[j].id })
+
+			if vs != exp {
+				t.Errorf("%v did not stably sort %v rows", c.name, o)
+			}
+		}
+
+		var vs [100]row
+		copy(vs[:], rows(len(vs), o))
+
+		sortRowArrayByID(&vs)
+
+		if !sort.SliceIsSorted(vs[:], func(i, j int) bool { return vs[i].id < vs[j].id }) {
+			t.Errorf("sortRowArrayByID did not sort %v rows", o)
+		}
+	}
+}
+
+func TestComposed(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		order func([]row, int, int) sorter.Ordered
+		sort  func([]row)
+	}{
+		{"sortRowsByIDThenSeq", orderRowsByIDThenSeq, sortRowsByIDThenSeq},
+		{"stableSortRowsByIDThenSeq", orderRowsByIDThenSeq, stableSortRowsByIDThenSeq},
+		{"sortRowsByIDThenSeqDesc", sorter.Desc(orderRowsByIDThenSeq), sortRowsByIDThenSeqDesc},
+		{"sortRowsByOddThenIDThenSeqDesc", orderRowsByOddThenIDThenSeqDesc, sortRowsByOddThenIDThenSeqDesc},
+		{"stableSortRowsByOddThenIDThenSeqDescDesc", sorter.Desc(orderRowsByOddThenIDThenSeqDesc), stableSortRowsByOddThenIDThenSeqDescDesc},
+	} {
+		for _, n := range sizes {
+			for _, o := range orders {
+				vs := rows(n, o)
+				exp := make([]row, n)
+				copy(exp, vs)
+
+				// the composed orders are total, hence there is only one
+				// correct result
+				c.sort(vs)
+				sort.Slice(exp, func(i, j int) bool { return bool(c.order(exp, i, j)) })
+
+				if !reflect.DeepEqual(vs, exp) {
+					t.Errorf("%v did not sort %v %v rows", c.name, n, o)
+				}
+			}
+		}
+	}
+}
+
+func TestSearchRowsByID(t *testing.T) {
+	vs := rows(1000, "sorted")
+
+	for id := -1; id <= 1000/4+1; id++ {
+		x := row{id: id}
+
+		if exp, got := sort.Search(len(vs), func(i int) bool { return vs[i].id >= id }), searchRowsByID(vs, x); got != exp {
+			t.Errorf("expected searchRowsByID for id %v to be %v; got %v", id, exp, got)
+		}
+
+		if exp, got := sort.Search(len(vs), func(i int) bool { return vs[i].id > id }), searchUpperRowsByID(vs, x); got != exp {
+			t.Errorf("expected searchUpperRowsByID for id %v to be %v; got %v", id, exp, got)
+		}
+	}
+}
+
+func TestIsSortedRowsByID(t *testing.T) {
+	for _, n := range sizes {
+		for _, o := range orders {
+			vs := rows(n, o)
+
+			if exp, got := sort.SliceIsSorted(vs, func(i, j int) bool { return vs[i].id < vs[j].id }), isSortedRowsByID(vs); got != exp {
+				t.Errorf("expected isSortedRowsByID for %v %v rows to be %v; got %v", n, o, exp, got)
+			}
+		}
+	}
+}
+
+func TestMergeSortedRowsByID(t *testing.T) {
+	for _, n := range sizes {
+		a, b := rows(n, "sorted"), rows(n/2, "sorted")
+		for i := range b {
+			b[i].seq += n
+		}
+
+		exp := append(append([]row{}, a...), b...)
+		sort.SliceStable(exp, func(i, j int) bool { return exp[i].id < exp[j].id })
+
+		if got := mergeSortedRowsByID(a, b); !reflect.DeepEqual(got, exp) {
+			t.Errorf("mergeSortedRowsByID did not stably merge %v and %v rows", n, n/2)
+		}
+	}
+}
+
+func TestDedupeSortedRowsByID(t *testing.T) {
+	for _, n := range sizes {
+		vs := rows(n, "sorted")
+
+		exp := []row{}
+		for i, v := range vs {
+			if i == 0 || vs[i-1].id != v.id {
+				exp = append(exp, v)
+			}
+		}
+
+		if got := dedupeSortedRowsByID(vs); !reflect.DeepEqual(got, exp) {
+			t.Errorf("dedupeSortedRowsByID did not dedupe %v rows", n)
+		}
+	}
+}
+
+func TestTopKRowsByID(t *testing.T) {
+	for _, n := range sizes {
+		for _, o := range orders {
+			for _, k := range []int{-1, 0, 1, 10, n} {
+				vs := rows(n, o)
+
+				exp := make([]int, n)
+				for i, v := range vs {
+					exp[i] = v.id
+				}
+				sort.Ints(exp)
+				exp = exp[:max(min(k, n), 0)]
+
+				got := make([]int, 0, len(exp))
+				for _, v := range topKRowsByID(vs, k) {
+					got = append(got, v.id)
+				}
+
+				if !reflect.DeepEqual(got, exp) {
+					t.Errorf("expected topKRowsByID(%v) of %v %v rows to be %v; got %v", k, n, o, exp, got)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkSortRowsByID(b *testing.B) {
+	benchmarkSort(b, wrapperSortRowsByID, sortRowsByID)
+}
+
+func BenchmarkStableSortRowsByID(b *testing.B) {
+	benchmarkSort(b, wrapperStableSortRowsByID, stableSortRowsByID)
+}
+
+func benchmarkSort(b *testing.B, wrapper, generated func([]row)) {
+	for _, n := range []int{100, 10000, 1000000} {
+		for _, o := range orders {
+			vs := rows(n, o)
+			data := make([]row, n)
+
+			for _, s := range []struct {
+				name string
+				fn   func([]row)
+			}{
+				{"Wrapper", wrapper},
+				{"Generated", generated},
+			} {
+				b.Run(fmt.Sprintf("%v/%v/%v", s.name, o, n), func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						b.StopTimer()
+						copy(data, vs)
+						b.StartTimer()
+
+						s.fn(data)
+					}
+				})
+			}
+		}
+	}
+}
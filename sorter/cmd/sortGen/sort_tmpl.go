@@ -0,0 +1,717 @@
+// Copyright (c) 2016 Paul Jolly <paul@myitcv.org.uk>, all rights reserved.
+// Use of this document is governed by a license found in the LICENSE document.
+
+package main
+
+// The templates below are adapted from sort/zsortfunc.go in the Go standard
+// library, which is:
+//
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// Each is executed for a single sort function, named .Name, with a receiver
+// .HRecv (or none) and a slice type .Typ. Calls to data.Less and data.Swap in
+// the original are replaced by the template functions Less and Swap, which
+// return the specialised expression or statement for the given indices.
+
+// sortFnsTmpl declares the sort and stable sort functions for an order
+// function, in terms of the functions declared by pdqsortTmpl and stableTmpl
+const sortFnsTmpl = `
+func {{.Recv}} {{.Sort}}(vs {{.Typ}}) {{if .Imm}}{{.Typ}} {{end}}{
+{{- if .Imm}}
+	theVs := vs.AsMutable()
+	{{.Self}}pdqsort_{{.Sort}}(theVs, 0, theVs.Len(), bits.Len(uint(theVs.Len())))
+
+	return theVs.AsImmutable(vs)
+{{- else}}
+	{{.Self}}pdqsort_{{.Sort}}(vs, 0, len(vs), bits.Len(uint(len(vs))))
+{{- end}}
+}
+
+func {{.Recv}} {{.Stable}}(vs {{.Typ}}) {{if .Imm}}{{.Typ}} {{end}}{
+{{- if .Imm}}
+	theVs := vs.AsMutable()
+	{{.Self}}stable_{{.Stable}}(theVs, theVs.Len())
+
+	return theVs.AsImmutable(vs)
+{{- else}}
+	{{.Self}}stable_{{.Stable}}(vs, len(vs))
+{{- end}}
+}
+`
+
+// helpersTmpl declares the functions generated for an order function in
+// addition to the sort functions, with the -helpers flag. Get, Set, Len and
+// Append are template functions that return the expression for the
+// corresponding operation on a slice, be it a regular or immutable slice.
+const helpersTmpl = `
+// {{.Search}} returns the smallest index i at which vs[i] is not less than x,
+// or the length of vs if there is no such index. vs must be sorted.
+func {{.HRecv}} {{.Search}}(vs {{.Typ}}, x {{.Elem}}) int {
+	data := {{Scratch}}
+	{{Set "data" "1" "x"}}
+
+	i, j := 0, {{Len "vs"}}
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		{{Set "data" "0" (Get "vs" "h")}}
+		if {{Less "0" "1"}} {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// {{.SearchUpper}} returns the smallest index i at which x is less than vs[i],
+// or the length of vs if there is no such index. vs must be sorted.
+func {{.HRecv}} {{.SearchUpper}}(vs {{.Typ}}, x {{.Elem}}) int {
+	data := {{Scratch}}
+	{{Set "data" "1" "x"}}
+
+	i, j := 0, {{Len "vs"}}
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		{{Set "data" "0" (Get "vs" "h")}}
+		if !{{Less "1" "0"}} {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// {{.IsSorted}} returns whether data is sorted.
+func {{.HRecv}} {{.IsSorted}}(data {{.Typ}}) bool {
+	for i := {{Len "data"}} - 1; i > 0; i-- {
+		if {{Less "i" "i-1"}} {
+			return false
+		}
+	}
+	return true
+}
+
+// {{.MergeSorted}} returns the result of merging a and b, which must be sorted.
+// The merge is stable: of equal elements, those of a precede those of b.
+func {{.HRecv}} {{.MergeSorted}}(a, b {{.Typ}}) {{.Typ}} {
+	data := {{Scratch}}
+{{- if .Imm}}
+	res := {{.New}}(0).AsMutable()
+{{- else}}
+	res := make({{.Typ}}, 0, len(a)+len(b))
+{{- end}}
+
+	i, j := 0, 0
+	for i < {{Len "a"}} && j < {{Len "b"}} {
+		{{Set "data" "0" (Get "b" "j")}}
+		{{Set "data" "1" (Get "a" "i")}}
+		if {{Less "0" "1"}} {
+			res = {{Append "res" (Get "b" "j")}}
+			j++
+		} else {
+			res = {{Append "res" (Get "a" "i")}}
+			i++
+		}
+	}
+	for ; i < {{Len "a"}}; i++ {
+		res = {{Append "res" (Get "a" "i")}}
+	}
+	for ; j < {{Len "b"}}; j++ {
+		res = {{Append "res" (Get "b" "j")}}
+	}
+{{- if .Imm}}
+
+	return res.AsImmutable(nil)
+{{- else}}
+
+	return res
+{{- end}}
+}
+
+// {{.DedupeSorted}} returns data with all but the first of each run of equal
+// elements removed. data must be sorted.
+{{- if not .Imm}} The elements are moved within data, the
+// prefix of which is returned.
+{{- end}}
+func {{.HRecv}} {{.DedupeSorted}}(data {{.Typ}}) {{.Typ}} {
+	if {{Len "data"}} == 0 {
+		return data
+	}
+{{- if .Imm}}
+
+	res := {{.New}}(0).AsMutable().Append(data.Get(0))
+
+	last := 0
+	for i := 1; i < data.Len(); i++ {
+		if {{Less "last" "i"}} {
+			res.Append(data.Get(i))
+			last = i
+		}
+	}
+
+	return res.AsImmutable(nil)
+{{- else}}
+
+	k := 1
+	for i := 1; i < len(data); i++ {
+		if {{Less "k-1" "i"}} {
+			data[k] = data[i]
+			k++
+		}
+	}
+
+	return data[:k]
+{{- end}}
+}
+
+// {{.TopK}} returns the k least elements of vs, in order, using a heap of
+// size k.
+{{- if not .Imm}} The elements are moved within vs, the prefix of which is
+// returned.
+{{- end}}
+func {{.HRecv}} {{.TopK}}(vs {{.Typ}}, k int) {{.Typ}} {
+{{- if .Imm}}
+	data := vs.AsMutable()
+{{- else}}
+	data := vs
+{{- end}}
+	n := {{Len "data"}}
+
+	if k > n {
+		k = n
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	// a max heap of the least k elements seen so far
+	for i := (k - 1) / 2; i >= 0; i-- {
+		{{.HSelf}}siftDown_{{.Sort}}(data, i, k, 0)
+	}
+	for i := k; i < n; i++ {
+		if {{Less "i" "0"}} {
+			{{Swap "0" "i"}}
+			{{.HSelf}}siftDown_{{.Sort}}(data, 0, k, 0)
+		}
+	}
+	{{.HSelf}}heapSort_{{.Sort}}(data, 0, k)
+{{- if .Imm}}
+
+	res := {{.New}}(0).AsMutable()
+	for i := 0; i < k; i++ {
+		res.Append(data.Get(i))
+	}
+
+	return res.AsImmutable(nil)
+{{- else}}
+
+	return data[:k]
+{{- end}}
+}
+`
+
+// swapTmpl declares the function that swaps two elements of a mutable
+// immutable slice, the Swap of an immutable slice being a call to it
+const swapTmpl = `
+{{- if .Imm}}
+
+// swap_{{.Name}} swaps the elements i and j of data, which must be mutable.
+func {{.HRecv}} swap_{{.Name}}(data {{.Typ}}, i, j int) {
+	vi, vj := data.Get(i), data.Get(j)
+	data.Set(i, vj)
+	data.Set(j, vi)
+}
+{{- end}}
+`
+
+// pdqsortTmpl declares a pattern-defeating quicksort
+const pdqsortTmpl = swapTmpl + `
+// insertionSort_{{.Name}} sorts data[a:b] using insertion sort.
+func {{.HRecv}} insertionSort_{{.Name}}(data {{.Typ}}, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && {{Less "j" "j-1"}}; j-- {
+			{{Swap "j" "j-1"}}
+		}
+	}
+}
+
+// siftDown_{{.Name}} implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func {{.HRecv}} siftDown_{{.Name}}(data {{.Typ}}, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && {{Less "first+child" "first+child+1"}} {
+			child++
+		}
+		if !{{Less "first+root" "first+child"}} {
+			return
+		}
+		{{Swap "first+root" "first+child"}}
+		root = child
+	}
+}
+
+func {{.HRecv}} heapSort_{{.Name}}(data {{.Typ}}, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		{{.HSelf}}siftDown_{{.Name}}(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		{{Swap "first" "first+i"}}
+		{{.HSelf}}siftDown_{{.Name}}(data, lo, i, first)
+	}
+}
+
+// pdqsort_{{.Name}} sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func {{.HRecv}} pdqsort_{{.Name}}(data {{.Typ}}, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			{{.HSelf}}insertionSort_{{.Name}}(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			{{.HSelf}}heapSort_{{.Name}}(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			{{.HSelf}}breakPatterns_{{.Name}}(data, a, b)
+			limit--
+		}
+
+		pivot, hint := {{.HSelf}}choosePivot_{{.Name}}(data, a, b)
+		if hint == sorter.DecreasingHint {
+			{{.HSelf}}reverseRange_{{.Name}}(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if {{.HSelf}}partialInsertionSort_{{.Name}}(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !{{Less "a-1" "pivot"}} {
+			mid := {{.HSelf}}partitionEqual_{{.Name}}(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := {{.HSelf}}partition_{{.Name}}(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			{{.HSelf}}pdqsort_{{.Name}}(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			{{.HSelf}}pdqsort_{{.Name}}(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_{{.Name}} does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func {{.HRecv}} partition_{{.Name}}(data {{.Typ}}, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	{{Swap "a" "pivot"}}
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && {{Less "i" "a"}} {
+		i++
+	}
+	for i <= j && !{{Less "j" "a"}} {
+		j--
+	}
+	if i > j {
+		{{Swap "j" "a"}}
+		return j, true
+	}
+	{{Swap "i" "j"}}
+	i++
+	j--
+
+	for {
+		for i <= j && {{Less "i" "a"}} {
+			i++
+		}
+		for i <= j && !{{Less "j" "a"}} {
+			j--
+		}
+		if i > j {
+			break
+		}
+		{{Swap "i" "j"}}
+		i++
+		j--
+	}
+	{{Swap "j" "a"}}
+	return j, false
+}
+
+// partitionEqual_{{.Name}} partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func {{.HRecv}} partitionEqual_{{.Name}}(data {{.Typ}}, a, b, pivot int) (newpivot int) {
+	{{Swap "a" "pivot"}}
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !{{Less "a" "i"}} {
+			i++
+		}
+		for i <= j && {{Less "a" "j"}} {
+			j--
+		}
+		if i > j {
+			break
+		}
+		{{Swap "i" "j"}}
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_{{.Name}} partially sorts a slice, returns true if the slice is sorted at the end.
+func {{.HRecv}} partialInsertionSort_{{.Name}}(data {{.Typ}}, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !{{Less "i" "i-1"}} {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		{{Swap "i" "i-1"}}
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !{{Less "j" "j-1"}} {
+					break
+				}
+				{{Swap "j" "j-1"}}
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !{{Less "j" "j-1"}} {
+					break
+				}
+				{{Swap "j" "j-1"}}
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_{{.Name}} scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func {{.HRecv}} breakPatterns_{{.Name}}(data {{.Typ}}, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			{{Swap "idx" "a+other"}}
+		}
+	}
+}
+
+// choosePivot_{{.Name}} chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func {{.HRecv}} choosePivot_{{.Name}}(data {{.Typ}}, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = {{.HSelf}}medianAdjacent_{{.Name}}(data, i, &swaps)
+			j = {{.HSelf}}medianAdjacent_{{.Name}}(data, j, &swaps)
+			k = {{.HSelf}}medianAdjacent_{{.Name}}(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = {{.HSelf}}median_{{.Name}}(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_{{.Name}} returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func {{.HRecv}} order2_{{.Name}}(data {{.Typ}}, a, b int, swaps *int) (int, int) {
+	if {{Less "b" "a"}} {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_{{.Name}} returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func {{.HRecv}} median_{{.Name}}(data {{.Typ}}, a, b, c int, swaps *int) int {
+	a, b = {{.HSelf}}order2_{{.Name}}(data, a, b, swaps)
+	b, c = {{.HSelf}}order2_{{.Name}}(data, b, c, swaps)
+	a, b = {{.HSelf}}order2_{{.Name}}(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_{{.Name}} finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func {{.HRecv}} medianAdjacent_{{.Name}}(data {{.Typ}}, a int, swaps *int) int {
+	return {{.HSelf}}median_{{.Name}}(data, a-1, a, a+1, swaps)
+}
+
+func {{.HRecv}} reverseRange_{{.Name}}(data {{.Typ}}, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		{{Swap "i" "j"}}
+		i++
+		j--
+	}
+}
+`
+
+// stableTmpl declares a stable insertion and symmetric merge sort
+const stableTmpl = swapTmpl + `
+// insertionSort_{{.Name}} sorts data[a:b] using insertion sort.
+func {{.HRecv}} insertionSort_{{.Name}}(data {{.Typ}}, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && {{Less "j" "j-1"}}; j-- {
+			{{Swap "j" "j-1"}}
+		}
+	}
+}
+
+// stable_{{.Name}} sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_{{.Name}}.
+func {{.HRecv}} stable_{{.Name}}(data {{.Typ}}, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		{{.HSelf}}insertionSort_{{.Name}}(data, a, b)
+		a = b
+		b += blockSize
+	}
+	{{.HSelf}}insertionSort_{{.Name}}(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			{{.HSelf}}symMerge_{{.Name}}(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			{{.HSelf}}symMerge_{{.Name}}(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_{{.Name}} merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func {{.HRecv}} symMerge_{{.Name}}(data {{.Typ}}, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if {{Less "h" "a"}} {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			{{Swap "k" "k+1"}}
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !{{Less "m" "h"}} {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			{{Swap "k" "k-1"}}
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !{{Less "p-c" "c"}} {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		{{.HSelf}}rotate_{{.Name}}(data, start, m, end)
+	}
+	if a < start && start < mid {
+		{{.HSelf}}symMerge_{{.Name}}(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		{{.HSelf}}symMerge_{{.Name}}(data, mid, end, b)
+	}
+}
+
+// rotate_{{.Name}} rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func {{.HRecv}} rotate_{{.Name}}(data {{.Typ}}, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			{{.HSelf}}swapRange_{{.Name}}(data, m-i, m, j)
+			i -= j
+		} else {
+			{{.HSelf}}swapRange_{{.Name}}(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	{{.HSelf}}swapRange_{{.Name}}(data, m-i, m, i)
+}
+
+func {{.HRecv}} swapRange_{{.Name}}(data {{.Typ}}, a, b, n int) {
+	for i := 0; i < n; i++ {
+		{{Swap "a+i" "b+i"}}
+	}
+}
+`
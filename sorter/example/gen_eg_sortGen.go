@@ -5,58 +5,989 @@
 
 package main
 
-import "sort"
-import "myitcv.io/sorter"
+import (
+	"math/bits"
+
+	"myitcv.io/sorter"
+)
 
 func sortByName(vs []person) {
-	sort.Sort(&sorter.Wrapper{
-		LenFunc: func() int {
-			return len(vs)
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(orderByName(vs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			vs[i], vs[j] = vs[j], vs[i]
-		},
-	})
+	pdqsort_sortByName(vs, 0, len(vs), bits.Len(uint(len(vs))))
 }
+
 func stableSortByName(vs []person) {
-	sort.Stable(&sorter.Wrapper{
-		LenFunc: func() int {
-			return len(vs)
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(orderByName(vs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			vs[i], vs[j] = vs[j], vs[i]
-		},
-	})
+	stable_stableSortByName(vs, len(vs))
+}
+
+// insertionSort_sortByName sorts data[a:b] using insertion sort.
+func insertionSort_sortByName(data []person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data[j].name < data[j-1].name); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// siftDown_sortByName implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDown_sortByName(data []person, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && (data[first+child].name < data[first+child+1].name) {
+			child++
+		}
+		if !(data[first+root].name < data[first+child].name) {
+			return
+		}
+		data[first+root], data[first+child] = data[first+child], data[first+root]
+		root = child
+	}
+}
+
+func heapSort_sortByName(data []person, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDown_sortByName(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i] = data[first+i], data[first]
+		siftDown_sortByName(data, lo, i, first)
+	}
+}
+
+// pdqsort_sortByName sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsort_sortByName(data []person, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort_sortByName(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort_sortByName(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatterns_sortByName(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot_sortByName(data, a, b)
+		if hint == sorter.DecreasingHint {
+			reverseRange_sortByName(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if partialInsertionSort_sortByName(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !(data[a-1].name < data[pivot].name) {
+			mid := partitionEqual_sortByName(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition_sortByName(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsort_sortByName(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsort_sortByName(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_sortByName does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partition_sortByName(data []person, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && (data[i].name < data[a].name) {
+		i++
+	}
+	for i <= j && !(data[j].name < data[a].name) {
+		j--
+	}
+	if i > j {
+		data[j], data[a] = data[a], data[j]
+		return j, true
+	}
+	data[i], data[j] = data[j], data[i]
+	i++
+	j--
+
+	for {
+		for i <= j && (data[i].name < data[a].name) {
+			i++
+		}
+		for i <= j && !(data[j].name < data[a].name) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	data[j], data[a] = data[a], data[j]
+	return j, false
+}
+
+// partitionEqual_sortByName partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqual_sortByName(data []person, a, b, pivot int) (newpivot int) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !(data[a].name < data[i].name) {
+			i++
+		}
+		for i <= j && (data[a].name < data[j].name) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_sortByName partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSort_sortByName(data []person, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !(data[i].name < data[i-1].name) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		data[i], data[i-1] = data[i-1], data[i]
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !(data[j].name < data[j-1].name) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !(data[j].name < data[j-1].name) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_sortByName scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatterns_sortByName(data []person, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			data[idx], data[a+other] = data[a+other], data[idx]
+		}
+	}
+}
+
+// choosePivot_sortByName chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivot_sortByName(data []person, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacent_sortByName(data, i, &swaps)
+			j = medianAdjacent_sortByName(data, j, &swaps)
+			k = medianAdjacent_sortByName(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = median_sortByName(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_sortByName returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2_sortByName(data []person, a, b int, swaps *int) (int, int) {
+	if data[b].name < data[a].name {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_sortByName returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func median_sortByName(data []person, a, b, c int, swaps *int) int {
+	a, b = order2_sortByName(data, a, b, swaps)
+	b, c = order2_sortByName(data, b, c, swaps)
+	a, b = order2_sortByName(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_sortByName finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacent_sortByName(data []person, a int, swaps *int) int {
+	return median_sortByName(data, a-1, a, a+1, swaps)
+}
+
+func reverseRange_sortByName(data []person, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+}
+
+// insertionSort_stableSortByName sorts data[a:b] using insertion sort.
+func insertionSort_stableSortByName(data []person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data[j].name < data[j-1].name); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// stable_stableSortByName sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_stableSortByName.
+func stable_stableSortByName(data []person, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSort_stableSortByName(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort_stableSortByName(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMerge_stableSortByName(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMerge_stableSortByName(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_stableSortByName merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMerge_stableSortByName(data []person, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if data[h].name < data[a].name {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1] = data[k+1], data[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(data[m].name < data[h].name) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1] = data[k-1], data[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(data[p-c].name < data[c].name) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate_stableSortByName(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge_stableSortByName(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge_stableSortByName(data, mid, end, b)
+	}
+}
+
+// rotate_stableSortByName rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotate_stableSortByName(data []person, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange_stableSortByName(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange_stableSortByName(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange_stableSortByName(data, m-i, m, i)
+}
+
+func swapRange_stableSortByName(data []person, a, b, n int) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i] = data[b+i], data[a+i]
+	}
 }
 func (m *myStruct) sortByAge(vs []person) {
-	sort.Sort(&sorter.Wrapper{
-		LenFunc: func() int {
-			return len(vs)
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(m.orderByAge(vs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			vs[i], vs[j] = vs[j], vs[i]
-		},
-	})
+	m.pdqsort_sortByAge(vs, 0, len(vs), bits.Len(uint(len(vs))))
 }
+
 func (m *myStruct) stableSortByAge(vs []person) {
-	sort.Stable(&sorter.Wrapper{
-		LenFunc: func() int {
-			return len(vs)
-		},
-		LessFunc: func(i, j int) bool {
-			return bool(m.orderByAge(vs, i, j))
-		},
-		SwapFunc: func(i, j int) {
-			vs[i], vs[j] = vs[j], vs[i]
-		},
-	})
+	m.stable_stableSortByAge(vs, len(vs))
+}
+
+// insertionSort_sortByAge sorts data[a:b] using insertion sort.
+func (recv *myStruct) insertionSort_sortByAge(data []person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data[j].age < data[j-1].age); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// siftDown_sortByAge implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func (recv *myStruct) siftDown_sortByAge(data []person, lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && (data[first+child].age < data[first+child+1].age) {
+			child++
+		}
+		if !(data[first+root].age < data[first+child].age) {
+			return
+		}
+		data[first+root], data[first+child] = data[first+child], data[first+root]
+		root = child
+	}
+}
+
+func (recv *myStruct) heapSort_sortByAge(data []person, a, b int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		recv.siftDown_sortByAge(data, i, hi, first)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i] = data[first+i], data[first]
+		recv.siftDown_sortByAge(data, lo, i, first)
+	}
+}
+
+// pdqsort_sortByAge sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func (recv *myStruct) pdqsort_sortByAge(data []person, a, b, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			recv.insertionSort_sortByAge(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			recv.heapSort_sortByAge(data, a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			recv.breakPatterns_sortByAge(data, a, b)
+			limit--
+		}
+
+		pivot, hint := recv.choosePivot_sortByAge(data, a, b)
+		if hint == sorter.DecreasingHint {
+			recv.reverseRange_sortByAge(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = sorter.IncreasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == sorter.IncreasingHint {
+			if recv.partialInsertionSort_sortByAge(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !(data[a-1].age < data[pivot].age) {
+			mid := recv.partitionEqual_sortByAge(data, a, b, pivot)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := recv.partition_sortByAge(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			recv.pdqsort_sortByAge(data, a, mid, limit)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			recv.pdqsort_sortByAge(data, mid+1, b, limit)
+			b = mid
+		}
+	}
+}
+
+// partition_sortByAge does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func (recv *myStruct) partition_sortByAge(data []person, a, b, pivot int) (newpivot int, alreadyPartitioned bool) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && (data[i].age < data[a].age) {
+		i++
+	}
+	for i <= j && !(data[j].age < data[a].age) {
+		j--
+	}
+	if i > j {
+		data[j], data[a] = data[a], data[j]
+		return j, true
+	}
+	data[i], data[j] = data[j], data[i]
+	i++
+	j--
+
+	for {
+		for i <= j && (data[i].age < data[a].age) {
+			i++
+		}
+		for i <= j && !(data[j].age < data[a].age) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	data[j], data[a] = data[a], data[j]
+	return j, false
+}
+
+// partitionEqual_sortByAge partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func (recv *myStruct) partitionEqual_sortByAge(data []person, a, b, pivot int) (newpivot int) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !(data[a].age < data[i].age) {
+			i++
+		}
+		for i <= j && (data[a].age < data[j].age) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSort_sortByAge partially sorts a slice, returns true if the slice is sorted at the end.
+func (recv *myStruct) partialInsertionSort_sortByAge(data []person, a, b int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !(data[i].age < data[i-1].age) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		data[i], data[i-1] = data[i-1], data[i]
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !(data[j].age < data[j-1].age) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !(data[j].age < data[j-1].age) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+	}
+	return false
+}
+
+// breakPatterns_sortByAge scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func (recv *myStruct) breakPatterns_sortByAge(data []person, a, b int) {
+	length := b - a
+	if length >= 8 {
+		random := sorter.XorShift(length)
+		modulus := sorter.NextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			data[idx], data[a+other] = data[a+other], data[idx]
+		}
+	}
+}
+
+// choosePivot_sortByAge chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func (recv *myStruct) choosePivot_sortByAge(data []person, a, b int) (pivot int, hint sorter.SortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = recv.medianAdjacent_sortByAge(data, i, &swaps)
+			j = recv.medianAdjacent_sortByAge(data, j, &swaps)
+			k = recv.medianAdjacent_sortByAge(data, k, &swaps)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = recv.median_sortByAge(data, i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, sorter.IncreasingHint
+	case maxSwaps:
+		return j, sorter.DecreasingHint
+	default:
+		return j, sorter.UnknownHint
+	}
+}
+
+// order2_sortByAge returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func (recv *myStruct) order2_sortByAge(data []person, a, b int, swaps *int) (int, int) {
+	if data[b].age < data[a].age {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// median_sortByAge returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func (recv *myStruct) median_sortByAge(data []person, a, b, c int, swaps *int) int {
+	a, b = recv.order2_sortByAge(data, a, b, swaps)
+	b, c = recv.order2_sortByAge(data, b, c, swaps)
+	a, b = recv.order2_sortByAge(data, a, b, swaps)
+	return b
+}
+
+// medianAdjacent_sortByAge finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func (recv *myStruct) medianAdjacent_sortByAge(data []person, a int, swaps *int) int {
+	return recv.median_sortByAge(data, a-1, a, a+1, swaps)
+}
+
+func (recv *myStruct) reverseRange_sortByAge(data []person, a, b int) {
+	i := a
+	j := b - 1
+	for i < j {
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+}
+
+// insertionSort_stableSortByAge sorts data[a:b] using insertion sort.
+func (recv *myStruct) insertionSort_stableSortByAge(data []person, a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (data[j].age < data[j-1].age); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// stable_stableSortByAge sorts data[0:n] using insertion sort on blocks of 20
+// elements, which are then merged using symMerge_stableSortByAge.
+func (recv *myStruct) stable_stableSortByAge(data []person, n int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		recv.insertionSort_stableSortByAge(data, a, b)
+		a = b
+		b += blockSize
+	}
+	recv.insertionSort_stableSortByAge(data, a, n)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			recv.symMerge_stableSortByAge(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			recv.symMerge_stableSortByAge(data, a, m, n)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge_stableSortByAge merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func (recv *myStruct) symMerge_stableSortByAge(data []person, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if data[h].age < data[a].age {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1] = data[k+1], data[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(data[m].age < data[h].age) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1] = data[k-1], data[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(data[p-c].age < data[c].age) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		recv.rotate_stableSortByAge(data, start, m, end)
+	}
+	if a < start && start < mid {
+		recv.symMerge_stableSortByAge(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		recv.symMerge_stableSortByAge(data, mid, end, b)
+	}
+}
+
+// rotate_stableSortByAge rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func (recv *myStruct) rotate_stableSortByAge(data []person, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			recv.swapRange_stableSortByAge(data, m-i, m, j)
+			i -= j
+		} else {
+			recv.swapRange_stableSortByAge(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	recv.swapRange_stableSortByAge(data, m-i, m, i)
+}
+
+func (recv *myStruct) swapRange_stableSortByAge(data []person, a, b, n int) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i] = data[b+i], data[a+i]
+	}
 }
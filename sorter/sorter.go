@@ -3,6 +3,8 @@
 
 package sorter
 
+import "math/bits"
+
 // Inspired by https://github.com/mattn/sorter
 
 // Ordered is a named type used to help identify order functions. See sortGen
@@ -17,9 +19,48 @@ const (
 	// PkgName is the package to which this definition belongs, again exposed
 	// here for the benefit of generators
 	PkgName = "myitcv.io/sorter"
+
+	// ThenName and DescName are the names of the functions below that compose
+	// order functions, again exposed for the benefit of generators
+	ThenName = "Then"
+	DescName = "Desc"
 )
 
-// Wrapper is a light wrapper to faciliate calls to sort.Sort
+// Then returns an order function that orders by each of fns in turn: elements
+// that are equal according to one function are ordered by the next. Assigning
+// the result to a package-level variable the name of which is that of an order
+// function declares a composed order, for which sortGen generates sort
+// functions:
+//
+//	var orderByAgeThenName = sorter.Then(orderByAge, orderByName)
+//
+// The arguments of Then in a composed order must be order functions, other
+// composed orders or calls to Then or Desc.
+func Then[S any](fns ...func(S, int, int) Ordered) func(S, int, int) Ordered {
+	return func(s S, i, j int) Ordered {
+		for _, fn := range fns {
+			if fn(s, i, j) {
+				return true
+			}
+			if fn(s, j, i) {
+				return false
+			}
+		}
+		return false
+	}
+}
+
+// Desc returns an order function that orders in the reverse of the order of
+// fn. Like Then, Desc can be used to declare a composed order.
+func Desc[S any](fn func(S, int, int) Ordered) func(S, int, int) Ordered {
+	return func(s S, i, j int) Ordered {
+		return fn(s, j, i)
+	}
+}
+
+// Wrapper is a light wrapper to faciliate calls to sort.Sort. It is no longer
+// used by sortGen, which generates type-specialised sort functions instead
+// (see below).
 type Wrapper struct {
 	LenFunc  func() int
 	LessFunc func(i, j int) bool
@@ -37,3 +78,34 @@ func (w *Wrapper) Less(i, j int) bool {
 func (w *Wrapper) Swap(i, j int) {
 	w.SwapFunc(i, j)
 }
+
+// The following are used by the pattern-defeating quicksort generated by
+// sortGen, which is adapted from the sort package in the Go standard library.
+
+// SortedHint is a hint for pdqsort when choosing the pivot
+type SortedHint int
+
+const (
+	UnknownHint SortedHint = iota
+	IncreasingHint
+	DecreasingHint
+)
+
+// XorShift is a xorshift pseudo random number generator, used to break
+// patterns in the input to pdqsort that would otherwise result in unbalanced
+// partitions. See https://www.jstatsoft.org/article/view/v008i14/xorshift.pdf
+type XorShift uint64
+
+// Next returns the next number in the sequence generated by r
+func (r *XorShift) Next() uint64 {
+	*r ^= *r << 13
+	*r ^= *r >> 7
+	*r ^= *r << 17
+	return uint64(*r)
+}
+
+// NextPowerOfTwo returns the smallest power of two greater than length
+func NextPowerOfTwo(length int) uint {
+	shift := uint(bits.Len(uint(length)))
+	return uint(1 << shift)
+}